@@ -0,0 +1,296 @@
+package apptest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// appAlias is the network alias of the application container
+const appAlias = "app"
+
+// Request represents the application started by Run, and the dependencies it's wired to
+type Request struct {
+	Image        string                               // the image of the application, e.g. the one built by the CI
+	Port         string                               // the port serving the application, e.g. "8080/tcp", used by BaseURL
+	HealthPath   string                               // the path answering 200 when the application is ready. If empty, the port listening is awaited
+	Env          map[string]string                    // the environment variables of the application, overriding the wired ones
+	Dependencies []Dependency                         // the dependencies of the application, started in order before it
+	Options      []testcontainers.ContainerCustomizer // the customizers of the application container, e.g. a different wait strategy
+}
+
+// Dependency represents a container the application depends on, e.g. its database, cache or broker,
+// started by Run in the network of the application. Build it with Module or Generic.
+type Dependency interface {
+	// Alias returns the network alias of the dependency, i.e. the host name of its container for the application
+	Alias() string
+	// start starts the container of the dependency in the network, returning it with the environment variables
+	// wiring the application to it
+	start(ctx context.Context, nw *testcontainers.DockerNetwork) (testcontainers.Container, map[string]string, error)
+}
+
+// ModuleDependency is a Dependency started by the RunContainer function of a module
+type ModuleDependency[T testcontainers.Container] struct {
+	alias string
+	run   func(context.Context, ...testcontainers.ContainerCustomizer) (T, error)
+	opts  []testcontainers.ContainerCustomizer
+	env   []envValue[T]
+}
+
+// envValue is an environment variable of the application, obtained from the container of a dependency
+type envValue[T testcontainers.Container] struct {
+	name  string
+	value func(ctx context.Context, c T) (string, error)
+}
+
+// Module returns a Dependency started by the RunContainer function of a module with its options, e.g.:
+//
+//	apptest.Module("db", postgres.RunContainer, postgres.WithDatabase("app"))
+func Module[T testcontainers.Container](alias string, run func(context.Context, ...testcontainers.ContainerCustomizer) (T, error), opts ...testcontainers.ContainerCustomizer) *ModuleDependency[T] {
+	return &ModuleDependency[T]{alias: alias, run: run, opts: opts}
+}
+
+// Generic returns a Dependency started as a generic container, e.g. a service without a module.
+func Generic(alias string, req testcontainers.GenericContainerRequest) *ModuleDependency[testcontainers.Container] {
+	run := func(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		r := req
+		r.Started = true
+		for _, opt := range opts {
+			opt.Customize(&r)
+		}
+
+		return testcontainers.GenericContainer(ctx, r)
+	}
+
+	return Module(alias, run)
+}
+
+// Alias returns the network alias of the dependency
+func (d *ModuleDependency[T]) Alias() string {
+	return d.alias
+}
+
+// WithEnv adds the environment variable to the application, with the value returned by the connection helper
+// of the module, e.g. its ConnectionString method. The addresses of the container in the value, i.e. its host
+// and mapped ports, are replaced with its network alias and ports, so that the application reaches it in the network:
+//
+//	apptest.Module("db", postgres.RunContainer).
+//		WithEnv("DATABASE_URL", func(ctx context.Context, c *postgres.PostgresContainer) (string, error) {
+//			return c.ConnectionString(ctx, "sslmode=disable")
+//		})
+func (d *ModuleDependency[T]) WithEnv(name string, value func(ctx context.Context, c T) (string, error)) *ModuleDependency[T] {
+	d.env = append(d.env, envValue[T]{name: name, value: value})
+	return d
+}
+
+func (d *ModuleDependency[T]) start(ctx context.Context, nw *testcontainers.DockerNetwork) (testcontainers.Container, map[string]string, error) {
+	opts := append(append([]testcontainers.ContainerCustomizer{}, d.opts...), network.WithNetwork([]string{d.alias}, nw))
+
+	c, err := d.run(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error starting the dependency %s: %w", d.alias, err)
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return c, nil, fmt.Errorf("error wiring the dependency %s: %w", d.alias, err)
+	}
+
+	ports, err := c.Ports(ctx)
+	if err != nil {
+		return c, nil, fmt.Errorf("error wiring the dependency %s: %w", d.alias, err)
+	}
+
+	env := dependencyEnv(d.alias, ports)
+
+	for _, e := range d.env {
+		value, err := e.value(ctx, c)
+		if err != nil {
+			return c, nil, fmt.Errorf("error wiring the dependency %s to %s: %w", d.alias, e.name, err)
+		}
+
+		env[e.name] = rewriteAddresses(value, host, d.alias, ports)
+	}
+
+	return c, env, nil
+}
+
+// dependencyEnv returns the environment variables with the address of the dependency in the network,
+// as SetEnv does for the test process, e.g. for the "db" alias:
+//   - DB_HOST: the network alias of the dependency.
+//   - DB_PORT: the lowest exposed port of the dependency.
+//   - DB_PORT_<port>: each TCP exposed port, e.g. DB_PORT_5432, and DB_PORT_<port>_UDP for the UDP ones.
+func dependencyEnv(alias string, ports nat.PortMap) map[string]string {
+	prefix := strings.ReplaceAll(alias, "-", "_")
+
+	env := map[string]string{
+		testcontainers.EnvName(prefix, "HOST"): alias,
+	}
+
+	exposedPorts := make([]nat.Port, 0, len(ports))
+	for port := range ports {
+		exposedPorts = append(exposedPorts, port)
+	}
+	nat.Sort(exposedPorts, func(i, j nat.Port) bool {
+		if i.Int() != j.Int() {
+			return i.Int() < j.Int()
+		}
+		return i.Proto() < j.Proto()
+	})
+
+	for i, port := range exposedPorts {
+		if i == 0 {
+			env[testcontainers.EnvName(prefix, "PORT")] = port.Port()
+		}
+
+		name := testcontainers.EnvName(prefix, "PORT", port.Port())
+		if port.Proto() != "tcp" {
+			name = testcontainers.EnvName(name, port.Proto())
+		}
+		env[name] = port.Port()
+	}
+
+	return env
+}
+
+// rewriteAddresses replaces the addresses of the container for the test process, i.e. the host with the
+// mapped ports, with the ones for the containers in its network, i.e. the alias with the exposed ports.
+func rewriteAddresses(value string, host string, alias string, ports nat.PortMap) string {
+	exposed := map[string]string{}
+	for port, bindings := range ports {
+		for _, binding := range bindings {
+			exposed[binding.HostPort] = port.Port()
+		}
+	}
+
+	address := regexp.MustCompile(regexp.QuoteMeta(host) + `:(\d+)`)
+
+	return address.ReplaceAllStringFunc(value, func(match string) string {
+		mappedPort := strings.TrimPrefix(match, host+":")
+		if port, ok := exposed[mappedPort]; ok {
+			return alias + ":" + port
+		}
+
+		return match
+	})
+}
+
+// App represents the application started by Run, with its dependencies
+type App struct {
+	testcontainers.Container
+	Network      *testcontainers.DockerNetwork
+	Dependencies map[string]testcontainers.Container // the containers of the dependencies, by alias
+	Env          map[string]string                   // the environment variables of the application
+
+	port       nat.Port
+	containers []testcontainers.Container // the dependencies, in start order
+}
+
+// Run starts the application image in a new network, after its dependencies, wiring it to them with environment
+// variables: the ones with the address of each dependency in the network, e.g. DB_HOST and DB_PORT for the "db"
+// alias, and the ones added with WithEnv. The application is ready when its health path answers 200.
+// If anything fails to start, the containers already started and the network are removed.
+func Run(ctx context.Context, req Request) (*App, error) {
+	nw, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the network of the application: %w", err)
+	}
+
+	app := &App{
+		Network:      nw,
+		Dependencies: map[string]testcontainers.Container{},
+		Env:          map[string]string{},
+		port:         nat.Port(req.Port),
+	}
+
+	for _, dep := range req.Dependencies {
+		if _, ok := app.Dependencies[dep.Alias()]; ok {
+			return nil, errors.Join(fmt.Errorf("duplicate dependency alias %s", dep.Alias()), app.Terminate(ctx))
+		}
+
+		c, env, err := dep.start(ctx, nw)
+		if c != nil {
+			app.Dependencies[dep.Alias()] = c
+			app.containers = append(app.containers, c)
+		}
+		if err != nil {
+			return nil, errors.Join(err, app.Terminate(ctx))
+		}
+
+		for k, v := range env {
+			app.Env[k] = v
+		}
+	}
+
+	for k, v := range req.Env {
+		app.Env[k] = v
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        req.Image,
+			ExposedPorts: []string{req.Port},
+			Env:          app.Env,
+		},
+		Started: true,
+	}
+
+	network.WithNetwork([]string{appAlias}, nw).Customize(&genericContainerReq)
+
+	for _, opt := range req.Options {
+		opt.Customize(&genericContainerReq)
+	}
+
+	if genericContainerReq.WaitingFor == nil {
+		if req.HealthPath != "" {
+			genericContainerReq.WaitingFor = wait.ForHTTP(req.HealthPath).WithPort(app.port)
+		} else {
+			genericContainerReq.WaitingFor = wait.ForListeningPort(app.port)
+		}
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	app.Container = c
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error starting the application: %w", err), app.Terminate(ctx))
+	}
+
+	return app, nil
+}
+
+// BaseURL returns the base URL of the application, with the format http://<host>:<port>
+func (a *App) BaseURL(ctx context.Context) (string, error) {
+	return a.PortEndpoint(ctx, a.port, "http")
+}
+
+// Terminate terminates the application and its dependencies, in reverse start order, and removes the network,
+// returning all the errors together.
+func (a *App) Terminate(ctx context.Context) error {
+	var errs []error
+
+	if a.Container != nil {
+		if err := a.Container.Terminate(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error terminating the application: %w", err))
+		}
+	}
+
+	for i := len(a.containers) - 1; i >= 0; i-- {
+		if err := a.containers[i].Terminate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := a.Network.Remove(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error removing the network of the application: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
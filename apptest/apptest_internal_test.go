@@ -0,0 +1,48 @@
+package apptest
+
+import (
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyEnv(t *testing.T) {
+	ports := nat.PortMap{
+		"5432/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "32768"}},
+		"8080/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "32769"}},
+		"53/udp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "32770"}},
+	}
+
+	env := dependencyEnv("my-db", ports)
+
+	require.Equal(t, map[string]string{
+		"MY_DB_HOST":        "my-db",
+		"MY_DB_PORT":        "53",
+		"MY_DB_PORT_53_UDP": "53",
+		"MY_DB_PORT_5432":   "5432",
+		"MY_DB_PORT_8080":   "8080",
+	}, env)
+}
+
+func TestRewriteAddresses(t *testing.T) {
+	ports := nat.PortMap{
+		"5432/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "32768"}},
+		"8080/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "3276"}},
+	}
+
+	t.Run("connection-string", func(t *testing.T) {
+		value := rewriteAddresses("postgres://user:pw@localhost:32768/app?sslmode=disable", "localhost", "db", ports)
+		require.Equal(t, "postgres://user:pw@db:5432/app?sslmode=disable", value)
+	})
+
+	t.Run("several-addresses", func(t *testing.T) {
+		value := rewriteAddresses("localhost:32768,localhost:3276", "localhost", "db", ports)
+		require.Equal(t, "db:5432,db:8080", value)
+	})
+
+	t.Run("unknown-port", func(t *testing.T) {
+		value := rewriteAddresses("http://localhost:9999", "localhost", "db", ports)
+		require.Equal(t, "http://localhost:9999", value)
+	})
+}
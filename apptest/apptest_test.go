@@ -0,0 +1,76 @@
+package apptest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/apptest"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// nginxTemplate makes nginx answer with the environment variables wiring it to the cache
+const nginxTemplate = `server {
+    listen 80;
+    location / {
+        return 200 "${CACHE_HOST}:${CACHE_PORT} ${CACHE_URL}";
+    }
+}
+`
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	// runApp {
+	cache := apptest.Generic("cache", testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "docker.io/redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForListeningPort("6379/tcp"),
+		},
+	}).WithEnv("CACHE_URL", func(ctx context.Context, c testcontainers.Container) (string, error) {
+		// the address of the container for the test process is replaced with the one in the network
+		return c.PortEndpoint(ctx, "6379/tcp", "redis")
+	})
+
+	app, err := apptest.Run(ctx, apptest.Request{
+		Image:        "docker.io/nginx:1.25-alpine",
+		Port:         "80/tcp",
+		HealthPath:   "/",
+		Dependencies: []apptest.Dependency{cache},
+		Options: []testcontainers.ContainerCustomizer{
+			testcontainers.CustomizeRequestOption(func(req *testcontainers.GenericContainerRequest) {
+				req.Files = append(req.Files, testcontainers.ContainerFile{
+					Reader:            strings.NewReader(nginxTemplate),
+					ContainerFilePath: "/etc/nginx/templates/default.conf.template",
+					FileMode:          0o644,
+				})
+			}),
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, app.Terminate(ctx))
+	})
+
+	baseURL, err := app.BaseURL(ctx)
+	require.NoError(t, err)
+	// }
+
+	resp, err := http.Get(baseURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "cache:6379 redis://cache:6379", string(body))
+
+	require.Equal(t, "cache", app.Env["CACHE_HOST"])
+	require.Equal(t, "6379", app.Env["CACHE_PORT_6379"])
+	require.Contains(t, app.Dependencies, "cache")
+}
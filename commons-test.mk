@@ -1,6 +1,10 @@
 ROOT_DIR:=$(shell dirname $(realpath $(lastword $(MAKEFILE_LIST))))
 GOBIN= $(GOPATH)/bin
 
+# Architectures the tests of a module run on. A module overrides it in its Makefile
+# when the images it uses are not available for all of them.
+ARCHS ?= amd64 arm64
+
 define go_install
     go install $(1)
 endef
@@ -40,6 +44,28 @@ test-%: $(GOBIN)/gotestsum
 		-coverprofile=coverage.out \
 		-timeout=30m
 
+# Runs the benchmarks, e.g. the ones generated by modulegen with --with-benchmarks, skipping the tests.
+.PHONY: bench
+bench:
+	@echo "Running benchmarks in $(CURDIR)..."
+	go test -run='^$$' -bench=. -benchmem -timeout=30m ./...
+
+# Runs the tests against the linux/<arch> images, e.g. "make test-arch-arm64", setting the platform of the images
+# with the image.platform property of Testcontainers, and DOCKER_DEFAULT_PLATFORM for the Docker CLI and Compose.
+# On a host of a different architecture, it requires the QEMU emulators to be installed.
+.PHONY: test-arch-%
+test-arch-%:
+	$(if $(filter $*,$(ARCHS)),,$(error the tests do not run on $*, only on: $(ARCHS)))
+	@echo "Running tests on linux/$*..."
+	TESTCONTAINERS_IMAGE_PLATFORM=linux/$* DOCKER_DEFAULT_PLATFORM=linux/$* $(MAKE) test
+
+# Runs the tests against the images of all the architectures in ARCHS.
+.PHONY: test-archs
+test-archs:
+	@for arch in $(ARCHS); do \
+		$(MAKE) test-arch-$$arch || exit 1; \
+	done
+
 .PHONY: tools
 tools:
 	go mod download
@@ -0,0 +1,18 @@
+// Package config exposes the effective configuration of Testcontainers, read from the
+// ~/.testcontainers.properties file and the environment variables, e.g. to debug it.
+package config
+
+import (
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+// Config is the effective configuration of Testcontainers.
+// The zero durations use the defaults of the library.
+type Config = config.Config
+
+// Read returns the effective configuration of Testcontainers, where the environment variables
+// take precedence over the properties of the ~/.testcontainers.properties file.
+// The configuration is read once, so the later changes are not taken into account.
+func Read() Config {
+	return config.Read()
+}
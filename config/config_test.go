@@ -0,0 +1,31 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/testcontainers/testcontainers-go/config"
+	internalconfig "github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestRead(t *testing.T) {
+	t.Cleanup(internalconfig.Reset)
+
+	// do not mess with local .testcontainers.properties
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("USERPROFILE", tmpDir) // Windows support
+	t.Setenv("TESTCONTAINERS_WAIT_STARTUP_TIMEOUT", "90s")
+	t.Setenv("TESTCONTAINERS_PULL_TIMEOUT", "10m")
+	internalconfig.Reset()
+
+	// readConfig {
+	cfg := config.Read()
+	// }
+
+	assert.Equal(t, 90*time.Second, cfg.StartupTimeout)
+	assert.Equal(t, 10*time.Minute, cfg.PullTimeout)
+	assert.Zero(t, cfg.WaitPollInterval)
+}
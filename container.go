@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -71,8 +73,10 @@ type ImageBuildInfo interface {
 	GetDockerfile() string                          // the relative path to the Dockerfile, including the fileitself
 	GetRepo() string                                // get repo label for image
 	GetTag() string                                 // get tag label for image
-	ShouldPrintBuildLog() bool                      // allow build log to be printed to stdout
+	ShouldPrintBuildLog() bool                      // Deprecated: use BuildLogWriter instead. Allow build log to be printed to stdout
+	BuildLogWriter() io.Writer                      // for output of build log, defaults to io.Discard
 	ShouldBuildImage() bool                         // return true if the image needs to be built
+	ShouldUseImageDigest() bool                     // return true if the container must be created from the digest of the built image
 	GetBuildArgs() map[string]*string               // return the environment args used to build the from Dockerfile
 	GetAuthConfigs() map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Return the auth configs to be able to pull from an authenticated docker registry
 }
@@ -86,7 +90,8 @@ type FromDockerfile struct {
 	Repo           string                         // the repo label for image, defaults to UUID
 	Tag            string                         // the tag label for image, defaults to UUID
 	BuildArgs      map[string]*string             // enable user to pass build args to docker daemon
-	PrintBuildLog  bool                           // enable user to print build log
+	PrintBuildLog  bool                           // Deprecated: use BuildLogWriter instead. Enable user to print build log
+	BuildLogWriter io.Writer                      // for output of build log, use os.Stdout or os.Stderr to print it, defaults to io.Discard
 	AuthConfigs    map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Enable auth configs to be able to pull from an authenticated docker registry
 	// KeepImage describes whether DockerContainer.Terminate should not delete the
 	// container image. Useful for images that are built from a Dockerfile and take a
@@ -96,19 +101,40 @@ type FromDockerfile struct {
 	// advanced configurations while building the image. Please consider that the modifier
 	// is called after the default build options are set.
 	BuildOptionsModifier func(*types.ImageBuildOptions)
+	// UseImageDigest describes whether the container is created from the digest of the built image,
+	// as reported by the build, instead of from its tag. If Repo and Tag are not set, the image is not tagged,
+	// which skips the tag roundtrips in build-then-run loops, e.g. with the containerd image store.
+	UseImageDigest bool
 }
 
 type ContainerFile struct {
-	HostFilePath      string    // If Reader is present, HostFilePath is ignored
+	HostFilePath      string    // If Reader or FS are present, HostFilePath is ignored. It can be a directory
 	Reader            io.Reader // If Reader is present, HostFilePath is ignored
+	Archive           bool      // If true, Reader is a tar archive, which can be compressed, extracted into ContainerFilePath as a directory
+	FS                fs.FS     // If FS is present, its whole tree is copied into ContainerFilePath as a directory, and Reader and HostFilePath are ignored
 	ContainerFilePath string
-	FileMode          int64
+	FileMode          int64 // A zero value keeps the mode of each file for HostFilePath and FS, and is 0o644 for Reader
+	UID               int   // If UID or GID are not zero, the user ID owning the files in the container
+	GID               int   // If UID or GID are not zero, the group ID owning the files in the container
+}
+
+// copyOptions returns the options to copy the file to the container, setting its ownership if UID or GID are set.
+func (c *ContainerFile) copyOptions() []CopyOption {
+	if c.UID == 0 && c.GID == 0 {
+		return nil
+	}
+
+	return []CopyOption{CopyWithOwner(c.UID, c.GID)}
 }
 
 // validate validates the ContainerFile
 func (c *ContainerFile) validate() error {
-	if c.HostFilePath == "" && c.Reader == nil {
-		return errors.New("either HostFilePath or Reader must be specified")
+	if c.HostFilePath == "" && c.Reader == nil && c.FS == nil {
+		return errors.New("either HostFilePath, Reader or FS must be specified")
+	}
+
+	if c.Archive && c.Reader == nil {
+		return errors.New("Reader must be specified for an archive")
 	}
 
 	if c.ContainerFilePath == "" {
@@ -139,6 +165,7 @@ type ContainerRequest struct {
 	Privileged              bool                                       // For starting privileged container
 	Networks                []string                                   // for specifying network names
 	NetworkAliases          map[string][]string                        // for specifying network aliases
+	NetworkAttachments      []NetworkAttachment                        // for attaching the container to networks at creation, with per-network aliases and static IPs
 	NetworkMode             container.NetworkMode                      // Deprecated: Use HostConfigModifier instead
 	Resources               container.Resources                        // Deprecated: Use HostConfigModifier instead
 	Files                   []ContainerFile                            // files which will be copied when container starts
@@ -147,10 +174,15 @@ type ContainerRequest struct {
 	ReaperImage             string                                     // Deprecated: use WithImageName ContainerOption instead. Alternative reaper image
 	ReaperOptions           []ContainerOption                          // Deprecated: the reaper is configured at the properties level, for an entire test session
 	AutoRemove              bool                                       // Deprecated: Use HostConfigModifier instead. If set to true, the container will be removed from the host when stopped
-	AlwaysPullImage         bool                                       // Always pull image
-	ImagePlatform           string                                     // ImagePlatform describes the platform which the image runs on.
+	AlwaysPullImage         bool                                       // Always pull image, same as setting ImagePullPolicy to PullAlways
+	ImagePullPolicy         ImagePullPolicy                            // ImagePullPolicy defines when the image is pulled. Defaults to PullIfNotPresent
+	ImageDigest             string                                     // ImageDigest is the digest the image must match, e.g. sha256:..., verified before the container is created
+	ImagePlatform           string                                     // ImagePlatform describes the platform which the image runs on. Defaults to the image.platform property, if set.
 	Binds                   []string                                   // Deprecated: Use HostConfigModifier instead
 	ShmSize                 int64                                      // Amount of memory shared with the host (in bytes)
+	DeviceRequests          []container.DeviceRequest                  // Requests for devices to the device drivers, e.g. GPUs. See WithGPUs
+	Devices                 []container.DeviceMapping                  // Devices of the host mapped into the container, e.g. /dev/fuse. See WithDevices
+	HealthCheck             *container.HealthConfig                    // HealthCheck of the container, overriding the HEALTHCHECK of the image. See WithHealthCheck
 	CapAdd                  []string                                   // Deprecated: Use HostConfigModifier instead. Add Linux capabilities
 	CapDrop                 []string                                   // Deprecated: Use HostConfigModifier instead. Drop Linux capabilities
 	ConfigModifier          func(*container.Config)                    // Modifier for the config before container creation
@@ -160,6 +192,28 @@ type ContainerRequest struct {
 	LogConsumerCfg          *LogConsumerConfig                         // define the configuration for the log producer and its log consumers to follow the logs
 }
 
+// ImagePullPolicy defines when the image of a container request is pulled from the registry
+type ImagePullPolicy string
+
+const (
+	// PullAlways pulls the image on every container creation, even if it is present locally
+	PullAlways ImagePullPolicy = "Always"
+	// PullIfNotPresent pulls the image only if it is not present locally, or if it does not match
+	// the requested platform or digest. It's the default policy
+	PullIfNotPresent ImagePullPolicy = "IfNotPresent"
+	// PullNever never pulls the image, failing if it is not present locally
+	PullNever ImagePullPolicy = "Never"
+)
+
+var (
+	ErrInvalidImagePullPolicy = errors.New("invalid image pull policy")
+	ErrInvalidImageDigest     = errors.New("invalid image digest")
+	ErrImageDigestMismatch    = errors.New("image digest mismatch")
+)
+
+// imageDigestRegex matches a content-addressable digest, e.g. sha256:<64 hex chars>
+var imageDigestRegex = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+
 // containerOptions functional options for a container
 type containerOptions struct {
 	ImageName           string
@@ -193,6 +247,7 @@ func (c *ContainerRequest) Validate() error {
 		c.validateContextAndImage,
 		c.validateContextOrImageIsSpecified,
 		c.validateMounts,
+		c.validateImagePullPolicy,
 	}
 
 	var err error
@@ -333,10 +388,31 @@ func (c *ContainerRequest) ShouldKeepBuiltImage() bool {
 	return c.FromDockerfile.KeepImage
 }
 
+// ShouldUseImageDigest returns true if the container must be created from the digest of the built image
+func (c *ContainerRequest) ShouldUseImageDigest() bool {
+	return c.FromDockerfile.UseImageDigest
+}
+
+// Deprecated: use BuildLogWriter instead
 func (c *ContainerRequest) ShouldPrintBuildLog() bool {
 	return c.FromDockerfile.PrintBuildLog
 }
 
+// BuildLogWriter returns the io.Writer for the output of the build log when building a Docker image
+// from a Dockerfile. It returns the BuildLogWriter from the ContainerRequest, defaults to io.Discard.
+// For backward compatibility, if BuildLogWriter is not set and PrintBuildLog is true, it returns os.Stderr.
+func (c *ContainerRequest) BuildLogWriter() io.Writer {
+	if c.FromDockerfile.BuildLogWriter != nil {
+		return c.FromDockerfile.BuildLogWriter
+	}
+
+	if c.FromDockerfile.PrintBuildLog {
+		return os.Stderr
+	}
+
+	return io.Discard
+}
+
 // BuildOptions returns the image build options when building a Docker image from a Dockerfile.
 // It will apply some defaults and finally call the BuildOptionsModifier from the FromDockerfile struct,
 // if set.
@@ -371,6 +447,11 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 		buildOptions.AuthConfigs[registry] = authConfig
 	}
 
+	// images referenced by digest are tagged only if requested
+	if c.ShouldUseImageDigest() && c.FromDockerfile.Repo == "" && c.FromDockerfile.Tag == "" {
+		return buildOptions, nil
+	}
+
 	// make sure the first tag is the one defined in the ContainerRequest
 	tag := fmt.Sprintf("%s:%s", c.GetRepo(), c.GetTag())
 	if len(buildOptions.Tags) > 0 {
@@ -399,6 +480,46 @@ func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
 	return nil
 }
 
+// validateImagePullPolicy ensures that the pull policy is known, and that the image digest,
+// if any, is valid and not combined with an image built from a Dockerfile.
+func (c *ContainerRequest) validateImagePullPolicy() error {
+	switch c.ImagePullPolicy {
+	case "", PullAlways, PullIfNotPresent, PullNever:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidImagePullPolicy, c.ImagePullPolicy)
+	}
+
+	if c.AlwaysPullImage && c.ImagePullPolicy == PullNever {
+		return fmt.Errorf("%w: AlwaysPullImage cannot be combined with %s", ErrInvalidImagePullPolicy, PullNever)
+	}
+
+	if c.ImageDigest != "" {
+		if c.ShouldBuildImage() {
+			return errors.New("you cannot specify an image digest for an image built from a Dockerfile")
+		}
+
+		if !imageDigestRegex.MatchString(c.ImageDigest) {
+			return fmt.Errorf("%w: %s", ErrInvalidImageDigest, c.ImageDigest)
+		}
+	}
+
+	return nil
+}
+
+// imagePullPolicy returns the effective pull policy of the request,
+// honouring the AlwaysPullImage field.
+func (c *ContainerRequest) imagePullPolicy() ImagePullPolicy {
+	if c.AlwaysPullImage {
+		return PullAlways
+	}
+
+	if c.ImagePullPolicy == "" {
+		return PullIfNotPresent
+	}
+
+	return c.ImagePullPolicy
+}
+
 // validateMounts ensures that the mounts do not have duplicate targets.
 // It will check the Mounts and HostConfigModifier.Binds fields.
 func (c *ContainerRequest) validateMounts() error {
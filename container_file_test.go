@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 func TestContainerFileValidation(t *testing.T) {
@@ -36,15 +37,39 @@ func TestContainerFileValidation(t *testing.T) {
 				ContainerFilePath: "/path/to/container",
 			},
 		},
+		{
+			Name: "valid container file: has file system",
+			File: ContainerFile{
+				FS:                fstest.MapFS{"hello.sh": {Data: []byte("echo hello")}},
+				ContainerFilePath: "/path/to/container",
+			},
+		},
+		{
+			Name: "valid container file: has archive",
+			File: ContainerFile{
+				Reader:            f,
+				Archive:           true,
+				ContainerFilePath: "/path/to/container",
+			},
+		},
 		{
 			Name:          "invalid container file",
-			ExpectedError: errors.New("either HostFilePath or Reader must be specified"),
+			ExpectedError: errors.New("either HostFilePath, Reader or FS must be specified"),
 			File: ContainerFile{
 				HostFilePath:      "",
 				Reader:            nil,
 				ContainerFilePath: "/path/to/container",
 			},
 		},
+		{
+			Name:          "invalid container file: archive without reader",
+			ExpectedError: errors.New("Reader must be specified for an archive"),
+			File: ContainerFile{
+				HostFilePath:      "/path/to/host",
+				Archive:           true,
+				ContainerFilePath: "/path/to/container",
+			},
+		},
 		{
 			Name:          "invalid container file",
 			ExpectedError: errors.New("ContainerFilePath must be specified"),
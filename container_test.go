@@ -84,6 +84,57 @@ func Test_ContainerValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:          "Can set a valid pull policy",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:           "redis:latest",
+				ImagePullPolicy: testcontainers.PullNever,
+			},
+		},
+		{
+			Name:          "Invalid pull policy",
+			ExpectedError: errors.New("invalid image pull policy: Sometimes"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:           "redis:latest",
+				ImagePullPolicy: "Sometimes",
+			},
+		},
+		{
+			Name:          "Cannot always pull with the Never pull policy",
+			ExpectedError: errors.New("invalid image pull policy: AlwaysPullImage cannot be combined with Never"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:           "redis:latest",
+				AlwaysPullImage: true,
+				ImagePullPolicy: testcontainers.PullNever,
+			},
+		},
+		{
+			Name:          "Can set a valid image digest",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:       "redis:latest",
+				ImageDigest: "sha256:6a4e9ad2ecd1f1dd4cbd4d4d3f1ab4e2bb8d32d5a6db4af8d5a1c1c2d9c67b2c",
+			},
+		},
+		{
+			Name:          "Invalid image digest",
+			ExpectedError: errors.New("invalid image digest: 6a4e9ad2"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:       "redis:latest",
+				ImageDigest: "6a4e9ad2",
+			},
+		},
+		{
+			Name:          "Cannot set an image digest when building from a Dockerfile",
+			ExpectedError: errors.New("you cannot specify an image digest for an image built from a Dockerfile"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				FromDockerfile: testcontainers.FromDockerfile{
+					Context: ".",
+				},
+				ImageDigest: "sha256:6a4e9ad2ecd1f1dd4cbd4d4d3f1ab4e2bb8d32d5a6db4af8d5a1c1c2d9c67b2c",
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
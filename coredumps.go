@@ -0,0 +1,66 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+// CoreDumpsDir is the directory of the container where the host directory passed to WithCoreDumps is mounted.
+// The core_pattern of the kernel running the containers must write the core dumps into it, e.g. /tmp/cores/core.%e.%p,
+// as the core_pattern is not namespaced and cannot be set per container.
+const CoreDumpsDir = "/tmp/cores"
+
+// WithCoreDumps makes the processes of the container dump their core when they crash, removing the size limit
+// of the core dumps and mounting the given host directory at CoreDumpsDir, so that the core dumps, and any other
+// crash artifact written into that directory, survive the container. The host directory is created if it does
+// not exist, and it must be reachable by the Docker daemon. Use CoreDumps to retrieve them after a crash.
+func WithCoreDumps(hostDir string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if absDir, err := filepath.Abs(hostDir); err == nil {
+			hostDir = absDir
+		}
+
+		chainHostConfigModifier(req, func(hostConfig *container.HostConfig) {
+			hostConfig.Ulimits = append(hostConfig.Ulimits, &units.Ulimit{Name: "core", Soft: -1, Hard: -1})
+			hostConfig.Binds = append(hostConfig.Binds, hostDir+":"+CoreDumpsDir)
+		})
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreCreates: []ContainerRequestHook{
+				func(_ context.Context, _ ContainerRequest) error {
+					if err := os.MkdirAll(hostDir, 0o755); err != nil {
+						return fmt.Errorf("error creating the core dumps dir: %w", err)
+					}
+
+					// the processes of the container can run as any user
+					return os.Chmod(hostDir, 0o777)
+				},
+			},
+		})
+	}
+}
+
+// CoreDumps returns the paths of the core dumps, and any other crash artifact, found in the host directory
+// passed to WithCoreDumps, e.g. to keep them as artifacts of a failed test.
+func CoreDumps(hostDir string) ([]string, error) {
+	entries, err := os.ReadDir(hostDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the core dumps dir: %w", err)
+	}
+
+	dumps := []string{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		dumps = append(dumps, filepath.Join(hostDir, entry.Name()))
+	}
+
+	return dumps, nil
+}
@@ -0,0 +1,110 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWithCoreDumps(t *testing.T) {
+	hostDir := filepath.Join(t.TempDir(), "cores")
+
+	req := &GenericContainerRequest{}
+	WithCoreDumps(hostDir).Customize(req)
+
+	hostConfig := &container.HostConfig{}
+	req.HostConfigModifier(hostConfig)
+
+	assert.Equal(t, []*units.Ulimit{{Name: "core", Soft: -1, Hard: -1}}, hostConfig.Ulimits)
+	assert.Equal(t, []string{hostDir + ":" + CoreDumpsDir}, hostConfig.Binds)
+
+	require.Len(t, req.LifecycleHooks, 1)
+	require.Len(t, req.LifecycleHooks[0].PreCreates, 1)
+
+	err := req.LifecycleHooks[0].PreCreates[0](context.Background(), req.ContainerRequest)
+	require.NoError(t, err)
+
+	info, err := os.Stat(hostDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.Equal(t, os.FileMode(0o777), info.Mode().Perm())
+}
+
+func TestCoreDumps(t *testing.T) {
+	t.Run("files", func(t *testing.T) {
+		hostDir := t.TempDir()
+
+		require.NoError(t, os.WriteFile(filepath.Join(hostDir, "core.app.42"), []byte("core"), 0o644))
+		require.NoError(t, os.Mkdir(filepath.Join(hostDir, "subdir"), 0o755))
+
+		dumps, err := CoreDumps(hostDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(hostDir, "core.app.42")}, dumps)
+	})
+
+	t.Run("missing-dir", func(t *testing.T) {
+		_, err := CoreDumps(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+func TestWithCoreDumps_container(t *testing.T) {
+	ctx := context.Background()
+
+	// withCoreDumps {
+	hostDir := filepath.Join(t.TempDir(), "cores")
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/alpine",
+			// crash a process running in the core dumps dir, where relative core patterns write the core dumps
+			Cmd:        []string{"sh", "-c", "cat /proc/sys/kernel/core_pattern; cd " + CoreDumpsDir + "; sh -c 'sleep 60' & pid=$!; sleep 1; kill -SEGV $pid; wait $pid; echo $?"},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	}
+	WithCoreDumps(hostDir).Customize(&req)
+	// }
+
+	c, err := GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	logs, err := c.Logs(ctx)
+	require.NoError(t, err)
+	defer logs.Close()
+
+	out, err := io.ReadAll(logs)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 2)
+
+	corePattern := strings.TrimSpace(lines[0])
+	if strings.HasPrefix(corePattern, "|") || (strings.HasPrefix(corePattern, "/") && !strings.HasPrefix(corePattern, CoreDumpsDir+"/")) {
+		t.Skipf("the core_pattern of the kernel, %q, does not write the core dumps into %s", corePattern, CoreDumpsDir)
+	}
+
+	// 139 is 128 + SIGSEGV, with the core dumped
+	assert.Equal(t, "139", strings.TrimSpace(lines[1]))
+
+	// coreDumps {
+	dumps, err := CoreDumps(hostDir)
+	// }
+	require.NoError(t, err)
+	require.Len(t, dumps, 1)
+
+	info, err := os.Stat(dumps[0])
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+}
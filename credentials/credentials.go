@@ -0,0 +1,169 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+const (
+	lowerChars = "abcdefghijklmnopqrstuvwxyz"
+	upperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars = "0123456789"
+)
+
+// Policy defines the length and the character classes of the generated secrets.
+// Each enabled class is used at least once.
+type Policy struct {
+	Length int
+	Lower  bool
+	Upper  bool
+	Digits bool
+	// Symbols are the symbols allowed in the secret. If empty, no symbols are used.
+	Symbols string
+	// LetterFirst forces the first character to be a letter, as required by most usernames.
+	LetterFirst bool
+}
+
+var (
+	// PasswordPolicy is the policy of the passwords: 24 alphanumeric characters, safe to use in URLs
+	// and connection strings without escaping.
+	PasswordPolicy = Policy{Length: 24, Lower: true, Upper: true, Digits: true}
+
+	// ComplexPasswordPolicy is the policy of the passwords of databases enforcing a complexity policy,
+	// e.g. SQL Server: 24 characters, including symbols.
+	ComplexPasswordPolicy = Policy{Length: 24, Lower: true, Upper: true, Digits: true, Symbols: "!#%*-_=+"}
+
+	// UsernamePolicy is the policy of the usernames: 12 lowercase letters and digits, starting with a letter.
+	UsernamePolicy = Policy{Length: 12, Lower: true, Digits: true, LetterFirst: true}
+
+	// TokenPolicy is the policy of the tokens, e.g. API keys: 32 alphanumeric characters.
+	TokenPolicy = Policy{Length: 32, Lower: true, Upper: true, Digits: true}
+)
+
+// Generate returns a random secret following the policy, using a cryptographically secure random generator.
+func Generate(policy Policy) (string, error) {
+	classes := []string{}
+	if policy.Lower {
+		classes = append(classes, lowerChars)
+	}
+	if policy.Upper {
+		classes = append(classes, upperChars)
+	}
+	if policy.Digits {
+		classes = append(classes, digitChars)
+	}
+	if policy.Symbols != "" {
+		classes = append(classes, policy.Symbols)
+	}
+
+	if len(classes) == 0 {
+		return "", errors.New("the policy allows no characters")
+	}
+	if policy.Length < len(classes) {
+		return "", fmt.Errorf("the length of the policy, %d, is lower than its %d character classes", policy.Length, len(classes))
+	}
+
+	charset := strings.Join(classes, "")
+	letters := ""
+	if policy.Lower {
+		letters += lowerChars
+	}
+	if policy.Upper {
+		letters += upperChars
+	}
+	if policy.LetterFirst && letters == "" {
+		return "", errors.New("the policy requires a letter first, but allows no letters")
+	}
+
+	// secrets missing a character class are discarded, so that the characters are uniformly distributed
+	for {
+		secret := make([]byte, policy.Length)
+		for i := range secret {
+			chars := charset
+			if i == 0 && policy.LetterFirst {
+				chars = letters
+			}
+
+			c, err := randomChar(chars)
+			if err != nil {
+				return "", fmt.Errorf("error generating a random secret: %w", err)
+			}
+			secret[i] = c
+		}
+
+		if hasAllClasses(string(secret), classes) {
+			return string(secret), nil
+		}
+	}
+}
+
+// Deterministic returns true if the modules must use their documented, hardcoded credentials
+// instead of random ones, as configured with the credentials.deterministic property,
+// or the TESTCONTAINERS_DETERMINISTIC_CREDENTIALS environment variable.
+func Deterministic() bool {
+	return config.Read().DeterministicCredentials
+}
+
+// Password returns a random password following the PasswordPolicy,
+// or the given deterministic password if deterministic credentials are enabled.
+func Password(deterministic string) (string, error) {
+	return generateOr(deterministic, PasswordPolicy)
+}
+
+// Username returns a random username following the UsernamePolicy,
+// or the given deterministic username if deterministic credentials are enabled.
+func Username(deterministic string) (string, error) {
+	return generateOr(deterministic, UsernamePolicy)
+}
+
+// Token returns a random token following the TokenPolicy,
+// or the given deterministic token if deterministic credentials are enabled.
+func Token(deterministic string) (string, error) {
+	return generateOr(deterministic, TokenPolicy)
+}
+
+// WithPolicy returns a random secret following the policy,
+// or the given deterministic secret if deterministic credentials are enabled.
+func WithPolicy(deterministic string, policy Policy) (string, error) {
+	return generateOr(deterministic, policy)
+}
+
+// Equal compares two secrets in constant time, so that the comparison does not leak their content
+// through timing, e.g. when checking the credentials received by a fake server.
+func Equal(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func generateOr(deterministic string, policy Policy) (string, error) {
+	if Deterministic() {
+		return deterministic, nil
+	}
+
+	return Generate(policy)
+}
+
+// randomChar returns a uniformly distributed random character of the string
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[n.Int64()], nil
+}
+
+func hasAllClasses(secret string, classes []string) bool {
+	for _, class := range classes {
+		if !strings.ContainsAny(secret, class) {
+			return false
+		}
+	}
+
+	return true
+}
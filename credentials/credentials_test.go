@@ -0,0 +1,96 @@
+package credentials_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("password", func(t *testing.T) {
+		password, err := credentials.Generate(credentials.PasswordPolicy)
+		require.NoError(t, err)
+
+		assert.Len(t, password, 24)
+		assert.True(t, strings.ContainsAny(password, "abcdefghijklmnopqrstuvwxyz"))
+		assert.True(t, strings.ContainsAny(password, "ABCDEFGHIJKLMNOPQRSTUVWXYZ"))
+		assert.True(t, strings.ContainsAny(password, "0123456789"))
+	})
+
+	t.Run("complex-password", func(t *testing.T) {
+		password, err := credentials.Generate(credentials.ComplexPasswordPolicy)
+		require.NoError(t, err)
+
+		assert.Len(t, password, 24)
+		assert.True(t, strings.ContainsAny(password, credentials.ComplexPasswordPolicy.Symbols))
+	})
+
+	t.Run("username", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			username, err := credentials.Generate(credentials.UsernamePolicy)
+			require.NoError(t, err)
+
+			assert.Len(t, username, 12)
+			assert.True(t, unicode.IsLower(rune(username[0])), username)
+			assert.Equal(t, strings.ToLower(username), username)
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		a, err := credentials.Generate(credentials.TokenPolicy)
+		require.NoError(t, err)
+		b, err := credentials.Generate(credentials.TokenPolicy)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("invalid-policies", func(t *testing.T) {
+		_, err := credentials.Generate(credentials.Policy{Length: 10})
+		require.Error(t, err)
+
+		_, err = credentials.Generate(credentials.Policy{Length: 2, Lower: true, Upper: true, Digits: true})
+		require.Error(t, err)
+
+		_, err = credentials.Generate(credentials.Policy{Length: 10, Digits: true, LetterFirst: true})
+		require.Error(t, err)
+	})
+}
+
+func TestDeterministic(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	t.Run("random", func(t *testing.T) {
+		t.Setenv("TESTCONTAINERS_DETERMINISTIC_CREDENTIALS", "false")
+		config.Reset()
+
+		password, err := credentials.Password("test")
+		require.NoError(t, err)
+		assert.NotEqual(t, "test", password)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Setenv("TESTCONTAINERS_DETERMINISTIC_CREDENTIALS", "true")
+		config.Reset()
+
+		password, err := credentials.Password("test")
+		require.NoError(t, err)
+		assert.Equal(t, "test", password)
+
+		username, err := credentials.Username("test")
+		require.NoError(t, err)
+		assert.Equal(t, "test", username)
+	})
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, credentials.Equal("s3cr3t", "s3cr3t"))
+	assert.False(t, credentials.Equal("s3cr3t", "s3cr3T"))
+	assert.False(t, credentials.Equal("s3cr3t", "s3cr3t!"))
+}
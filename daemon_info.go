@@ -0,0 +1,143 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// Capability is a feature of the Docker daemon that tests may depend on
+type Capability string
+
+const (
+	// CapabilityBuildKit is available if the daemon builds the images with BuildKit
+	CapabilityBuildKit Capability = "buildkit"
+	// CapabilityCgroupV2 is available if the daemon runs on a host using the cgroup v2 hierarchy
+	CapabilityCgroupV2 Capability = "cgroupv2"
+	// CapabilityGPU is available if the daemon provides the NVIDIA runtime, needed by WithGPUs
+	CapabilityGPU Capability = "gpu"
+	// CapabilityLinuxContainers is available if the daemon runs Linux containers
+	CapabilityLinuxContainers Capability = "linux"
+	// CapabilityRootless is available if the daemon runs in rootless mode
+	CapabilityRootless Capability = "rootless"
+	// CapabilityUserNamespaces is available if the daemon remaps the users of the containers with user namespaces
+	CapabilityUserNamespaces Capability = "userns"
+)
+
+// DaemonCapabilities describes the Docker daemon the containers run on
+type DaemonCapabilities struct {
+	// ServerVersion is the version of the Docker daemon, e.g. 25.0.3
+	ServerVersion string
+	// APIVersion is the maximum API version supported by the Docker daemon, e.g. 1.44
+	APIVersion string
+	// OperatingSystem is the name of the operating system of the host, e.g. "Docker Desktop"
+	OperatingSystem string
+	// Environment is the kind of environment running the daemon: "native", "docker-desktop", "colima", "lima" or "remote".
+	// The daemons running in a VM, i.e. Docker Desktop, Colima and Lima, cannot mount the paths of the host.
+	Environment string
+	// OSType is the operating system of the containers, "linux" or "windows"
+	OSType string
+	// Architecture is the hardware architecture of the host, e.g. x86_64 or aarch64
+	Architecture string
+	// CgroupVersion is the version of the cgroup hierarchy of the host, "1" or "2"
+	CgroupVersion string
+	// StorageDriver is the storage driver of the daemon, e.g. overlay2
+	StorageDriver string
+	// Runtimes are the names of the OCI runtimes of the daemon, sorted, e.g. runc
+	Runtimes []string
+	// BuildKit is true if the daemon builds the images with BuildKit
+	BuildKit bool
+	// UserNamespaces is true if the daemon remaps the users of the containers with user namespaces
+	UserNamespaces bool
+	// Rootless is true if the daemon runs in rootless mode
+	Rootless bool
+}
+
+// DaemonInfo returns the capabilities of the Docker daemon resolved by testcontainers,
+// so tests can be skipped or adjusted on the environments not supporting them.
+// See RequireCapability to skip the tests.
+func DaemonInfo(ctx context.Context) (DaemonCapabilities, error) {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return DaemonCapabilities{}, err
+	}
+	defer cli.Close()
+
+	info, err := cli.Info(ctx)
+	if err != nil {
+		return DaemonCapabilities{}, err
+	}
+
+	ping, err := cli.Ping(ctx)
+	if err != nil {
+		return DaemonCapabilities{}, fmt.Errorf("failed to ping the docker daemon: %w", err)
+	}
+
+	caps := newDaemonCapabilities(info, ping)
+	caps.Environment = string(core.DetectDockerEnvironment(cli.DaemonHost(), info))
+
+	return caps, nil
+}
+
+// newDaemonCapabilities builds the capabilities of the daemon from its info and ping responses
+func newDaemonCapabilities(info system.Info, ping types.Ping) DaemonCapabilities {
+	caps := DaemonCapabilities{
+		ServerVersion:   info.ServerVersion,
+		APIVersion:      ping.APIVersion,
+		OperatingSystem: info.OperatingSystem,
+		OSType:          info.OSType,
+		Architecture:    info.Architecture,
+		CgroupVersion:   info.CgroupVersion,
+		StorageDriver:   info.Driver,
+		BuildKit:        ping.BuilderVersion == types.BuilderBuildKit,
+	}
+
+	for name := range info.Runtimes {
+		caps.Runtimes = append(caps.Runtimes, name)
+	}
+	sort.Strings(caps.Runtimes)
+
+	// the security options are in the name=<option>,<key>=<value> format, e.g. name=seccomp,profile=default
+	for _, opt := range info.SecurityOptions {
+		name, _, _ := strings.Cut(strings.TrimPrefix(opt, "name="), ",")
+		switch name {
+		case "userns":
+			caps.UserNamespaces = true
+		case "rootless":
+			caps.Rootless = true
+		}
+	}
+
+	return caps
+}
+
+// Has returns true if the daemon provides the capability
+func (d DaemonCapabilities) Has(capability Capability) bool {
+	switch capability {
+	case CapabilityBuildKit:
+		return d.BuildKit
+	case CapabilityCgroupV2:
+		return d.CgroupVersion == "2"
+	case CapabilityGPU:
+		for _, runtime := range d.Runtimes {
+			if runtime == "nvidia" {
+				return true
+			}
+		}
+		return false
+	case CapabilityLinuxContainers:
+		return d.OSType == "linux"
+	case CapabilityRootless:
+		return d.Rootless
+	case CapabilityUserNamespaces:
+		return d.UserNamespaces
+	default:
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDaemonCapabilities(t *testing.T) {
+	info := system.Info{
+		ServerVersion:   "25.0.3",
+		OperatingSystem: "Ubuntu 22.04.3 LTS",
+		OSType:          "linux",
+		Architecture:    "x86_64",
+		CgroupVersion:   "2",
+		Driver:          "overlay2",
+		Runtimes: map[string]system.RuntimeWithStatus{
+			"runc":   {},
+			"nvidia": {},
+		},
+		SecurityOptions: []string{"name=seccomp,profile=builtin", "name=rootless", "name=cgroupns"},
+	}
+	ping := types.Ping{APIVersion: "1.44", BuilderVersion: types.BuilderBuildKit}
+
+	caps := newDaemonCapabilities(info, ping)
+
+	assert.Equal(t, DaemonCapabilities{
+		ServerVersion:   "25.0.3",
+		APIVersion:      "1.44",
+		OperatingSystem: "Ubuntu 22.04.3 LTS",
+		OSType:          "linux",
+		Architecture:    "x86_64",
+		CgroupVersion:   "2",
+		StorageDriver:   "overlay2",
+		Runtimes:        []string{"nvidia", "runc"},
+		BuildKit:        true,
+		Rootless:        true,
+	}, caps)
+
+	for _, capability := range []Capability{CapabilityBuildKit, CapabilityCgroupV2, CapabilityGPU, CapabilityLinuxContainers, CapabilityRootless} {
+		assert.True(t, caps.Has(capability), capability)
+	}
+	assert.False(t, caps.Has(CapabilityUserNamespaces))
+	assert.False(t, caps.Has("unknown"))
+
+	caps = newDaemonCapabilities(system.Info{OSType: "windows", SecurityOptions: []string{"name=userns"}}, types.Ping{BuilderVersion: types.BuilderV1})
+	assert.True(t, caps.Has(CapabilityUserNamespaces))
+	assert.False(t, caps.Has(CapabilityBuildKit))
+	assert.False(t, caps.Has(CapabilityLinuxContainers))
+	assert.False(t, caps.Has(CapabilityGPU))
+}
+
+func TestDaemonInfo(t *testing.T) {
+	// daemonInfo {
+	info, err := DaemonInfo(context.Background())
+	// }
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, info.ServerVersion)
+	assert.NotEmpty(t, info.APIVersion)
+	assert.True(t, info.Has(CapabilityLinuxContainers))
+}
+
+func TestRequireCapability(t *testing.T) {
+	// requireCapability {
+	RequireCapability(t, CapabilityLinuxContainers)
+	// }
+
+	t.Run("unknown", func(t *testing.T) {
+		RequireCapability(t, "unknown")
+		t.Fatal("the test must be skipped")
+	})
+}
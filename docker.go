@@ -3,7 +3,6 @@ package testcontainers
 import (
 	"archive/tar"
 	"bufio"
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/binary"
@@ -12,10 +11,14 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
+	"math"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -36,6 +39,7 @@ import (
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/internal/config"
 	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/internal/retry"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -49,6 +53,9 @@ const (
 	packagePath   = "github.com/testcontainers/testcontainers-go"
 
 	logStoppedForOutOfSyncMessage = "Stopping log consumer: Headers out of sync"
+
+	// inspectCacheTTL is the time the inspection of a container is reused by its accessors
+	inspectCacheTTL = time.Second
 )
 
 var createContainerFailDueToNameConflictRegex = regexp.MustCompile("Conflict. The container name .* is already in use by container .*")
@@ -60,17 +67,28 @@ type DockerContainer struct {
 	WaitingFor wait.Strategy
 	Image      string
 
-	isRunning     bool
 	imageWasBuilt bool
+	// waitAttempts is the number of readiness attempts of the wait strategy the last time it was evaluated
+	waitAttempts int
 	// keepBuiltImage makes Terminate not remove the image if imageWasBuilt.
 	keepBuiltImage     bool
 	provider           *DockerProvider
 	sessionID          string
 	terminationSignal  chan bool
 	consumers          []LogConsumer
-	raw                *types.ContainerJSON
 	logProductionError chan error
 
+	// stateMtx protects the running state and the cached inspection of the container, which are read
+	// by the accessors, e.g. MappedPort, possibly from several goroutines.
+	stateMtx  sync.RWMutex
+	isRunning bool
+	raw       *types.ContainerJSON
+	// rawTime is the time of the inspection of raw, cached for inspectCacheTTL
+	rawTime time.Time
+	// rawGeneration is increased when the cached inspection is invalidated,
+	// so that an inspection started before is not cached
+	rawGeneration uint64
+
 	// TODO: Remove locking and wait group once the deprecated StartLogProducer and
 	// StopLogProducer have been removed and hence logging can only be started and
 	// stopped once.
@@ -86,6 +104,10 @@ type DockerContainer struct {
 	logProductionTimeout *time.Duration
 	logger               Logging
 	lifecycleHooks       []ContainerLifecycleHooks
+
+	// portForwardersMtx protects the port forwarders, which are closed when the container is terminated
+	portForwardersMtx sync.Mutex
+	portForwarders    []*PortForwarder
 }
 
 // SetLogger sets the logger for the container
@@ -102,10 +124,30 @@ func (c *DockerContainer) GetContainerID() string {
 	return c.ID
 }
 
+// shortID returns the short ID of the container, as printed by the Docker CLI
+func (c *DockerContainer) shortID() string {
+	if len(c.ID) > 12 {
+		return c.ID[:12]
+	}
+
+	return c.ID
+}
+
 func (c *DockerContainer) IsRunning() bool {
+	c.stateMtx.RLock()
+	defer c.stateMtx.RUnlock()
+
 	return c.isRunning
 }
 
+// setRunning sets the running state of the container
+func (c *DockerContainer) setRunning(running bool) {
+	c.stateMtx.Lock()
+	defer c.stateMtx.Unlock()
+
+	c.isRunning = running
+}
+
 // Endpoint gets proto://host:port string for the first exposed port
 // Will returns just host:port if proto is ""
 func (c *DockerContainer) Endpoint(ctx context.Context, proto string) (string, error) {
@@ -124,7 +166,8 @@ func (c *DockerContainer) Endpoint(ctx context.Context, proto string) (string, e
 	return c.PortEndpoint(ctx, firstPort, proto)
 }
 
-// PortEndpoint gets proto://host:port string for the given exposed port
+// PortEndpoint gets proto://host:port string for the given exposed port, enclosing the host in
+// square brackets if it's an IPv6 address, e.g. http://[::1]:8080.
 // Will returns just host:port if proto is ""
 func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto string) (string, error) {
 	host, err := c.Host(ctx)
@@ -142,11 +185,13 @@ func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto
 		protoFull = fmt.Sprintf("%s://", proto)
 	}
 
-	return fmt.Sprintf("%s%s:%s", protoFull, host, outerPort.Port()), nil
+	// the IPv6 addresses are enclosed in square brackets, e.g. http://[::1]:8080
+	return protoFull + net.JoinHostPort(host, outerPort.Port()), nil
 }
 
 // Host gets host (ip or name) of the docker daemon where the container port is exposed
-// Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
+// Warning: this is based on your Docker host setting. For ssh:// Docker hosts, it's the remote host
+// of the ssh connection, e.g. my.docker.host for ssh://user@my.docker.host, where the ports are exposed.
 // You can use the "TC_HOST" env variable to set this yourself
 func (c *DockerContainer) Host(ctx context.Context) (string, error) {
 	host, err := c.provider.DaemonHost(ctx)
@@ -183,7 +228,7 @@ func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Po
 		return nat.NewPort(k.Proto(), p[0].HostPort)
 	}
 
-	return "", errors.New("port not found")
+	return "", fmt.Errorf("%w: %s", ErrPortNotExposed, port)
 }
 
 // Ports gets the exposed ports for the container.
@@ -207,17 +252,23 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 		return err
 	}
 
-	if err := c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{}); err != nil {
-		return err
+	err = retry.Do(ctx, func() error {
+		return c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{})
+	})
+	if err != nil {
+		return wrapContainerError(err, "starting", c.ID)
 	}
 	defer c.provider.Close()
 
+	// the ports are mapped when the container starts
+	c.invalidateInspect()
+
 	err = c.startedHook(ctx)
 	if err != nil {
 		return err
 	}
 
-	c.isRunning = true
+	c.setRunning(true)
 
 	err = c.readiedHook(ctx)
 	if err != nil {
@@ -237,24 +288,69 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 // otherwise the engine default. A negative timeout value can be specified,
 // meaning no timeout, i.e. no forceful termination is performed.
 func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) error {
-	err := c.stoppingHook(ctx)
-	if err != nil {
-		return err
-	}
-
 	var options container.StopOptions
 
 	if timeout != nil {
-		timeoutSeconds := int(timeout.Seconds())
+		timeoutSeconds := stopTimeoutSeconds(*timeout)
 		options.Timeout = &timeoutSeconds
 	}
 
-	if err := c.provider.client.ContainerStop(ctx, c.ID, options); err != nil {
+	return c.stop(ctx, options)
+}
+
+// StopOptions defines how a container is stopped gracefully
+type StopOptions struct {
+	// Signal is the signal sent to the container to stop it, e.g. "SIGTERM" or "SIGINT".
+	// If empty, the container's StopSignal value is used, if set, otherwise SIGTERM.
+	Signal string
+
+	// Timeout is the time to wait for the container to stop after sending the signal,
+	// before killing it. If zero, the container's StopTimeout value is used, if set,
+	// otherwise the engine default. A negative timeout means no forceful termination is performed.
+	Timeout time.Duration
+}
+
+// StopWithOptions will stop an already started container, sending it the signal of the options
+// and killing it if it does not stop within the timeout of the options. Stopping a container
+// gracefully allows processes like databases to flush their data before exiting.
+func (c *DockerContainer) StopWithOptions(ctx context.Context, opts StopOptions) error {
+	options := container.StopOptions{
+		Signal: opts.Signal,
+	}
+
+	if opts.Timeout != 0 {
+		timeoutSeconds := stopTimeoutSeconds(opts.Timeout)
+		options.Timeout = &timeoutSeconds
+	}
+
+	return c.stop(ctx, options)
+}
+
+// stopTimeoutSeconds converts the stop timeout to the seconds expected by the engine, rounding up,
+// so a sub-second timeout still gives the container time to stop instead of killing it immediately.
+// A negative timeout is converted to -1, which waits for the container to stop without killing it.
+func stopTimeoutSeconds(timeout time.Duration) int {
+	if timeout < 0 {
+		return -1
+	}
+
+	return int(math.Ceil(timeout.Seconds()))
+}
+
+// stop stops the container with the given options, running the stop lifecycle hooks
+func (c *DockerContainer) stop(ctx context.Context, options container.StopOptions) error {
+	err := c.stoppingHook(ctx)
+	if err != nil {
 		return err
 	}
+
+	if err := c.provider.client.ContainerStop(ctx, c.ID, options); err != nil {
+		return wrapContainerError(err, "stopping", c.ID)
+	}
 	defer c.provider.Close()
 
-	c.isRunning = false
+	c.invalidateInspect()
+	c.setRunning(false)
 
 	err = c.stoppedHook(ctx)
 	if err != nil {
@@ -264,6 +360,71 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	return nil
 }
 
+// RestartOptions defines how a container is restarted
+type RestartOptions struct {
+	// StopOptions defines how the container is stopped gracefully before starting it again.
+	StopOptions
+
+	// Kill kills the container with SIGKILL instead of stopping it gracefully, e.g. to simulate
+	// a crash of the process. The StopOptions are ignored.
+	Kill bool
+
+	// WaitingFor is the wait strategy used to wait for the container to be ready again, instead of
+	// the wait strategy of the container request, e.g. to wait for a log entry with CountAcrossRestarts.
+	WaitingFor wait.Strategy
+}
+
+// Restart stops the container, or kills it, and starts it again, running the stop and start lifecycle hooks,
+// so the wait strategy of the container is evaluated again before returning. It allows tests to check
+// that the code under test recovers from the restart of a dependency, e.g. reconnecting to a database.
+// The ports of the container can be mapped to different host ports after the restart.
+func (c *DockerContainer) Restart(ctx context.Context, opts RestartOptions) error {
+	if opts.Kill {
+		if err := c.kill(ctx); err != nil {
+			return err
+		}
+	} else if err := c.StopWithOptions(ctx, opts.StopOptions); err != nil {
+		return err
+	}
+
+	if opts.WaitingFor != nil {
+		waitingFor := c.WaitingFor
+		c.WaitingFor = opts.WaitingFor
+		defer func() {
+			c.WaitingFor = waitingFor
+		}()
+	}
+
+	return c.Start(ctx)
+}
+
+// kill kills the container with SIGKILL, running the stop lifecycle hooks, and waits for it to exit
+func (c *DockerContainer) kill(ctx context.Context) error {
+	err := c.stoppingHook(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.provider.client.ContainerKill(ctx, c.ID, "SIGKILL"); err != nil {
+		return wrapContainerError(err, "killing", c.ID)
+	}
+	defer c.provider.Close()
+
+	statusCh, errCh := c.provider.client.ContainerWait(ctx, c.ID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return wrapContainerError(err, "waiting for the exit of", c.ID)
+		}
+	case <-statusCh:
+	}
+
+	c.invalidateInspect()
+	c.setRunning(false)
+
+	return c.stoppedHook(ctx)
+}
+
 // Terminate is used to kill the container. It is usually triggered by as defer function.
 func (c *DockerContainer) Terminate(ctx context.Context) error {
 	select {
@@ -275,11 +436,12 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 	defer c.provider.client.Close()
 
 	errs := []error{
+		c.closePortForwarders(),
 		c.terminatingHook(ctx),
-		c.provider.client.ContainerRemove(ctx, c.GetContainerID(), container.RemoveOptions{
+		wrapContainerError(c.provider.client.ContainerRemove(ctx, c.GetContainerID(), container.RemoveOptions{
 			RemoveVolumes: true,
 			Force:         true,
-		}),
+		}), "removing", c.GetContainerID()),
 		c.terminatedHook(ctx),
 	}
 
@@ -292,35 +454,90 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 	}
 
 	c.sessionID = ""
-	c.isRunning = false
+	c.invalidateInspect()
+	c.setRunning(false)
 	return errors.Join(errs...)
 }
 
-// update container raw info
+// Refresh discards the cached inspection of the container, and inspects it again. The accessors,
+// e.g. MappedPort or Networks, reuse the inspection of the container for a short time, to reduce the
+// round-trips to the daemon. The cache is discarded when the container is started, stopped or terminated,
+// so Refresh is only needed when the container is changed out of band, e.g. with the Docker CLI.
+func (c *DockerContainer) Refresh(ctx context.Context) error {
+	c.invalidateInspect()
+
+	_, err := c.inspectRawContainer(ctx)
+	return err
+}
+
+// invalidateInspect discards the cached inspection of the container
+func (c *DockerContainer) invalidateInspect() {
+	c.stateMtx.Lock()
+	defer c.stateMtx.Unlock()
+
+	c.rawTime = time.Time{}
+	c.rawGeneration++
+}
+
+// update container raw info, caching it for the accessors
 func (c *DockerContainer) inspectRawContainer(ctx context.Context) (*types.ContainerJSON, error) {
+	c.stateMtx.RLock()
+	generation := c.rawGeneration
+	c.stateMtx.RUnlock()
+
 	defer c.provider.Close()
-	inspect, err := c.provider.client.ContainerInspect(ctx, c.ID)
+	var inspect types.ContainerJSON
+	err := retry.Do(ctx, func() (err error) {
+		inspect, err = c.provider.client.ContainerInspect(ctx, c.ID)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return nil, wrapContainerError(err, "inspecting", c.ID)
 	}
 
+	c.stateMtx.Lock()
+	defer c.stateMtx.Unlock()
+
 	c.raw = &inspect
-	return c.raw, nil
+	if generation == c.rawGeneration {
+		// not invalidated while inspecting
+		c.rawTime = time.Now()
+	}
+
+	return &inspect, nil
 }
 
+// inspectContainer returns the cached inspection of the container, if it's more recent than inspectCacheTTL,
+// otherwise it inspects the container again. The result must not be modified.
 func (c *DockerContainer) inspectContainer(ctx context.Context) (*types.ContainerJSON, error) {
-	defer c.provider.Close()
-	inspect, err := c.provider.client.ContainerInspect(ctx, c.ID)
-	if err != nil {
-		return nil, err
+	c.stateMtx.RLock()
+	raw, rawTime := c.raw, c.rawTime
+	c.stateMtx.RUnlock()
+
+	if raw != nil && time.Since(rawTime) < inspectCacheTTL {
+		return raw, nil
 	}
 
-	return &inspect, nil
+	return c.inspectRawContainer(ctx)
+}
+
+// lastInspect returns the last inspection of the container, even if it's no longer cached, or nil
+func (c *DockerContainer) lastInspect() *types.ContainerJSON {
+	c.stateMtx.RLock()
+	defer c.stateMtx.RUnlock()
+
+	return c.raw
 }
 
 // Logs will fetch both STDOUT and STDERR from the current container. Returns a
 // ReadCloser and leaves it up to the caller to extract what it wants.
 func (c *DockerContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
+	return c.LogsWithOptions(ctx)
+}
+
+// LogsWithOptions will fetch the logs of the container, as Logs does, applying the given options
+// to select the window of logs to be returned, e.g. LogsSince, LogsUntil, LogsTail, LogsTimestamps or LogsFollow.
+func (c *DockerContainer) LogsWithOptions(ctx context.Context, opts ...LogsOption) (io.ReadCloser, error) {
 	const streamHeaderSize = 8
 
 	options := container.LogsOptions{
@@ -328,9 +545,13 @@ func (c *DockerContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
 		ShowStderr: true,
 	}
 
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, options)
 	if err != nil {
-		return nil, err
+		return nil, wrapContainerError(err, "getting the logs of", c.ID)
 	}
 	defer c.provider.Close()
 
@@ -394,16 +615,32 @@ func (c *DockerContainer) Name(ctx context.Context) (string, error) {
 
 // State returns container's running state
 func (c *DockerContainer) State(ctx context.Context) (*types.ContainerState, error) {
+	// the state is always inspected, as the container can exit or change its health at any time
 	inspect, err := c.inspectRawContainer(ctx)
 	if err != nil {
-		if c.raw != nil {
-			return c.raw.State, err
+		if raw := c.lastInspect(); raw != nil {
+			return raw.State, err
 		}
 		return nil, err
 	}
 	return inspect.State, nil
 }
 
+// HealthCheck returns the health check of the container, i.e. the one of the request or the HEALTHCHECK
+// of the image, or nil if it has none.
+func (c *DockerContainer) HealthCheck(ctx context.Context) (*container.HealthConfig, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if inspect.Config == nil {
+		return nil, nil
+	}
+
+	return inspect.Config.Healthcheck, nil
+}
+
 // Networks gets the names of the networks the container is attached to.
 func (c *DockerContainer) Networks(ctx context.Context) ([]string, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -422,6 +659,31 @@ func (c *DockerContainer) Networks(ctx context.Context) ([]string, error) {
 	return n, nil
 }
 
+// Mounts gets the mounts of the container, as reported by the Docker daemon, including the anonymous
+// volumes declared by the image.
+func (c *DockerContainer) Mounts(ctx context.Context) ([]MountPoint, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make([]MountPoint, 0, len(inspect.Mounts))
+	for _, m := range inspect.Mounts {
+		mounts = append(mounts, MountPoint{
+			Type:        m.Type,
+			Name:        m.Name,
+			Source:      m.Source,
+			Destination: m.Destination,
+			Driver:      m.Driver,
+			Mode:        m.Mode,
+			RW:          m.RW,
+			Propagation: m.Propagation,
+		})
+	}
+
+	return mounts, nil
+}
+
 // ContainerIP gets the IP address of the primary network within the container.
 func (c *DockerContainer) ContainerIP(ctx context.Context) (string, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -460,6 +722,21 @@ func (c *DockerContainer) ContainerIPs(ctx context.Context) ([]string, error) {
 	return ips, nil
 }
 
+// ContainerIPsByNetwork gets the IP addresses of the container, keyed by the name of each of its networks.
+func (c *DockerContainer) ContainerIPsByNetwork(ctx context.Context) (map[string]string, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make(map[string]string, len(inspect.NetworkSettings.Networks))
+	for name, nw := range inspect.NetworkSettings.Networks {
+		ips[name] = nw.IPAddress
+	}
+
+	return ips, nil
+}
+
 // NetworkAliases gets the aliases of the container for the networks it is attached to.
 func (c *DockerContainer) NetworkAliases(ctx context.Context) (map[string][]string, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -482,9 +759,12 @@ func (c *DockerContainer) NetworkAliases(ctx context.Context) (map[string][]stri
 // It returns the exit status of the executed command, an [io.Reader] containing the combined
 // stdout and stderr, and any encountered error. Note that reading directly from the [io.Reader]
 // may result in unexpected bytes due to custom stream multiplexing headers.
-// Use [tcexec.Multiplexed] option to read the combined output without the multiplexing headers.
+// Use [tcexec.Multiplexed] option to read the combined output without the multiplexing headers,
+// or [tcexec.Demultiplexed] to write stdout and stderr to separate writers.
 // Alternatively, to separate the stdout and stderr from [io.Reader] and interpret these headers properly,
 // [github.com/docker/docker/pkg/stdcopy.StdCopy] from the Docker API should be used.
+// Use [tcexec.WithStdin] to send data to the standard input of the command, and [tcexec.WithTTY]
+// to allocate a pseudo-TTY, in which case the output has no multiplexing headers.
 func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
 	cli := c.provider.client
 
@@ -501,11 +781,19 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 		return 0, nil, err
 	}
 
-	hijack, err := cli.ContainerExecAttach(ctx, response.ID, types.ExecStartCheck{})
+	hijack, err := cli.ContainerExecAttach(ctx, response.ID, types.ExecStartCheck{Tty: processOptions.ExecConfig.Tty})
 	if err != nil {
 		return 0, nil, err
 	}
 
+	if processOptions.Stdin != nil {
+		go func() {
+			_, _ = io.Copy(hijack.Conn, processOptions.Stdin)
+			// closing the write side of the connection sends an EOF to the command
+			_ = hijack.CloseWrite()
+		}()
+	}
+
 	processOptions.Reader = hijack.Reader
 
 	// second loop to process the multiplexed option, as now we have a reader
@@ -570,8 +858,14 @@ func (c *DockerContainer) CopyFileFromContainer(ctx context.Context, filePath st
 }
 
 // CopyDirToContainer copies the contents of a directory to a parent path in the container. This parent path must exist in the container first
-// as we cannot create it
+// as we cannot create it. If fileMode is zero, each file keeps its mode, e.g. scripts keep their executable bits.
 func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64) error {
+	return c.CopyDirToContainerWithOptions(ctx, hostDirPath, containerParentPath, fileMode)
+}
+
+// CopyDirToContainerWithOptions copies the contents of a directory to a parent path in the container, as CopyDirToContainer does,
+// setting the attributes of the copied files with the options, e.g. CopyWithOwner.
+func (c *DockerContainer) CopyDirToContainerWithOptions(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64, opts ...CopyOption) error {
 	dir, err := isDir(hostDirPath)
 	if err != nil {
 		return err
@@ -582,7 +876,7 @@ func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath st
 		return fmt.Errorf("path %s is not a directory", hostDirPath)
 	}
 
-	buff, err := tarDir(hostDirPath, fileMode)
+	buff, err := tarDir(hostDirPath, fileMode, opts...)
 	if err != nil {
 		return err
 	}
@@ -599,14 +893,22 @@ func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath st
 	return nil
 }
 
+// CopyFileToContainer copies a file, or a directory, of the host to the container.
+// If fileMode is zero, the file keeps its mode, e.g. scripts keep their executable bits.
 func (c *DockerContainer) CopyFileToContainer(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64) error {
+	return c.CopyFileToContainerWithOptions(ctx, hostFilePath, containerFilePath, fileMode)
+}
+
+// CopyFileToContainerWithOptions copies a file, or a directory, of the host to the container, as CopyFileToContainer does,
+// setting the attributes of the copied files with the options, e.g. CopyWithOwner.
+func (c *DockerContainer) CopyFileToContainerWithOptions(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64, opts ...CopyOption) error {
 	dir, err := isDir(hostFilePath)
 	if err != nil {
 		return err
 	}
 
 	if dir {
-		return c.CopyDirToContainer(ctx, hostFilePath, containerFilePath, fileMode)
+		return c.CopyDirToContainerWithOptions(ctx, hostFilePath, containerFilePath, fileMode, opts...)
 	}
 
 	f, err := os.Open(hostFilePath)
@@ -620,6 +922,10 @@ func (c *DockerContainer) CopyFileToContainer(ctx context.Context, hostFilePath
 		return err
 	}
 
+	if fileMode == 0 {
+		fileMode = int64(info.Mode().Perm())
+	}
+
 	// In Go 1.22 os.File is always an io.WriterTo. However, testcontainers
 	// currently allows Go 1.21, so we need to trick the compiler a little.
 	var file fs.File = f
@@ -631,19 +937,46 @@ func (c *DockerContainer) CopyFileToContainer(ctx context.Context, hostFilePath
 		}
 		_, err := io.Copy(tw, f)
 		return err
-	}, info.Size(), containerFilePath, fileMode)
+	}, info.Size(), containerFilePath, fileMode, opts...)
 }
 
-// CopyToContainer copies fileContent data to a file in container
+// CopyArchiveToContainer extracts a tar archive, which can be compressed, into a directory in the container.
+// The directory is created if it does not exist.
+func (c *DockerContainer) CopyArchiveToContainer(ctx context.Context, archive io.Reader, containerDirPath string, opts ...CopyOption) error {
+	buffer, err := tarArchive(archive, containerDirPath, opts...)
+	if err != nil {
+		return err
+	}
+
+	err = c.provider.client.CopyToContainer(ctx, c.ID, "/", buffer, types.CopyToContainerOptions{})
+	if err != nil {
+		return err
+	}
+	defer c.provider.Close()
+
+	return nil
+}
+
+// CopyToContainer copies fileContent data to a file in container. If fileMode is zero, the file is created with 0o644.
 func (c *DockerContainer) CopyToContainer(ctx context.Context, fileContent []byte, containerFilePath string, fileMode int64) error {
+	return c.CopyToContainerWithOptions(ctx, fileContent, containerFilePath, fileMode)
+}
+
+// CopyToContainerWithOptions copies fileContent data to a file in container, as CopyToContainer does,
+// setting the attributes of the file with the options, e.g. CopyWithOwner.
+func (c *DockerContainer) CopyToContainerWithOptions(ctx context.Context, fileContent []byte, containerFilePath string, fileMode int64, opts ...CopyOption) error {
+	if fileMode == 0 {
+		fileMode = 0o644
+	}
+
 	return c.copyToContainer(ctx, func(tw io.Writer) error {
 		_, err := tw.Write(fileContent)
 		return err
-	}, int64(len(fileContent)), containerFilePath, fileMode)
+	}, int64(len(fileContent)), containerFilePath, fileMode, opts...)
 }
 
-func (c *DockerContainer) copyToContainer(ctx context.Context, fileContent func(tw io.Writer) error, fileContentSize int64, containerFilePath string, fileMode int64) error {
-	buffer, err := tarFile(containerFilePath, fileContent, fileContentSize, fileMode)
+func (c *DockerContainer) copyToContainer(ctx context.Context, fileContent func(tw io.Writer) error, fileContentSize int64, containerFilePath string, fileMode int64, opts ...CopyOption) error {
+	buffer, err := tarFile(containerFilePath, fileContent, fileContentSize, fileMode, opts...)
 	if err != nil {
 		return err
 	}
@@ -657,6 +990,51 @@ func (c *DockerContainer) copyToContainer(ctx context.Context, fileContent func(
 	return nil
 }
 
+// LogsOption is a functional option to select the logs returned by LogsWithOptions.
+type LogsOption func(*container.LogsOptions)
+
+// LogsSince returns only the logs produced at or after the given time.
+func LogsSince(since time.Time) LogsOption {
+	return func(o *container.LogsOptions) {
+		o.Since = since.Format(time.RFC3339Nano)
+	}
+}
+
+// LogsUntil returns only the logs produced at or before the given time.
+func LogsUntil(until time.Time) LogsOption {
+	return func(o *container.LogsOptions) {
+		o.Until = until.Format(time.RFC3339Nano)
+	}
+}
+
+// LogsTimestamps prefixes each line of the logs with the time it was produced, in RFC3339Nano format.
+func LogsTimestamps() LogsOption {
+	return func(o *container.LogsOptions) {
+		o.Timestamps = true
+	}
+}
+
+// LogsTail returns only the given number of lines from the end of the logs.
+// A negative number returns all the lines.
+func LogsTail(lines int) LogsOption {
+	return func(o *container.LogsOptions) {
+		if lines < 0 {
+			o.Tail = "all"
+			return
+		}
+
+		o.Tail = strconv.Itoa(lines)
+	}
+}
+
+// LogsFollow keeps the returned reader open, streaming the new logs of the container
+// until it stops or the context is done.
+func LogsFollow() LogsOption {
+	return func(o *container.LogsOptions) {
+		o.Follow = true
+	}
+}
+
 type LogProductionOption func(*DockerContainer)
 
 // WithLogProductionTimeout is a functional option that sets the timeout for the log production.
@@ -713,8 +1091,12 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 
 	c.logProductionError = make(chan error, 1)
 
+	// each consumer accepts the logs in order, without waiting for the others
+	fanOut := &logFanOut{}
+
 	go func() {
 		defer func() {
+			fanOut.close()
 			close(c.logProductionError)
 			c.logProductionWaitGroup.Done()
 		}()
@@ -790,12 +1172,10 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 					_, _ = fmt.Fprintln(os.Stderr, logStoppedForOutOfSyncMessage)
 					return
 				}
-				for _, c := range c.consumers {
-					c.Accept(Log{
-						LogType: logTypes[logType],
-						Content: b,
-					})
-				}
+				fanOut.dispatch(c.consumers, Log{
+					LogType: logTypes[logType],
+					Content: b,
+				})
 			}
 		}
 	}()
@@ -897,7 +1277,7 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 			if errors.As(err, &enf) {
 				return backoff.Permanent(err)
 			}
-			Logger.Printf("Failed to build image: %s, will retry", err)
+			logAt(ctx, Logger, slog.LevelWarn, fmt.Sprintf("Failed to build image: %s, will retry", err))
 			return err
 		}
 		defer p.Close()
@@ -908,32 +1288,60 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 		return "", errors.Join(buildError, err)
 	}
 
-	if img.ShouldPrintBuildLog() {
-		termFd, isTerm := term.GetFdInfo(os.Stderr)
-		err = jsonmessage.DisplayJSONMessagesStream(resp.Body, os.Stderr, termFd, isTerm, nil)
-		if err != nil {
-			return "", err
-		}
-	}
+	defer resp.Body.Close()
+
+	output := img.BuildLogWriter()
+	recorder := &buildLogRecorder{}
 
-	// need to read the response from Docker, I think otherwise the image
-	// might not finish building before continuing to execute here
-	buf := new(bytes.Buffer)
-	_, err = buf.ReadFrom(resp.Body)
+	// Always process the output, even if it is not printed, so that the image
+	// finishes building before continuing, and the build errors are not swallowed.
+	termFd, isTerm := term.GetFdInfo(output)
+	imageID := ""
+	err = jsonmessage.DisplayJSONMessagesStream(resp.Body, io.MultiWriter(output, recorder), termFd, isTerm, func(msg jsonmessage.JSONMessage) {
+		if id := builtImageID(msg); id != "" {
+			imageID = id
+		}
+	})
 	if err != nil {
-		return "", err
+		return "", recorder.buildError(err)
 	}
 
-	_ = resp.Body.Close()
+	if img.ShouldUseImageDigest() {
+		if imageID == "" {
+			return "", errors.New("error building image: the daemon did not report the digest of the built image")
+		}
+
+		return imageID, nil
+	}
 
 	// the first tag is the one we want
 	return buildOptions.Tags[0], nil
 }
 
+// builtImageID returns the ID of the built image from an auxiliary message of the build output,
+// sent by both the legacy builder and BuildKit, or an empty string for any other message.
+func builtImageID(msg jsonmessage.JSONMessage) string {
+	if msg.Aux == nil {
+		return ""
+	}
+
+	var aux struct {
+		ID string `json:"ID"`
+	}
+	if err := json.Unmarshal(*msg.Aux, &aux); err != nil {
+		return ""
+	}
+
+	return aux.ID
+}
+
 // CreateContainer fulfills a request for a container without starting it
 func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
 	var err error
 
+	// the logs of the creation of the container share the ID of the request
+	ctx = withRequestID(ctx)
+
 	// defer the close of the Docker client connection the soonest
 	defer p.Close()
 
@@ -978,7 +1386,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 
 	var termSignal chan bool
 	// the reaper does not need to start a reaper for itself
-	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage)
+	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage) || req.Labels[core.LabelReaper] == "true"
 	if !tcConfig.RyukDisabled && !isReaperContainer {
 		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), core.SessionID(), p)
 		if err != nil {
@@ -1011,7 +1419,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		}
 
 		if modifiedTag != imageName {
-			p.Logger.Printf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag)
+			logAt(ctx, p.Logger, slog.LevelInfo, fmt.Sprintf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag), LogKeyImage, modifiedTag)
 			imageName = modifiedTag
 		}
 	}
@@ -1024,6 +1432,11 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			return nil, err
 		}
 	} else {
+		// opt-in, e.g. to run the tests against the images of another architecture
+		if req.ImagePlatform == "" {
+			req.ImagePlatform = p.config.Config.ImagePlatform
+		}
+
 		if req.ImagePlatform != "" {
 			p, err := platforms.Parse(req.ImagePlatform)
 			if err != nil {
@@ -1032,21 +1445,35 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			platform = &p
 		}
 
+		pullPolicy := req.imagePullPolicy()
+
 		var shouldPullImage bool
 
-		if req.AlwaysPullImage {
+		if pullPolicy == PullAlways {
 			shouldPullImage = true // If requested always attempt to pull image
 		} else {
-			image, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
+			image, err := p.inspectImage(ctx, imageName)
 			if err != nil {
-				if client.IsErrNotFound(err) {
-					shouldPullImage = true
-				} else {
+				if !client.IsErrNotFound(err) {
 					return nil, err
 				}
-			}
-			if platform != nil && (image.Architecture != platform.Architecture || image.Os != platform.OS) {
+
+				if pullPolicy == PullNever {
+					return nil, fmt.Errorf("image %s not found locally and the pull policy is %s: %w", imageName, pullPolicy, err)
+				}
+
 				shouldPullImage = true
+			} else {
+				if platform != nil && (image.Architecture != platform.Architecture || image.Os != platform.OS) {
+					shouldPullImage = true
+				}
+				if req.ImageDigest != "" && !imageMatchesDigest(image, req.ImageDigest) {
+					shouldPullImage = true
+				}
+				// with the Never policy, a mismatching local image is reported by the digest check below
+				if pullPolicy == PullNever {
+					shouldPullImage = false
+				}
 			}
 		}
 
@@ -1058,24 +1485,41 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 				return nil, err
 			}
 		}
+
+		if err := p.useImage(ctx, imageName, shouldPullImage); err != nil {
+			logAt(ctx, p.Logger, slog.LevelWarn, fmt.Sprintf("Failed to update the image cache for %s: %s", imageName, err), LogKeyImage, imageName)
+		}
+
+		if req.ImageDigest != "" {
+			image, err := p.inspectImage(ctx, imageName)
+			if err != nil {
+				return nil, fmt.Errorf("error inspecting image %s: %w", imageName, err)
+			}
+
+			if !imageMatchesDigest(image, req.ImageDigest) {
+				return nil, fmt.Errorf("%w: image %s does not match %s (repo digests: %v)", ErrImageDigestMismatch, imageName, req.ImageDigest, image.RepoDigests)
+			}
+		}
 	}
 
 	if !isReaperContainer {
-		// add the labels that the reaper will use to terminate the container to the request
-		for k, v := range core.DefaultLabels(core.SessionID()) {
+		// add the labels that the reaper will use to terminate the container to the request,
+		// and the session labels
+		for k, v := range GenericLabels() {
 			req.Labels[k] = v
 		}
 	}
 
 	dockerInput := &container.Config{
-		Entrypoint: req.Entrypoint,
-		Image:      imageName,
-		Env:        env,
-		Labels:     req.Labels,
-		Cmd:        req.Cmd,
-		Hostname:   req.Hostname,
-		User:       req.User,
-		WorkingDir: req.WorkingDir,
+		Entrypoint:  req.Entrypoint,
+		Image:       imageName,
+		Env:         env,
+		Labels:      req.Labels,
+		Cmd:         req.Cmd,
+		Hostname:    req.Hostname,
+		User:        req.User,
+		WorkingDir:  req.WorkingDir,
+		Healthcheck: req.HealthCheck,
 	}
 
 	hostConfig := &container.HostConfig{
@@ -1102,14 +1546,25 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
-	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+	// creating a container is not idempotent, so it's retried only if the daemon did not process the request
+	var resp container.CreateResponse
+	err = retry.Do(ctx, func() (err error) {
+		resp, err = p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+		return err
+	}, retry.WithRetryable(retry.IsNotSent))
 	if err != nil {
 		return nil, err
 	}
+	trackResource(p.host, containerResource(resp.ID))
 
 	// #248: If there is more than one network specified in the request attach newly created container to them one by one
 	if len(req.Networks) > 1 {
 		for _, n := range req.Networks[1:] {
+			if req.hasNetworkAttachment(n) {
+				// connected with the settings of its attachment
+				continue
+			}
+
 			nw, err := p.GetNetwork(ctx, NetworkRequest{
 				Name: n,
 			})
@@ -1125,6 +1580,26 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		}
 	}
 
+	// connect the container to the networks of the attachments not joined at creation
+	for _, attachment := range req.NetworkAttachments {
+		if _, ok := networkingConfig.EndpointsConfig[attachment.Name]; ok {
+			continue
+		}
+
+		nw, err := p.GetNetwork(ctx, NetworkRequest{
+			Name: attachment.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting network %s: %w", attachment.Name, err)
+		}
+
+		settings := attachment.endpointSettings(nw.ID)
+		settings.Aliases = append(settings.Aliases, req.NetworkAliases[attachment.Name]...)
+		if err := p.client.NetworkConnect(ctx, nw.ID, resp.ID, settings); err != nil {
+			return nil, fmt.Errorf("error connecting container to network %s: %w", attachment.Name, err)
+		}
+	}
+
 	c := &DockerContainer{
 		ID:                resp.ID,
 		WaitingFor:        req.WaitingFor,
@@ -1243,7 +1718,7 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		return nil, err
 	}
 
-	dc.isRunning = true
+	dc.setRunning(true)
 
 	err = dc.readiedHook(ctx)
 	if err != nil {
@@ -1253,45 +1728,150 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 	return dc, nil
 }
 
+// imageMatchesDigest returns true if the image has the given digest, either as one of
+// its repository digests (the manifest digest reported by the registry) or as its ID.
+func imageMatchesDigest(image types.ImageInspect, digest string) bool {
+	if image.ID == digest {
+		return true
+	}
+
+	for _, repoDigest := range image.RepoDigests {
+		if strings.HasSuffix(repoDigest, "@"+digest) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inspectImage inspects the image, retrying on the transient errors of the daemon
+func (p *DockerProvider) inspectImage(ctx context.Context, imageName string) (types.ImageInspect, error) {
+	var image types.ImageInspect
+	err := retry.Do(ctx, func() (err error) {
+		image, _, err = p.client.ImageInspectWithRaw(ctx, imageName)
+		return err
+	})
+
+	return image, err
+}
+
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
 // Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
 func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions) error {
 	registry, imageAuth, err := DockerImageAuth(ctx, tag)
 	if err != nil {
-		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is:%s", registry, tag, err)
+		logAt(ctx, p.Logger, slog.LevelWarn, fmt.Sprintf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is:%s", registry, tag, err), LogKeyImage, tag)
 	} else {
 		// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
 		encodedJSON, err := json.Marshal(imageAuth)
 		if err != nil {
-			p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is:%s", tag, err)
+			logAt(ctx, p.Logger, slog.LevelWarn, fmt.Sprintf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is:%s", tag, err), LogKeyImage, tag)
 		} else {
 			pullOpt.RegistryAuth = base64.URLEncoding.EncodeToString(encodedJSON)
 		}
 	}
 
-	var pull io.ReadCloser
-	err = backoff.Retry(func() error {
-		pull, err = p.client.ImagePull(ctx, tag, pullOpt)
-		if err != nil {
-			var enf errdefs.ErrNotFound
-			if errors.As(err, &enf) {
-				return backoff.Permanent(err)
-			}
-			Logger.Printf("Failed to pull image: %s, will retry", err)
-			return err
+	key := tag
+	if pullOpt.Platform != "" {
+		key += " (" + pullOpt.Platform + ")"
+	}
+
+	// the pull, including its retries, is bounded by the pull.timeout property, if set
+	if timeout := config.Read().PullTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// parallel tests pulling the same image share a single pull
+	return imagePulls.do(ctx, key, p.Logger, func(progress func(string)) error {
+		return p.pullImage(ctx, tag, pullOpt, progress)
+	})
+}
+
+// pullImage pulls the image, reporting the progress of its layers to the progress function.
+// The pull is retried on the transient errors, e.g. a connection reset by the registry, and the layers
+// already pulled by the daemon are reused by the next attempts, so that only the missing ones are downloaded.
+func (p *DockerProvider) pullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions, progress func(string)) error {
+	defer p.Close()
+
+	layers := newPullLayers()
+	attempt := 0
+
+	return backoff.Retry(func() error {
+		attempt++
+		if completed := layers.completed(); attempt > 1 && completed > 0 {
+			logAt(ctx, p.Logger, slog.LevelInfo, fmt.Sprintf("🔄 Retrying the pull of %s, reusing the %d layers already pulled", tag, completed), LogKeyImage, tag)
 		}
-		defer p.Close()
 
-		return nil
-	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+		err := p.pullImageOnce(ctx, tag, pullOpt, layers, progress)
+		if err == nil {
+			return nil
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return backoff.Permanent(ctxErr)
+		}
+
+		var enf errdefs.ErrNotFound
+		if errors.As(err, &enf) || !isTransientPullError(err) {
+			return backoff.Permanent(err)
+		}
+
+		logAt(ctx, p.Logger, slog.LevelWarn, fmt.Sprintf("Failed to pull image: %s, will retry", err), LogKeyImage, tag)
+		return err
+	}, retry.NewBackOff(ctx, retry.WithMaxAttempts(0), retry.WithMaxElapsedTime(backoff.DefaultMaxElapsedTime)))
+}
+
+// pullImageOnce runs a single attempt of the pull, consuming the stream of the daemon until it ends.
+// The stream is closed as soon as the context is done, so that the pull stops promptly,
+// even if the daemon does not send any message, e.g. while a large layer is being extracted.
+func (p *DockerProvider) pullImageOnce(ctx context.Context, tag string, pullOpt types.ImagePullOptions, layers *pullLayers, progress func(string)) error {
+	pull, err := p.client.ImagePull(ctx, tag, pullOpt)
 	if err != nil {
 		return err
 	}
 	defer pull.Close()
 
+	stop := context.AfterFunc(ctx, func() {
+		_ = pull.Close()
+	})
+	defer stop()
+
+	lastLog := time.Now()
+
 	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
+	decoder := json.NewDecoder(pull)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		// the errors of the pull, e.g. a layer that cannot be downloaded, are sent in the stream
+		if msg.Error != nil {
+			return &pullError{message: msg.Error.Message}
+		}
+
+		layers.update(msg)
+		status := layers.String()
+		if status == "" {
+			continue
+		}
+
+		progress(status)
+
+		if time.Since(lastLog) >= pullProgressInterval {
+			lastLog = time.Now()
+			logAt(ctx, p.Logger, slog.LevelInfo, fmt.Sprintf("⏳ Pulling %s: %s", tag, status), LogKeyImage, tag)
+		}
+	}
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1323,8 +1903,12 @@ func (p *DockerProvider) Config() TestcontainersConfig {
 	return p.config
 }
 
+// dockerDesktopHostName is the name of the host of Docker Desktop, resolved inside its containers
+const dockerDesktopHostName = "host.docker.internal"
+
 // DaemonHost gets the host or ip of the Docker daemon where ports are exposed on
-// Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
+// Warning: this is based on your Docker host setting. For ssh:// Docker hosts, it's the remote host
+// of the ssh connection, e.g. my.docker.host for ssh://user@my.docker.host, where the ports are exposed.
 // You can use the "TC_HOST" env variable to set this yourself
 func (p *DockerProvider) DaemonHost(ctx context.Context) (string, error) {
 	return daemonHost(ctx, p)
@@ -1337,11 +1921,13 @@ func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 
 	host, exists := os.LookupEnv("TC_HOST")
 	if exists {
-		p.hostCache = host
+		// an IPv6 address could be enclosed in square brackets, as in a URL
+		p.hostCache = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
 		return p.hostCache, nil
 	}
 
-	// infer from Docker host
+	// infer from Docker host. The clients of the ssh:// Docker hosts report the remote host
+	// of the ssh connection with the http schema, e.g. http://my.docker.host
 	url, err := url.Parse(p.client.DaemonHost())
 	if err != nil {
 		return "", err
@@ -1353,6 +1939,13 @@ func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 		p.hostCache = url.Hostname()
 	case "unix", "npipe":
 		if core.InAContainer() {
+			// Docker Desktop forwards the published ports to the host, reachable from the containers
+			// with its own name instead of the gateway IP of the VM
+			if info, err := p.client.Info(ctx); err == nil && core.DetectDockerEnvironment(p.host, info) == core.DockerEnvironmentDockerDesktop {
+				p.hostCache = dockerDesktopHostName
+				break
+			}
+
 			ip, err := p.GetGatewayIP(ctx)
 			if err != nil {
 				ip, err = core.DefaultGatewayIP()
@@ -1417,8 +2010,9 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		}
 	}
 
-	// add the labels that the reaper will use to terminate the network to the request
-	for k, v := range core.DefaultLabels(sessionID) {
+	// add the labels that the reaper will use to terminate the network to the request,
+	// and the session labels
+	for k, v := range GenericLabels() {
 		req.Labels[k] = v
 	}
 
@@ -1433,6 +2027,7 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 	if err != nil {
 		return &DockerNetwork{}, err
 	}
+	trackResource(p.host, networkResource(response.ID))
 
 	n := &DockerNetwork{
 		ID:                response.ID,
@@ -1525,8 +2120,8 @@ func (p *DockerProvider) getDefaultNetwork(ctx context.Context, cli client.APICl
 }
 
 // containerFromDockerResponse builds a Docker container struct from the response of the Docker API
-func containerFromDockerResponse(ctx context.Context, response types.Container) (*DockerContainer, error) {
-	provider, err := NewDockerProvider()
+func containerFromDockerResponse(ctx context.Context, response types.Container, opts ...DockerProviderOption) (*DockerContainer, error) {
+	provider, err := NewDockerProvider(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1606,7 +2201,53 @@ func (p *DockerProvider) SaveImages(ctx context.Context, output string, images .
 	return nil
 }
 
+// LoadImage imports the images of a tar, e.g. the one exported by SaveImages, into the Docker daemon,
+// so that the images cached by a previous CI job are not pulled again
+func (p *DockerProvider) LoadImage(ctx context.Context, input string) error {
+	inputFile, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("opening input file %w", err)
+	}
+	defer func() {
+		_ = inputFile.Close()
+	}()
+
+	resp, err := p.client.ImageLoad(ctx, inputFile, true)
+	if err != nil {
+		return fmt.Errorf("loading images %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// the daemon reports the errors, e.g. a corrupted tar, in the stream of messages
+	if err := jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return fmt.Errorf("loading images %w", err)
+	}
+
+	return nil
+}
+
+// PruneBuildCache removes the dangling build cache of the Docker daemon, e.g. the one left by the images
+// built from a Dockerfile, returning the space reclaimed in bytes
+func (p *DockerProvider) PruneBuildCache(ctx context.Context) (uint64, error) {
+	report, err := p.client.BuildCachePrune(ctx, types.BuildCachePruneOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("pruning build cache %w", err)
+	}
+
+	return report.SpaceReclaimed, nil
+}
+
 // PullImage pulls image from registry
 func (p *DockerProvider) PullImage(ctx context.Context, image string) error {
-	return p.attemptToPullImage(ctx, image, types.ImagePullOptions{})
+	if err := p.attemptToPullImage(ctx, image, types.ImagePullOptions{}); err != nil {
+		return err
+	}
+
+	if err := p.useImage(ctx, image, true); err != nil {
+		logAt(ctx, p.Logger, slog.LevelWarn, fmt.Sprintf("Failed to update the image cache for %s: %s", image, err), LogKeyImage, image)
+	}
+
+	return nil
 }
@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Attach attaches to the output of the container, returning the stdout and stderr
+// streams as separate readers, and a function to detach from the container.
+// It can be called before the container is started, setting Started to false in the
+// GenericContainerRequest, so that no output is missed, e.g. one-time credentials
+// printed by the container at boot. The readers return io.EOF once the container stops
+// or after detaching. If the container uses a TTY, all the output is sent to stdout.
+func (c *DockerContainer) Attach(ctx context.Context) (io.Reader, io.Reader, func(), error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resp, err := c.provider.client.ContainerAttach(ctx, c.ID, container.AttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	stdout := newAttachStream()
+	stderr := newAttachStream()
+	detached := make(chan struct{})
+
+	go func() {
+		var err error
+		if inspect.Config != nil && inspect.Config.Tty {
+			_, err = io.Copy(stdout, resp.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		}
+
+		select {
+		case <-detached:
+			// reading from a closed connection is expected after detaching
+			err = nil
+		default:
+		}
+
+		stdout.close(err)
+		stderr.close(err)
+	}()
+
+	var once sync.Once
+	detach := func() {
+		once.Do(func() {
+			close(detached)
+			resp.Close()
+		})
+	}
+
+	return stdout, stderr, detach, nil
+}
+
+// attachStream is an in-memory pipe for an attached output stream. Writes never block,
+// so that not reading one of the streams does not block the other one.
+type attachStream struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	buf  bytes.Buffer
+	err  error
+}
+
+func newAttachStream() *attachStream {
+	s := &attachStream{}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Write implements io.Writer.
+func (s *attachStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.buf.Write(p)
+	s.cond.Broadcast()
+
+	return n, err
+}
+
+// Read implements io.Reader, blocking until there is data to read or the stream is closed.
+func (s *attachStream) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.buf.Len() == 0 && s.err == nil {
+		s.cond.Wait()
+	}
+
+	if s.buf.Len() > 0 {
+		return s.buf.Read(p)
+	}
+
+	return 0, s.err
+}
+
+// close closes the stream, so that reads return the given error, or io.EOF if nil,
+// once the buffered data has been read.
+func (s *attachStream) close(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		err = io.EOF
+	}
+
+	s.err = err
+	s.cond.Broadcast()
+}
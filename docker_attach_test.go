@@ -0,0 +1,111 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestAttach(t *testing.T) {
+	ctx := context.Background()
+
+	// attachBeforeStart {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/bash",
+			Cmd:        []string{"bash", "-c", "echo 'password: s3cr3t' && echo 'warning' >&2"},
+			WaitingFor: wait.ForExit(),
+		},
+		// do not start the container, so that the output is not missed
+		Started: false,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	stdout, stderr, detach, err := c.(*DockerContainer).Attach(ctx)
+	require.NoError(t, err)
+	defer detach()
+
+	err = c.Start(ctx)
+	require.NoError(t, err)
+	// }
+
+	out, err := io.ReadAll(stdout)
+	require.NoError(t, err)
+	assert.Equal(t, "password: s3cr3t\n", string(out))
+
+	errOut, err := io.ReadAll(stderr)
+	require.NoError(t, err)
+	assert.Equal(t, "warning\n", string(errOut))
+}
+
+func TestAttach_detach(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	stdout, stderr, detach, err := c.(*DockerContainer).Attach(ctx)
+	require.NoError(t, err)
+
+	detach()
+	// detaching twice is a no-op
+	detach()
+
+	_, err = io.ReadAll(stdout)
+	require.NoError(t, err)
+	_, err = io.ReadAll(stderr)
+	require.NoError(t, err)
+	require.True(t, c.IsRunning())
+}
+
+func TestAttachStream(t *testing.T) {
+	t.Run("read-after-close", func(t *testing.T) {
+		s := newAttachStream()
+
+		_, err := s.Write([]byte("hello "))
+		require.NoError(t, err)
+		_, err = s.Write([]byte("world"))
+		require.NoError(t, err)
+		s.close(nil)
+
+		bs, err := io.ReadAll(s)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(bs))
+	})
+
+	t.Run("read-blocks-until-write", func(t *testing.T) {
+		s := newAttachStream()
+
+		go func() {
+			_, _ = s.Write([]byte("hello"))
+			s.close(nil)
+		}()
+
+		bs, err := io.ReadAll(s)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(bs))
+	})
+
+	t.Run("close-with-error", func(t *testing.T) {
+		s := newAttachStream()
+		errConn := errors.New("connection reset")
+
+		s.close(errConn)
+
+		_, err := io.ReadAll(s)
+		require.ErrorIs(t, err, errConn)
+	})
+}
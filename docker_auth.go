@@ -2,10 +2,15 @@ package testcontainers
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
+	"sync"
 
 	"github.com/cpuguy83/dockercfg"
 	"github.com/docker/docker/api/types/registry"
@@ -15,71 +20,103 @@ import (
 
 // DockerImageAuth returns the auth config for the given Docker image, extracting first its Docker registry.
 // Finally, it will use the credential helpers to extract the information from the docker config file
-// for that registry, if it exists.
+// for that registry, if it exists. The resolved credentials are cached per registry.
 func DockerImageAuth(ctx context.Context, image string) (string, registry.AuthConfig, error) {
 	defaultRegistry := defaultRegistry(ctx)
 	reg := core.ExtractRegistry(image, defaultRegistry)
 
-	cfgs, err := getDockerAuthConfigs()
+	cfg, err := getDockerConfig()
 	if err != nil {
 		return reg, registry.AuthConfig{}, err
 	}
 
-	if cfg, ok := getRegistryAuth(reg, cfgs); ok {
-		return reg, cfg, nil
-	}
+	authConfig, err := registryCredentials.get(reg, cfg)
+	return reg, authConfig, err
+}
 
-	return reg, registry.AuthConfig{}, dockercfg.ErrCredentialsNotFound
+// RegistryCredentialsFor returns the credentials for the registry of the given image reference,
+// as they are resolved to pull the images of the containers. It's useful for modules that pull
+// extra images on their own, e.g. from inside a container.
+// It returns dockercfg.ErrCredentialsNotFound if there are no credentials for the registry.
+func RegistryCredentialsFor(ctx context.Context, ref string) (registry.AuthConfig, error) {
+	_, authConfig, err := DockerImageAuth(ctx, ref)
+	return authConfig, err
 }
 
-func getRegistryAuth(reg string, cfgs map[string]registry.AuthConfig) (registry.AuthConfig, bool) {
-	if cfg, ok := cfgs[reg]; ok {
-		return cfg, true
-	}
+// registryCredentials is the cache of the credentials resolved for each registry
+var registryCredentials = newCredentialsCache(resolveRegistryAuth)
+
+// credentialsCache caches the credentials resolved for each registry, so the credential helpers,
+// which can be slow, e.g. when they request a token to a cloud provider, are called once per registry.
+// The docker config is part of the key, so changes to it are honoured. The credentials are resolved
+// under a lock per key, so a slow credential helper does not block the other registries.
+type credentialsCache struct {
+	mtx     sync.Mutex
+	entries map[string]*credentialsEntry
+	resolve func(reg string, cfg dockercfg.Config) (registry.AuthConfig, error)
+}
 
-	// fallback match using authentication key host
-	for k, cfg := range cfgs {
-		keyURL, err := url.Parse(k)
-		if err != nil {
-			continue
-		}
+type credentialsEntry struct {
+	// mtx is held while the credentials of the entry are resolved
+	mtx        sync.Mutex
+	resolved   bool
+	authConfig registry.AuthConfig
+	err        error
+}
 
-		if keyURL.Host == reg {
-			return cfg, true
-		}
+func newCredentialsCache(resolve func(reg string, cfg dockercfg.Config) (registry.AuthConfig, error)) *credentialsCache {
+	return &credentialsCache{
+		entries: map[string]*credentialsEntry{},
+		resolve: resolve,
 	}
-
-	return registry.AuthConfig{}, false
 }
 
-// defaultRegistry returns the default registry to use when pulling images
-// It will use the docker daemon to get the default registry, returning "https://index.docker.io/v1/" if
-// it fails to get the information from the daemon
-func defaultRegistry(ctx context.Context) string {
-	client, err := NewDockerClientWithOpts(ctx)
+// get returns the credentials for the registry, resolving them if they are not in the cache
+func (c *credentialsCache) get(reg string, cfg dockercfg.Config) (registry.AuthConfig, error) {
+	rawCfg, err := json.Marshal(cfg)
 	if err != nil {
-		return core.IndexDockerIO
+		return c.resolve(reg, cfg)
 	}
-	defer client.Close()
 
-	info, err := client.Info(ctx)
-	if err != nil {
-		return core.IndexDockerIO
+	hash := sha256.Sum256(append([]byte(reg+"\n"), rawCfg...))
+	key := hex.EncodeToString(hash[:])
+
+	c.mtx.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &credentialsEntry{}
+		c.entries[key] = entry
 	}
+	c.mtx.Unlock()
 
-	return info.IndexServerAddress
+	entry.mtx.Lock()
+	defer entry.mtx.Unlock()
+
+	if entry.resolved {
+		return entry.authConfig, entry.err
+	}
+
+	authConfig, err := c.resolve(reg, cfg)
+	if err == nil || errors.Is(err, dockercfg.ErrCredentialsNotFound) {
+		// do not cache unexpected errors, e.g. a credential helper failing
+		entry.resolved = true
+		entry.authConfig = authConfig
+		entry.err = err
+	}
+
+	return authConfig, err
 }
 
-// getDockerAuthConfigs returns a map with the auth configs from the docker config file
-// using the registry as the key
-func getDockerAuthConfigs() (map[string]registry.AuthConfig, error) {
-	cfg, err := getDockerConfig()
-	if err != nil {
-		return nil, err
+// resolveRegistryAuth resolves the credentials for the registry from the docker config, looking in this particular order:
+// 1. the credential helper configured for the registry in the credHelpers section, e.g. for ECR, GCR or ACR.
+// 2. the credentials stored for the registry in the auths section. If they are empty, the credentials store is used.
+// 3. the credentials store configured in the credsStore section.
+func resolveRegistryAuth(reg string, cfg dockercfg.Config) (registry.AuthConfig, error) {
+	if helper, key, ok := lookupRegistry(reg, cfg.CredentialHelpers); ok {
+		return credentialsFromHelper(helper, key)
 	}
 
-	cfgs := map[string]registry.AuthConfig{}
-	for k, v := range cfg.AuthConfigs {
+	if v, key, ok := lookupRegistry(reg, cfg.AuthConfigs); ok {
 		ac := registry.AuthConfig{
 			Auth:          v.Auth,
 			Email:         v.Email,
@@ -90,31 +127,106 @@ func getDockerAuthConfigs() (map[string]registry.AuthConfig, error) {
 			Username:      v.Username,
 		}
 
-		if v.Username == "" && v.Password == "" {
-			u, p, _ := dockercfg.GetRegistryCredentials(k)
+		switch {
+		case v.Username != "" || v.Password != "" || v.IdentityToken != "":
+			// the credentials are explicitly set
+		case v.Auth != "":
+			u, p, err := dockercfg.DecodeBase64Auth(v)
+			if err != nil {
+				return registry.AuthConfig{}, err
+			}
+			ac.Username = u
+			ac.Password = p
+		default:
+			// the entry just marks the registry as logged in, the credentials live in the credentials store,
+			// or in the default credential helper of the platform if there is no credentials store
+			u, p, _ := dockercfg.GetCredentialsFromHelper(cfg.CredentialsStore, key)
 			ac.Username = u
 			ac.Password = p
 		}
 
-		if v.Auth == "" {
+		if ac.Auth == "" {
 			ac.Auth = base64.StdEncoding.EncodeToString([]byte(ac.Username + ":" + ac.Password))
 		}
 
-		cfgs[k] = ac
+		return ac, nil
 	}
 
-	// in the case where the auth field in the .docker/conf.json is empty, and the user has credential helpers registered
-	// the auth comes from there
-	for k := range cfg.CredentialHelpers {
-		ac := registry.AuthConfig{}
-		u, p, _ := dockercfg.GetRegistryCredentials(k)
-		ac.Username = u
-		ac.Password = p
+	if cfg.CredentialsStore != "" {
+		return credentialsFromHelper(cfg.CredentialsStore, dockercfg.ResolveRegistryHost(reg))
+	}
+
+	return registry.AuthConfig{}, dockercfg.ErrCredentialsNotFound
+}
 
-		cfgs[k] = ac
+// lookupRegistry returns the value for the registry in the given section of the docker config,
+// and the key it was found with. Docker Hub aliases are resolved, and as a fallback, the keys are
+// matched by their host.
+func lookupRegistry[T any](reg string, section map[string]T) (T, string, bool) {
+	for _, key := range []string{reg, dockercfg.ResolveRegistryHost(reg)} {
+		if v, ok := section[key]; ok {
+			return v, key, true
+		}
 	}
 
-	return cfgs, nil
+	// fallback match using authentication key host
+	for key, v := range section {
+		keyURL, err := url.Parse(key)
+		if err != nil {
+			continue
+		}
+
+		if keyURL.Host == reg {
+			return v, key, true
+		}
+	}
+
+	var zero T
+	return zero, "", false
+}
+
+// credentialsFromHelper returns the credentials for the registry from the given credential helper,
+// returning dockercfg.ErrCredentialsNotFound if the helper has no credentials for it.
+func credentialsFromHelper(helper string, reg string) (registry.AuthConfig, error) {
+	u, p, err := dockercfg.GetCredentialsFromHelper(helper, reg)
+	if err != nil {
+		return registry.AuthConfig{}, fmt.Errorf("credential helper %s: %w", helper, err)
+	}
+
+	if u == "" && p == "" {
+		return registry.AuthConfig{}, dockercfg.ErrCredentialsNotFound
+	}
+
+	ac := registry.AuthConfig{ServerAddress: reg}
+	if u == "" {
+		// the helper returns an identity token instead of a password
+		ac.IdentityToken = p
+		return ac, nil
+	}
+
+	ac.Username = u
+	ac.Password = p
+	ac.Auth = base64.StdEncoding.EncodeToString([]byte(u + ":" + p))
+
+	return ac, nil
+}
+
+// defaultRegistry returns the default registry to use when pulling images
+// It will use the docker daemon to get the default registry, returning "https://index.docker.io/v1/" if
+// it fails to get the information from the daemon
+func defaultRegistry(ctx context.Context) string {
+	client, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return core.IndexDockerIO
+	}
+	defer client.Close()
+
+	info, err := client.Info(ctx)
+	if err != nil {
+		return core.IndexDockerIO
+	}
+
+	return info.IndexServerAddress
 }
 
 // getDockerConfig returns the docker config file. It will internally check, in this particular order:
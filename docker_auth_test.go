@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/cpuguy83/dockercfg"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -160,6 +163,175 @@ func TestGetDockerConfig(t *testing.T) {
 	})
 }
 
+// setupCredentialHelper creates a fake docker credential helper with the given name in the PATH,
+// returning the username "gopher" and the secret "secret-<registry>" for any registry, and
+// recording each call in the returned file.
+func setupCredentialHelper(t *testing.T, name string) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake credential helper is a shell script")
+	}
+
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+
+	script := `#!/bin/sh
+read reg
+echo "$reg" >> ` + calls + `
+echo "{\"Username\": \"gopher\", \"Secret\": \"secret-$reg\"}"
+`
+	err := os.WriteFile(filepath.Join(dir, "docker-credential-"+name), []byte(script), 0o755)
+	require.NoError(t, err)
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return calls
+}
+
+func TestRegistryCredentialsFor(t *testing.T) {
+	t.Run("credential helper for the registry", func(t *testing.T) {
+		setupCredentialHelper(t, "tc-ecr")
+
+		t.Setenv("DOCKER_AUTH_CONFIG", `{
+			"credHelpers": {
+				"123456789.dkr.ecr.us-east-1.amazonaws.com": "tc-ecr"
+			}
+		}`)
+
+		// registryCredentialsFor {
+		cfg, err := RegistryCredentialsFor(context.Background(), "123456789.dkr.ecr.us-east-1.amazonaws.com/my/image:latest")
+		// }
+		require.NoError(t, err)
+
+		assert.Equal(t, "gopher", cfg.Username)
+		assert.Equal(t, "secret-123456789.dkr.ecr.us-east-1.amazonaws.com", cfg.Password)
+		assert.Equal(t, "123456789.dkr.ecr.us-east-1.amazonaws.com", cfg.ServerAddress)
+	})
+
+	t.Run("credential helper takes precedence over auths", func(t *testing.T) {
+		setupCredentialHelper(t, "tc-gcr")
+
+		t.Setenv("DOCKER_AUTH_CONFIG", `{
+			"auths": {
+				"gcr.io": { "username": "other", "password": "other" }
+			},
+			"credHelpers": {
+				"gcr.io": "tc-gcr"
+			}
+		}`)
+
+		cfg, err := RegistryCredentialsFor(context.Background(), "gcr.io/my/image:latest")
+		require.NoError(t, err)
+
+		assert.Equal(t, "gopher", cfg.Username)
+		assert.Equal(t, "secret-gcr.io", cfg.Password)
+	})
+
+	t.Run("credentials store for registries not in auths", func(t *testing.T) {
+		setupCredentialHelper(t, "tc-store")
+
+		t.Setenv("DOCKER_AUTH_CONFIG", `{
+			"credsStore": "tc-store"
+		}`)
+
+		cfg, err := RegistryCredentialsFor(context.Background(), "myregistry.azurecr.io/my/image:latest")
+		require.NoError(t, err)
+
+		assert.Equal(t, "gopher", cfg.Username)
+		assert.Equal(t, "secret-myregistry.azurecr.io", cfg.Password)
+	})
+
+	t.Run("credentials store for empty auths", func(t *testing.T) {
+		setupCredentialHelper(t, "tc-store")
+
+		t.Setenv("DOCKER_AUTH_CONFIG", `{
+			"auths": {
+				"https://myregistry.azurecr.io": {}
+			},
+			"credsStore": "tc-store"
+		}`)
+
+		cfg, err := RegistryCredentialsFor(context.Background(), "myregistry.azurecr.io/my/image:latest")
+		require.NoError(t, err)
+
+		assert.Equal(t, "gopher", cfg.Username)
+		assert.Equal(t, "secret-https://myregistry.azurecr.io", cfg.Password)
+	})
+
+	t.Run("credentials are cached per registry", func(t *testing.T) {
+		calls := setupCredentialHelper(t, "tc-cached")
+
+		// start with an empty cache, in case the test runs more than once
+		registryCredentials.mtx.Lock()
+		registryCredentials.entries = map[string]*credentialsEntry{}
+		registryCredentials.mtx.Unlock()
+
+		t.Setenv("DOCKER_AUTH_CONFIG", `{
+			"credHelpers": {
+				"cached.example.com": "tc-cached",
+				"other.example.com": "tc-cached"
+			}
+		}`)
+
+		for i := 0; i < 3; i++ {
+			_, err := RegistryCredentialsFor(context.Background(), "cached.example.com/my/image:latest")
+			require.NoError(t, err)
+			_, err = RegistryCredentialsFor(context.Background(), "other.example.com/my/image:latest")
+			require.NoError(t, err)
+		}
+
+		content, err := os.ReadFile(calls)
+		require.NoError(t, err)
+		assert.Equal(t, "cached.example.com\nother.example.com\n", string(content))
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		t.Setenv("DOCKER_AUTH_CONFIG", `{
+			"auths": {
+				"https://example.com": { "username": "gopher", "password": "secret" }
+			}
+		}`)
+
+		cfg, err := RegistryCredentialsFor(context.Background(), "not-found.example.com/my/image:latest")
+		require.ErrorIs(t, err, dockercfg.ErrCredentialsNotFound)
+		require.Empty(t, cfg)
+	})
+}
+
+func TestCredentialsCache_slowRegistry(t *testing.T) {
+	release := make(chan struct{})
+	cache := newCredentialsCache(func(reg string, _ dockercfg.Config) (registry.AuthConfig, error) {
+		if reg == "slow.example.com" {
+			// a credential helper requesting a token to a cloud provider
+			<-release
+		}
+		return registry.AuthConfig{Username: reg}, nil
+	})
+
+	slow := make(chan registry.AuthConfig)
+	go func() {
+		ac, _ := cache.get("slow.example.com", dockercfg.Config{})
+		slow <- ac
+	}()
+
+	done := make(chan registry.AuthConfig)
+	go func() {
+		ac, _ := cache.get("fast.example.com", dockercfg.Config{})
+		done <- ac
+	}()
+
+	select {
+	case ac := <-done:
+		assert.Equal(t, "fast.example.com", ac.Username)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the credentials of a registry are blocked by a slow credential helper of another registry")
+	}
+
+	close(release)
+	assert.Equal(t, "slow.example.com", (<-slow).Username)
+}
+
 func TestBuildContainerFromDockerfile(t *testing.T) {
 	ctx := context.Background()
 	req := ContainerRequest{
@@ -0,0 +1,100 @@
+package testcontainers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// maxBuildErrorOutputLines is the maximum number of lines of the failing step kept in a BuildError
+const maxBuildErrorOutputLines = 50
+
+// BuildError is returned when the build of an image from a Dockerfile fails.
+// It includes the step that failed and the output produced by that step.
+type BuildError struct {
+	Step   string // the failing step, e.g. "Step 2/3 : RUN make"
+	Output string // the output of the failing step, limited to the last lines
+	Err    error  // the error returned by the Docker daemon
+}
+
+// Error implements the error interface.
+func (e *BuildError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("build image")
+	if e.Step != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", e.Step))
+	}
+	sb.WriteString(fmt.Sprintf(": %v", e.Err))
+
+	if e.Output != "" {
+		sb.WriteString(fmt.Sprintf("\n%s", e.Output))
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// buildLogRecorder is an io.Writer that keeps track of the current step of a
+// Docker build, and of the output produced by it, so that it can be reported
+// in the case the build fails.
+type buildLogRecorder struct {
+	step    string
+	lines   []string
+	partial bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (r *buildLogRecorder) Write(p []byte) (int, error) {
+	r.partial.Write(p)
+
+	for {
+		line, err := r.partial.ReadString('\n')
+		if err != nil {
+			// keep the incomplete line for the next write
+			r.partial.Reset()
+			r.partial.WriteString(line)
+			break
+		}
+
+		r.record(strings.TrimRight(line, "\r\n"))
+	}
+
+	return len(p), nil
+}
+
+// record stores a complete line of the build log.
+func (r *buildLogRecorder) record(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	if strings.HasPrefix(line, "Step ") {
+		r.step = line
+		r.lines = nil
+		return
+	}
+
+	r.lines = append(r.lines, line)
+	if len(r.lines) > maxBuildErrorOutputLines {
+		r.lines = r.lines[len(r.lines)-maxBuildErrorOutputLines:]
+	}
+}
+
+// buildError wraps the given error into a BuildError, including the recorded step and output.
+func (r *buildLogRecorder) buildError(err error) *BuildError {
+	if r.partial.Len() > 0 {
+		r.record(r.partial.String())
+		r.partial.Reset()
+	}
+
+	return &BuildError{
+		Step:   r.step,
+		Output: strings.Join(r.lines, "\n"),
+		Err:    err,
+	}
+}
@@ -0,0 +1,67 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLogRecorder(t *testing.T) {
+	errBuild := errors.New("The command '/bin/sh -c exit 1' returned a non-zero code: 1")
+
+	t.Run("failing-step", func(t *testing.T) {
+		recorder := &buildLogRecorder{}
+
+		_, err := recorder.Write([]byte("Step 1/2 : FROM docker.io/alpine\n ---> 05455a08881e\n"))
+		require.NoError(t, err)
+		_, err = recorder.Write([]byte("Step 2/2 : RUN echo failing && exit 1\n ---> Running in 4c1d4a5a6c8e\nfail"))
+		require.NoError(t, err)
+		_, err = recorder.Write([]byte("ing\n"))
+		require.NoError(t, err)
+
+		buildErr := recorder.buildError(errBuild)
+		assert.Equal(t, "Step 2/2 : RUN echo failing && exit 1", buildErr.Step)
+		assert.Equal(t, " ---> Running in 4c1d4a5a6c8e\nfailing", buildErr.Output)
+		require.ErrorIs(t, buildErr, errBuild)
+		assert.Contains(t, buildErr.Error(), "build image (Step 2/2 : RUN echo failing && exit 1)")
+	})
+
+	t.Run("incomplete-line", func(t *testing.T) {
+		recorder := &buildLogRecorder{}
+
+		_, err := recorder.Write([]byte("Step 1/1 : RUN exit 1\nno new line"))
+		require.NoError(t, err)
+
+		buildErr := recorder.buildError(errBuild)
+		assert.Equal(t, "Step 1/1 : RUN exit 1", buildErr.Step)
+		assert.Equal(t, "no new line", buildErr.Output)
+	})
+
+	t.Run("output-is-limited", func(t *testing.T) {
+		recorder := &buildLogRecorder{}
+
+		_, err := recorder.Write([]byte("Step 1/1 : RUN seq 100\n"))
+		require.NoError(t, err)
+		for i := 1; i <= 100; i++ {
+			_, err = recorder.Write([]byte(fmt.Sprintf("%d\n", i)))
+			require.NoError(t, err)
+		}
+
+		buildErr := recorder.buildError(errBuild)
+		lines := strings.Split(buildErr.Output, "\n")
+		require.Len(t, lines, maxBuildErrorOutputLines)
+		assert.Equal(t, "100", lines[len(lines)-1])
+	})
+
+	t.Run("no-step", func(t *testing.T) {
+		recorder := &buildLogRecorder{}
+
+		buildErr := recorder.buildError(errBuild)
+		assert.Empty(t, buildErr.Step)
+		assert.Equal(t, "build image: "+errBuild.Error(), buildErr.Error())
+	})
+}
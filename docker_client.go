@@ -3,6 +3,7 @@ package testcontainers
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/docker/docker/api/types"
@@ -63,14 +64,14 @@ func (c *DockerClient) Info(ctx context.Context) (system.Info, error) {
   Test ProcessID: %s
 `
 
-	Logger.Printf(infoMessage, packagePath,
+	logAt(ctx, Logger, slog.LevelInfo, fmt.Sprintf(infoMessage, packagePath,
 		dockerInfo.ServerVersion, c.Client.ClientVersion(),
 		dockerInfo.OperatingSystem, dockerInfo.MemTotal/1024/1024,
 		core.ExtractDockerHost(ctx),
 		core.ExtractDockerSocket(ctx),
 		core.SessionID(),
 		core.ProcessID(),
-	)
+	), LogKeySessionID, core.SessionID())
 
 	return dockerInfo, nil
 }
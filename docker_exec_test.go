@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/pkg/stdcopy"
@@ -137,3 +138,80 @@ func TestExecWithNonMultiplexedResponse(t *testing.T) {
 	require.Equal(t, "stdout\n", stdout.String())
 	require.Equal(t, "stderr\n", stderr.String())
 }
+
+func TestExecWithDemultiplexedResponse(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execDemultiplexed {
+	var stdout, stderr bytes.Buffer
+	code, _, err := container.Exec(ctx, []string{"sh", "-c", "echo stdout; echo stderr >&2; exit 3"}, tcexec.Demultiplexed(&stdout, &stderr))
+	// }
+	require.NoError(t, err)
+	require.Equal(t, 3, code)
+
+	require.Equal(t, "stdout\n", stdout.String())
+	require.Equal(t, "stderr\n", stderr.String())
+}
+
+func TestExecWithStdin(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execWithStdin {
+	code, reader, err := container.Exec(ctx, []string{"wc", "-l"}, tcexec.WithStdin(strings.NewReader("one\ntwo\nthree\n")), tcexec.Multiplexed())
+	// }
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	b, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "3", strings.TrimSpace(string(b)))
+}
+
+func TestExecWithTTY(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execWithTTY {
+	var stdout, stderr bytes.Buffer
+	code, _, err := container.Exec(ctx, []string{"sh", "-c", "tty; echo stderr >&2"}, tcexec.WithTTY(), tcexec.Demultiplexed(&stdout, &stderr))
+	// }
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	// with a TTY, stderr is combined into stdout
+	require.Contains(t, stdout.String(), "/dev/pts/")
+	require.Contains(t, stdout.String(), "stderr")
+	require.Empty(t, stderr.String())
+}
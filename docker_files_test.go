@@ -1,15 +1,21 @@
 package testcontainers_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -95,6 +101,58 @@ func TestCopyFileToRunningContainer(t *testing.T) {
 	require.NoError(t, container.Terminate(ctx))
 }
 
+func TestCopyFileToContainer_modeAndOwner(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	scriptPath := filepath.Join(t.TempDir(), "init.sh")
+	require.NoError(t, os.WriteFile(scriptPath, []byte("echo init"), 0o755))
+
+	// copyFileModeAndOwner {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash:5.2.26",
+			Files: []testcontainers.ContainerFile{
+				{
+					// the zero FileMode keeps the executable bits of the script
+					HostFilePath:      scriptPath,
+					ContainerFilePath: "/docker-entrypoint-initdb.d/init.sh",
+					UID:               999,
+					GID:               999,
+				},
+			},
+			Cmd: []string{"sleep", "30"},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	// copyWithOwner {
+	dc, ok := container.(*testcontainers.DockerContainer)
+	require.True(t, ok)
+
+	err = dc.CopyToContainerWithOptions(ctx, []byte("select 1"), "/docker-entrypoint-initdb.d/init.sql", 0o600, testcontainers.CopyWithOwner(70, 70))
+	// }
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"/docker-entrypoint-initdb.d/init.sh":  "755 999:999",
+		"/docker-entrypoint-initdb.d/init.sql": "600 70:70",
+	} {
+		code, r, err := container.Exec(ctx, []string{"stat", "-c", "%a %u:%g", path}, tcexec.Multiplexed())
+		require.NoError(t, err)
+		require.Zero(t, code)
+
+		bs, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, expected, strings.TrimSpace(string(bs)))
+	}
+}
+
 func TestCopyDirectoryToContainer(t *testing.T) {
 	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cnl()
@@ -130,6 +188,80 @@ func TestCopyDirectoryToContainer(t *testing.T) {
 	require.NoError(t, container.Terminate(ctx))
 }
 
+func TestCopyFSToContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	// copyFSOnCreate {
+	// any fs.FS can be used, e.g. an embed.FS
+	fixtures := fstest.MapFS{
+		"scripts/hello.sh": {Data: []byte("echo done"), Mode: 0o700},
+		"data/users.csv":   {Data: []byte("id,name\n1,john\n")},
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash",
+			Files: []testcontainers.ContainerFile{
+				{
+					FS: fixtures,
+					// the directory is created if it does not exist
+					ContainerFilePath: "/opt/fixtures",
+				},
+			},
+			Cmd:        []string{"bash", "/opt/fixtures/scripts/hello.sh"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	r, err := container.CopyFileFromContainer(ctx, "/opt/fixtures/data/users.csv")
+	require.NoError(t, err)
+	defer r.Close()
+
+	bs, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "id,name\n1,john\n", string(bs))
+}
+
+func TestCopyArchiveToContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	content := []byte("echo done")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "hello.sh", Mode: 0o700, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	// copyArchiveOnCreate {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash",
+			Files: []testcontainers.ContainerFile{
+				{
+					Reader:            &archive,
+					Archive:           true,
+					ContainerFilePath: "/opt/scripts",
+				},
+			},
+			Cmd:        []string{"bash", "/opt/scripts/hello.sh"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	require.NoError(t, container.Terminate(ctx))
+}
+
 func TestCopyDirectoryToRunningContainerAsFile(t *testing.T) {
 	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cnl()
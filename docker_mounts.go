@@ -9,6 +9,26 @@ var mountTypeMapping = map[MountType]mount.Type{
 	MountTypePipe:   mount.TypeNamedPipe,
 }
 
+// MountPoint represents a mount of a running container, as reported by the Docker daemon
+type MountPoint struct {
+	// Type is the type of the mount, e.g. volume, bind or tmpfs
+	Type mount.Type
+	// Name is the name of the volume, empty for the other types of mounts
+	Name string
+	// Source is the path of the mount on the host. For volumes, it's the mountpoint of the volume
+	Source string
+	// Destination is the path where the mount is available within the container
+	Destination string
+	// Driver is the driver of the volume, empty for the other types of mounts
+	Driver string
+	// Mode is the comma-separated list of options of the mount, e.g. "ro" or "z"
+	Mode string
+	// RW is true if the mount is writable
+	RW bool
+	// Propagation is the propagation mode of bind mounts, e.g. rprivate
+	Propagation mount.Propagation
+}
+
 // Deprecated: use Files or HostConfigModifier in the ContainerRequest, or copy files container APIs to make containers portable across Docker environments
 // BindMounter can optionally be implemented by mount sources
 // to support advanced scenarios based on mount.BindOptions
@@ -115,16 +135,17 @@ func mapToDockerMounts(containerMounts ContainerMounts) []mount.Mount {
 		case TmpfsMounter:
 			containerMount.TmpfsOptions = typedMounter.GetTmpfsOptions()
 		case BindMounter:
-			Logger.Printf("Mount type %s is not supported by Testcontainers for Go", m.Source.Type())
+			containerMount.BindOptions = typedMounter.GetBindOptions()
 		default:
 			// The provided source type has no custom options
 		}
 
 		if mountType == mount.TypeVolume {
 			if containerMount.VolumeOptions == nil {
-				containerMount.VolumeOptions = &mount.VolumeOptions{
-					Labels: make(map[string]string),
-				}
+				containerMount.VolumeOptions = &mount.VolumeOptions{}
+			}
+			if containerMount.VolumeOptions.Labels == nil {
+				containerMount.VolumeOptions.Labels = make(map[string]string)
 			}
 			for k, v := range GenericLabels() {
 				containerMount.VolumeOptions.Labels[k] = v
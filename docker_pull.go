@@ -0,0 +1,235 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/go-units"
+)
+
+// pullProgressInterval is the interval at which the goroutines waiting for a pull
+// in progress log its last status
+const pullProgressInterval = 10 * time.Second
+
+// imagePulls coalesces the concurrent pulls of the same image in the test process, so that
+// parallel tests requesting it share a single pull, instead of hammering the registry with
+// identical pulls and tripping its rate limits.
+var imagePulls = &pullGroup{calls: map[string]*pullCall{}}
+
+// pullCall represents a pull in progress
+type pullCall struct {
+	done chan struct{}
+	err  error
+
+	// progress is the last status of the pull reported by the daemon, shared with the waiters
+	progress string
+}
+
+// pullGroup deduplicates the pulls in progress, by image and platform
+type pullGroup struct {
+	mtx   sync.Mutex
+	calls map[string]*pullCall
+}
+
+// do runs the pull, unless a pull with the same key is already in progress, in which case it waits
+// for its result instead. The pull reports its progress with the given function, which is logged
+// periodically by the waiters. If the pull in progress is cancelled by the context of the goroutine
+// running it, the waiters whose context is still alive run the pull again.
+func (g *pullGroup) do(ctx context.Context, key string, logger Logging, pull func(progress func(string)) error) error {
+	for {
+		g.mtx.Lock()
+		if c, ok := g.calls[key]; ok {
+			g.mtx.Unlock()
+
+			err := g.wait(ctx, key, c, logger)
+			if ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+				continue
+			}
+			return err
+		}
+
+		c := &pullCall{done: make(chan struct{})}
+		g.calls[key] = c
+		g.mtx.Unlock()
+
+		c.err = pull(func(progress string) {
+			g.mtx.Lock()
+			c.progress = progress
+			g.mtx.Unlock()
+		})
+
+		g.mtx.Lock()
+		delete(g.calls, key)
+		g.mtx.Unlock()
+		close(c.done)
+
+		return c.err
+	}
+}
+
+// wait waits for the pull in progress to finish, or for the context to be done
+func (g *pullGroup) wait(ctx context.Context, key string, c *pullCall, logger Logging) error {
+	logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🕐 Waiting for the pull of %s already in progress", key), LogKeyImage, key)
+
+	ticker := time.NewTicker(pullProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return c.err
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			g.mtx.Lock()
+			progress := c.progress
+			g.mtx.Unlock()
+
+			if progress != "" {
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🕐 Waiting for the pull of %s: %s", key, progress), LogKeyImage, key)
+			}
+		}
+	}
+}
+
+// pullStatus formats a message of the pull stream sent by the daemon,
+// e.g. "a3ed95caeb02: Downloading 12.5MB/25MB"
+func pullStatus(msg jsonmessage.JSONMessage) string {
+	parts := []string{}
+	if msg.ID != "" {
+		parts = append(parts, msg.ID+":")
+	}
+	if msg.Status != "" {
+		parts = append(parts, msg.Status)
+	}
+	// the progress bar of the message is meant for a terminal, so only the sizes are logged
+	if p := msg.Progress; p != nil && p.Current > 0 {
+		progress := units.HumanSize(float64(p.Current))
+		if p.Total > 0 {
+			progress += "/" + units.HumanSize(float64(p.Total))
+		}
+		parts = append(parts, progress)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// pullLayers tracks the progress of the layers of a pull, across its attempts, as the daemon
+// reuses the layers already pulled when the pull is retried.
+type pullLayers struct {
+	mtx sync.Mutex
+
+	// ids are the IDs of the layers, in the order they are reported by the daemon
+	ids []string
+
+	// done are the layers already pulled, or already in the daemon
+	done map[string]bool
+
+	// last is the last status of the pull, e.g. "a3ed95caeb02: Downloading 12.5MB/25MB"
+	last string
+}
+
+func newPullLayers() *pullLayers {
+	return &pullLayers{done: map[string]bool{}}
+}
+
+// update records a message of the pull stream sent by the daemon
+func (l *pullLayers) update(msg jsonmessage.JSONMessage) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if status := pullStatus(msg); status != "" {
+		l.last = status
+	}
+
+	// the messages of the layers have a progress, or one of the statuses of the layers,
+	// the other ones being about the image, e.g. "Pulling from library/nginx"
+	if msg.ID == "" || (msg.Progress == nil && !isLayerStatus(msg.Status)) {
+		return
+	}
+
+	if _, ok := l.done[msg.ID]; !ok {
+		l.ids = append(l.ids, msg.ID)
+		l.done[msg.ID] = false
+	}
+
+	if msg.Status == "Pull complete" || msg.Status == "Already exists" {
+		l.done[msg.ID] = true
+	}
+}
+
+// completed returns the number of layers already pulled
+func (l *pullLayers) completed() int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	completed := 0
+	for _, done := range l.done {
+		if done {
+			completed++
+		}
+	}
+
+	return completed
+}
+
+// String returns the progress of the pull, with the number of layers pulled and the last status,
+// e.g. "2/5 layers, a3ed95caeb02: Downloading 12.5MB/25MB"
+func (l *pullLayers) String() string {
+	completed := l.completed()
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if len(l.ids) == 0 {
+		return l.last
+	}
+
+	return fmt.Sprintf("%d/%d layers, %s", completed, len(l.ids), l.last)
+}
+
+// isLayerStatus returns true if the status is one of the statuses of the layers, sent by the daemon
+func isLayerStatus(status string) bool {
+	switch status {
+	case "Pulling fs layer", "Waiting", "Downloading", "Verifying Checksum", "Download complete",
+		"Extracting", "Pull complete", "Already exists":
+		return true
+	}
+
+	return strings.HasPrefix(status, "Retrying in")
+}
+
+// pullError is an error of the pull sent by the daemon in the pull stream
+type pullError struct {
+	message string
+}
+
+func (e *pullError) Error() string {
+	return "error pulling image: " + e.message
+}
+
+// isTransientPullError returns true if the pull can succeed when retried, e.g. if the connection
+// to the daemon, or the one of the daemon to the registry, is interrupted. The errors about the image,
+// e.g. a missing manifest or denied access, are not transient.
+func isTransientPullError(err error) bool {
+	var pullErr *pullError
+	if !errors.As(err, &pullErr) {
+		// the errors of the requests to the daemon are retried, as before the stream started
+		return true
+	}
+
+	msg := strings.ToLower(pullErr.message)
+	for _, transient := range []string{"eof", "connection reset", "connection refused", "timeout", "tls handshake", "temporary", "broken pipe", "too many requests"} {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+
+	return false
+}
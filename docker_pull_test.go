@@ -0,0 +1,254 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullGroup(t *testing.T) {
+	t.Run("concurrent-pulls-are-coalesced", func(t *testing.T) {
+		g := &pullGroup{calls: map[string]*pullCall{}}
+
+		var pulls atomic.Int32
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		pull := func(progress func(string)) error {
+			if pulls.Add(1) == 1 {
+				close(started)
+			}
+			progress("downloading")
+			<-release
+			return nil
+		}
+
+		// the first pull is in progress when the other goroutines request the image
+		wg := sync.WaitGroup{}
+		errs := make(chan error, 10)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- g.do(context.Background(), "nginx:alpine", TestLogger(t), pull)
+		}()
+		<-started
+
+		for i := 0; i < 9; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- g.do(context.Background(), "nginx:alpine", TestLogger(t), pull)
+			}()
+		}
+
+		waitForWaiters(t, g, "nginx:alpine")
+		close(release)
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), pulls.Load())
+		assert.Empty(t, g.calls)
+	})
+
+	t.Run("error-is-shared", func(t *testing.T) {
+		g := &pullGroup{calls: map[string]*pullCall{}}
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		errPull := errors.New("pull access denied")
+
+		var first error
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			first = g.do(context.Background(), "private:latest", TestLogger(t), func(_ func(string)) error {
+				close(started)
+				<-release
+				return errPull
+			})
+		}()
+		<-started
+
+		waiter := make(chan error)
+		go func() {
+			waiter <- g.do(context.Background(), "private:latest", TestLogger(t), func(_ func(string)) error {
+				return errors.New("the pull in progress must be shared")
+			})
+		}()
+
+		waitForWaiters(t, g, "private:latest")
+		close(release)
+		<-done
+
+		require.ErrorIs(t, first, errPull)
+		require.ErrorIs(t, <-waiter, errPull)
+	})
+
+	t.Run("different-keys-pull-in-parallel", func(t *testing.T) {
+		g := &pullGroup{calls: map[string]*pullCall{}}
+
+		var pulls atomic.Int32
+		pull := func(_ func(string)) error {
+			pulls.Add(1)
+			return nil
+		}
+
+		require.NoError(t, g.do(context.Background(), "nginx:alpine", TestLogger(t), pull))
+		require.NoError(t, g.do(context.Background(), "nginx:alpine (linux/arm64)", TestLogger(t), pull))
+		assert.Equal(t, int32(2), pulls.Load())
+	})
+
+	t.Run("waiter-retries-when-the-pull-is-cancelled", func(t *testing.T) {
+		g := &pullGroup{calls: map[string]*pullCall{}}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{})
+
+		var first error
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			first = g.do(ctx, "nginx:alpine", TestLogger(t), func(_ func(string)) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		}()
+		<-started
+
+		var retried atomic.Bool
+		waiter := make(chan error)
+		go func() {
+			waiter <- g.do(context.Background(), "nginx:alpine", TestLogger(t), func(_ func(string)) error {
+				retried.Store(true)
+				return nil
+			})
+		}()
+
+		waitForWaiters(t, g, "nginx:alpine")
+		cancel()
+		<-done
+
+		require.ErrorIs(t, first, context.Canceled)
+		require.NoError(t, <-waiter)
+		assert.True(t, retried.Load())
+	})
+
+	t.Run("waiter-context-is-cancelled", func(t *testing.T) {
+		g := &pullGroup{calls: map[string]*pullCall{}}
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = g.do(context.Background(), "nginx:alpine", TestLogger(t), func(_ func(string)) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := g.do(ctx, "nginx:alpine", TestLogger(t), func(_ func(string)) error {
+			return errors.New("the pull in progress must be shared")
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(release)
+		<-done
+	})
+}
+
+func TestPullStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  jsonmessage.JSONMessage
+		want string
+	}{
+		{
+			name: "status",
+			msg:  jsonmessage.JSONMessage{Status: "Pulling from library/nginx", ID: "alpine"},
+			want: "alpine: Pulling from library/nginx",
+		},
+		{
+			name: "progress",
+			msg: jsonmessage.JSONMessage{
+				Status:   "Downloading",
+				ID:       "a3ed95caeb02",
+				Progress: &jsonmessage.JSONProgress{Current: 1000, Total: 2000},
+			},
+			want: "a3ed95caeb02: Downloading 1kB/2kB",
+		},
+		{
+			name: "empty",
+			msg:  jsonmessage.JSONMessage{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pullStatus(tt.msg))
+		})
+	}
+}
+
+func TestPullLayers(t *testing.T) {
+	layers := newPullLayers()
+
+	layers.update(jsonmessage.JSONMessage{Status: "Pulling from library/nginx", ID: "alpine"})
+	assert.Equal(t, "alpine: Pulling from library/nginx", layers.String())
+
+	layers.update(jsonmessage.JSONMessage{Status: "Already exists", ID: "4abcf2066143"})
+	layers.update(jsonmessage.JSONMessage{Status: "Pulling fs layer", ID: "a3ed95caeb02"})
+	layers.update(jsonmessage.JSONMessage{
+		Status:   "Downloading",
+		ID:       "a3ed95caeb02",
+		Progress: &jsonmessage.JSONProgress{Current: 1000, Total: 2000},
+	})
+	assert.Equal(t, 1, layers.completed())
+	assert.Equal(t, "1/2 layers, a3ed95caeb02: Downloading 1kB/2kB", layers.String())
+
+	// the next attempt reports the layers already pulled as existing
+	layers.update(jsonmessage.JSONMessage{Status: "Pull complete", ID: "a3ed95caeb02"})
+	layers.update(jsonmessage.JSONMessage{Status: "Already exists", ID: "4abcf2066143"})
+	assert.Equal(t, 2, layers.completed())
+	assert.Equal(t, "2/2 layers, 4abcf2066143: Already exists", layers.String())
+
+	layers.update(jsonmessage.JSONMessage{Status: "Digest: sha256:abc"})
+	assert.Equal(t, "2/2 layers, Digest: sha256:abc", layers.String())
+}
+
+func TestIsTransientPullError(t *testing.T) {
+	assert.True(t, isTransientPullError(errors.New("error during connect")))
+	assert.True(t, isTransientPullError(&pullError{message: "unexpected EOF"}))
+	assert.True(t, isTransientPullError(&pullError{message: "read tcp 10.0.0.1:443: connection reset by peer"}))
+	assert.True(t, isTransientPullError(&pullError{message: "toomanyrequests: Too Many Requests"}))
+	assert.False(t, isTransientPullError(&pullError{message: "manifest for nginx:foo not found: manifest unknown"}))
+	assert.False(t, isTransientPullError(&pullError{message: "pull access denied for foo, repository does not exist"}))
+}
+
+// waitForWaiters gives the goroutines the time to find the pull in progress, checking it's still registered
+func waitForWaiters(t *testing.T, g *pullGroup, key string) {
+	t.Helper()
+
+	time.Sleep(100 * time.Millisecond)
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	require.Contains(t, g.calls, key)
+}
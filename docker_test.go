@@ -12,13 +12,18 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
 	"github.com/docker/go-units"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1085,6 +1090,144 @@ func ExampleContainer_Stop() {
 	// Container has been stopped
 }
 
+// trapSignalCmd runs a shell exiting with a message when it receives the SIGINT signal,
+// and ignoring the SIGTERM signal, as it runs as PID 1 without a handler for it
+var trapSignalCmd = []string{"sh", "-c", "trap 'echo got SIGINT; exit 0' INT; echo ready; while true; do sleep 0.1; done"}
+
+func Test_stopTimeoutSeconds(t *testing.T) {
+	tests := []struct {
+		timeout  time.Duration
+		expected int
+	}{
+		{timeout: 0, expected: 0},
+		{timeout: 500 * time.Millisecond, expected: 1},
+		{timeout: time.Millisecond, expected: 1},
+		{timeout: time.Second, expected: 1},
+		{timeout: 1500 * time.Millisecond, expected: 2},
+		{timeout: 30 * time.Second, expected: 30},
+		{timeout: -time.Second, expected: -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.timeout.String(), func(t *testing.T) {
+			assert.Equal(t, test.expected, stopTimeoutSeconds(test.timeout))
+		})
+	}
+}
+
+func TestDockerContainer_StopWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("signal", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "docker.io/alpine",
+				Cmd:        trapSignalCmd,
+				WaitingFor: wait.ForLog("ready"),
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, ctr)
+
+		// stopWithOptions {
+		err = ctr.(*DockerContainer).StopWithOptions(ctx, StopOptions{Signal: "SIGINT", Timeout: 30 * time.Second})
+		// }
+		require.NoError(t, err)
+		assert.False(t, ctr.IsRunning())
+
+		state, err := ctr.State(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, state.ExitCode)
+
+		logs, err := ctr.Logs(ctx)
+		require.NoError(t, err)
+		defer logs.Close()
+
+		b, err := io.ReadAll(logs)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "got SIGINT")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "docker.io/alpine",
+				Cmd:        trapSignalCmd,
+				WaitingFor: wait.ForLog("ready"),
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, ctr)
+
+		// the SIGTERM signal is ignored, so the container is killed after the timeout
+		err = ctr.(*DockerContainer).StopWithOptions(ctx, StopOptions{Signal: "SIGTERM", Timeout: time.Second})
+		require.NoError(t, err)
+
+		state, err := ctr.State(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 137, state.ExitCode)
+	})
+}
+
+func TestDockerContainer_Restart(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForHTTP("/").WithPort("80/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, ctr)
+
+	assertServing := func(t *testing.T) {
+		t.Helper()
+
+		// the host port can change after the restart
+		endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+		require.NoError(t, err)
+
+		resp, err := http.Get(endpoint)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	t.Run("graceful", func(t *testing.T) {
+		// restartContainer {
+		err := ctr.(*DockerContainer).Restart(ctx, RestartOptions{
+			StopOptions: StopOptions{Timeout: 10 * time.Second},
+		})
+		// }
+		require.NoError(t, err)
+		assert.True(t, ctr.IsRunning())
+
+		assertServing(t)
+	})
+
+	t.Run("kill", func(t *testing.T) {
+		// killContainer {
+		err := ctr.(*DockerContainer).Restart(ctx, RestartOptions{
+			Kill:       true,
+			WaitingFor: wait.ForListeningPort("80/tcp"),
+		})
+		// }
+		require.NoError(t, err)
+		assert.True(t, ctr.IsRunning())
+
+		// the wait strategy of the request is kept for the next restarts
+		assert.IsType(t, &wait.HTTPStrategy{}, ctr.(*DockerContainer).WaitingFor)
+
+		assertServing(t)
+	})
+}
+
 func ExampleContainer_MappedPort() {
 	ctx := context.Background()
 	req := ContainerRequest{
@@ -1295,6 +1438,127 @@ func TestContainerCustomPlatformImage(t *testing.T) {
 	})
 }
 
+func TestContainerImagePullPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("never-fails-with-missing-image", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:           "docker.io/testcontainers/not-present-locally:latest",
+				ImagePullPolicy: PullNever,
+			},
+		})
+		terminateContainerOnEnd(t, ctx, c)
+
+		require.ErrorContains(t, err, "not found locally")
+	})
+
+	t.Run("never-uses-local-image", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: nginxAlpineImage,
+			},
+		})
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+
+		c, err = GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:           nginxAlpineImage,
+				ImagePullPolicy: PullNever,
+			},
+		})
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+	})
+}
+
+func TestContainerImageDigest(t *testing.T) {
+	ctx := context.Background()
+
+	dockerCli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer dockerCli.Close()
+
+	// make sure the image is present locally, to read its digest
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	img, _, err := dockerCli.ImageInspectWithRaw(ctx, nginxAlpineImage)
+	require.NoError(t, err)
+	require.NotEmpty(t, img.RepoDigests)
+
+	_, digest, found := strings.Cut(img.RepoDigests[0], "@")
+	require.True(t, found)
+
+	t.Run("matching-digest", func(t *testing.T) {
+		// withImageDigest {
+		req := GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: nginxAlpineImage,
+			},
+		}
+		WithImagePullPolicy(PullNever).Customize(&req)
+		WithImageDigest(digest).Customize(&req)
+		// }
+
+		c, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatching-digest", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:           nginxAlpineImage,
+				ImagePullPolicy: PullNever,
+				ImageDigest:     "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		})
+		terminateContainerOnEnd(t, ctx, c)
+
+		require.ErrorIs(t, err, ErrImageDigestMismatch)
+	})
+}
+
+func TestImageMatchesDigest(t *testing.T) {
+	digest := "sha256:6a4e9ad2ecd1f1dd4cbd4d4d3f1ab4e2bb8d32d5a6db4af8d5a1c1c2d9c67b2c"
+
+	t.Run("repo-digest", func(t *testing.T) {
+		img := types.ImageInspect{
+			ID:          "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			RepoDigests: []string{"nginx@" + digest},
+		}
+		require.True(t, imageMatchesDigest(img, digest))
+	})
+
+	t.Run("image-id", func(t *testing.T) {
+		img := types.ImageInspect{
+			ID: digest,
+		}
+		require.True(t, imageMatchesDigest(img, digest))
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		img := types.ImageInspect{
+			ID:          "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			RepoDigests: []string{"nginx@sha256:2222222222222222222222222222222222222222222222222222222222222222"},
+		}
+		require.False(t, imageMatchesDigest(img, digest))
+	})
+}
+
 func TestContainerWithCustomHostname(t *testing.T) {
 	ctx := context.Background()
 	name := fmt.Sprintf("some-nginx-%s-%d", t.Name(), rand.Int())
@@ -2057,3 +2321,135 @@ func TestImageBuiltFromDockerfile_KeepBuiltImage(t *testing.T) {
 		})
 	}
 }
+
+// inspectCountingClient is a Docker client counting the inspections of the containers,
+// which always report the 80/tcp port mapped to the host port, and the networks they are connected to.
+type inspectCountingClient struct {
+	client.APIClient
+	inspections atomic.Int32
+	hostPort    atomic.Value
+
+	networksMtx sync.Mutex
+	networks    map[string]*network.EndpointSettings
+}
+
+func (c *inspectCountingClient) NetworkConnect(_ context.Context, nw, _ string, _ *network.EndpointSettings) error {
+	c.networksMtx.Lock()
+	defer c.networksMtx.Unlock()
+
+	if c.networks == nil {
+		c.networks = map[string]*network.EndpointSettings{}
+	}
+	c.networks[nw] = &network.EndpointSettings{IPAddress: fmt.Sprintf("172.18.0.%d", len(c.networks)+2)}
+
+	return nil
+}
+
+func (c *inspectCountingClient) ContainerInspect(_ context.Context, id string) (types.ContainerJSON, error) {
+	c.inspections.Add(1)
+
+	c.networksMtx.Lock()
+	networks := make(map[string]*network.EndpointSettings, len(c.networks))
+	for name, settings := range c.networks {
+		networks[name] = settings
+	}
+	c.networksMtx.Unlock()
+
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         id,
+			State:      &types.ContainerState{Running: true},
+			HostConfig: &container.HostConfig{},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{
+				Ports: nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: c.hostPort.Load().(string)}},
+				},
+			},
+			Networks: networks,
+		},
+	}, nil
+}
+
+func (c *inspectCountingClient) Close() error {
+	return nil
+}
+
+func TestDockerContainer_inspectCache(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &inspectCountingClient{}
+	cli.hostPort.Store("32768")
+
+	c := &DockerContainer{ID: "abc", provider: &DockerProvider{client: cli}}
+
+	for i := 0; i < 10; i++ {
+		port, err := c.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, nat.Port("32768/tcp"), port)
+	}
+	require.Equal(t, int32(1), cli.inspections.Load())
+
+	t.Run("state-is-not-cached", func(t *testing.T) {
+		before := cli.inspections.Load()
+
+		state, err := c.State(ctx)
+		require.NoError(t, err)
+		require.True(t, state.Running)
+		require.Equal(t, before+1, cli.inspections.Load())
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		// e.g. the container is restarted with the Docker CLI
+		cli.hostPort.Store("32769")
+
+		require.NoError(t, c.Refresh(ctx))
+
+		port, err := c.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, nat.Port("32769/tcp"), port)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := c.Ports(ctx)
+				assert.NoError(t, err)
+				_ = c.IsRunning()
+			}()
+		}
+
+		c.invalidateInspect()
+		c.setRunning(true)
+		wg.Wait()
+	})
+}
+
+func TestHostPortForwarder_attach(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &inspectCountingClient{}
+	cli.hostPort.Store("32768")
+
+	sshd := &DockerContainer{ID: "sshd", provider: &DockerProvider{client: cli}}
+	f := &HostPortForwarder{sshd: sshd, dockerCli: cli}
+
+	// the inspection of the SSH server is cached before it's connected to the network
+	ips, err := sshd.ContainerIPsByNetwork(ctx)
+	require.NoError(t, err)
+	require.Empty(t, ips)
+
+	ip, err := f.attach(ctx, "app")
+	require.NoError(t, err)
+	require.Equal(t, "172.18.0.2", ip)
+
+	// within the TTL of the cache, the accessors see the network
+	ips, err = sshd.ContainerIPsByNetwork(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "172.18.0.2"}, ips)
+}
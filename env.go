@@ -0,0 +1,227 @@
+package testcontainers
+
+import (
+	"bufio"
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// parseEnvFile reads the environment variables of the .env file, in the format of the env files of compose:
+//   - one KEY=VALUE per line, optionally prefixed with "export ". Blank lines and lines starting with # are ignored.
+//   - unquoted values are trimmed, and everything after " #" is a comment.
+//   - double-quoted values can span several lines and support the \n, \t, \" and \\ escapes.
+//   - single-quoted values are literal.
+//   - a KEY without value takes the value of the variable in the environment of the tests, if it's set.
+//
+// The variables are not interpolated.
+func parseEnvFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s:%d: invalid variable name %q", path, lineNumber, key)
+		}
+
+		if !found {
+			if v, ok := os.LookupEnv(key); ok {
+				env[key] = v
+			}
+			continue
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			env[key] = strings.TrimSpace(value)
+			continue
+		}
+
+		// the quoted value continues until the closing quote, on the following lines if needed
+		quote := value[0]
+		value = value[1:]
+		for !hasClosingQuote(value, quote) {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("%s:%d: unterminated quoted value of %s", path, lineNumber, key)
+			}
+			lineNumber++
+			value += "\n" + scanner.Text()
+		}
+
+		value = value[:closingQuote(value, quote)]
+		if quote == '"' {
+			value = unescapeEnvValue(value)
+		}
+		env[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// closingQuote returns the index of the quote closing the value, -1 if there is none.
+// The double quotes escaped with a backslash do not close the value.
+func closingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		if quote == '"' && value[i] == '\\' {
+			i++
+			continue
+		}
+
+		if value[i] == quote {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func hasClosingQuote(value string, quote byte) bool {
+	return closingQuote(value, quote) >= 0
+}
+
+// unescapeEnvValue replaces the escapes of a double-quoted value
+func unescapeEnvValue(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value)
+}
+
+// envFromStruct returns the environment variables of the fields of the struct with an env tag, e.g. `env:"DB_HOST"`,
+// skipping the zero values of the fields with the omitempty option, e.g. `env:"DB_PORT,omitempty"`.
+// The fields of the embedded structs are included, and nil pointers are skipped.
+func envFromStruct(v any) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("the environment struct is nil")
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the environment must be a struct, got %T", v)
+	}
+
+	env := map[string]string{}
+	if err := addStructEnv(env, rv); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func addStructEnv(env map[string]string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		value := rv.Field(i)
+
+		tag, tagged := field.Tag.Lookup("env")
+		if !tagged {
+			if field.Anonymous && value.Kind() == reflect.Struct {
+				if err := addStructEnv(env, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			return fmt.Errorf("the env tag of the %s field has no name", field.Name)
+		}
+
+		if !field.IsExported() {
+			return fmt.Errorf("the %s field is not exported", field.Name)
+		}
+
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+
+		if opts == "omitempty" && value.IsZero() {
+			continue
+		}
+
+		s, err := formatEnvValue(value)
+		if err != nil {
+			return fmt.Errorf("the %s field: %w", field.Name, err)
+		}
+
+		env[name] = s
+	}
+
+	return nil
+}
+
+// formatEnvValue formats the value of a field: the strings, booleans and numbers, the durations,
+// e.g. "1m30s", the types implementing encoding.TextMarshaler or fmt.Stringer, and the slices of them,
+// joined with commas.
+func formatEnvValue(value reflect.Value) (string, error) {
+	if value.CanInterface() {
+		switch v := value.Interface().(type) {
+		case encoding.TextMarshaler:
+			text, err := v.MarshalText()
+			return string(text), err
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := formatEnvValue(value.Index(i))
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return strings.Join(items, ","), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
@@ -0,0 +1,118 @@
+package testcontainers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	// ErrContainerNotFound is returned when the container does not exist in the Docker daemon,
+	// e.g. when it has been removed by another process.
+	ErrContainerNotFound = errors.New("container not found")
+
+	// ErrPortNotExposed is returned when the port of a container is not exposed to the host.
+	ErrPortNotExposed = errors.New("port not exposed")
+)
+
+// timeoutErrorLogLines is the number of lines of the container logs included in a TimeoutError
+const timeoutErrorLogLines = 50
+
+// TimeoutError is returned when the wait strategy of a container is not satisfied before its timeout.
+// It includes the last state and the last lines of the logs of the container, to diagnose why it was not ready.
+type TimeoutError struct {
+	// Strategy is the wait strategy that timed out
+	Strategy wait.Strategy
+
+	// LastState is the state of the container when the strategy timed out, nil if it could not be retrieved
+	LastState *types.ContainerState
+
+	// Logs are the last lines of the logs of the container
+	Logs []string
+
+	// Err is the error returned by the strategy, wrapping context.DeadlineExceeded
+	Err error
+}
+
+// Error returns the error of the strategy, followed by the last state and logs of the container.
+func (e *TimeoutError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("container not ready: wait strategy %s timed out: %v", strategyName(e.Strategy), e.Err))
+
+	if e.LastState != nil {
+		sb.WriteString(fmt.Sprintf("\ncontainer status: %s, exit code: %d", e.LastState.Status, e.LastState.ExitCode))
+	}
+
+	if len(e.Logs) > 0 {
+		sb.WriteString(fmt.Sprintf("\nlast %d lines of the container logs:\n", len(e.Logs)))
+		sb.WriteString(strings.Join(e.Logs, "\n"))
+	}
+
+	return sb.String()
+}
+
+// strategyName describes a wait strategy with its String method, if it has one, or its type otherwise,
+// as formatting the struct would dump its internals, e.g. the pointers and functions it holds.
+func strategyName(strategy wait.Strategy) string {
+	if s, ok := strategy.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T", strategy)
+}
+
+// Unwrap returns the error of the strategy, so that errors.Is(err, context.DeadlineExceeded) is true.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// newTimeoutError builds a TimeoutError for the container, retrieving its last state and logs
+// with a fresh context, as the one of the strategy is already done.
+func newTimeoutError(c *DockerContainer, strategy wait.Strategy, err error) *TimeoutError {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	timeoutErr := &TimeoutError{
+		Strategy: strategy,
+		Err:      err,
+	}
+
+	if state, err := c.State(ctx); err == nil {
+		timeoutErr.LastState = state
+	}
+
+	logs, err := c.LogsWithOptions(ctx, LogsTail(timeoutErrorLogLines))
+	if err != nil {
+		return timeoutErr
+	}
+	defer logs.Close()
+
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		timeoutErr.Logs = append(timeoutErr.Logs, scanner.Text())
+	}
+
+	return timeoutErr
+}
+
+// wrapContainerError adds the operation and the container to an error of the Docker daemon,
+// wrapping ErrContainerNotFound too if the container does not exist.
+func wrapContainerError(err error, operation string, id string) error {
+	if err == nil {
+		return nil
+	}
+
+	if errdefs.IsNotFound(err) {
+		return fmt.Errorf("error %s container %s: %w: %w", operation, id, ErrContainerNotFound, err)
+	}
+
+	return fmt.Errorf("error %s container %s: %w", operation, id, err)
+}
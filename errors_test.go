@@ -0,0 +1,120 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWrapContainerError(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		require.NoError(t, wrapContainerError(nil, "inspecting", "abc"))
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		daemonErr := errdefs.NotFound(errors.New("No such container: abc"))
+
+		err := wrapContainerError(daemonErr, "inspecting", "abc")
+		require.ErrorIs(t, err, ErrContainerNotFound)
+		require.ErrorIs(t, err, daemonErr)
+		assert.Equal(t, "error inspecting container abc: container not found: No such container: abc", err.Error())
+	})
+
+	t.Run("other", func(t *testing.T) {
+		daemonErr := errdefs.System(errors.New("boom"))
+
+		err := wrapContainerError(daemonErr, "stopping", "abc")
+		require.ErrorIs(t, err, daemonErr)
+		require.NotErrorIs(t, err, ErrContainerNotFound)
+		assert.Equal(t, "error stopping container abc: boom", err.Error())
+	})
+}
+
+func TestTimeoutError(t *testing.T) {
+	err := &TimeoutError{
+		Strategy:  wait.ForLog("ready"),
+		LastState: &types.ContainerState{Status: "running"},
+		Logs:      []string{"starting", "still starting"},
+		Err:       context.DeadlineExceeded,
+	}
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "wait strategy *wait.LogStrategy timed out: context deadline exceeded")
+	assert.Contains(t, err.Error(), "container status: running, exit code: 0")
+	assert.Contains(t, err.Error(), "last 2 lines of the container logs:\nstarting\nstill starting")
+
+	err.Strategy = stringerStrategy{}
+	assert.Contains(t, err.Error(), "wait strategy for the stringer timed out")
+}
+
+type stringerStrategy struct {
+	wait.Strategy
+}
+
+func (stringerStrategy) String() string {
+	return "for the stringer"
+}
+
+func TestContainerErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("port-not-exposed", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, c)
+
+		_, err = c.MappedPort(ctx, "8080/tcp")
+		require.ErrorIs(t, err, ErrPortNotExposed)
+	})
+
+	t.Run("container-not-found", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: nginxAlpineImage,
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		require.NoError(t, c.Terminate(ctx))
+
+		_, err = c.State(ctx)
+		require.ErrorIs(t, err, ErrContainerNotFound)
+	})
+
+	t.Run("wait-strategy-timeout", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "alpine",
+				Cmd:        []string{"sh", "-c", "echo starting; sleep 60"},
+				WaitingFor: wait.ForLog("ready").WithStartupTimeout(2 * time.Second),
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, c)
+
+		// timeoutError {
+		var timeoutErr *TimeoutError
+		if errors.As(err, &timeoutErr) {
+			t.Logf("container not ready, status %s, logs:\n%v", timeoutErr.LastState.Status, timeoutErr.Logs)
+		}
+		// }
+		require.ErrorAs(t, err, &timeoutErr)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, "running", timeoutErr.LastState.Status)
+		assert.Equal(t, []string{"starting"}, timeoutErr.Logs)
+	})
+}
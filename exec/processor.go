@@ -12,6 +12,8 @@ import (
 type ProcessOptions struct {
 	ExecConfig types.ExecConfig
 	Reader     io.Reader
+	// Stdin is copied to the standard input of the command, if set
+	Stdin io.Reader
 }
 
 // NewProcessOptions returns a new ProcessOptions instance
@@ -60,6 +62,23 @@ func WithEnv(env []string) ProcessOption {
 	})
 }
 
+// WithStdin returns a [ProcessOption] that copies the given reader to the standard input of the command.
+// The standard input is closed once the reader is consumed, so the command receives an EOF.
+func WithStdin(stdin io.Reader) ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		opts.ExecConfig.AttachStdin = true
+		opts.Stdin = stdin
+	})
+}
+
+// WithTTY returns a [ProcessOption] that allocates a pseudo-TTY for the command.
+// With a TTY, the output has no multiplexing headers, as stdout and stderr are combined by the TTY itself.
+func WithTTY() ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		opts.ExecConfig.Tty = true
+	})
+}
+
 // Multiplexed returns a [ProcessOption] that configures the command execution
 // to combine stdout and stderr into a single stream without Docker's multiplexing headers.
 func Multiplexed() ProcessOption {
@@ -67,7 +86,8 @@ func Multiplexed() ProcessOption {
 		// returning fast to bypass those options with a nil reader,
 		// which could be the case when other options are used
 		// to configure the exec creation.
-		if opts.Reader == nil {
+		// With a TTY, the output is already a single stream.
+		if opts.Reader == nil || opts.ExecConfig.Tty {
 			return
 		}
 
@@ -87,3 +107,27 @@ func Multiplexed() ProcessOption {
 		opts.Reader = io.MultiReader(&outBuff, &errBuff)
 	})
 }
+
+// Demultiplexed returns a [ProcessOption] that configures the command execution
+// to write its stdout and stderr to the given writers, interpreting Docker's multiplexing headers.
+// The reader returned by Exec still contains the combined output, without the headers.
+// With a TTY, the whole output is written to stdout.
+func Demultiplexed(stdout io.Writer, stderr io.Writer) ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		// returning fast to bypass those options with a nil reader,
+		// which could be the case when other options are used
+		// to configure the exec creation.
+		if opts.Reader == nil {
+			return
+		}
+
+		var combined bytes.Buffer
+		if opts.ExecConfig.Tty {
+			_, _ = io.Copy(io.MultiWriter(stdout, &combined), opts.Reader)
+		} else {
+			_, _ = stdcopy.StdCopy(io.MultiWriter(stdout, &combined), io.MultiWriter(stderr, &combined), opts.Reader)
+		}
+
+		opts.Reader = &combined
+	})
+}
@@ -4,11 +4,18 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+
+	"github.com/docker/docker/pkg/archive"
 )
 
 func isDir(path string) (bool, error) {
@@ -30,8 +37,52 @@ func isDir(path string) (bool, error) {
 	return false, nil
 }
 
-// tarDir compress a directory using tar + gzip algorithms
-func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
+// CopyOption is a functional option to set the attributes of the files copied to the container, e.g. CopyWithOwner.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	// chown is true if the ownership of the files is set with uid and gid,
+	// otherwise each file keeps the ownership of its tar header.
+	chown bool
+	uid   int
+	gid   int
+}
+
+// CopyWithOwner sets the user and group IDs owning the files copied to the container,
+// e.g. the ones of the user of the container, so that its process can write them.
+func CopyWithOwner(uid int, gid int) CopyOption {
+	return func(o *copyOptions) {
+		o.chown = true
+		o.uid = uid
+		o.gid = gid
+	}
+}
+
+func newCopyOptions(opts ...CopyOption) copyOptions {
+	var o copyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// applyTo sets the ownership of the file described by the tar header. The user and group names are
+// removed, as the Docker daemon resolves the ownership from the IDs only.
+func (o copyOptions) applyTo(header *tar.Header) {
+	if !o.chown {
+		return
+	}
+
+	header.Uid = o.uid
+	header.Gid = o.gid
+	header.Uname = ""
+	header.Gname = ""
+}
+
+// tarDir compress a directory using tar + gzip algorithms.
+// If fileMode is zero, the mode of each file in the directory is kept.
+func tarDir(src string, fileMode int64, opts ...CopyOption) (*bytes.Buffer, error) {
 	// always pass src as absolute path
 	abs, err := filepath.Abs(src)
 	if err != nil {
@@ -40,8 +91,9 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 	src = abs
 
 	buffer := &bytes.Buffer{}
+	options := newCopyOptions(opts...)
 
-	Logger.Printf(">> creating TAR file from directory: %s\n", src)
+	logAt(context.Background(), Logger, slog.LevelDebug, fmt.Sprintf(">> creating TAR file from directory: %s\n", src))
 
 	// tar > gzip > buffer
 	zr := gzip.NewWriter(buffer)
@@ -59,7 +111,7 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 
 		// if a symlink, skip file
 		if fi.Mode().Type() == os.ModeSymlink {
-			Logger.Printf(">> skipping symlink: %s\n", file)
+			logAt(context.Background(), Logger, slog.LevelDebug, fmt.Sprintf(">> skipping symlink: %s\n", file))
 			return nil
 		}
 
@@ -73,7 +125,10 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 		// Since fs.FileInfo's Name method only returns the base name of the file it describes,
 		// it may be necessary to modify Header.Name to provide the full path name of the file.
 		header.Name = filepath.ToSlash(file[index:])
-		header.Mode = fileMode
+		if fileMode != 0 {
+			header.Mode = fileMode
+		}
+		options.applyTo(header)
 
 		// write header
 		if err := tw.WriteHeader(header); err != nil {
@@ -110,7 +165,7 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 }
 
 // tarFile compress a single file using tar + gzip algorithms
-func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentSize int64, fileMode int64) (*bytes.Buffer, error) {
+func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentSize int64, fileMode int64, opts ...CopyOption) (*bytes.Buffer, error) {
 	buffer := &bytes.Buffer{}
 
 	zr := gzip.NewWriter(buffer)
@@ -121,6 +176,7 @@ func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentS
 		Mode: fileMode,
 		Size: fileContentSize,
 	}
+	newCopyOptions(opts...).applyTo(hdr)
 	if err := tw.WriteHeader(hdr); err != nil {
 		return buffer, err
 	}
@@ -139,3 +195,154 @@ func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentS
 
 	return buffer, nil
 }
+
+// tarFS compress the whole tree of a file system using tar + gzip algorithms.
+// If fileMode is zero, the mode of each file in the file system is kept.
+func tarFS(fsys fs.FS, fileMode int64, opts ...CopyOption) (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+	options := newCopyOptions(opts...)
+
+	zr := gzip.NewWriter(buffer)
+	tw := tar.NewWriter(zr)
+
+	err := fs.WalkDir(fsys, ".", func(file string, d fs.DirEntry, errFn error) error {
+		if errFn != nil {
+			return fmt.Errorf("error traversing the file system: %w", errFn)
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("error getting file info: %w", err)
+		}
+
+		// if a symlink, skip file
+		if fi.Mode().Type() == fs.ModeSymlink {
+			logAt(context.Background(), Logger, slog.LevelDebug, fmt.Sprintf(">> skipping symlink: %s\n", file))
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return fmt.Errorf("error getting file info header: %w", err)
+		}
+
+		if file == "." {
+			// the root of the file system is the target directory
+			return nil
+		}
+		header.Name = file
+
+		if d.IsDir() {
+			// directories of read-only file systems, such as embed.FS, must be writable in the container
+			header.Name += "/"
+			header.Mode = 0o755
+		} else if fileMode != 0 {
+			header.Mode = fileMode
+		}
+		options.applyTo(header)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		data, err := fsys.Open(file)
+		if err != nil {
+			return fmt.Errorf("error opening file: %w", err)
+		}
+		defer data.Close()
+
+		if _, err := io.Copy(tw, data); err != nil {
+			return fmt.Errorf("error compressing file: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return buffer, err
+	}
+
+	// produce tar
+	if err := tw.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing tar file: %w", err)
+	}
+	// produce gzip
+	if err := zr.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing gzip file: %w", err)
+	}
+
+	return buffer, nil
+}
+
+// tarArchive rewrites a tar archive, which can be compressed, moving its entries
+// under the given directory. The result is compressed using gzip.
+func tarArchive(r io.Reader, dirPath string, opts ...CopyOption) (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+	options := newCopyOptions(opts...)
+
+	rc, err := archive.DecompressStream(r)
+	if err != nil {
+		return buffer, fmt.Errorf("error decompressing archive: %w", err)
+	}
+	defer rc.Close()
+
+	zr := gzip.NewWriter(buffer)
+	tw := tar.NewWriter(zr)
+	tr := tar.NewReader(rc)
+
+	base := strings.TrimPrefix(path.Clean("/"+dirPath), "/")
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return buffer, fmt.Errorf("error reading archive: %w", err)
+		}
+
+		name := rebaseArchiveEntry(base, header.Name)
+		if name == "" {
+			continue
+		}
+
+		if strings.HasSuffix(header.Name, "/") {
+			name += "/"
+		}
+		header.Name = name
+
+		// hard links point to another entry of the archive, which is moved under the directory too
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = rebaseArchiveEntry(base, header.Linkname)
+		}
+		options.applyTo(header)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return buffer, fmt.Errorf("error writing header: %w", err)
+		}
+
+		if _, err := io.Copy(tw, tr); err != nil {
+			return buffer, fmt.Errorf("error compressing file: %w", err)
+		}
+	}
+
+	// produce tar
+	if err := tw.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing tar file: %w", err)
+	}
+	// produce gzip
+	if err := zr.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing gzip file: %w", err)
+	}
+
+	return buffer, nil
+}
+
+// rebaseArchiveEntry returns the name of an entry of an archive moved under the base directory.
+// Cleaning the name as an absolute path keeps the entry under the directory.
+func rebaseArchiveEntry(base string, name string) string {
+	return strings.TrimPrefix(path.Join(base, path.Clean("/"+name)), "/")
+}
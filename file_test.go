@@ -6,12 +6,14 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -140,6 +142,201 @@ func Test_TarFile(t *testing.T) {
 	assert.Equal(t, b, untarBytes)
 }
 
+func Test_TarDir_fileMode(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "scripts")
+	require.NoError(t, os.MkdirAll(src, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "init.sh"), []byte("echo init"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "init.sql"), []byte("select 1"), 0o644))
+
+	t.Run("keep-file-modes", func(t *testing.T) {
+		buff, err := tarDir(src, 0)
+		require.NoError(t, err)
+
+		headers := tarHeaders(t, buff)
+		assert.Equal(t, int64(0o755), headers["scripts/init.sh"].Mode&0o777)
+		assert.Equal(t, int64(0o644), headers["scripts/init.sql"].Mode&0o777)
+	})
+
+	t.Run("override-file-modes", func(t *testing.T) {
+		buff, err := tarDir(src, 0o700)
+		require.NoError(t, err)
+
+		headers := tarHeaders(t, buff)
+		assert.Equal(t, int64(0o700), headers["scripts/init.sh"].Mode)
+		assert.Equal(t, int64(0o700), headers["scripts/init.sql"].Mode)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		buff, err := tarDir(src, 0, CopyWithOwner(999, 998))
+		require.NoError(t, err)
+
+		for name, header := range tarHeaders(t, buff) {
+			assert.Equal(t, 999, header.Uid, name)
+			assert.Equal(t, 998, header.Gid, name)
+			assert.Empty(t, header.Uname, name)
+			assert.Empty(t, header.Gname, name)
+		}
+	})
+}
+
+func Test_TarFile_owner(t *testing.T) {
+	content := []byte("echo init")
+	write := func(tw io.Writer) error {
+		_, err := tw.Write(content)
+		return err
+	}
+
+	buff, err := tarFile("init.sh", write, int64(len(content)), 0o755)
+	require.NoError(t, err)
+
+	header := tarHeaders(t, buff)["init.sh"]
+	assert.Equal(t, 0, header.Uid)
+	assert.Equal(t, 0, header.Gid)
+
+	buff, err = tarFile("init.sh", write, int64(len(content)), 0o755, CopyWithOwner(999, 998))
+	require.NoError(t, err)
+
+	header = tarHeaders(t, buff)["init.sh"]
+	assert.Equal(t, int64(0o755), header.Mode)
+	assert.Equal(t, 999, header.Uid)
+	assert.Equal(t, 998, header.Gid)
+}
+
+func Test_TarFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"hello.sh":         {Data: []byte("echo hello"), Mode: 0o755},
+		"conf/app.yaml":    {Data: []byte("name: app")},
+		"conf/empty/.keep": {Data: []byte{}},
+	}
+
+	t.Run("keep-file-modes", func(t *testing.T) {
+		buff, err := tarFS(fsys, 0)
+		require.NoError(t, err)
+
+		tmpDir := t.TempDir()
+		require.NoError(t, untar(tmpDir, bytes.NewReader(buff.Bytes())))
+
+		bs, err := os.ReadFile(filepath.Join(tmpDir, "conf", "app.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "name: app", string(bs))
+
+		fi, err := os.Stat(filepath.Join(tmpDir, "hello.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o755), fi.Mode().Perm())
+
+		_, err = os.Stat(filepath.Join(tmpDir, "conf", "empty", ".keep"))
+		require.NoError(t, err)
+	})
+
+	t.Run("override-file-modes", func(t *testing.T) {
+		buff, err := tarFS(fsys, 0o600)
+		require.NoError(t, err)
+
+		tmpDir := t.TempDir()
+		require.NoError(t, untar(tmpDir, bytes.NewReader(buff.Bytes())))
+
+		fi, err := os.Stat(filepath.Join(tmpDir, "hello.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		buff, err := tarFS(fsys, 0, CopyWithOwner(999, 998))
+		require.NoError(t, err)
+
+		for name, header := range tarHeaders(t, buff) {
+			assert.Equal(t, 999, header.Uid, name)
+			assert.Equal(t, 998, header.Gid, name)
+		}
+	})
+}
+
+func Test_TarArchive(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"hello.sh":          "echo hello",
+		"conf/app.yaml":     "name: app",
+		"../../etc/escaped": "escaped",
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	buff, err := tarArchive(&archive, "/opt/fixtures")
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "opt", "fixtures", "conf"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "opt", "fixtures", "etc"), 0o755))
+	require.NoError(t, untar(tmpDir, bytes.NewReader(buff.Bytes())))
+
+	for name, content := range map[string]string{
+		"hello.sh":      "echo hello",
+		"conf/app.yaml": "name: app",
+		"etc/escaped":   "escaped",
+	} {
+		bs, err := os.ReadFile(filepath.Join(tmpDir, "opt", "fixtures", filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(bs))
+	}
+}
+
+func Test_TarArchive_hardLink(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "bin/app", Mode: 0o755, Size: int64(len("app"))}))
+	_, err := tw.Write([]byte("app"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "bin/app-link", Typeflag: tar.TypeLink, Linkname: "bin/app", Mode: 0o755}))
+	require.NoError(t, tw.Close())
+
+	buff, err := tarArchive(&archive, "/opt/fixtures")
+	require.NoError(t, err)
+
+	gzr, err := gzip.NewReader(bytes.NewReader(buff.Bytes()))
+	require.NoError(t, err)
+	tr := tar.NewReader(gzr)
+
+	_, err = tr.Next()
+	require.NoError(t, err)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "opt/fixtures/bin/app-link", header.Name)
+	assert.Equal(t, "opt/fixtures/bin/app", header.Linkname)
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "opt", "fixtures", "bin"), 0o755))
+	require.NoError(t, untar(tmpDir, bytes.NewReader(buff.Bytes())))
+
+	bs, err := os.ReadFile(filepath.Join(tmpDir, "opt", "fixtures", "bin", "app-link"))
+	require.NoError(t, err)
+	assert.Equal(t, "app", string(bs))
+}
+
+// tarHeaders returns the headers of the entries of a tar + gzip archive, by name
+func tarHeaders(t *testing.T, r io.Reader) map[string]*tar.Header {
+	t.Helper()
+
+	gzr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	defer gzr.Close()
+
+	headers := map[string]*tar.Header{}
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return headers
+		}
+		require.NoError(t, err)
+
+		headers[header.Name] = header
+	}
+}
+
 // untar takes a destination path and a reader; a tar reader loops over the tarfile
 // creating the file structure at 'dst' along the way, and writing any files
 func untar(dst string, r io.Reader) error {
@@ -202,6 +399,12 @@ func untar(dst string, r io.Reader) error {
 			// manually close here after each file operation; defering would cause each file close
 			// to wait until all operations have completed.
 			f.Close()
+
+		// if it's a hard link, link it to the file extracted before
+		case tar.TypeLink:
+			if err := os.Link(filepath.Join(dst, header.Linkname), target); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -0,0 +1,172 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Handles represents the resources started by a Fixture, to be used by the tests.
+type Handles struct {
+	Containers map[string]Container // the containers of the fixture, by name
+}
+
+// Container returns the container of the fixture with the given name,
+// or an error if the fixture did not start a container with that name.
+func (h Handles) Container(name string) (Container, error) {
+	c, ok := h.Containers[name]
+	if !ok {
+		return nil, fmt.Errorf("no container found in the fixture for name %s", name)
+	}
+
+	return c, nil
+}
+
+// Fixture represents a reusable set of preconfigured containers, e.g. the backing services of a platform,
+// that can be published as a Go package, versioned, and started by the tests of other repositories with one call.
+type Fixture interface {
+	// Start starts the resources of the fixture, returning their handles.
+	Start(ctx context.Context) (Handles, error)
+	// Terminate terminates the resources started by Start.
+	Terminate(ctx context.Context) error
+}
+
+// ContainerRunFunc is a function starting a container, e.g. the RunContainer function of a module
+// with its options applied.
+type ContainerRunFunc func(ctx context.Context) (Container, error)
+
+// containerFixture is a Fixture running a single container
+type containerFixture struct {
+	name string
+	run  ContainerRunFunc
+
+	mtx       sync.Mutex
+	container Container
+}
+
+var _ Fixture = (*containerFixture)(nil)
+
+// ContainerFixture returns a Fixture running a single generic container, made available
+// in the handles with the given name.
+func ContainerFixture(name string, req GenericContainerRequest) Fixture {
+	req.Started = true
+
+	return ContainerFixtureFunc(name, func(ctx context.Context) (Container, error) {
+		return GenericContainer(ctx, req)
+	})
+}
+
+// ContainerFixtureFunc returns a Fixture running a single container with the given function,
+// made available in the handles with the given name. It allows to use modules in a fixture, e.g.:
+//
+//	ContainerFixtureFunc("db", func(ctx context.Context) (Container, error) {
+//		return postgres.RunContainer(ctx, postgres.WithDatabase("platform"))
+//	})
+func ContainerFixtureFunc(name string, run ContainerRunFunc) Fixture {
+	return &containerFixture{name: name, run: run}
+}
+
+// Start runs the container of the fixture.
+func (f *containerFixture) Start(ctx context.Context) (Handles, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.container != nil {
+		return Handles{}, fmt.Errorf("fixture %s already started", f.name)
+	}
+
+	c, err := f.run(ctx)
+	if err != nil {
+		if c != nil {
+			_ = c.Terminate(ctx)
+		}
+		return Handles{}, fmt.Errorf("error starting fixture %s: %w", f.name, err)
+	}
+
+	f.container = c
+
+	return Handles{Containers: map[string]Container{f.name: c}}, nil
+}
+
+// Terminate terminates the container of the fixture, if started.
+func (f *containerFixture) Terminate(ctx context.Context) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.container == nil {
+		return nil
+	}
+
+	if err := f.container.Terminate(ctx); err != nil {
+		return fmt.Errorf("error terminating fixture %s: %w", f.name, err)
+	}
+
+	f.container = nil
+
+	return nil
+}
+
+// combinedFixture is a Fixture composed by other fixtures
+type combinedFixture struct {
+	fixtures []Fixture
+
+	mtx     sync.Mutex
+	started []Fixture
+}
+
+var _ Fixture = (*combinedFixture)(nil)
+
+// CombineFixtures returns a Fixture composed by the given fixtures, e.g. a platform fixture
+// composed by the database, the broker and the cache fixtures. They are started in order,
+// so a fixture can depend on the ones before it, and terminated in reverse order.
+// The handles of all the fixtures are merged, so their container names must be unique.
+func CombineFixtures(fixtures ...Fixture) Fixture {
+	return &combinedFixture{fixtures: fixtures}
+}
+
+// Start starts the fixtures in order. If a fixture fails to start, the ones already started
+// are terminated and the error is returned.
+func (f *combinedFixture) Start(ctx context.Context) (Handles, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	handles := Handles{Containers: map[string]Container{}}
+
+	for _, fixture := range f.fixtures {
+		h, err := fixture.Start(ctx)
+		if err != nil {
+			return Handles{}, errors.Join(err, f.terminate(ctx))
+		}
+		f.started = append(f.started, fixture)
+
+		for name, c := range h.Containers {
+			if _, ok := handles.Containers[name]; ok {
+				return Handles{}, errors.Join(fmt.Errorf("duplicate container name %s in the fixtures", name), f.terminate(ctx))
+			}
+			handles.Containers[name] = c
+		}
+	}
+
+	return handles, nil
+}
+
+// Terminate terminates the started fixtures in reverse order, returning all the errors together.
+func (f *combinedFixture) Terminate(ctx context.Context) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.terminate(ctx)
+}
+
+func (f *combinedFixture) terminate(ctx context.Context) error {
+	var errs []error
+	for i := len(f.started) - 1; i >= 0; i-- {
+		if err := f.started[i].Terminate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	f.started = nil
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,141 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// recordingFixture is a Fixture recording the order of the calls to Start and Terminate
+type recordingFixture struct {
+	name     string
+	calls    *[]string
+	startErr error
+}
+
+func (f *recordingFixture) Start(_ context.Context) (Handles, error) {
+	*f.calls = append(*f.calls, "start "+f.name)
+	if f.startErr != nil {
+		return Handles{}, f.startErr
+	}
+
+	return Handles{Containers: map[string]Container{f.name: &DockerContainer{ID: f.name}}}, nil
+}
+
+func (f *recordingFixture) Terminate(_ context.Context) error {
+	*f.calls = append(*f.calls, "terminate "+f.name)
+	return nil
+}
+
+func TestCombineFixtures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("start-in-order-terminate-in-reverse", func(t *testing.T) {
+		var calls []string
+
+		f := CombineFixtures(
+			&recordingFixture{name: "db", calls: &calls},
+			&recordingFixture{name: "broker", calls: &calls},
+			&recordingFixture{name: "cache", calls: &calls},
+		)
+
+		handles, err := f.Start(ctx)
+		require.NoError(t, err)
+		assert.Len(t, handles.Containers, 3)
+
+		c, err := handles.Container("broker")
+		require.NoError(t, err)
+		assert.Equal(t, "broker", c.GetContainerID())
+
+		_, err = handles.Container("search")
+		require.Error(t, err)
+
+		require.NoError(t, f.Terminate(ctx))
+
+		assert.Equal(t, []string{
+			"start db", "start broker", "start cache",
+			"terminate cache", "terminate broker", "terminate db",
+		}, calls)
+	})
+
+	t.Run("terminate-started-on-failure", func(t *testing.T) {
+		var calls []string
+		errBroker := errors.New("broker failed")
+
+		f := CombineFixtures(
+			&recordingFixture{name: "db", calls: &calls},
+			&recordingFixture{name: "broker", calls: &calls, startErr: errBroker},
+			&recordingFixture{name: "cache", calls: &calls},
+		)
+
+		_, err := f.Start(ctx)
+		require.ErrorIs(t, err, errBroker)
+
+		assert.Equal(t, []string{"start db", "start broker", "terminate db"}, calls)
+	})
+
+	t.Run("duplicate-names", func(t *testing.T) {
+		var calls []string
+
+		f := CombineFixtures(
+			&recordingFixture{name: "db", calls: &calls},
+			&recordingFixture{name: "db", calls: &calls},
+		)
+
+		_, err := f.Start(ctx)
+		require.Error(t, err)
+
+		assert.Equal(t, []string{"start db", "start db", "terminate db", "terminate db"}, calls)
+	})
+}
+
+func TestContainerFixture(t *testing.T) {
+	ctx := context.Background()
+
+	// platformFixture {
+	platform := CombineFixtures(
+		ContainerFixture("web", GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{"80/tcp"},
+				WaitingFor:   wait.ForListeningPort("80/tcp"),
+			},
+		}),
+		ContainerFixtureFunc("cache", func(ctx context.Context) (Container, error) {
+			return GenericContainer(ctx, GenericContainerRequest{
+				ContainerRequest: ContainerRequest{
+					Image:        "redis:7",
+					ExposedPorts: []string{"6379/tcp"},
+					WaitingFor:   wait.ForLog("Ready to accept connections"),
+				},
+				Started: true,
+			})
+		}),
+	)
+
+	handles, err := platform.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, platform.Terminate(ctx))
+	})
+
+	web, err := handles.Container("web")
+	require.NoError(t, err)
+	// }
+
+	state, err := web.State(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Running)
+
+	cache, err := handles.Container("cache")
+	require.NoError(t, err)
+
+	state, err = cache.State(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Running)
+}
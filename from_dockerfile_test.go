@@ -1,7 +1,10 @@
 package testcontainers
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -10,6 +13,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -122,6 +126,72 @@ func TestBuildImageFromDockerfile_NoTag(t *testing.T) {
 	})
 }
 
+func TestBuildImageFromDockerfile_UseImageDigest(t *testing.T) {
+	ctx := context.Background()
+
+	// useImageDigest {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:        "testdata",
+				Dockerfile:     "echo.Dockerfile",
+				UseImageDigest: true,
+			},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	image := c.(*DockerContainer).Image
+	assert.True(t, strings.HasPrefix(image, "sha256:"), image)
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	// the container is created from the digest of the built image
+	inspect, err := cli.ContainerInspect(ctx, c.GetContainerID())
+	require.NoError(t, err)
+	assert.Equal(t, image, inspect.Image)
+
+	// which is not tagged, as neither Repo nor Tag are set
+	img, _, err := cli.ImageInspectWithRaw(ctx, image)
+	require.NoError(t, err)
+	assert.Equal(t, image, img.ID)
+	assert.Empty(t, img.RepoTags)
+}
+
+func TestBuiltImageID(t *testing.T) {
+	aux := func(s string) *json.RawMessage {
+		raw := json.RawMessage(s)
+		return &raw
+	}
+
+	assert.Equal(t, "sha256:abc", builtImageID(jsonmessage.JSONMessage{ID: "moby.image.id", Aux: aux(`{"ID":"sha256:abc"}`)}))
+	assert.Empty(t, builtImageID(jsonmessage.JSONMessage{ID: "moby.buildkit.trace", Aux: aux(`"Cm0KR3NoYTI1Ng=="`)}))
+	assert.Empty(t, builtImageID(jsonmessage.JSONMessage{Stream: "Step 1/2 : FROM alpine"}))
+}
+
+func TestBuildOptions_UseImageDigest(t *testing.T) {
+	t.Run("untagged", func(t *testing.T) {
+		req := &ContainerRequest{FromDockerfile: FromDockerfile{Context: "testdata", Dockerfile: "echo.Dockerfile", UseImageDigest: true}}
+
+		opts, err := req.BuildOptions()
+		require.NoError(t, err)
+		assert.Empty(t, opts.Tags)
+	})
+
+	t.Run("tagged", func(t *testing.T) {
+		req := &ContainerRequest{FromDockerfile: FromDockerfile{Context: "testdata", Dockerfile: "echo.Dockerfile", Repo: "test-repo", Tag: "test-tag", UseImageDigest: true}}
+
+		opts, err := req.BuildOptions()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"test-repo:test-tag"}, opts.Tags)
+	})
+}
+
 func TestBuildImageFromDockerfile_Target(t *testing.T) {
 	// there are thre targets: target0, target1 and target2.
 	for i := 0; i < 3; i++ {
@@ -215,3 +285,52 @@ func TestBuildImageFromDockerfile_TargetDoesNotExist(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestBuildImageFromDockerfile_BuildLogWriter(t *testing.T) {
+	ctx := context.Background()
+
+	var buffer strings.Builder
+
+	// fromDockerfileWithBuildLogWriter {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:        "testdata",
+				Dockerfile:     "buildlog.Dockerfile",
+				BuildLogWriter: &buffer,
+			},
+		},
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	assert.Regexp(t, `(?i)Step\s*1/1\s*:\s*FROM docker.io/alpine`, buffer.String())
+}
+
+func TestBuildImageFromDockerfile_BuildError(t *testing.T) {
+	ctx := context.Background()
+
+	dockerfile := "FROM docker.io/alpine\nRUN echo 'this step fails' && exit 1\n"
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0o644, Size: int64(len(dockerfile))}))
+	_, err := tw.Write([]byte(dockerfile))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	_, err = GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				ContextArchive: &buf,
+			},
+		},
+	})
+	require.Error(t, err)
+
+	var buildErr *BuildError
+	require.ErrorAs(t, err, &buildErr)
+	assert.Contains(t, buildErr.Step, "RUN echo 'this step fails' && exit 1")
+	assert.Contains(t, buildErr.Output, "this step fails")
+}
@@ -14,13 +14,20 @@ var (
 	ErrReuseEmptyName = errors.New("with reuse option a container name mustn't be empty")
 )
 
+var (
+	sessionLabelsMx sync.RWMutex
+	sessionLabels   = map[string]string{}
+)
+
 // GenericContainerRequest represents parameters to a generic container
 type GenericContainerRequest struct {
-	ContainerRequest              // embedded request for provider
-	Started          bool         // whether to auto-start the container
-	ProviderType     ProviderType // which provider to use, Docker if empty
-	Logger           Logging      // provide a container specific Logging - use default global logger if empty
-	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	ContainerRequest                // embedded request for provider
+	Started          bool           // whether to auto-start the container
+	ProviderType     ProviderType   // which provider to use, Docker if empty
+	Logger           Logging        // provide a container specific Logging - use default global logger if empty
+	Reuse            bool           // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	DockerHost       string         // the Docker host of the daemon running the container, e.g. tcp://remote:2376. The one of the environment if empty
+	ReaperOptions    []ReaperOption // the settings of the reaper overriding the ones of the properties and the environment, e.g. a mirrored image
 }
 
 // Deprecated: will be removed in the future.
@@ -51,11 +58,22 @@ func GenericContainer(ctx context.Context, req GenericContainerRequest) (Contain
 		return nil, ErrReuseEmptyName
 	}
 
+	// the logs of the creation of the container share the ID of the request
+	ctx = withRequestID(ctx)
+
 	logging := req.Logger
 	if logging == nil {
 		logging = Logger
 	}
-	provider, err := req.ProviderType.GetProvider(WithLogger(logging))
+	providerOpts := []GenericProviderOption{WithLogger(logging)}
+	if req.DockerHost != "" {
+		providerOpts = append(providerOpts, WithDockerHost(req.DockerHost))
+	}
+	for _, ro := range req.ReaperOptions {
+		providerOpts = append(providerOpts, ro)
+	}
+
+	provider, err := req.ProviderType.GetProvider(providerOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +110,34 @@ type GenericProvider interface {
 	ImageProvider
 }
 
-// GenericLabels returns a map of labels that can be used to identify containers created by this library
+// GenericLabels returns a map of labels that can be used to identify containers created by this library,
+// including the session labels added with WithLabels
 func GenericLabels() map[string]string {
-	return core.DefaultLabels(core.SessionID())
+	sessionLabelsMx.RLock()
+	defer sessionLabelsMx.RUnlock()
+
+	labels := make(map[string]string, len(sessionLabels))
+	for k, v := range sessionLabels {
+		labels[k] = v
+	}
+
+	// the default labels take precedence, as the reaper relies on them
+	for k, v := range core.DefaultLabels(core.SessionID()) {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// WithLabels adds the labels to every container, network and volume created from now on in the test session,
+// on top of the labels of Testcontainers, which cannot be overridden. Call it before creating any resource,
+// e.g. in TestMain, to identify the resources of a test run, e.g. with the ID of the CI job, so they can be
+// removed with Prune when the reaper is disabled.
+func WithLabels(labels map[string]string) {
+	sessionLabelsMx.Lock()
+	defer sessionLabelsMx.Unlock()
+
+	for k, v := range labels {
+		sessionLabels[k] = v
+	}
 }
@@ -0,0 +1,266 @@
+package testcontainers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HostInternal is the name the containers customized by a HostPortForwarder resolve
+	// to the host running the tests, e.g. http://host.testcontainers.internal:8080
+	HostInternal = "host.testcontainers.internal"
+
+	sshdImage = "testcontainers/sshd:1.2.0"
+	sshdPort  = "22/tcp"
+	sshdUser  = "root"
+)
+
+// HostPortForwarder forwards ports of the host running the tests to the containers it customizes,
+// so that they can call services of the test process, e.g. an HTTP server receiving callbacks,
+// at HostInternal:port. The ports are forwarded through an SSH tunnel to an SSH server container,
+// so it works with remote and rootless Docker hosts too.
+type HostPortForwarder struct {
+	sshd      Container
+	client    *ssh.Client
+	dockerCli client.APIClient
+	ports     []int
+	listeners []net.Listener
+
+	// mtx serializes the connections of the SSH server to the networks of the containers
+	mtx sync.Mutex
+}
+
+// Compiler check to ensure that HostPortForwarder implements the ContainerCustomizer interface.
+var _ ContainerCustomizer = (*HostPortForwarder)(nil)
+
+// ExposeHostPorts starts an SSH server container, and forwards the given ports of the host to it.
+// Pass the returned HostPortForwarder as an option to the containers calling the host: they are
+// attached to it, and resolve HostInternal to it. Call Terminate to stop forwarding the ports.
+func ExposeHostPorts(ctx context.Context, ports ...int) (*HostPortForwarder, error) {
+	if len(ports) == 0 {
+		return nil, errors.New("no ports to expose")
+	}
+
+	password, err := randomPassword()
+	if err != nil {
+		return nil, fmt.Errorf("error generating the SSH password: %w", err)
+	}
+
+	sshd, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        sshdImage,
+			ExposedPorts: []string{sshdPort},
+			Env:          map[string]string{"PASSWORD": password},
+			WaitingFor:   wait.ForListeningPort(sshdPort),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error starting the SSH server: %w", err)
+	}
+
+	f := &HostPortForwarder{
+		sshd:  sshd,
+		ports: ports,
+	}
+
+	if err := f.start(ctx, password); err != nil {
+		return nil, errors.Join(err, f.Terminate(ctx))
+	}
+
+	return f, nil
+}
+
+// start connects to the SSH server, and forwards the ports of the host to it
+func (f *HostPortForwarder) start(ctx context.Context, password string) error {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return err
+	}
+	f.dockerCli = cli
+
+	endpoint, err := f.sshd.PortEndpoint(ctx, sshdPort, "")
+	if err != nil {
+		return err
+	}
+
+	f.client, err = ssh.Dial("tcp", endpoint, &ssh.ClientConfig{
+		User:            sshdUser,
+		Auth:            []ssh.AuthMethod{ssh.Password(password)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // nolint:gosec
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("error connecting to the SSH server: %w", err)
+	}
+
+	for _, port := range f.ports {
+		// the SSH server listens on all its interfaces, forwarding the connections to the host
+		l, err := f.client.Listen("tcp", net.JoinHostPort("0.0.0.0", strconv.Itoa(port)))
+		if err != nil {
+			return fmt.Errorf("error forwarding port %d: %w", port, err)
+		}
+		f.listeners = append(f.listeners, l)
+
+		go forward(l, net.JoinHostPort("localhost", strconv.Itoa(port)))
+	}
+
+	return nil
+}
+
+// Customize attaches the container to the SSH server, connecting the server to the first network
+// of the container, and resolving HostInternal to the IP address of the server in that network.
+// The network is resolved right before the container is created, once all the options are applied.
+func (f *HostPortForwarder) Customize(req *GenericContainerRequest) {
+	// the host config is built before the user-defined pre-create hooks run, so it's captured
+	// by the modifier, to add the address of the SSH server to it once the network is resolved
+	var hostConfig *container.HostConfig
+	chainHostConfigModifier(req, func(hc *container.HostConfig) {
+		hostConfig = hc
+	})
+
+	req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+		PreCreates: []ContainerRequestHook{
+			func(ctx context.Context, req ContainerRequest) error {
+				if hostConfig == nil {
+					return errors.New("error attaching the container to the host port forwarder: its host config modifier was replaced")
+				}
+
+				ip, err := f.attach(ctx, hostPortForwarderNetwork(req))
+				if err != nil {
+					return fmt.Errorf("error attaching the container to the host port forwarder: %w", err)
+				}
+
+				hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, HostInternal+":"+ip)
+				return nil
+			},
+		},
+	})
+}
+
+// hostPortForwarderNetwork returns the network the container joins at creation, where it calls the SSH server:
+// the first of its networks, or of its network attachments, or the default bridge network.
+func hostPortForwarderNetwork(req ContainerRequest) string {
+	if len(req.Networks) > 0 {
+		return req.Networks[0]
+	}
+
+	if len(req.NetworkAttachments) > 0 {
+		return req.NetworkAttachments[0].Name
+	}
+
+	return "bridge"
+}
+
+// Terminate stops forwarding the ports of the host, and terminates the SSH server container.
+func (f *HostPortForwarder) Terminate(ctx context.Context) error {
+	var errs []error
+	for _, l := range f.listeners {
+		errs = append(errs, l.Close())
+	}
+
+	if f.client != nil {
+		errs = append(errs, f.client.Close())
+	}
+
+	if f.dockerCli != nil {
+		errs = append(errs, f.dockerCli.Close())
+	}
+
+	errs = append(errs, f.sshd.Terminate(ctx))
+
+	return errors.Join(errs...)
+}
+
+// attach connects the SSH server to the network, if it's not connected yet,
+// and returns its IP address in that network
+func (f *HostPortForwarder) attach(ctx context.Context, nw string) (string, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	ips, err := f.sshd.(*DockerContainer).ContainerIPsByNetwork(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := ips[nw]; !ok {
+		err := f.dockerCli.NetworkConnect(ctx, nw, f.sshd.GetContainerID(), &network.EndpointSettings{})
+		if err != nil {
+			return "", fmt.Errorf("error connecting the SSH server to network %s: %w", nw, err)
+		}
+
+		// the cached inspection of the SSH server does not include the network yet
+		if err := f.sshd.(*DockerContainer).Refresh(ctx); err != nil {
+			return "", err
+		}
+
+		ips, err = f.sshd.(*DockerContainer).ContainerIPsByNetwork(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ip, ok := ips[nw]
+	if !ok || ip == "" {
+		return "", fmt.Errorf("the SSH server has no IP address in network %s", nw)
+	}
+
+	return ip, nil
+}
+
+// forward accepts the connections of the listener, forwarding them to the address
+func forward(l net.Listener, addr string) {
+	for {
+		remote, err := l.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+
+		go func() {
+			defer remote.Close()
+
+			local, err := net.Dial("tcp", addr)
+			if err != nil {
+				return
+			}
+			defer local.Close()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				_, _ = io.Copy(local, remote)
+				done <- struct{}{}
+			}()
+			go func() {
+				_, _ = io.Copy(remote, local)
+				done <- struct{}{}
+			}()
+			<-done
+		}()
+	}
+}
+
+// randomPassword returns a random hex password for the SSH server
+func randomPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
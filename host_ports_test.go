@@ -0,0 +1,162 @@
+package testcontainers_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+// startHostServer starts an HTTP server on the loopback interface of the host, returning its port
+func startHostServer(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "hello from the host")
+		}),
+	}
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestExposeHostPorts(t *testing.T) {
+	ctx := context.Background()
+
+	port := startHostServer(t)
+
+	// exposeHostPorts {
+	forwarder, err := testcontainers.ExposeHostPorts(ctx, port)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, forwarder.Terminate(ctx))
+	})
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "curlimages/curl:8.7.1",
+			Cmd:   []string{"sleep", "infinity"},
+		},
+		Started: true,
+	}
+	// the container resolves testcontainers.HostInternal to the forwarder
+	forwarder.Customize(&req)
+	// }
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	t.Run("bridge", func(t *testing.T) {
+		assertCallsHost(t, ctx, c, port)
+	})
+
+	t.Run("custom-network", func(t *testing.T) {
+		nw, err := network.New(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, nw.Remove(ctx))
+		})
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:    "curlimages/curl:8.7.1",
+				Cmd:      []string{"sleep", "infinity"},
+				Networks: []string{nw.Name},
+			},
+			Started: true,
+		}
+		forwarder.Customize(&req)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, c)
+
+		assertCallsHost(t, ctx, c, port)
+	})
+
+	t.Run("network-option-after-forwarder", func(t *testing.T) {
+		nw, err := network.New(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, nw.Remove(ctx))
+		})
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "curlimages/curl:8.7.1",
+				Cmd:   []string{"sleep", "infinity"},
+			},
+			Started: true,
+		}
+		// the network is resolved once all the options are applied
+		forwarder.Customize(&req)
+		network.WithNetwork([]string{"curl"}, nw)(&req)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, c)
+
+		assertCallsHost(t, ctx, c, port)
+	})
+
+	t.Run("network-attachment", func(t *testing.T) {
+		nw, err := network.New(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, nw.Remove(ctx))
+		})
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:              "curlimages/curl:8.7.1",
+				Cmd:                []string{"sleep", "infinity"},
+				NetworkAttachments: []testcontainers.NetworkAttachment{{Name: nw.Name}},
+			},
+			Started: true,
+		}
+		forwarder.Customize(&req)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, c)
+
+		assertCallsHost(t, ctx, c, port)
+	})
+}
+
+func TestExposeHostPorts_noPorts(t *testing.T) {
+	_, err := testcontainers.ExposeHostPorts(context.Background())
+	require.EqualError(t, err, "no ports to expose")
+}
+
+// assertCallsHost asserts that the container reaches the server of the host, through the forwarder
+func assertCallsHost(t *testing.T, ctx context.Context, c testcontainers.Container, port int) {
+	t.Helper()
+
+	url := fmt.Sprintf("http://%s:%d", testcontainers.HostInternal, port)
+	code, r, err := c.Exec(ctx, []string{"curl", "-sf", url})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "hello from the host")
+}
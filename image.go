@@ -14,5 +14,7 @@ type ImageInfo struct {
 type ImageProvider interface {
 	ListImages(context.Context) ([]ImageInfo, error)
 	SaveImages(context.Context, string, ...string) error
+	LoadImage(context.Context, string) error
+	PruneBuildCache(context.Context) (uint64, error)
 	PullImage(context.Context, string) error
 }
@@ -0,0 +1,319 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+)
+
+// imageCacheGracePeriod is the time since their last use during which the images are never pruned,
+// so that another test process does not remove an image it just pulled, before creating its container.
+const imageCacheGracePeriod = 10 * time.Minute
+
+// imageCacheMu serialises the access to the state file of the image caches of the process.
+// The test processes sharing the state file are serialised with a lock file, see lock.
+var imageCacheMu sync.Mutex
+
+// imageCacheEntry represents an image tracked by the image cache
+type imageCacheEntry struct {
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// imageCacheState is the content of the state file of the image cache
+type imageCacheState struct {
+	Images map[string]imageCacheEntry `json:"images"`
+}
+
+// ImageCache tracks the images pulled by Testcontainers in a local state file, with the last time
+// they were used, and removes the least recently used ones when the total size of the tracked images
+// exceeds a disk budget. Images not pulled by Testcontainers are never removed.
+type ImageCache struct {
+	client    client.APIClient
+	stateFile string
+	maxSize   int64
+	now       func() time.Time
+}
+
+// NewImageCache returns an image cache storing its state in the given file, which removes
+// the least recently used images when their total size exceeds maxSize, in bytes.
+func NewImageCache(cli client.APIClient, stateFile string, maxSize int64) *ImageCache {
+	return &ImageCache{
+		client:    cli,
+		stateFile: stateFile,
+		maxSize:   maxSize,
+		now:       time.Now,
+	}
+}
+
+// defaultImageCacheStateFile returns the path of the state file of the image cache,
+// in the cache directory of the user.
+func defaultImageCacheStateFile() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting the user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, "testcontainers", "images.json"), nil
+}
+
+// Track records the image as pulled by Testcontainers, and used now.
+func (c *ImageCache) Track(image string) error {
+	return c.update(func(state *imageCacheState) bool {
+		state.Images[image] = imageCacheEntry{LastUsed: c.now()}
+		return true
+	})
+}
+
+// Touch records the image as used now, if it's tracked by the cache.
+func (c *ImageCache) Touch(image string) error {
+	return c.update(func(state *imageCacheState) bool {
+		if _, ok := state.Images[image]; !ok {
+			return false
+		}
+
+		state.Images[image] = imageCacheEntry{LastUsed: c.now()}
+		return true
+	})
+}
+
+// Prune removes the least recently used images tracked by the cache, until their total size,
+// as reported by Docker, fits in the budget of the cache. The images passed as keep, the images
+// in use by a container, and the images used in the last 10 minutes, e.g. just pulled by another
+// test process, are never removed. It returns the removed images.
+func (c *ImageCache) Prune(ctx context.Context, keep ...string) ([]string, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	state, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, image := range keep {
+		kept[image] = true
+	}
+
+	type cachedImage struct {
+		name     string
+		size     int64
+		lastUsed time.Time
+	}
+
+	var (
+		images []cachedImage
+		total  int64
+	)
+	// the same image could be tracked by many names, so its size is counted just once
+	seen := map[string]bool{}
+	for name, entry := range state.Images {
+		inspect, _, err := c.client.ImageInspectWithRaw(ctx, name)
+		if err != nil {
+			if client.IsErrNotFound(err) {
+				// removed outside Testcontainers
+				delete(state.Images, name)
+				continue
+			}
+			return nil, fmt.Errorf("error inspecting image %s: %w", name, err)
+		}
+
+		size := inspect.Size
+		if seen[inspect.ID] {
+			size = 0
+		}
+		seen[inspect.ID] = true
+
+		total += size
+		images = append(images, cachedImage{name: name, size: size, lastUsed: entry.LastUsed})
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].lastUsed.Before(images[j].lastUsed)
+	})
+
+	var removed []string
+	for _, image := range images {
+		if total <= c.maxSize {
+			break
+		}
+
+		if kept[image.name] || c.now().Sub(image.lastUsed) < imageCacheGracePeriod {
+			continue
+		}
+
+		_, err := c.client.ImageRemove(ctx, image.name, types.ImageRemoveOptions{PruneChildren: true})
+		if err != nil {
+			if client.IsErrNotFound(err) {
+				delete(state.Images, image.name)
+				continue
+			}
+			// most likely in use by a container, so it's kept
+			logAt(ctx, Logger, slog.LevelInfo, fmt.Sprintf("🧹 Skipping the removal of the cached image %s: %v", image.name, err), LogKeyImage, image.name)
+			continue
+		}
+
+		total -= image.size
+		delete(state.Images, image.name)
+		removed = append(removed, image.name)
+	}
+
+	return removed, c.save(state)
+}
+
+// update applies fn to the state of the cache, saving it if fn returns true.
+func (c *ImageCache) update(fn func(state *imageCacheState) bool) error {
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	if !fn(state) {
+		return nil
+	}
+
+	return c.save(state)
+}
+
+// lock serialises the access to the state file of the cache across goroutines and processes,
+// e.g. the test binaries of the packages run by go test, so that none of them overwrites
+// the changes of the others. It takes an exclusive lock on a sidecar lock file, held
+// for the whole read-modify-write cycle, and returns the function releasing it.
+func (c *ImageCache) lock() (func(), error) {
+	imageCacheMu.Lock()
+
+	if err := os.MkdirAll(filepath.Dir(c.stateFile), 0o755); err != nil {
+		imageCacheMu.Unlock()
+		return nil, fmt.Errorf("error creating image cache dir: %w", err)
+	}
+
+	f, err := os.OpenFile(c.stateFile+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		imageCacheMu.Unlock()
+		return nil, fmt.Errorf("error opening image cache lock: %w", err)
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		imageCacheMu.Unlock()
+		return nil, fmt.Errorf("error locking image cache state: %w", err)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+		imageCacheMu.Unlock()
+	}, nil
+}
+
+// load reads the state file of the cache, returning an empty state if it does not exist.
+func (c *ImageCache) load() (*imageCacheState, error) {
+	state := &imageCacheState{Images: map[string]imageCacheEntry{}}
+
+	bs, err := os.ReadFile(c.stateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("error reading image cache state: %w", err)
+	}
+
+	if err := json.Unmarshal(bs, state); err != nil {
+		return nil, fmt.Errorf("error parsing image cache state %s: %w", c.stateFile, err)
+	}
+
+	if state.Images == nil {
+		state.Images = map[string]imageCacheEntry{}
+	}
+
+	return state, nil
+}
+
+// save writes the state file of the cache atomically, so that a concurrent
+// test process never reads a partially written file.
+func (c *ImageCache) save(state *imageCacheState) error {
+	bs, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error marshalling image cache state: %w", err)
+	}
+
+	dir := filepath.Dir(c.stateFile)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating image cache dir: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(c.stateFile)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating image cache state: %w", err)
+	}
+
+	if _, err := f.Write(bs); err != nil {
+		return errors.Join(fmt.Errorf("error writing image cache state: %w", err), f.Close(), os.Remove(f.Name()))
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Join(fmt.Errorf("error writing image cache state: %w", err), os.Remove(f.Name()))
+	}
+
+	if err := os.Rename(f.Name(), c.stateFile); err != nil {
+		return errors.Join(fmt.Errorf("error writing image cache state: %w", err), os.Remove(f.Name()))
+	}
+
+	return nil
+}
+
+// useImage records the usage of the image in the image cache, when it's enabled with the
+// image.cache.max.size property, pruning the least recently used images after a pull.
+func (p *DockerProvider) useImage(ctx context.Context, image string, pulled bool) error {
+	maxSize := p.config.Config.ImageCacheMaxSize
+	if maxSize == "" {
+		return nil
+	}
+
+	budget, err := units.FromHumanSize(maxSize)
+	if err != nil {
+		return fmt.Errorf("invalid image cache max size %s: %w", maxSize, err)
+	}
+
+	stateFile, err := defaultImageCacheStateFile()
+	if err != nil {
+		return err
+	}
+
+	cache := NewImageCache(p.client, stateFile, budget)
+
+	if !pulled {
+		return cache.Touch(image)
+	}
+
+	if err := cache.Track(image); err != nil {
+		return err
+	}
+
+	// only pulls increase the disk usage, so there is no need to prune otherwise
+	removed, err := cache.Prune(ctx, image)
+	for _, r := range removed {
+		logAt(ctx, p.Logger, slog.LevelInfo, fmt.Sprintf("🧹 Removed the least recently used image %s", r), LogKeyImage, r)
+	}
+
+	return err
+}
@@ -0,0 +1,174 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageCache_TrackAndTouch(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "images.json")
+
+	cache := NewImageCache(nil, stateFile, 0)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	// touching an image not pulled by Testcontainers does not track it
+	require.NoError(t, cache.Touch("docker.io/alpine:3.19"))
+
+	state, err := cache.load()
+	require.NoError(t, err)
+	require.Empty(t, state.Images)
+
+	require.NoError(t, cache.Track("docker.io/alpine:3.19"))
+
+	now = now.Add(time.Hour)
+	require.NoError(t, cache.Touch("docker.io/alpine:3.19"))
+
+	state, err = cache.load()
+	require.NoError(t, err)
+	require.Len(t, state.Images, 1)
+	require.Equal(t, now, state.Images["docker.io/alpine:3.19"].LastUsed)
+}
+
+func TestImageCache_Prune(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	const (
+		oldest = "docker.io/busybox:1.35.0"
+		newest = "docker.io/busybox:1.36.1"
+	)
+
+	for _, image := range []string{oldest, newest} {
+		require.NoError(t, provider.attemptToPullImage(ctx, image, types.ImagePullOptions{}))
+	}
+
+	cache := NewImageCache(provider.client, filepath.Join(t.TempDir(), "images.json"), 1)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	require.NoError(t, cache.Track(oldest))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, cache.Track(newest))
+
+	// the images used within the grace period are never removed
+	t.Run("grace-period", func(t *testing.T) {
+		removed, err := cache.Prune(ctx, newest)
+		require.NoError(t, err)
+		require.Empty(t, removed)
+	})
+
+	now = now.Add(imageCacheGracePeriod)
+
+	t.Run("within-budget", func(t *testing.T) {
+		removed, err := NewImageCache(provider.client, cache.stateFile, 1<<40).Prune(ctx)
+		require.NoError(t, err)
+		require.Empty(t, removed)
+	})
+
+	t.Run("least-recently-used", func(t *testing.T) {
+		removed, err := cache.Prune(ctx, newest)
+		require.NoError(t, err)
+		require.Equal(t, []string{oldest}, removed)
+
+		_, _, err = provider.client.ImageInspectWithRaw(ctx, oldest)
+		require.Error(t, err)
+
+		_, _, err = provider.client.ImageInspectWithRaw(ctx, newest)
+		require.NoError(t, err)
+
+		state, err := cache.load()
+		require.NoError(t, err)
+		require.Len(t, state.Images, 1)
+		require.Contains(t, state.Images, newest)
+	})
+}
+
+// imageRemovingClient is a Docker client with images of 1 byte, recording the removed ones
+type imageRemovingClient struct {
+	client.APIClient
+
+	removed []string
+}
+
+func (c *imageRemovingClient) ImageInspectWithRaw(_ context.Context, name string) (types.ImageInspect, []byte, error) {
+	return types.ImageInspect{ID: name, Size: 1}, nil, nil
+}
+
+func (c *imageRemovingClient) ImageRemove(_ context.Context, name string, _ types.ImageRemoveOptions) ([]image.DeleteResponse, error) {
+	c.removed = append(c.removed, name)
+	return nil, nil
+}
+
+func TestImageCache_PruneGracePeriod(t *testing.T) {
+	cli := &imageRemovingClient{}
+	cache := NewImageCache(cli, filepath.Join(t.TempDir(), "images.json"), 0)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	require.NoError(t, cache.Track("docker.io/busybox:1.35.0"))
+
+	// pulled by another test process, which did not create its container yet
+	now = now.Add(imageCacheGracePeriod)
+	require.NoError(t, cache.Track("docker.io/busybox:1.36.1"))
+
+	removed, err := cache.Prune(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"docker.io/busybox:1.35.0"}, removed)
+	require.Equal(t, removed, cli.removed)
+}
+
+func TestImageCache_lock(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "images.json")
+
+	// another process holding the lock of the state file
+	f, err := os.OpenFile(stateFile+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, lockFile(f))
+
+	tracked := make(chan error)
+	go func() {
+		tracked <- NewImageCache(nil, stateFile, 0).Track("docker.io/alpine:3.19")
+	}()
+
+	select {
+	case <-tracked:
+		t.Fatal("the state file was updated while locked by another process")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	require.NoError(t, unlockFile(f))
+	require.NoError(t, <-tracked)
+
+	// concurrent updates don't overwrite each other
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, NewImageCache(nil, stateFile, 0).Track(fmt.Sprintf("docker.io/image:%d", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	state, err := NewImageCache(nil, stateFile, 0).load()
+	require.NoError(t, err)
+	require.Len(t, state.Images, 11)
+}
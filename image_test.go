@@ -93,3 +93,60 @@ func TestSaveImages(t *testing.T) {
 		t.Fatalf("output file is empty")
 	}
 }
+
+func TestLoadImage(t *testing.T) {
+	t.Setenv("DOCKER_HOST", core.ExtractDockerHost(context.Background()))
+
+	provider, err := ProviderDocker.GetProvider()
+	if err != nil {
+		t.Fatalf("failed to get provider %v", err)
+	}
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	image := "redis:latest"
+	if err := provider.PullImage(context.Background(), image); err != nil {
+		t.Fatalf("pulling image %q: %v", image, err)
+	}
+
+	output := filepath.Join(t.TempDir(), "images.tar")
+	err = provider.SaveImages(context.Background(), output, image)
+	if err != nil {
+		t.Fatalf("saving image %q: %v", image, err)
+	}
+
+	err = provider.LoadImage(context.Background(), output)
+	if err != nil {
+		t.Fatalf("loading image %q: %v", image, err)
+	}
+
+	// a file which is not a tar is reported by the daemon
+	invalid := filepath.Join(t.TempDir(), "invalid.tar")
+	if err := os.WriteFile(invalid, []byte("not a tar"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = provider.LoadImage(context.Background(), invalid)
+	if err == nil {
+		t.Fatal("expected an error loading an invalid tar")
+	}
+}
+
+func TestPruneBuildCache(t *testing.T) {
+	t.Setenv("DOCKER_HOST", core.ExtractDockerHost(context.Background()))
+
+	provider, err := ProviderDocker.GetProvider()
+	if err != nil {
+		t.Fatalf("failed to get provider %v", err)
+	}
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if _, err := provider.PruneBuildCache(context.Background()); err != nil {
+		t.Fatalf("pruning build cache %v", err)
+	}
+}
@@ -20,18 +20,27 @@ var (
 
 // testcontainersConfig {
 
-// Config represents the configuration for Testcontainers
+// Config represents the configuration for Testcontainers.
+// The zero durations use the defaults of the library, e.g. 60 seconds for the startup timeout of the wait strategies.
 type Config struct {
-	Host                    string        `properties:"docker.host,default="`
-	TLSVerify               int           `properties:"docker.tls.verify,default=0"`
-	CertPath                string        `properties:"docker.cert.path,default="`
-	HubImageNamePrefix      string        `properties:"hub.image.name.prefix,default="`
-	RyukDisabled            bool          `properties:"ryuk.disabled,default=false"`
-	RyukPrivileged          bool          `properties:"ryuk.container.privileged,default=false"`
-	RyukReconnectionTimeout time.Duration `properties:"ryuk.reconnection.timeout,default=10s"`
-	RyukConnectionTimeout   time.Duration `properties:"ryuk.connection.timeout,default=1m"`
-	RyukVerbose             bool          `properties:"ryuk.verbose,default=false"`
-	TestcontainersHost      string        `properties:"tc.host,default="`
+	Host                     string        `properties:"docker.host,default="`
+	TLSVerify                int           `properties:"docker.tls.verify,default=0"`
+	CertPath                 string        `properties:"docker.cert.path,default="`
+	DockerContext            string        `properties:"docker.context,default="`
+	HubImageNamePrefix       string        `properties:"hub.image.name.prefix,default="`
+	RyukDisabled             bool          `properties:"ryuk.disabled,default=false"`
+	RyukPrivileged           bool          `properties:"ryuk.container.privileged,default=false"`
+	RyukImage                string        `properties:"ryuk.container.image,default="`
+	RyukReconnectionTimeout  time.Duration `properties:"ryuk.reconnection.timeout,default=10s"`
+	RyukConnectionTimeout    time.Duration `properties:"ryuk.connection.timeout,default=1m"`
+	RyukVerbose              bool          `properties:"ryuk.verbose,default=false"`
+	TestcontainersHost       string        `properties:"tc.host,default="`
+	ImageCacheMaxSize        string        `properties:"image.cache.max.size,default="`
+	ImagePlatform            string        `properties:"image.platform,default="`
+	DeterministicCredentials bool          `properties:"credentials.deterministic,default=false"`
+	StartupTimeout           time.Duration `properties:"wait.startup.timeout,default=0s"`
+	WaitPollInterval         time.Duration `properties:"wait.poll.interval,default=0s"`
+	PullTimeout              time.Duration `properties:"pull.timeout,default=0s"`
 }
 
 // }
@@ -72,6 +81,11 @@ func read() Config {
 			config.RyukDisabled = ryukDisabledEnv == "true"
 		}
 
+		dockerContext := os.Getenv("TESTCONTAINERS_DOCKER_CONTEXT")
+		if dockerContext != "" {
+			config.DockerContext = dockerContext
+		}
+
 		hubImageNamePrefix := os.Getenv("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX")
 		if hubImageNamePrefix != "" {
 			config.HubImageNamePrefix = hubImageNamePrefix
@@ -82,11 +96,51 @@ func read() Config {
 			config.RyukPrivileged = ryukPrivilegedEnv == "true"
 		}
 
+		ryukImage := os.Getenv("TESTCONTAINERS_RYUK_CONTAINER_IMAGE")
+		if ryukImage != "" {
+			config.RyukImage = ryukImage
+		}
+
 		ryukVerboseEnv := os.Getenv("TESTCONTAINERS_RYUK_VERBOSE")
 		if parseBool(ryukVerboseEnv) {
 			config.RyukVerbose = ryukVerboseEnv == "true"
 		}
 
+		imageCacheMaxSize := os.Getenv("TESTCONTAINERS_IMAGE_CACHE_MAX_SIZE")
+		if imageCacheMaxSize != "" {
+			config.ImageCacheMaxSize = imageCacheMaxSize
+		}
+
+		imagePlatform := os.Getenv("TESTCONTAINERS_IMAGE_PLATFORM")
+		if imagePlatform != "" {
+			config.ImagePlatform = imagePlatform
+		}
+
+		deterministicCredentialsEnv := os.Getenv("TESTCONTAINERS_DETERMINISTIC_CREDENTIALS")
+		if parseBool(deterministicCredentialsEnv) {
+			config.DeterministicCredentials = deterministicCredentialsEnv == "true"
+		}
+
+		if d, ok := parseDuration(os.Getenv("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT")); ok {
+			config.RyukConnectionTimeout = d
+		}
+
+		if d, ok := parseDuration(os.Getenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT")); ok {
+			config.RyukReconnectionTimeout = d
+		}
+
+		if d, ok := parseDuration(os.Getenv("TESTCONTAINERS_WAIT_STARTUP_TIMEOUT")); ok {
+			config.StartupTimeout = d
+		}
+
+		if d, ok := parseDuration(os.Getenv("TESTCONTAINERS_WAIT_POLL_INTERVAL")); ok {
+			config.WaitPollInterval = d
+		}
+
+		if d, ok := parseDuration(os.Getenv("TESTCONTAINERS_PULL_TIMEOUT")); ok {
+			config.PullTimeout = d
+		}
+
 		return config
 	}
 
@@ -114,3 +168,13 @@ func parseBool(input string) bool {
 	_, err := strconv.ParseBool(input)
 	return err == nil
 }
+
+// parseDuration parses a duration, e.g. 90s, returning false if it's not a valid, non-negative duration
+func parseDuration(input string) (time.Duration, bool) {
+	d, err := time.ParseDuration(input)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+
+	return d, true
+}
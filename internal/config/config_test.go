@@ -22,7 +22,17 @@ func resetTestEnv(t *testing.T) {
 	t.Setenv("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX", "")
 	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED", "")
+	t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_IMAGE", "")
 	t.Setenv("TESTCONTAINERS_RYUK_VERBOSE", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_CONTEXT", "")
+	t.Setenv("TESTCONTAINERS_IMAGE_CACHE_MAX_SIZE", "")
+	t.Setenv("TESTCONTAINERS_IMAGE_PLATFORM", "")
+	t.Setenv("TESTCONTAINERS_DETERMINISTIC_CREDENTIALS", "")
+	t.Setenv("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_WAIT_STARTUP_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_WAIT_POLL_INTERVAL", "")
+	t.Setenv("TESTCONTAINERS_PULL_TIMEOUT", "")
 }
 
 func TestReadConfig(t *testing.T) {
@@ -119,12 +129,14 @@ func TestReadTCConfig(t *testing.T) {
 		t.Setenv("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX", defaultHubPrefix)
 		t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED", "true")
 		t.Setenv("TESTCONTAINERS_RYUK_VERBOSE", "true")
+		t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_IMAGE", "registry.mycompany.com/testcontainers/ryuk:0.7.0")
 
 		config := read()
 		expected := Config{
 			HubImageNamePrefix: defaultHubPrefix,
 			RyukDisabled:       true,
 			RyukPrivileged:     true,
+			RyukImage:          "registry.mycompany.com/testcontainers/ryuk:0.7.0",
 			RyukVerbose:        true,
 		}
 
@@ -337,6 +349,50 @@ func TestReadTCConfig(t *testing.T) {
 				},
 				defaultConfig,
 			},
+			{
+				"With Ryuk image using properties",
+				`ryuk.container.image=registry.mycompany.com/testcontainers/ryuk:0.7.0`,
+				map[string]string{},
+				Config{
+					RyukImage:               "registry.mycompany.com/testcontainers/ryuk:0.7.0",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With Ryuk image using an env var and properties. Env var wins",
+				`ryuk.container.image=registry.mycompany.com/testcontainers/ryuk:0.7.0`,
+				map[string]string{
+					"TESTCONTAINERS_RYUK_CONTAINER_IMAGE": "mirror.local/testcontainers/ryuk:0.7.0",
+				},
+				Config{
+					RyukImage:               "mirror.local/testcontainers/ryuk:0.7.0",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With Docker context using properties",
+				`docker.context=colima`,
+				map[string]string{},
+				Config{
+					DockerContext:           "colima",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With Docker context using an env var and properties. Env var wins",
+				`docker.context=colima`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_CONTEXT": "desktop-linux",
+				},
+				Config{
+					DockerContext:           "desktop-linux",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
 			{
 				"With Ryuk verbose using an env var and properties. Env var wins (0)",
 				`ryuk.verbose=true`,
@@ -480,6 +536,109 @@ func TestReadTCConfig(t *testing.T) {
 					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
 				},
 			},
+			{
+				"With image cache max size set as properties",
+				`image.cache.max.size=10GB`,
+				map[string]string{},
+				Config{
+					ImageCacheMaxSize:       "10GB",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With deterministic credentials set as properties",
+				`credentials.deterministic=true`,
+				map[string]string{},
+				Config{
+					DeterministicCredentials: true,
+					RyukConnectionTimeout:    defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout:  defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With deterministic credentials set as properties and disabled as env var: Env var wins",
+				`credentials.deterministic=true`,
+				map[string]string{
+					"TESTCONTAINERS_DETERMINISTIC_CREDENTIALS": "false",
+				},
+				Config{
+					DeterministicCredentials: false,
+					RyukConnectionTimeout:    defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout:  defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With timeouts set as properties",
+				`wait.startup.timeout=90s
+				wait.poll.interval=500ms
+				pull.timeout=10m
+				ryuk.connection.timeout=2m`,
+				map[string]string{},
+				Config{
+					StartupTimeout:          90 * time.Second,
+					WaitPollInterval:        500 * time.Millisecond,
+					PullTimeout:             10 * time.Minute,
+					RyukConnectionTimeout:   2 * time.Minute,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With timeouts set as env vars and properties: Env var wins",
+				`wait.startup.timeout=90s
+				pull.timeout=10m`,
+				map[string]string{
+					"TESTCONTAINERS_WAIT_STARTUP_TIMEOUT":      "2m",
+					"TESTCONTAINERS_WAIT_POLL_INTERVAL":        "1s",
+					"TESTCONTAINERS_PULL_TIMEOUT":              "5m",
+					"TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT":   "3m",
+					"TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT": "30s",
+				},
+				Config{
+					StartupTimeout:          2 * time.Minute,
+					WaitPollInterval:        time.Second,
+					PullTimeout:             5 * time.Minute,
+					RyukConnectionTimeout:   3 * time.Minute,
+					RyukReconnectionTimeout: 30 * time.Second,
+				},
+			},
+			{
+				"With timeouts set as env vars and properties: Env var does not win because it's not a valid duration",
+				`wait.startup.timeout=90s`,
+				map[string]string{
+					"TESTCONTAINERS_WAIT_STARTUP_TIMEOUT": "foo",
+					"TESTCONTAINERS_PULL_TIMEOUT":         "-1m",
+				},
+				Config{
+					StartupTimeout:          90 * time.Second,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With image cache max size set as env var and properties: Env var wins",
+				`image.cache.max.size=10GB`,
+				map[string]string{
+					"TESTCONTAINERS_IMAGE_CACHE_MAX_SIZE": "5GB",
+				},
+				Config{
+					ImageCacheMaxSize:       "5GB",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With image platform set as env var and properties: Env var wins",
+				`image.platform=linux/amd64`,
+				map[string]string{
+					"TESTCONTAINERS_IMAGE_PLATFORM": "linux/arm64",
+				},
+				Config{
+					ImagePlatform:           "linux/arm64",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
 		}
 		for _, tt := range tests {
 			t.Run(fmt.Sprintf(tt.name), func(t *testing.T) {
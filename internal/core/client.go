@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"path/filepath"
+	"strings"
 
 	"github.com/docker/docker/client"
 
@@ -12,12 +13,25 @@ import (
 
 // NewClient returns a new docker client extracting the docker host from the different alternatives
 func NewClient(ctx context.Context, ops ...client.Opt) (*client.Client, error) {
-	tcConfig := config.Read()
+	return NewClientWithHost(ctx, ExtractDockerHost(ctx), ops...)
+}
 
-	dockerHost := ExtractDockerHost(ctx)
+// NewClientWithHost returns a new docker client for the given docker host, e.g. tcp://remote:2376 or
+// ssh://user@remote, instead of the one extracted from the different alternatives. The TLS configuration
+// of the properties applies to it too.
+func NewClientWithHost(ctx context.Context, dockerHost string, ops ...client.Opt) (*client.Client, error) {
+	tcConfig := config.Read()
 
 	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
-	if dockerHost != "" {
+	if strings.HasPrefix(dockerHost, SSHSchema) {
+		// the Docker API is proxied over ssh, so TLS does not apply
+		helper, err := newSSHHelper(dockerHost)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, client.WithHost(helper.host), client.WithDialContext(helper.DialContext))
+	} else if dockerHost != "" {
 		opts = append(opts, client.WithHost(dockerHost))
 
 		// for further information, read https://docs.docker.com/engine/security/protect-access/
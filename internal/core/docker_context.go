@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cpuguy83/dockercfg"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+// defaultDockerContext is the name of the Docker context using the DOCKER_HOST environment variable,
+// or the default Docker socket, which are resolved by other steps of the Docker host resolution chain.
+const defaultDockerContext = "default"
+
+var (
+	ErrDockerContextNotSet   = errors.New("docker context is not set, or it's the default one")
+	ErrDockerContextNotFound = errors.New("docker context not found")
+)
+
+// dockerContextMetadata is the metadata of a Docker context, stored by the Docker CLI
+// in the contexts/meta/<sha256 of the name>/meta.json file of its config directory.
+type dockerContextMetadata struct {
+	Name      string `json:"Name"`
+	Endpoints map[string]struct {
+		Host string `json:"Host"`
+	} `json:"Endpoints"`
+}
+
+// dockerHostFromDockerContext returns the docker host of the endpoint of the Docker context, which is, in order:
+//
+//  1. The context set with the "docker.context" property, or the TESTCONTAINERS_DOCKER_CONTEXT environment variable.
+//  2. The context set with the DOCKER_CONTEXT environment variable, as in the Docker CLI.
+//  3. The current context of the Docker CLI config file, ~/.docker/config.json, or config.json in DOCKER_CONFIG.
+//
+// The default context is skipped, as it's the DOCKER_HOST environment variable or the default Docker socket.
+func dockerHostFromDockerContext(_ context.Context) (string, error) {
+	configPath, err := dockercfg.ConfigPath()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDockerContextNotSet, err)
+	}
+
+	name, err := currentDockerContext(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	return dockerContextHost(filepath.Dir(configPath), name)
+}
+
+// currentDockerContext returns the name of the Docker context to use, or ErrDockerContextNotSet if it's the default one
+func currentDockerContext(configPath string) (string, error) {
+	name := config.Read().DockerContext
+	if name == "" {
+		name = os.Getenv("DOCKER_CONTEXT")
+	}
+
+	if name == "" {
+		var cfg dockercfg.Config
+		// a missing or invalid config file means there is no current context
+		if err := dockercfg.FromFile(configPath, &cfg); err == nil {
+			name = cfg.CurrentContext
+		}
+	}
+
+	if name == "" || name == defaultDockerContext {
+		return "", ErrDockerContextNotSet
+	}
+
+	return name, nil
+}
+
+// dockerContextHost returns the host of the docker endpoint of the Docker context, reading its metadata
+// from the config directory of the Docker CLI.
+func dockerContextHost(configDir string, name string) (string, error) {
+	digest := sha256.Sum256([]byte(name))
+	metaPath := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(digest[:]), "meta.json")
+
+	b, err := os.ReadFile(metaPath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrDockerContextNotFound, name, err)
+	}
+
+	var meta dockerContextMetadata
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return "", fmt.Errorf("invalid metadata of the Docker context %s: %w", name, err)
+	}
+
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok || endpoint.Host == "" {
+		return "", fmt.Errorf("%w: %s has no docker endpoint", ErrDockerContextNotFound, name)
+	}
+
+	return endpoint.Host, nil
+}
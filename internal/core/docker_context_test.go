@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestDockerHostFromDockerContext(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+
+		configDir := t.TempDir()
+		t.Setenv("DOCKER_CONFIG", configDir)
+		t.Setenv("DOCKER_CONTEXT", "")
+		t.Setenv("TESTCONTAINERS_DOCKER_CONTEXT", "")
+		t.Setenv("HOME", t.TempDir()) // no properties file
+		config.Reset()
+		t.Cleanup(config.Reset)
+
+		setupDockerContext(t, configDir, "colima", "unix:///Users/me/.colima/default/docker.sock")
+		setupDockerContext(t, configDir, "remote", "ssh://user@remote")
+
+		return configDir
+	}
+
+	t.Run("Current context", func(t *testing.T) {
+		configDir := setup(t)
+		setupDockerConfig(t, configDir, `{"currentContext": "colima"}`)
+
+		host, err := dockerHostFromDockerContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "unix:///Users/me/.colima/default/docker.sock", host)
+	})
+
+	t.Run("DOCKER_CONTEXT takes precedence over the current context", func(t *testing.T) {
+		configDir := setup(t)
+		setupDockerConfig(t, configDir, `{"currentContext": "colima"}`)
+		t.Setenv("DOCKER_CONTEXT", "remote")
+
+		host, err := dockerHostFromDockerContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "ssh://user@remote", host)
+	})
+
+	t.Run("TESTCONTAINERS_DOCKER_CONTEXT takes precedence over DOCKER_CONTEXT", func(t *testing.T) {
+		setup(t)
+		t.Setenv("DOCKER_CONTEXT", "remote")
+		t.Setenv("TESTCONTAINERS_DOCKER_CONTEXT", "colima")
+
+		host, err := dockerHostFromDockerContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "unix:///Users/me/.colima/default/docker.sock", host)
+	})
+
+	t.Run("Default context", func(t *testing.T) {
+		configDir := setup(t)
+		setupDockerConfig(t, configDir, `{"currentContext": "default"}`)
+
+		_, err := dockerHostFromDockerContext(context.Background())
+		require.ErrorIs(t, err, ErrDockerContextNotSet)
+	})
+
+	t.Run("No config file", func(t *testing.T) {
+		setup(t)
+
+		_, err := dockerHostFromDockerContext(context.Background())
+		require.ErrorIs(t, err, ErrDockerContextNotSet)
+	})
+
+	t.Run("Context not found", func(t *testing.T) {
+		setup(t)
+		t.Setenv("TESTCONTAINERS_DOCKER_CONTEXT", "missing")
+
+		_, err := dockerHostFromDockerContext(context.Background())
+		require.ErrorIs(t, err, ErrDockerContextNotFound)
+	})
+
+	t.Run("Docker host as DOCKER_HOST takes precedence over the context", func(t *testing.T) {
+		configDir := setup(t)
+		setupDockerConfig(t, configDir, `{"currentContext": "colima"}`)
+		t.Setenv("DOCKER_HOST", "/path/to/docker.sock")
+
+		host := extractDockerHost(context.Background())
+		assert.Equal(t, "/path/to/docker.sock", host)
+	})
+
+	t.Run("Context takes precedence over the rootless and default Docker sockets", func(t *testing.T) {
+		configDir := setup(t)
+		setupDockerConfig(t, configDir, `{"currentContext": "colima"}`)
+		t.Setenv("DOCKER_HOST", "")
+		setupDockerSocket(t)
+
+		host := extractDockerHost(context.Background())
+		assert.Equal(t, "unix:///Users/me/.colima/default/docker.sock", host)
+	})
+}
+
+// setupDockerConfig writes the config file of the Docker CLI in the config directory
+func setupDockerConfig(t *testing.T, configDir string, content string) {
+	t.Helper()
+
+	err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(content), 0o644)
+	require.NoError(t, err)
+}
+
+// setupDockerContext writes the metadata of a Docker context in the config directory, as the Docker CLI does
+func setupDockerContext(t *testing.T, configDir string, name string, host string) {
+	t.Helper()
+
+	digest := sha256.Sum256([]byte(name))
+	metaDir := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(digest[:]))
+	require.NoError(t, os.MkdirAll(metaDir, 0o755))
+
+	content := `{"Name":"` + name + `","Metadata":{},"Endpoints":{"docker":{"Host":"` + host + `","SkipTLSVerify":false}}}`
+	err := os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(content), 0o644)
+	require.NoError(t, err)
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/docker/docker/api/types/system"
+)
+
+// DockerEnvironment is the kind of environment running the Docker daemon. It determines the socket
+// that can be mounted in the containers, e.g. in the reaper, and the address the mapped ports are reached on.
+type DockerEnvironment string
+
+const (
+	// DockerEnvironmentNative is a Docker daemon running on the host of the tests, e.g. Docker Engine on Linux
+	DockerEnvironmentNative DockerEnvironment = "native"
+	// DockerEnvironmentDockerDesktop is Docker Desktop, running the daemon in a VM on macOS and Windows
+	DockerEnvironmentDockerDesktop DockerEnvironment = "docker-desktop"
+	// DockerEnvironmentColima is Colima, running the daemon in a Lima VM on macOS
+	DockerEnvironmentColima DockerEnvironment = "colima"
+	// DockerEnvironmentLima is a Lima VM running the daemon, e.g. with the docker template of limactl
+	DockerEnvironmentLima DockerEnvironment = "lima"
+	// DockerEnvironmentRemote is a Docker daemon reached over tcp or ssh
+	DockerEnvironmentRemote DockerEnvironment = "remote"
+)
+
+// DetectDockerEnvironment returns the kind of environment running the Docker daemon of the Docker host,
+// e.g. unix:///Users/me/.colima/default/docker.sock, using its info to detect the VMs:
+//
+//  1. Docker Desktop, if the operating system is "Docker Desktop", or the socket is in ~/.docker/desktop or ~/.docker/run.
+//  2. Colima, if the socket is in ~/.colima, or the name of the VM is colima or colima-<profile>.
+//  3. Lima, if the socket is in ~/.lima, or the name of the VM is lima-<instance>.
+//  4. Remote, if the Docker host uses the tcp, http, https or ssh schema.
+//  5. Else, native.
+func DetectDockerEnvironment(dockerHost string, info system.Info) DockerEnvironment {
+	var scheme, socket string
+	if u, err := url.Parse(dockerHost); err == nil {
+		scheme = u.Scheme
+		socket = u.Path
+	}
+
+	switch {
+	case info.OperatingSystem == "Docker Desktop",
+		strings.Contains(socket, "/.docker/desktop/"),
+		strings.Contains(socket, "/.docker/run/"):
+		return DockerEnvironmentDockerDesktop
+	case strings.Contains(socket, "/.colima/"),
+		info.Name == "colima",
+		strings.HasPrefix(info.Name, "colima-"):
+		return DockerEnvironmentColima
+	case strings.Contains(socket, "/.lima/"),
+		strings.HasPrefix(info.Name, "lima-"):
+		return DockerEnvironmentLima
+	}
+
+	switch scheme {
+	case "tcp", "http", "https", "ssh":
+		return DockerEnvironmentRemote
+	}
+
+	return DockerEnvironmentNative
+}
+
+// IsVM returns true if the Docker daemon runs in a VM on the host of the tests, so the paths of the host,
+// such as the one of the Docker socket, do not exist for the daemon, and the published ports are forwarded
+// to the localhost of the host.
+func (e DockerEnvironment) IsVM() bool {
+	switch e {
+	case DockerEnvironmentDockerDesktop, DockerEnvironmentColima, DockerEnvironmentLima:
+		return true
+	}
+
+	return false
+}
+
+// DockerSocketInVM returns the path of the Docker socket inside the VM running the Docker daemon, which
+// is the one to mount in the containers, as the socket exposed to the host does not exist in the VM.
+// The second value is false if the daemon does not run in a VM.
+func (e DockerEnvironment) DockerSocketInVM() (string, bool) {
+	if !e.IsVM() {
+		return "", false
+	}
+
+	if e == DockerEnvironmentDockerDesktop && IsWindows() {
+		return WindowsDockerSocketPath, true
+	}
+
+	return DockerSocketPath, true
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDockerEnvironment(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerHost string
+		info       system.Info
+		expected   DockerEnvironment
+	}{
+		{
+			name:       "Docker Engine",
+			dockerHost: DockerSocketPathWithSchema,
+			info:       system.Info{OperatingSystem: "Ubuntu 22.04.4 LTS", Name: "ci-runner"},
+			expected:   DockerEnvironmentNative,
+		},
+		{
+			name:       "Docker Desktop from the operating system",
+			dockerHost: DockerSocketPathWithSchema,
+			info:       system.Info{OperatingSystem: "Docker Desktop", Name: "docker-desktop"},
+			expected:   DockerEnvironmentDockerDesktop,
+		},
+		{
+			name:       "Docker Desktop from the socket",
+			dockerHost: DockerSocketSchema + "/Users/me/.docker/run/docker.sock",
+			info:       system.Info{},
+			expected:   DockerEnvironmentDockerDesktop,
+		},
+		{
+			name:       "Colima from the socket",
+			dockerHost: DockerSocketSchema + "/Users/me/.colima/default/docker.sock",
+			info:       system.Info{OperatingSystem: "Ubuntu 23.10"},
+			expected:   DockerEnvironmentColima,
+		},
+		{
+			name:       "Colima profile from the name of the VM",
+			dockerHost: DockerSocketPathWithSchema,
+			info:       system.Info{OperatingSystem: "Ubuntu 23.10", Name: "colima-work"},
+			expected:   DockerEnvironmentColima,
+		},
+		{
+			name:       "Lima from the socket",
+			dockerHost: DockerSocketSchema + "/Users/me/.lima/docker/sock/docker.sock",
+			info:       system.Info{OperatingSystem: "Ubuntu 23.10"},
+			expected:   DockerEnvironmentLima,
+		},
+		{
+			name:       "Lima from the name of the VM",
+			dockerHost: DockerSocketPathWithSchema,
+			info:       system.Info{OperatingSystem: "Ubuntu 23.10", Name: "lima-docker"},
+			expected:   DockerEnvironmentLima,
+		},
+		{
+			name:       "Remote over tcp",
+			dockerHost: TCPSchema + "remote:2376",
+			info:       system.Info{OperatingSystem: "Ubuntu 22.04.4 LTS"},
+			expected:   DockerEnvironmentRemote,
+		},
+		{
+			name:       "Remote over ssh",
+			dockerHost: SSHSchema + "user@remote",
+			info:       system.Info{OperatingSystem: "Ubuntu 22.04.4 LTS"},
+			expected:   DockerEnvironmentRemote,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectDockerEnvironment(tt.dockerHost, tt.info))
+		})
+	}
+}
+
+func TestDockerEnvironment_DockerSocketInVM(t *testing.T) {
+	t.Setenv("GOOS", "linux")
+
+	for _, env := range []DockerEnvironment{DockerEnvironmentDockerDesktop, DockerEnvironmentColima, DockerEnvironmentLima} {
+		socket, ok := env.DockerSocketInVM()
+		assert.True(t, ok, env)
+		assert.Equal(t, DockerSocketPath, socket, env)
+	}
+
+	for _, env := range []DockerEnvironment{DockerEnvironmentNative, DockerEnvironmentRemote} {
+		_, ok := env.DockerSocketInVM()
+		assert.False(t, ok, env)
+	}
+
+	t.Run("Docker Desktop for Windows", func(t *testing.T) {
+		t.Setenv("GOOS", "windows")
+
+		socket, ok := DockerEnvironmentDockerDesktop.DockerSocketInVM()
+		assert.True(t, ok)
+		assert.Equal(t, WindowsDockerSocketPath, socket)
+	})
+}
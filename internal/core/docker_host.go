@@ -30,16 +30,77 @@ var (
 	ErrTestcontainersHostNotSetInProperties = errors.New("tc.host not set in ~/.testcontainers.properties")
 )
 
+// DockerHostStrategy is a step of the Docker host resolution chain, returning the Docker host,
+// or an error if it cannot be resolved by the step, so the next step in the chain is tried.
+type DockerHostStrategy func(context.Context) (string, error)
+
+// The steps of the default Docker host resolution chain, which can be combined with custom steps
+// using SetDockerHostStrategies.
+var (
+	TestcontainersHostFromPropertiesStrategy DockerHostStrategy = testcontainersHostFromProperties
+	DockerHostFromEnvStrategy                DockerHostStrategy = dockerHostFromEnv
+	DockerHostFromContextStrategy            DockerHostStrategy = dockerHostFromContext
+	DockerHostFromPropertiesStrategy         DockerHostStrategy = dockerHostFromProperties
+	DockerContextStrategy                    DockerHostStrategy = dockerHostFromDockerContext
+	RootlessDockerSocketStrategy             DockerHostStrategy = rootlessDockerSocketPath
+	DefaultDockerSocketStrategy              DockerHostStrategy = dockerSocketPath
+)
+
+// The resolved Docker host and socket are cached, and the locks protecting the caches are never
+// held while the strategies run, as they can be user code. The generation is increased every time
+// the strategies are replaced, discarding the results of the resolutions started before.
 var (
-	dockerHostCache string
-	dockerHostOnce  sync.Once
+	dockerHostMutex      sync.Mutex
+	dockerHostCache      string
+	dockerHostStrategies []DockerHostStrategy
+	dockerHostGeneration uint64
 )
 
 var (
+	dockerSocketPathMutex sync.Mutex
 	dockerSocketPathCache string
-	dockerSocketPathOnce  sync.Once
 )
 
+// resolvingDockerHostKey marks the context passed to the strategies, to detect the strategies
+// calling back into ExtractDockerHost or ExtractDockerSocket
+type resolvingDockerHostKey struct{}
+
+// DefaultDockerHostStrategies returns the steps of the default Docker host resolution chain, in order.
+// See ExtractDockerHost.
+func DefaultDockerHostStrategies() []DockerHostStrategy {
+	return []DockerHostStrategy{
+		TestcontainersHostFromPropertiesStrategy,
+		DockerHostFromEnvStrategy,
+		DockerHostFromContextStrategy,
+		DockerHostFromPropertiesStrategy,
+		DockerContextStrategy,
+		RootlessDockerSocketStrategy,
+		DefaultDockerSocketStrategy,
+	}
+}
+
+// SetDockerHostStrategies replaces the steps of the Docker host resolution chain, discarding the
+// Docker host resolved before, so it's resolved again with the new steps. If no steps are passed,
+// the default chain is restored. If none of the steps resolves the Docker host, the default
+// Docker socket including schema is used.
+//
+// The steps must not call back into the Docker host resolution, directly or through functions using it,
+// such as testcontainers.DaemonHost: the locks are not held while the steps run, so it doesn't deadlock,
+// but the resolution would never end. Calls receiving the context passed to the step get the Docker host
+// resolved by the default steps instead.
+func SetDockerHostStrategies(strategies ...DockerHostStrategy) {
+	dockerHostMutex.Lock()
+	dockerHostStrategies = strategies
+	dockerHostCache = ""
+	dockerHostGeneration++
+	dockerHostMutex.Unlock()
+
+	// the Docker socket depends on the Docker host
+	dockerSocketPathMutex.Lock()
+	dockerSocketPathCache = ""
+	dockerSocketPathMutex.Unlock()
+}
+
 // deprecated
 // see https://github.com/testcontainers/testcontainers-java/blob/main/core/src/main/java/org/testcontainers/dockerclient/DockerClientConfigUtils.java#L46
 func DefaultGatewayIP() (string, error) {
@@ -50,27 +111,64 @@ func DefaultGatewayIP() (string, error) {
 		return "", errors.New("failed to detect docker host")
 	}
 	ip := strings.TrimSpace(string(stdout))
+	if len(ip) == 0 {
+		// IPv6-only networks have no IPv4 default route
+		stdout, err = exec.Command("sh", "-c", "ip -6 route|awk '/default/ { print $3 }'").Output()
+		if err == nil {
+			ip = strings.TrimSpace(string(stdout))
+		}
+	}
 	if len(ip) == 0 {
 		return "", errors.New("failed to parse default gateway IP")
 	}
+	// only the first default route is used
+	ip, _, _ = strings.Cut(ip, "\n")
 	return ip, nil
 }
 
 // ExtractDockerHost Extracts the docker host from the different alternatives, caching the result to avoid unnecessary
 // calculations. Use this function to get the actual Docker host. This function does not consider Windows containers at the moment.
-// The possible alternatives are:
+// The possible alternatives are, unless they are replaced with SetDockerHostStrategies:
 //
 //  1. Docker host from the "tc.host" property in the ~/.testcontainers.properties file.
 //  2. DOCKER_HOST environment variable.
 //  3. Docker host from context.
-//  4. Docker host from the default docker socket path, without the unix schema.
-//  5. Docker host from the "docker.host" property in the ~/.testcontainers.properties file.
+//  4. Docker host from the "docker.host" property in the ~/.testcontainers.properties file.
+//  5. Docker host of the Docker context, selected with the "docker.context" property, or the current one of the Docker CLI.
 //  6. Rootless docker socket path.
-//  7. Else, the default Docker socket including schema will be returned.
+//  7. Docker host from the default docker socket path, if the socket exists.
+//  8. Else, the default Docker socket including schema will be returned.
+//
+// The steps run without holding any lock, so concurrent callers could run them at the same time,
+// the first resolved Docker host being cached.
 func ExtractDockerHost(ctx context.Context) string {
-	dockerHostOnce.Do(func() {
-		dockerHostCache = extractDockerHost(ctx)
-	})
+	// called back from a step: resolving the Docker host again would never end
+	if ctx.Value(resolvingDockerHostKey{}) != nil {
+		return extractDockerHostWith(ctx, nil)
+	}
+
+	dockerHostMutex.Lock()
+	if dockerHostCache != "" {
+		defer dockerHostMutex.Unlock()
+		return dockerHostCache
+	}
+	strategies := dockerHostStrategies
+	generation := dockerHostGeneration
+	dockerHostMutex.Unlock()
+
+	dockerHost := extractDockerHostWith(context.WithValue(ctx, resolvingDockerHostKey{}, true), strategies)
+
+	dockerHostMutex.Lock()
+	defer dockerHostMutex.Unlock()
+
+	// the steps were replaced while resolving the Docker host, so it's not cached
+	if generation != dockerHostGeneration {
+		return dockerHost
+	}
+
+	if dockerHostCache == "" {
+		dockerHostCache = dockerHost
+	}
 
 	return dockerHostCache
 }
@@ -82,16 +180,40 @@ func ExtractDockerHost(ctx context.Context) string {
 //
 //  1. Docker host from the "tc.host" property in the ~/.testcontainers.properties file.
 //  2. The TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE environment variable.
-//  3. Using a Docker client, check if the Info().OperativeSystem is "Docker Desktop" and return the default docker socket path for rootless docker.
-//  4. Else, Get the current Docker Host from the existing strategies: see ExtractDockerHost.
+//  3. Get the current Docker Host from the existing strategies: see ExtractDockerHost.
+//  4. If the daemon runs in a VM, i.e. Docker Desktop, Colima or Lima, the default docker socket path in the VM is returned: see DetectDockerEnvironment.
 //  5. If the socket contains the unix schema, the schema is removed (e.g. unix:///var/run/docker.sock -> /var/run/docker.sock)
 //  6. Else, the default location of the docker socket is used (/var/run/docker.sock)
 //
 // In any case, if the docker socket schema is "tcp://", the default docker socket path will be returned.
 func ExtractDockerSocket(ctx context.Context) string {
-	dockerSocketPathOnce.Do(func() {
-		dockerSocketPathCache = extractDockerSocket(ctx)
-	})
+	dockerSocketPathMutex.Lock()
+	if dockerSocketPathCache != "" {
+		defer dockerSocketPathMutex.Unlock()
+		return dockerSocketPathCache
+	}
+	dockerSocketPathMutex.Unlock()
+
+	dockerHostMutex.Lock()
+	generation := dockerHostGeneration
+	dockerHostMutex.Unlock()
+
+	socket := extractDockerSocket(ctx)
+
+	// the Docker host steps were replaced while resolving the socket, so it's not cached
+	dockerHostMutex.Lock()
+	replaced := generation != dockerHostGeneration
+	dockerHostMutex.Unlock()
+	if replaced || ctx.Value(resolvingDockerHostKey{}) != nil {
+		return socket
+	}
+
+	dockerSocketPathMutex.Lock()
+	defer dockerSocketPathMutex.Unlock()
+
+	if dockerSocketPathCache == "" {
+		dockerSocketPathCache = socket
+	}
 
 	return dockerSocketPathCache
 }
@@ -99,13 +221,14 @@ func ExtractDockerSocket(ctx context.Context) string {
 // extractDockerHost Extracts the docker host from the different alternatives, without caching the result.
 // This internal method is handy for testing purposes.
 func extractDockerHost(ctx context.Context) string {
-	dockerHostFns := []func(context.Context) (string, error){
-		testcontainersHostFromProperties,
-		dockerHostFromEnv,
-		dockerHostFromContext,
-		dockerSocketPath,
-		dockerHostFromProperties,
-		rootlessDockerSocketPath,
+	return extractDockerHostWith(ctx, nil)
+}
+
+// extractDockerHostWith Extracts the docker host using the given steps, or the default ones if empty,
+// without caching the result.
+func extractDockerHostWith(ctx context.Context, dockerHostFns []DockerHostStrategy) string {
+	if len(dockerHostFns) == 0 {
+		dockerHostFns = DefaultDockerHostStrategies()
 	}
 
 	outerErr := ErrSocketNotFound
@@ -137,23 +260,31 @@ func extractDockerSocket(ctx context.Context) string {
 	return extractDockerSocketFromClient(ctx, cli)
 }
 
+// DockerSocketFromHost returns the path of the docker socket in the host running the Docker daemon
+// of the given docker host, which is the default one for the remote daemons reached over tcp or ssh.
+func DockerSocketFromHost(socket string) string {
+	// this use case will cover the case when the docker host is a tcp socket
+	if strings.HasPrefix(socket, TCPSchema) {
+		return DockerSocketPath
+	}
+
+	// the remote Docker daemon reached over ssh listens on its default socket
+	if strings.HasPrefix(socket, SSHSchema) {
+		return DockerSocketPath
+	}
+
+	if strings.HasPrefix(socket, DockerSocketSchema) {
+		return strings.Replace(socket, DockerSocketSchema, "", 1)
+	}
+
+	return socket
+}
+
 // extractDockerSocketFromClient Extracts the docker socket from the different alternatives, without caching the result,
 // and receiving an instance of the Docker API client interface.
 // This internal method is handy for testing purposes, passing a mock type simulating the desired behaviour.
 func extractDockerSocketFromClient(ctx context.Context, cli client.APIClient) string {
-	// check that the socket is not a tcp or unix socket
-	checkDockerSocketFn := func(socket string) string {
-		// this use case will cover the case when the docker host is a tcp socket
-		if strings.HasPrefix(socket, TCPSchema) {
-			return DockerSocketPath
-		}
-
-		if strings.HasPrefix(socket, DockerSocketSchema) {
-			return strings.Replace(socket, DockerSocketSchema, "", 1)
-		}
-
-		return socket
-	}
+	checkDockerSocketFn := DockerSocketFromHost
 
 	tcHost, err := testcontainersHostFromProperties(ctx)
 	if err == nil {
@@ -170,16 +301,20 @@ func extractDockerSocketFromClient(ctx context.Context, cli client.APIClient) st
 		panic(err) // Docker Info is required to get the Operating System
 	}
 
-	// Because Docker Desktop runs in a VM, we need to use the default docker path for rootless docker
-	if info.OperatingSystem == "Docker Desktop" {
-		if IsWindows() {
-			return WindowsDockerSocketPath
-		}
-
-		return DockerSocketPath
+	// called back from a Docker host step: the default steps are used, as in ExtractDockerHost
+	var strategies []DockerHostStrategy
+	if ctx.Value(resolvingDockerHostKey{}) == nil {
+		dockerHostMutex.Lock()
+		strategies = dockerHostStrategies
+		dockerHostMutex.Unlock()
 	}
 
-	dockerHost := extractDockerHost(ctx)
+	dockerHost := extractDockerHostWith(context.WithValue(ctx, resolvingDockerHostKey{}, true), strategies)
+
+	// Docker Desktop, Colima and Lima run the daemon in a VM, where the socket of the host does not exist
+	if socket, ok := DetectDockerEnvironment(dockerHost, info).DockerSocketInVM(); ok {
+		return socket
+	}
 
 	return checkDockerSocketFn(dockerHost)
 }
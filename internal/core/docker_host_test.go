@@ -2,9 +2,12 @@ package core
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/client"
@@ -136,6 +139,35 @@ func TestExtractDockerHost(t *testing.T) {
 		assert.Equal(t, DockerSocketPathWithSchema, host)
 	})
 
+	t.Run("Docker Host as docker.host takes precedence over the default Docker socket", func(t *testing.T) {
+		setupRootlessNotFound(t)
+		setupDockerSocket(t)
+		content := "docker.host=" + DockerSocketSchema + "/this/is/a/sample.sock"
+
+		setupTestcontainersProperties(t, content)
+
+		host := extractDockerHost(context.Background())
+
+		assert.Equal(t, DockerSocketSchema+"/this/is/a/sample.sock", host)
+	})
+
+	t.Run("Rootless Docker socket takes precedence over the default Docker socket", func(t *testing.T) {
+		if IsWindows() {
+			t.Skip("Docker Rootless is not supported on Windows")
+		}
+
+		setupDockerSocket(t)
+
+		tmpDir := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", tmpDir)
+		err := createTmpDockerSocket(tmpDir)
+		require.NoError(t, err)
+
+		host := extractDockerHost(context.Background())
+
+		assert.Equal(t, DockerSocketSchema+filepath.Join(tmpDir, "docker.sock"), host)
+	})
+
 	t.Run("Extract Docker socket", func(t *testing.T) {
 		setupDockerHostNotFound(t)
 		t.Cleanup(resetSocketOverrideFn)
@@ -267,11 +299,81 @@ func TestExtractDockerHost(t *testing.T) {
 	})
 }
 
+func TestSetDockerHostStrategies(t *testing.T) {
+	setupDockerHostNotFound(t)
+	t.Cleanup(func() {
+		SetDockerHostStrategies()
+	})
+
+	customStrategy := func(_ context.Context) (string, error) {
+		return "tcp://custom.docker.host:2375", nil
+	}
+
+	t.Run("Custom strategy is used", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "/path/to/docker.sock")
+
+		SetDockerHostStrategies(customStrategy, DockerHostFromEnvStrategy)
+
+		assert.Equal(t, "tcp://custom.docker.host:2375", ExtractDockerHost(context.Background()))
+	})
+
+	t.Run("Next strategy is used when a strategy fails", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "/path/to/docker.sock")
+
+		failingStrategy := func(_ context.Context) (string, error) {
+			return "", errors.New("not in a custom environment")
+		}
+
+		SetDockerHostStrategies(failingStrategy, DockerHostFromEnvStrategy, customStrategy)
+
+		assert.Equal(t, "/path/to/docker.sock", ExtractDockerHost(context.Background()))
+	})
+
+	t.Run("Default Docker socket when all strategies fail", func(t *testing.T) {
+		SetDockerHostStrategies(DockerHostFromEnvStrategy)
+
+		assert.Equal(t, DockerSocketPathWithSchema, ExtractDockerHost(context.Background()))
+	})
+
+	t.Run("Default strategies are restored", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "/path/to/docker.sock")
+
+		SetDockerHostStrategies(customStrategy)
+		SetDockerHostStrategies()
+
+		assert.Equal(t, "/path/to/docker.sock", ExtractDockerHost(context.Background()))
+	})
+
+	t.Run("Strategy calling back does not deadlock", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "/path/to/docker.sock")
+
+		callingBackStrategy := func(ctx context.Context) (string, error) {
+			// the nested call gets the Docker host resolved by the default strategies
+			return "", fmt.Errorf("not in a custom environment, resolved %s", ExtractDockerHost(ctx))
+		}
+
+		SetDockerHostStrategies(callingBackStrategy, customStrategy)
+
+		done := make(chan string)
+		go func() {
+			done <- ExtractDockerHost(context.Background())
+		}()
+
+		select {
+		case host := <-done:
+			assert.Equal(t, "tcp://custom.docker.host:2375", host)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the Docker host resolution is deadlocked")
+		}
+	})
+}
+
 // mockCli is a mock implementation of client.APIClient, which is handy for simulating
 // different operating systems.
 type mockCli struct {
 	client.APIClient
-	OS string
+	OS   string
+	Name string
 }
 
 // Info returns a mock implementation of types.Info, which is handy for detecting the operating system,
@@ -279,6 +381,7 @@ type mockCli struct {
 func (m mockCli) Info(ctx context.Context) (system.Info, error) {
 	return system.Info{
 		OperatingSystem: m.OS,
+		Name:            m.Name,
 	}, nil
 }
 
@@ -365,6 +468,22 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 		assert.Equal(t, WindowsDockerSocketPath, socket)
 	})
 
+	t.Run("Unix Docker Socket is passed as DOCKER_HOST variable (Colima)", func(t *testing.T) {
+		t.Setenv("GOOS", "linux")
+		setupTestcontainersProperties(t, "")
+
+		t.Cleanup(resetSocketOverrideFn)
+
+		ctx := context.Background()
+		os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+		t.Setenv("DOCKER_HOST", DockerSocketSchema+"/Users/me/.colima/default/docker.sock")
+
+		// the socket of the host does not exist in the VM
+		socket := extractDockerSocketFromClient(ctx, mockCli{OS: "Ubuntu 23.10", Name: "colima"})
+
+		assert.Equal(t, DockerSocketPath, socket)
+	})
+
 	t.Run("Unix Docker Socket is passed as DOCKER_HOST variable (Not Docker Desktop)", func(t *testing.T) {
 		setupTestcontainersProperties(t, "")
 
@@ -413,6 +532,12 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 	})
 }
 
+func TestDockerSocketFromHost(t *testing.T) {
+	assert.Equal(t, DockerSocketPath, DockerSocketFromHost(TCPSchema+"remote:2376"))
+	assert.Equal(t, DockerSocketPath, DockerSocketFromHost(SSHSchema+"user@remote"))
+	assert.Equal(t, "/this/is/a/sample.sock", DockerSocketFromHost(DockerSocketSchema+"/this/is/a/sample.sock"))
+}
+
 func TestInAContainer(t *testing.T) {
 	t.Run("file does not exist", func(t *testing.T) {
 		tmpDir := t.TempDir()
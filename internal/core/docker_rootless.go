@@ -83,8 +83,8 @@ func parseURL(s string) (string, error) {
 	switch hostURL.Scheme {
 	case "unix", "npipe":
 		return hostURL.Path, nil
-	case "tcp":
-		// return the original URL, as it is a valid TCP URL
+	case "tcp", "ssh":
+		// return the original URL, as it is a valid TCP or SSH URL
 		return s, nil
 	default:
 		return "", ErrNoUnixSchema
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// SSHSchema is the ssh schema, used to connect to a remote Docker daemon over ssh, e.g. ssh://user@host.
+var SSHSchema = "ssh://"
+
+// sshHelper connects to a remote Docker daemon over ssh, as the ssh connection helper of the Docker CLI does:
+// it runs "docker system dial-stdio" on the remote host, and the Docker API is proxied through the
+// stdin and stdout of the ssh process. The ssh binary of the system is used, so the keys, agents and
+// the ~/.ssh/config file are honoured.
+type sshHelper struct {
+	// host is the URL used by the Docker client, which is not dialed. It contains the remote host,
+	// so that the host of the mapped ports is the remote one, and not localhost.
+	host string
+
+	// args are the arguments of the ssh command
+	args []string
+}
+
+// newSSHHelper returns the ssh helper for the ssh://[user@]host[:port] Docker host.
+func newSSHHelper(daemonURL string) (*sshHelper, error) {
+	u, err := url.Parse(daemonURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "ssh" {
+		return nil, fmt.Errorf("invalid ssh docker host %q: the schema must be ssh", daemonURL)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid ssh docker host %q: no host specified", daemonURL)
+	}
+
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("invalid ssh docker host %q: paths are not supported", daemonURL)
+	}
+
+	if u.RawQuery != "" {
+		return nil, fmt.Errorf("invalid ssh docker host %q: query parameters are not supported", daemonURL)
+	}
+
+	var args []string
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return nil, fmt.Errorf("invalid ssh docker host %q: plain-text passwords are not supported", daemonURL)
+		}
+
+		args = append(args, "-l", u.User.Username())
+	}
+
+	if u.Port() != "" {
+		args = append(args, "-p", u.Port())
+	}
+
+	args = append(args, "--", u.Hostname(), "docker", "system", "dial-stdio")
+
+	return &sshHelper{
+		host: "http://" + u.Host,
+		args: args,
+	}, nil
+}
+
+// DialContext starts a new ssh process connected to the remote Docker daemon, returning the connection to it.
+// The address is ignored, as the ssh process connects to the remote daemon.
+func (h *sshHelper) DialContext(ctx context.Context, _, _ string) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newCommandConn("ssh", h.args...)
+}
+
+// commandConn is a net.Conn over the stdin and stdout of a command.
+type commandConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr *lockedBuffer
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newCommandConn(name string, args ...string) (*commandConn, error) {
+	cmd := exec.Command(name, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderr := &lockedBuffer{}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting %s: %w", name, err)
+	}
+
+	return &commandConn{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}, nil
+}
+
+// Read reads from the stdout of the command, adding its stderr to the errors, e.g. ssh authentication errors.
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, c.withStderr(err)
+	}
+
+	return n, err
+}
+
+// Write writes to the stdin of the command, adding its stderr to the errors.
+func (c *commandConn) Write(p []byte) (int, error) {
+	n, err := c.stdin.Write(p)
+	if err != nil {
+		return n, c.withStderr(err)
+	}
+
+	return n, nil
+}
+
+// CloseWrite closes the stdin of the command, so that the half-closed connections of the Docker client,
+// e.g. when attaching to a container, are supported.
+func (c *commandConn) CloseWrite() error {
+	return c.stdin.Close()
+}
+
+// Close closes the connection, killing the command.
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		_ = c.stdin.Close()
+
+		if err := c.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			c.closeErr = err
+		}
+
+		// the command is killed, so the error of Wait is expected. Wait closes the stdout too.
+		_ = c.cmd.Wait()
+	})
+
+	return c.closeErr
+}
+
+func (c *commandConn) withStderr(err error) error {
+	if stderr := bytes.TrimSpace(c.stderr.Bytes()); len(stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, stderr)
+	}
+
+	return err
+}
+
+func (c *commandConn) LocalAddr() net.Addr {
+	return commandAddr{}
+}
+
+func (c *commandConn) RemoteAddr() net.Addr {
+	return commandAddr{}
+}
+
+// SetDeadline is a no-op, as the pipes of the command do not support deadlines.
+// The Docker client bounds the requests with their context instead.
+func (c *commandConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+// SetReadDeadline is a no-op. See SetDeadline.
+func (c *commandConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline is a no-op. See SetDeadline.
+func (c *commandConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// commandAddr is the address of both ends of a commandConn.
+type commandAddr struct{}
+
+func (commandAddr) Network() string {
+	return "command"
+}
+
+func (commandAddr) String() string {
+	return "command"
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use, as the stderr of the command is written
+// by the goroutine copying it while the connection is read.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]byte(nil), b.buf.Bytes()...)
+}
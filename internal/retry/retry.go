@@ -0,0 +1,229 @@
+// Package retry retries the calls to the Docker daemon failing with transient errors, e.g. a connection
+// reset by a loaded daemon, with a jittered exponential backoff, bounded by the retry budget of the process.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+)
+
+const (
+	defaultInitialInterval = 100 * time.Millisecond
+	defaultMaxInterval     = 2 * time.Second
+	defaultMaxElapsedTime  = 30 * time.Second
+	defaultMaxAttempts     = 5
+
+	// the jitter of the intervals, so that the concurrent calls are not retried at the same time
+	randomizationFactor = 0.5
+)
+
+// Budget limits the retries of the process, so that a failing daemon is not flooded with them:
+// each retry spends a token, and each successful call earns back a fraction of one, up to the maximum.
+// The retries stop while there are no tokens left, until enough calls succeed.
+type Budget struct {
+	mtx    sync.Mutex
+	tokens float64
+	max    float64
+	ratio  float64
+}
+
+// NewBudget returns a full budget of the given tokens, earning back the ratio of a token
+// for each successful call, e.g. NewBudget(20, 0.1) allows one retry every ten calls once exhausted.
+func NewBudget(tokens float64, ratio float64) *Budget {
+	return &Budget{tokens: tokens, max: tokens, ratio: ratio}
+}
+
+// withdraw spends a token for a retry, returning false if there are none left
+func (b *Budget) withdraw() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// deposit earns back the ratio of a token for a successful call
+func (b *Budget) deposit() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.tokens = min(b.max, b.tokens+b.ratio)
+}
+
+// defaultBudget is the retry budget shared by all the daemon calls of the process
+var defaultBudget = NewBudget(20, 0.1)
+
+type options struct {
+	maxAttempts    int
+	maxElapsedTime time.Duration
+	retryable      func(err error) bool
+	budget         *Budget
+}
+
+// Option configures the retries of a call
+type Option func(*options)
+
+// WithMaxAttempts sets the maximum number of attempts of the call, including the first one,
+// 5 by default. Zero means the attempts are bounded by the elapsed time only.
+func WithMaxAttempts(attempts int) Option {
+	return func(o *options) {
+		o.maxAttempts = attempts
+	}
+}
+
+// WithMaxElapsedTime sets the time after which the call is not retried anymore, 30 seconds by default.
+func WithMaxElapsedTime(d time.Duration) Option {
+	return func(o *options) {
+		o.maxElapsedTime = d
+	}
+}
+
+// WithRetryable sets the classification of the errors to be retried, IsRetryable by default.
+// The calls which are not idempotent, e.g. creating a container, use IsNotSent.
+func WithRetryable(retryable func(err error) bool) Option {
+	return func(o *options) {
+		o.retryable = retryable
+	}
+}
+
+// WithBudget sets the retry budget of the call, the one shared by the process by default.
+func WithBudget(budget *Budget) Option {
+	return func(o *options) {
+		o.budget = budget
+	}
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		maxAttempts:    defaultMaxAttempts,
+		maxElapsedTime: defaultMaxElapsedTime,
+		retryable:      IsRetryable,
+		budget:         defaultBudget,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// budgetBackOff stops the backoff when the retry budget is exhausted
+type budgetBackOff struct {
+	backoff.BackOff
+	budget *Budget
+}
+
+// NextBackOff returns the next interval of the backoff, spending a token of the budget
+func (b *budgetBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+	if next == backoff.Stop || !b.budget.withdraw() {
+		return backoff.Stop
+	}
+
+	return next
+}
+
+// NewBackOff returns the jittered exponential backoff of the calls to the daemon, bounded by the max attempts,
+// the max elapsed time, the retry budget and the context. It's meant for the calls classifying their own errors,
+// e.g. the pulls, which use it with backoff.Retry; the other calls use Do.
+func NewBackOff(ctx context.Context, opts ...Option) backoff.BackOffContext {
+	o := newOptions(opts...)
+
+	exp := backoff.NewExponentialBackOff()
+	exp.InitialInterval = defaultInitialInterval
+	exp.MaxInterval = defaultMaxInterval
+	exp.RandomizationFactor = randomizationFactor
+	exp.MaxElapsedTime = o.maxElapsedTime
+
+	var b backoff.BackOff = exp
+	if o.maxAttempts > 0 {
+		// the retries are the attempts after the first one
+		b = backoff.WithMaxRetries(b, uint64(o.maxAttempts-1))
+	}
+
+	return backoff.WithContext(&budgetBackOff{BackOff: b, budget: o.budget}, ctx)
+}
+
+// Do runs the call to the daemon, retrying it while it fails with a retryable error, see IsRetryable,
+// with a jittered exponential backoff. It returns the last error of the call, or the error of the context
+// if it's done before the call succeeds.
+func Do(ctx context.Context, call func() error, opts ...Option) error {
+	o := newOptions(opts...)
+
+	err := backoff.Retry(func() error {
+		err := call()
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil || !o.retryable(err) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}, NewBackOff(ctx, opts...))
+	if err == nil {
+		o.budget.deposit()
+	}
+
+	return err
+}
+
+// IsRetryable returns true if the error of the call to the daemon is transient, so that the call can succeed
+// when retried: the connection to the daemon is refused, reset, closed or timed out, or the daemon is unavailable,
+// answering with 503. The errors of the context are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if IsNotSent(err) {
+		return true
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// the client of the daemon does not wrap all the errors of the connection
+	msg := err.Error()
+	for _, transient := range []string{"connection reset by peer", "broken pipe", "unexpected EOF", "i/o timeout"} {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+
+	return strings.HasSuffix(msg, ": EOF")
+}
+
+// IsNotSent returns true if the error of the call to the daemon guarantees it was not processed: the connection
+// to the daemon is refused, or the daemon is unavailable, answering with 503. The calls which are not idempotent,
+// e.g. creating a container, are retried on these errors only.
+func IsNotSent(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) || client.IsErrConnectionFailed(err) || errdefs.IsUnavailable(err) ||
+		strings.Contains(err.Error(), "connection refused")
+}
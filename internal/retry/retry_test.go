@@ -0,0 +1,137 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries-transient-errors", func(t *testing.T) {
+		attempts := 0
+		err := Do(ctx, func() error {
+			attempts++
+			if attempts < 3 {
+				return fmt.Errorf("read unix @->/var/run/docker.sock: %w", syscall.ECONNRESET)
+			}
+			return nil
+		}, WithBudget(NewBudget(10, 0.1)))
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("returns-permanent-errors", func(t *testing.T) {
+		attempts := 0
+		err := Do(ctx, func() error {
+			attempts++
+			return errdefs.NotFound(errors.New("no such container"))
+		}, WithBudget(NewBudget(10, 0.1)))
+		require.Error(t, err)
+		assert.True(t, errdefs.IsNotFound(err))
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("max-attempts", func(t *testing.T) {
+		attempts := 0
+		err := Do(ctx, func() error {
+			attempts++
+			return io.EOF
+		}, WithMaxAttempts(2), WithBudget(NewBudget(10, 0.1)))
+		require.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("not-idempotent", func(t *testing.T) {
+		attempts := 0
+		err := Do(ctx, func() error {
+			attempts++
+			return io.EOF
+		}, WithRetryable(IsNotSent), WithBudget(NewBudget(10, 0.1)))
+		require.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 1, attempts, "the request could have been processed")
+	})
+
+	t.Run("budget-exhausted", func(t *testing.T) {
+		budget := NewBudget(1, 0.5)
+
+		attempts := 0
+		err := Do(ctx, func() error {
+			attempts++
+			return io.EOF
+		}, WithBudget(budget))
+		require.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 2, attempts, "only one retry is allowed by the budget")
+
+		// two successful calls earn back a token
+		require.NoError(t, Do(ctx, func() error { return nil }, WithBudget(budget)))
+		require.NoError(t, Do(ctx, func() error { return nil }, WithBudget(budget)))
+
+		attempts = 0
+		err = Do(ctx, func() error {
+			attempts++
+			return io.EOF
+		}, WithBudget(budget))
+		require.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("context-done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		err := Do(ctx, func() error {
+			return io.EOF
+		}, WithMaxAttempts(0), WithBudget(NewBudget(100, 0.1)))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// timeoutError is a net.Error timing out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		retryable bool
+		notSent   bool
+	}{
+		{name: "nil", err: nil},
+		{name: "eof", err: fmt.Errorf("error during connect: %w", io.EOF), retryable: true},
+		{name: "unexpected-eof", err: io.ErrUnexpectedEOF, retryable: true},
+		{name: "connection-reset", err: syscall.ECONNRESET, retryable: true},
+		{name: "connection-reset-message", err: errors.New("read tcp 10.0.0.1:2376: connection reset by peer"), retryable: true},
+		{name: "broken-pipe", err: syscall.EPIPE, retryable: true},
+		{name: "timeout", err: &net.OpError{Op: "dial", Err: timeoutError{}}, retryable: true},
+		{name: "connection-refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, retryable: true, notSent: true},
+		{name: "unavailable", err: errdefs.Unavailable(errors.New("daemon is shutting down")), retryable: true, notSent: true},
+		{name: "not-found", err: errdefs.NotFound(errors.New("no such image"))},
+		{name: "conflict", err: errdefs.Conflict(errors.New("name already in use"))},
+		{name: "context-canceled", err: context.Canceled},
+		{name: "context-deadline", err: fmt.Errorf("inspecting: %w", context.DeadlineExceeded)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.retryable, IsRetryable(tc.err))
+			assert.Equal(t, tc.notSent, IsNotSent(tc.err))
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// trackedResources are the resources created by Testcontainers in this process, grouped by the Docker host
+// of their provider, the only ones checked for leakage, as the session is shared by the test packages run
+// concurrently by "go test ./...".
+var trackedResources = struct {
+	sync.Mutex
+	keys map[string]map[string]bool
+}{keys: map[string]map[string]bool{}}
+
+// trackResource records a resource created by this process in the Docker host, identified by its key,
+// e.g. the one returned by containerResource
+func trackResource(dockerHost string, key string) {
+	trackedResources.Lock()
+	defer trackedResources.Unlock()
+
+	if trackedResources.keys[dockerHost] == nil {
+		trackedResources.keys[dockerHost] = map[string]bool{}
+	}
+	trackedResources.keys[dockerHost][key] = true
+}
+
+// isTrackedResource returns true if the resource of the Docker host was created by this process
+func isTrackedResource(dockerHost string, key string) bool {
+	trackedResources.Lock()
+	defer trackedResources.Unlock()
+
+	return trackedResources.keys[dockerHost][key]
+}
+
+// trackedHosts returns the Docker hosts where this process created resources, sorted, along with
+// the default one, which is always checked
+func trackedHosts(defaultHost string) []string {
+	trackedResources.Lock()
+	defer trackedResources.Unlock()
+
+	hosts := []string{defaultHost}
+	for host := range trackedResources.keys {
+		if host != defaultHost {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts[1:])
+
+	return hosts
+}
+
+func containerResource(id string) string {
+	return "container " + id
+}
+
+func networkResource(id string) string {
+	return "network " + id
+}
+
+func volumeResource(name string) string {
+	return "volume " + name
+}
+
+// AssertNoLeakage fails the test if it leaks containers, networks or volumes, i.e. if the resources
+// created by Testcontainers in the test process are not removed when the test completes, e.g. because
+// Terminate is not called. The failure lists the leaked resources.
+// It must be called at the beginning of the test, so that the check runs after the cleanup functions
+// registered later, e.g. the ones terminating the containers.
+// The resources existing before the call are ignored, as well as the reaper container, and the resources
+// created by other processes, e.g. the test packages run concurrently by "go test ./...". The resources
+// are checked in each Docker host where the process created them, e.g. with WithDockerHost.
+// The check is not reliable for the tests calling t.Parallel, as the resources created by the tests running
+// at the same time, in the same process, are reported as leaked if they are not removed yet.
+func AssertNoLeakage(t testing.TB) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	before, err := sessionResources(ctx)
+	if err != nil {
+		t.Fatalf("failed to list the resources of the session: %s", err)
+	}
+
+	t.Cleanup(func() {
+		after, err := sessionResources(ctx)
+		if err != nil {
+			t.Errorf("failed to list the resources of the session: %s", err)
+			return
+		}
+
+		if leaked := leakedResources(before, after); len(leaked) > 0 {
+			t.Errorf("%s", leakageMessage(leaked))
+		}
+	})
+}
+
+// RunWithLeakCheck runs the tests of the package as m.Run does, and checks that they don't leak containers,
+// networks or volumes, printing the leaked ones. Only the resources created by the test process are checked,
+// in each Docker host where it created them, so the test packages run concurrently by "go test ./..." don't
+// report the resources of the others. It returns the exit code of the tests, or 1 if they pass but leak
+// resources. It's meant to be used in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testcontainers.RunWithLeakCheck(m))
+//	}
+func RunWithLeakCheck(m *testing.M) int {
+	ctx := context.Background()
+
+	before, err := sessionResources(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to list the resources of the session: %s\n", err)
+		return 1
+	}
+
+	code := m.Run()
+
+	after, err := sessionResources(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to list the resources of the session: %s\n", err)
+		return 1
+	}
+
+	if leaked := leakedResources(before, after); len(leaked) > 0 {
+		fmt.Fprintln(os.Stderr, leakageMessage(leaked))
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	return code
+}
+
+// sessionResources returns the containers, networks and volumes of the session created by this process,
+// but the reaper, in each Docker host where it created them, indexed by their keys and described for the
+// leakage messages
+func sessionResources(ctx context.Context) (map[string]string, error) {
+	defaultHost := core.ExtractDockerHost(ctx)
+
+	resources := map[string]string{}
+	for _, host := range trackedHosts(defaultHost) {
+		// the providers created without a Docker host use the default one
+		if err := hostResources(ctx, host, host == defaultHost || host == "", resources); err != nil {
+			return nil, fmt.Errorf("docker host %s: %w", host, err)
+		}
+	}
+
+	return resources, nil
+}
+
+// hostResources adds the containers, networks and volumes of the session created by this process
+// in the Docker host to the resources. The ones of the hosts other than the default one are
+// described along with their host.
+func hostResources(ctx context.Context, dockerHost string, isDefault bool, resources map[string]string) error {
+	var (
+		cli client.APIClient
+		err error
+	)
+	if isDefault {
+		cli, err = NewDockerClientWithOpts(ctx)
+	} else {
+		cli, err = core.NewClientWithHost(ctx, dockerHost)
+	}
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	// the hosts other than the default one are named in the messages
+	suffix := ""
+	if !isDefault {
+		suffix = " on " + dockerHost
+	}
+
+	add := func(key string, description string) {
+		resources[dockerHost+" "+key] = description + suffix
+	}
+
+	args := filters.NewArgs(filters.Arg("label", core.LabelSessionID+"="+core.SessionID()))
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return fmt.Errorf("error listing the containers: %w", err)
+	}
+
+	for _, c := range containers {
+		if c.Labels[core.LabelReaper] == "true" || !isTrackedResource(dockerHost, containerResource(c.ID)) {
+			continue
+		}
+
+		name := ""
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		add(containerResource(c.ID), fmt.Sprintf("container %s (%s, image %s, %s)", c.ID[:12], name, c.Image, c.State))
+	}
+
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return fmt.Errorf("error listing the networks: %w", err)
+	}
+
+	for _, n := range networks {
+		if !isTrackedResource(dockerHost, networkResource(n.ID)) {
+			continue
+		}
+
+		add(networkResource(n.ID), fmt.Sprintf("network %s (%s)", n.Name, n.ID[:12]))
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return fmt.Errorf("error listing the volumes: %w", err)
+	}
+
+	for _, v := range volumes.Volumes {
+		if !isTrackedResource(dockerHost, volumeResource(v.Name)) {
+			continue
+		}
+
+		add(volumeResource(v.Name), "volume "+v.Name)
+	}
+
+	return nil
+}
+
+// leakedResources returns the sorted descriptions of the resources existing after, but not before
+func leakedResources(before map[string]string, after map[string]string) []string {
+	leaked := []string{}
+	for id, description := range after {
+		if _, ok := before[id]; !ok {
+			leaked = append(leaked, description)
+		}
+	}
+	sort.Strings(leaked)
+
+	return leaked
+}
+
+// leakageMessage describes the leaked resources, one per line
+func leakageMessage(leaked []string) string {
+	return fmt.Sprintf("%d resources leaked, missing Terminate or Remove calls:\n  - %s", len(leaked), strings.Join(leaked, "\n  - "))
+}
@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// leakT records the logs, the errors and the cleanup functions of AssertNoLeakage and Run, to check their failures
+type leakT struct {
+	testing.TB
+	logs     []string
+	errors   []string
+	cleanups []func()
+}
+
+func (t *leakT) Helper() {}
+
+func (t *leakT) Logf(format string, args ...any) {
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *leakT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *leakT) Fatalf(format string, args ...any) {
+	panic(fmt.Sprintf(format, args...))
+}
+
+func (t *leakT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *leakT) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestAssertNoLeakage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("terminated", func(t *testing.T) {
+		lt := &leakT{}
+		AssertNoLeakage(lt)
+
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+			Started:          true,
+		})
+		require.NoError(t, err)
+		require.NoError(t, c.Terminate(ctx))
+
+		lt.runCleanups()
+		assert.Empty(t, lt.errors)
+	})
+
+	t.Run("leaked", func(t *testing.T) {
+		lt := &leakT{}
+		AssertNoLeakage(lt)
+
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+			Started:          true,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, c.Terminate(ctx))
+		})
+
+		lt.runCleanups()
+		require.Len(t, lt.errors, 1)
+		assert.Contains(t, lt.errors[0], "1 resources leaked")
+		assert.Contains(t, lt.errors[0], "container "+c.GetContainerID()[:12])
+	})
+}
+
+func TestLeakedResources(t *testing.T) {
+	before := map[string]string{
+		"container 1": "container 1",
+		"network 1":   "network net1",
+	}
+	after := map[string]string{
+		"container 1": "container 1",
+		"container 2": "container 2",
+		"volume data": "volume data",
+	}
+
+	leaked := leakedResources(before, after)
+	assert.Equal(t, []string{"container 2", "volume data"}, leaked)
+	assert.Equal(t, "2 resources leaked, missing Terminate or Remove calls:\n  - container 2\n  - volume data", leakageMessage(leaked))
+
+	assert.Empty(t, leakedResources(after, after))
+}
+
+func TestTrackResource(t *testing.T) {
+	const (
+		defaultHost = "unix:///var/run/tracked.sock"
+		remoteHost  = "tcp://tracked-remote:2376"
+	)
+
+	key := containerResource("tracked-container")
+	assert.False(t, isTrackedResource(defaultHost, key))
+
+	trackResource(defaultHost, key)
+	assert.True(t, isTrackedResource(defaultHost, key))
+	assert.False(t, isTrackedResource(defaultHost, networkResource("tracked-container")))
+	assert.False(t, isTrackedResource(remoteHost, key))
+
+	trackResource(remoteHost, volumeResource("tracked-volume"))
+	assert.True(t, isTrackedResource(remoteHost, volumeResource("tracked-volume")))
+
+	// the default host is checked first, even if no resource was created in it
+	hosts := trackedHosts("unix:///var/run/docker.sock")
+	assert.Equal(t, "unix:///var/run/docker.sock", hosts[0])
+	assert.Contains(t, hosts, defaultHost)
+	assert.Contains(t, hosts, remoteHost)
+}
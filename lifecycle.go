@@ -5,12 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"strings"
+	"sync"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/go-connections/nat"
 	"golang.org/x/exp/slices"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
 
 // ContainerRequestHook is a hook that will be called before a container is created.
@@ -58,55 +64,55 @@ var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 	return ContainerLifecycleHooks{
 		PreCreates: []ContainerRequestHook{
 			func(ctx context.Context, req ContainerRequest) error {
-				logger.Printf("🐳 Creating container for image %s", req.Image)
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🐳 Creating container for image %s", req.Image), LogKeyImage, req.Image)
 				return nil
 			},
 		},
 		PostCreates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container created: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("✅ Container created: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PreStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Starting container: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🐳 Starting container: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PostStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container started: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("✅ Container started: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PostReadies: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🔔 Container is ready: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🔔 Container is ready: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PreStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Stopping container: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🐳 Stopping container: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PostStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container stopped: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("✅ Container stopped: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PreTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Terminating container: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🐳 Terminating container: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
 		PostTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🚫 Container terminated: %s", shortContainerID(c))
+				logAt(ctx, logger, slog.LevelInfo, fmt.Sprintf("🚫 Container terminated: %s", shortContainerID(c)), LogKeyContainerID, shortContainerID(c))
 				return nil
 			},
 		},
@@ -131,26 +137,50 @@ var defaultCopyFileToContainerHook = func(files []ContainerFile) ContainerLifecy
 		PostCreates: []ContainerHook{
 			// copy files to container after it's created
 			func(ctx context.Context, c Container) error {
+				if len(files) == 0 {
+					return nil
+				}
+
+				dc, ok := c.(*DockerContainer)
+				if !ok {
+					return fmt.Errorf("unsupported container type %T", c)
+				}
+
 				for _, f := range files {
 					if err := f.validate(); err != nil {
 						return fmt.Errorf("invalid file: %w", err)
 					}
 
 					var err error
-					// Bytes takes precedence over HostFilePath
-					if f.Reader != nil {
+					// FS takes precedence over Reader, and Reader over HostFilePath
+					switch {
+					case f.FS != nil:
+						buffer, tarErr := tarFS(f.FS, f.FileMode, f.copyOptions()...)
+						if tarErr != nil {
+							return fmt.Errorf("can't read from file system: %w", tarErr)
+						}
+
+						err = dc.CopyArchiveToContainer(ctx, buffer, f.ContainerFilePath)
+					case f.Reader != nil && f.Archive:
+						err = dc.CopyArchiveToContainer(ctx, f.Reader, f.ContainerFilePath, f.copyOptions()...)
+					case f.Reader != nil:
 						bs, ioerr := io.ReadAll(f.Reader)
 						if ioerr != nil {
 							return fmt.Errorf("can't read from reader: %w", ioerr)
 						}
 
-						err = c.CopyToContainer(ctx, bs, f.ContainerFilePath, f.FileMode)
-					} else {
-						err = c.CopyFileToContainer(ctx, f.HostFilePath, f.ContainerFilePath, f.FileMode)
+						err = dc.CopyToContainerWithOptions(ctx, bs, f.ContainerFilePath, f.FileMode, f.copyOptions()...)
+					default:
+						err = dc.CopyFileToContainerWithOptions(ctx, f.HostFilePath, f.ContainerFilePath, f.FileMode, f.copyOptions()...)
 					}
 
 					if err != nil {
-						return fmt.Errorf("can't copy %s to container: %w", f.HostFilePath, err)
+						source := f.HostFilePath
+						if f.FS != nil || f.Reader != nil {
+							source = f.ContainerFilePath
+						}
+
+						return fmt.Errorf("can't copy %s to container: %w", source, err)
 					}
 				}
 
@@ -207,16 +237,22 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 
 				// if a Wait Strategy has been specified, wait before returning
 				if dockerContainer.WaitingFor != nil {
-					dockerContainer.logger.Printf(
+					logAt(ctx, dockerContainer.logger, slog.LevelInfo, fmt.Sprintf(
 						"🚧 Waiting for container id %s image: %s. Waiting for: %+v",
 						dockerContainer.ID[:12], dockerContainer.Image, dockerContainer.WaitingFor,
-					)
-					if err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+					), LogKeyContainerID, dockerContainer.ID[:12], LogKeyImage, dockerContainer.Image)
+					target := &attemptsTarget{Container: c}
+					err := dockerContainer.WaitingFor.WaitUntilReady(ctx, target)
+					dockerContainer.waitAttempts = target.attempts()
+					if err != nil {
+						if errors.Is(err, context.DeadlineExceeded) {
+							return newTimeoutError(dockerContainer, dockerContainer.WaitingFor, err)
+						}
 						return err
 					}
 				}
 
-				dockerContainer.isRunning = true
+				dockerContainer.setRunning(true)
 
 				return nil
 			},
@@ -224,6 +260,51 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 	}
 }
 
+// attemptsTarget is the target of the wait strategy of a container, counting the calls of the strategy
+// to the state, the exec and the logs APIs of the container, to record its readiness attempts.
+type attemptsTarget struct {
+	Container
+
+	mx     sync.Mutex
+	states int
+	execs  int
+	logs   int
+}
+
+func (t *attemptsTarget) State(ctx context.Context) (*types.ContainerState, error) {
+	t.mx.Lock()
+	t.states++
+	t.mx.Unlock()
+
+	return t.Container.State(ctx)
+}
+
+func (t *attemptsTarget) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	t.mx.Lock()
+	t.execs++
+	t.mx.Unlock()
+
+	return t.Container.Exec(ctx, cmd, options...)
+}
+
+func (t *attemptsTarget) Logs(ctx context.Context) (io.ReadCloser, error) {
+	t.mx.Lock()
+	t.logs++
+	t.mx.Unlock()
+
+	return t.Container.Logs(ctx)
+}
+
+// attempts returns the number of readiness attempts of the wait strategy. The built-in strategies
+// call at least one of the state, the exec or the logs APIs of the container on each attempt,
+// and some of them call two, so the largest number of calls is used.
+func (t *attemptsTarget) attempts() int {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return max(t.states, t.execs, t.logs)
+}
+
 // creatingHook is a hook that will be called before a container is created.
 func (req ContainerRequest) creatingHook(ctx context.Context) error {
 	errs := make([]error, len(req.LifecycleHooks))
@@ -267,17 +348,17 @@ func (c *DockerContainer) readiedHook(ctx context.Context) error {
 func (c *DockerContainer) printLogs(ctx context.Context, cause error) {
 	reader, err := c.Logs(ctx)
 	if err != nil {
-		c.logger.Printf("failed accessing container logs: %v\n", err)
+		logAt(ctx, c.logger, slog.LevelWarn, fmt.Sprintf("failed accessing container logs: %v\n", err), LogKeyContainerID, c.shortID())
 		return
 	}
 
 	b, err := io.ReadAll(reader)
 	if err != nil {
-		c.logger.Printf("failed reading container logs: %v\n", err)
+		logAt(ctx, c.logger, slog.LevelWarn, fmt.Sprintf("failed reading container logs: %v\n", err), LogKeyContainerID, c.shortID())
 		return
 	}
 
-	c.logger.Printf("container logs (%s):\n%s", cause, b)
+	logAt(ctx, c.logger, slog.LevelError, fmt.Sprintf("container logs (%s):\n%s", cause, b), LogKeyContainerID, c.shortID())
 }
 
 // stoppingHook is a hook that will be called before a container is stopped.
@@ -420,6 +501,31 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 		}
 	}
 
+	// Docker allows multiple networks during container creation since API 1.44. Before that, the networks
+	// of the attachments not joined at creation are connected once the container is created, before it starts.
+	multipleNetworks := versions.GreaterThanOrEqualTo(p.client.ClientVersion(), "1.44")
+	for _, attachment := range req.NetworkAttachments {
+		existing, ok := endpointSettings[attachment.Name]
+		if !ok && len(endpointSettings) > 0 && !multipleNetworks {
+			continue
+		}
+
+		nw, err := p.GetNetwork(ctx, NetworkRequest{
+			Name: attachment.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("error getting network %s: %w", attachment.Name, err)
+		}
+
+		settings := attachment.endpointSettings(nw.ID)
+		if ok {
+			settings.Aliases = append(slices.Clone(existing.Aliases), settings.Aliases...)
+		} else {
+			settings.Aliases = append(settings.Aliases, req.NetworkAliases[attachment.Name]...)
+		}
+		endpointSettings[attachment.Name] = settings
+	}
+
 	if req.ConfigModifier != nil {
 		req.ConfigModifier(dockerInput)
 	}
@@ -429,6 +535,10 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 	}
 	req.HostConfigModifier(hostConfig)
 
+	// the devices are appended after the modifier, so they are not reset by the deprecated Resources field
+	hostConfig.DeviceRequests = append(hostConfig.DeviceRequests, req.DeviceRequests...)
+	hostConfig.Devices = append(hostConfig.Devices, req.Devices...)
+
 	if req.EnpointSettingsModifier != nil {
 		req.EnpointSettingsModifier(endpointSettings)
 	}
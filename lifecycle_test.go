@@ -616,6 +616,22 @@ func TestLifecycleHooks_WithDefaultLogger(t *testing.T) {
 	require.Len(t, dl.data, 12)
 }
 
+// notDockerContainer is a Container not created by the Docker provider
+type notDockerContainer struct {
+	Container
+}
+
+func TestDefaultCopyFileToContainerHook_unsupportedContainer(t *testing.T) {
+	hooks := defaultCopyFileToContainerHook([]ContainerFile{{
+		Reader:            strings.NewReader("hello"),
+		ContainerFilePath: "/hello.txt",
+		FileMode:          0o644,
+	}})
+
+	err := hooks.PostCreates[0](context.Background(), notDockerContainer{})
+	require.EqualError(t, err, "unsupported container type testcontainers.notDockerContainer")
+}
+
 func TestCombineLifecycleHooks(t *testing.T) {
 	prints := []string{}
 
@@ -1,5 +1,11 @@
 package testcontainers
 
+import (
+	"regexp"
+	"slices"
+	"sync"
+)
+
 // StdoutLog is the log type for STDOUT
 const StdoutLog = "STDOUT"
 
@@ -34,3 +40,93 @@ type LogConsumerConfig struct {
 	Opts      []LogProductionOption // options for the production of logs
 	Consumers []LogConsumer         // consumers for the logs
 }
+
+// LogFilter selects the logs accepted by a consumer wrapped with FilterLogs
+type LogFilter struct {
+	// Types are the types of the accepted logs, StdoutLog or StderrLog. All of them if empty
+	Types []string
+
+	// Include accepts only the logs matching the expression, if not nil
+	Include *regexp.Regexp
+
+	// Exclude discards the logs matching the expression, if not nil. It's checked after Include
+	Exclude *regexp.Regexp
+}
+
+// accepts returns true if the log passes the filter
+func (f LogFilter) accepts(l Log) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, l.LogType) {
+		return false
+	}
+
+	if f.Include != nil && !f.Include.Match(l.Content) {
+		return false
+	}
+
+	if f.Exclude != nil && f.Exclude.Match(l.Content) {
+		return false
+	}
+
+	return true
+}
+
+// FilterLogs wraps the consumer, so that it only accepts the logs passing the filter,
+// e.g. the errors printed to stderr:
+//
+//	FilterLogs(consumer, LogFilter{Types: []string{StderrLog}, Include: regexp.MustCompile("(?i)error")})
+func FilterLogs(consumer LogConsumer, filter LogFilter) LogConsumer {
+	return &filteredLogConsumer{consumer: consumer, filter: filter}
+}
+
+type filteredLogConsumer struct {
+	consumer LogConsumer
+	filter   LogFilter
+}
+
+// Accept passes the log to the wrapped consumer if it passes the filter
+func (c *filteredLogConsumer) Accept(l Log) {
+	if c.filter.accepts(l) {
+		c.consumer.Accept(l)
+	}
+}
+
+// logFanOutBuffer is the number of logs buffered for each consumer before the production of logs waits for it
+const logFanOutBuffer = 256
+
+// logFanOut sends the logs to the consumers concurrently, so that a slow consumer doesn't delay the others,
+// while each consumer receives the logs in order, from its own goroutine.
+type logFanOut struct {
+	queues []chan Log
+	wg     sync.WaitGroup
+}
+
+// dispatch sends the log to each consumer, starting the goroutines of the consumers added since the last call,
+// as the consumers can be added while the logs are produced, with the deprecated FollowOutput.
+func (f *logFanOut) dispatch(consumers []LogConsumer, l Log) {
+	for i := len(f.queues); i < len(consumers); i++ {
+		queue := make(chan Log, logFanOutBuffer)
+		f.queues = append(f.queues, queue)
+
+		f.wg.Add(1)
+		go func(consumer LogConsumer) {
+			defer f.wg.Done()
+
+			for l := range queue {
+				consumer.Accept(l)
+			}
+		}(consumers[i])
+	}
+
+	for _, queue := range f.queues {
+		queue <- l
+	}
+}
+
+// close waits for the consumers to accept the logs sent to them
+func (f *logFanOut) close() {
+	for _, queue := range f.queues {
+		close(queue)
+	}
+
+	f.wg.Wait()
+}
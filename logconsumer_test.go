@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"testing"
@@ -400,6 +401,72 @@ func TestContainerLogsShouldBeWithoutStreamHeader(t *testing.T) {
 	assert.Equal(t, "0", strings.TrimSpace(string(b)))
 }
 
+func TestContainerLogsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image:      "alpine:latest",
+		Cmd:        []string{"sh", "-c", "echo one && echo two && sleep 2 && echo three"},
+		WaitingFor: wait.ForExit(),
+	}
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, ctr)
+
+	container := ctr.(*DockerContainer)
+
+	readLogs := func(t *testing.T, opts ...LogsOption) string {
+		r, err := container.LogsWithOptions(ctx, opts...)
+		require.NoError(t, err)
+		defer r.Close()
+
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		return string(b)
+	}
+
+	t.Run("tail", func(t *testing.T) {
+		// logsTail {
+		r, err := container.LogsWithOptions(ctx, LogsTail(1))
+		// }
+		require.NoError(t, err)
+		defer r.Close()
+
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "three\n", string(b))
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(readLogs(t, LogsTimestamps())), "\n")
+		require.Len(t, lines, 3)
+
+		for i, expected := range []string{"one", "two", "three"} {
+			timestamp, msg, found := strings.Cut(lines[i], " ")
+			require.True(t, found)
+			assert.Equal(t, expected, msg)
+
+			_, err := time.Parse(time.RFC3339Nano, timestamp)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("since-until", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(readLogs(t, LogsTimestamps())), "\n")
+		require.Len(t, lines, 3)
+
+		timestamp, _, _ := strings.Cut(lines[2], " ")
+		third, err := time.Parse(time.RFC3339Nano, timestamp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "three\n", readLogs(t, LogsSince(third)))
+		assert.Equal(t, "one\ntwo\n", readLogs(t, LogsUntil(third.Add(-time.Second))))
+	})
+}
+
 func TestContainerLogsEnableAtStart(t *testing.T) {
 	ctx := context.Background()
 	g := TestLogConsumer{
@@ -638,3 +705,124 @@ func Test_MultiContainerLogConsumer_CancelledContext(t *testing.T) {
 	// the multiple containers.
 	assert.False(t, strings.Contains(actual, logStoppedForOutOfSyncMessage))
 }
+
+// recordingLogConsumer records the content of the accepted logs
+type recordingLogConsumer struct {
+	mtx  sync.Mutex
+	msgs []string
+}
+
+func (c *recordingLogConsumer) Accept(l Log) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.msgs = append(c.msgs, string(l.Content))
+}
+
+func (c *recordingLogConsumer) messages() []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([]string{}, c.msgs...)
+}
+
+func TestFilterLogs(t *testing.T) {
+	logs := []Log{
+		{LogType: StdoutLog, Content: []byte("starting")},
+		{LogType: StderrLog, Content: []byte("ERROR: connection refused")},
+		{LogType: StdoutLog, Content: []byte("error: retrying")},
+		{LogType: StderrLog, Content: []byte("debug: healthcheck")},
+	}
+
+	tests := []struct {
+		name     string
+		filter   LogFilter
+		expected []string
+	}{
+		{
+			name:     "no-filter",
+			filter:   LogFilter{},
+			expected: []string{"starting", "ERROR: connection refused", "error: retrying", "debug: healthcheck"},
+		},
+		{
+			name:     "stderr",
+			filter:   LogFilter{Types: []string{StderrLog}},
+			expected: []string{"ERROR: connection refused", "debug: healthcheck"},
+		},
+		{
+			name:     "include",
+			filter:   LogFilter{Include: regexp.MustCompile("(?i)error")},
+			expected: []string{"ERROR: connection refused", "error: retrying"},
+		},
+		{
+			name:     "exclude",
+			filter:   LogFilter{Exclude: regexp.MustCompile("^debug")},
+			expected: []string{"starting", "ERROR: connection refused", "error: retrying"},
+		},
+		{
+			name:     "stderr-include",
+			filter:   LogFilter{Types: []string{StderrLog}, Include: regexp.MustCompile("(?i)error")},
+			expected: []string{"ERROR: connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := &recordingLogConsumer{}
+			filtered := FilterLogs(consumer, tt.filter)
+
+			for _, l := range logs {
+				filtered.Accept(l)
+			}
+
+			assert.Equal(t, tt.expected, consumer.messages())
+		})
+	}
+}
+
+// blockingLogConsumer blocks the acceptance of the logs until it's released
+type blockingLogConsumer struct {
+	recordingLogConsumer
+	release chan struct{}
+}
+
+func (c *blockingLogConsumer) Accept(l Log) {
+	<-c.release
+	c.recordingLogConsumer.Accept(l)
+}
+
+func TestLogFanOut(t *testing.T) {
+	slow := &blockingLogConsumer{release: make(chan struct{})}
+	fast := &recordingLogConsumer{}
+
+	fanOut := &logFanOut{}
+	consumers := []LogConsumer{slow, fast}
+
+	expected := []string{}
+	for i := 0; i < 10; i++ {
+		msg := fmt.Sprintf("log %d", i)
+		expected = append(expected, msg)
+		fanOut.dispatch(consumers, Log{LogType: StdoutLog, Content: []byte(msg)})
+	}
+
+	// the fast consumer is not delayed by the slow one
+	require.Eventually(t, func() bool {
+		return len(fast.messages()) == 10
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, expected, fast.messages())
+	assert.Empty(t, slow.messages())
+
+	// the consumers added later receive the following logs
+	late := &recordingLogConsumer{}
+	consumers = append(consumers, late)
+	fanOut.dispatch(consumers, Log{LogType: StdoutLog, Content: []byte("log 10")})
+	expected = append(expected, "log 10")
+
+	close(slow.release)
+	fanOut.close()
+
+	// closing waits for every consumer to accept its logs, in order
+	assert.Equal(t, expected, slow.messages())
+	assert.Equal(t, expected, fast.messages())
+	assert.Equal(t, []string{"log 10"}, late.messages())
+}
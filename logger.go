@@ -2,11 +2,29 @@ package testcontainers
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/client"
+	"github.com/google/uuid"
+)
+
+// The keys of the attributes of the structured logs, identifying what they refer to.
+const (
+	// LogKeyRequestID is the key of the ID of the GenericContainer call, shared by the logs
+	// of the container it creates, e.g. while pulling its image
+	LogKeyRequestID = "request.id"
+	// LogKeyContainerID is the key of the short ID of the container
+	LogKeyContainerID = "container.id"
+	// LogKeyImage is the key of the image of the container
+	LogKeyImage = "image"
+	// LogKeySessionID is the key of the ID of the test session
+	LogKeySessionID = "session.id"
 )
 
 // Logger is the default log instance
@@ -15,6 +33,8 @@ var Logger Logging = log.New(os.Stderr, "", log.LstdFlags)
 // Validate our types implement the required interfaces.
 var (
 	_ Logging               = (*log.Logger)(nil)
+	_ StructuredLogging     = slogLogger{}
+	_ StructuredLogging     = testLogger{}
 	_ ContainerCustomizer   = LoggerOption{}
 	_ GenericProviderOption = LoggerOption{}
 	_ DockerProviderOption  = LoggerOption{}
@@ -25,6 +45,70 @@ type Logging interface {
 	Printf(format string, v ...interface{})
 }
 
+// StructuredLogging defines a leveled and structured Logger. If the logger implements it, the logs of
+// Testcontainers for Go are sent to its Log method, instead of Printf, with the attributes identifying
+// what they refer to, e.g. LogKeyRequestID and LogKeyContainerID, so that they can be correlated.
+// The args are key-value pairs or slog.Attr values, as in slog.Logger.Log.
+type StructuredLogging interface {
+	Logging
+	Log(ctx context.Context, level slog.Level, msg string, args ...any)
+}
+
+// SlogLogger returns a StructuredLogging implementation for the slog.Logger,
+// which logs the messages of Printf at the info level.
+func SlogLogger(logger *slog.Logger) StructuredLogging {
+	return slogLogger{logger: logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// Printf implements Logging.
+func (l slogLogger) Printf(format string, v ...interface{}) {
+	l.logger.Info(strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
+
+// Log implements StructuredLogging.
+func (l slogLogger) Log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	l.logger.Log(ctx, level, msg, args...)
+}
+
+type requestIDKey struct{}
+
+// withRequestID returns a context holding a new request ID, added to the structured logs
+// of the calls receiving it, unless it already holds one.
+func withRequestID(ctx context.Context) context.Context {
+	if requestIDFromContext(ctx) != "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, requestIDKey{}, uuid.NewString()[:8])
+}
+
+// requestIDFromContext returns the request ID of the context, if any
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logAt logs the message at the level. If the logger is a StructuredLogging, the message is logged with
+// the attributes, and the ID of the request of the context, if any. Otherwise, only the message is printed,
+// so it must include the relevant values.
+func logAt(ctx context.Context, logger Logging, level slog.Level, msg string, args ...any) {
+	structured, ok := logger.(StructuredLogging)
+	if !ok {
+		logger.Printf("%s", msg)
+		return
+	}
+
+	if id := requestIDFromContext(ctx); id != "" {
+		args = append([]any{LogKeyRequestID, id}, args...)
+	}
+
+	structured.Log(ctx, level, strings.TrimSuffix(msg, "\n"), args...)
+}
+
 // Deprecated: this function will be removed in a future release
 // LogDockerServerInfo logs the docker server info using the provided logger and Docker client
 func LogDockerServerInfo(ctx context.Context, client client.APIClient, logger Logging) {
@@ -35,7 +119,7 @@ func LogDockerServerInfo(ctx context.Context, client client.APIClient, logger Lo
 // This way logs from testcontainers are part of the test output of a test suite or test case.
 func TestLogger(tb testing.TB) Logging {
 	tb.Helper()
-	return testLogger{TB: tb}
+	return testLogger{tb: tb}
 }
 
 // WithLogger returns a generic option that sets the logger to be used.
@@ -73,11 +157,26 @@ func (o LoggerOption) Customize(req *GenericContainerRequest) {
 }
 
 type testLogger struct {
-	testing.TB
+	tb testing.TB
 }
 
 // Printf implements Logging.
 func (t testLogger) Printf(format string, v ...interface{}) {
-	t.Helper()
-	t.Logf(format, v...)
+	t.tb.Helper()
+	t.tb.Logf(format, v...)
+}
+
+// Log implements StructuredLogging, appending the attributes to the message as key=value pairs.
+func (t testLogger) Log(_ context.Context, level slog.Level, msg string, args ...any) {
+	t.tb.Helper()
+
+	var sb strings.Builder
+	r := slog.NewRecord(time.Time{}, level, msg, 0)
+	r.Add(args...)
+	r.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(&sb, " %s=%s", a.Key, a.Value)
+		return true
+	})
+
+	t.tb.Logf("%s %s%s", level, msg, sb.String())
 }
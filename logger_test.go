@@ -1,6 +1,11 @@
 package testcontainers
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -29,3 +34,77 @@ func TestWithLogger(t *testing.T) {
 		require.Equal(t, logger, opts.Logger)
 	})
 }
+
+// structuredLogger records the structured logs, as a StructuredLogging implementation.
+type structuredLogger struct {
+	records []string
+}
+
+func (l *structuredLogger) Printf(format string, v ...interface{}) {
+	l.records = append(l.records, fmt.Sprintf(format, v...))
+}
+
+func (l *structuredLogger) Log(_ context.Context, level slog.Level, msg string, args ...any) {
+	l.records = append(l.records, fmt.Sprint(append([]any{level, msg}, args...)...))
+}
+
+// printfLogger records the logs, as a Logging implementation.
+type printfLogger struct {
+	records []string
+}
+
+func (l *printfLogger) Printf(format string, v ...interface{}) {
+	l.records = append(l.records, fmt.Sprintf(format, v...))
+}
+
+func TestLogAt(t *testing.T) {
+	t.Run("structured", func(t *testing.T) {
+		logger := &structuredLogger{}
+
+		logAt(context.Background(), logger, slog.LevelWarn, "message\n", LogKeyContainerID, "abc")
+		require.Equal(t, []string{fmt.Sprint(slog.LevelWarn, "message", LogKeyContainerID, "abc")}, logger.records)
+	})
+
+	t.Run("structured/request-id", func(t *testing.T) {
+		logger := &structuredLogger{}
+
+		ctx := withRequestID(context.Background())
+		id := requestIDFromContext(ctx)
+		require.Len(t, id, 8)
+
+		// the ID of the request is kept
+		require.Equal(t, id, requestIDFromContext(withRequestID(ctx)))
+
+		logAt(ctx, logger, slog.LevelInfo, "message", LogKeyContainerID, "abc")
+		require.Equal(t, []string{fmt.Sprint(slog.LevelInfo, "message", LogKeyRequestID, id, LogKeyContainerID, "abc")}, logger.records)
+	})
+
+	t.Run("printf", func(t *testing.T) {
+		logger := &printfLogger{}
+
+		logAt(withRequestID(context.Background()), logger, slog.LevelInfo, "100% done", LogKeyContainerID, "abc")
+		require.Equal(t, []string{"100% done"}, logger.records)
+	})
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := SlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+
+	logger.Printf("printf %d\n", 1)
+	logAt(withRequestID(context.Background()), logger, slog.LevelDebug, "hidden")
+	logAt(context.Background(), logger, slog.LevelWarn, "log", LogKeyContainerID, "abc")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, []string{
+		`level=INFO msg="printf 1"`,
+		`level=WARN msg=log container.id=abc`,
+	}, lines)
+}
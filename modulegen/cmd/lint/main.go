@@ -0,0 +1,38 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	internal_lint "github.com/testcontainers/testcontainers-go/modulegen/internal/lint"
+)
+
+var LintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the existing Examples and Modules for drift",
+	Long:  "Check each Example and Module for drift from the files generated by modulegen: the job of the CI workflow, the nav of the docs, the version of testcontainers-go in its go.mod, the dependabot entries and the naming of its entrypoint. It fails if any issue is found, reporting them by module.",
+	Args:  cobra.NoArgs,
+	// the issues are reported in the output, so the usage is not printed on failure
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := context.GetRootContext()
+		if err != nil {
+			return fmt.Errorf(">> could not get the root dir: %w", err)
+		}
+
+		report, err := internal_lint.Lint(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(report) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "All the examples and modules are up to date.")
+			return nil
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), report.String())
+		return fmt.Errorf("found issues in %d examples and modules", len(report))
+	},
+}
@@ -19,6 +19,13 @@ func init() {
 	newExampleCmd.Flags().StringVarP(&tcModuleVar.Name, nameFlag, "n", "", "Name of the example. Only alphabetical characters are allowed.")
 	newExampleCmd.Flags().StringVarP(&tcModuleVar.NameTitle, titleFlag, "t", "", "(Optional) Title of the example name, used to override the name in the case of mixed casing (Mongodb -> MongoDB). Use camel-case when needed. Only alphabetical characters are allowed.")
 	newExampleCmd.Flags().StringVarP(&tcModuleVar.Image, imageFlag, "i", "", "Fully-qualified name of the Docker image to be used by the example")
+	newExampleCmd.Flags().StringSliceVarP(&tcModuleVar.Ports, portFlag, "p", nil, "(Optional) Ports exposed by the container, e.g. 8080/tcp. The first one is used as the default port. Defaults to the ports exposed by the image.")
+	newExampleCmd.Flags().StringVarP(&tcModuleVar.WaitStrategy, waitStrategyFlag, "w", "", "(Optional) Wait strategy of the example: healthcheck, http, log or port. Defaults to healthcheck.")
+	newExampleCmd.Flags().StringSliceVarP(&tcModuleVar.Archs, archFlag, "a", nil, "(Optional) Architectures the tests of the example run on: amd64, arm64. Use it to opt the example out of an architecture its images do not support. Defaults to amd64,arm64.")
+	newExampleCmd.Flags().StringVar(&tcModuleVar.TCVersion, tcVersionFlag, "", "(Optional) Version of testcontainers-go required by the go.mod file of the example, e.g. v0.27.0. It must exist. Defaults to the latest_version of mkdocs.yml.")
+	newExampleCmd.Flags().StringVar(&tcModuleVar.TemplatesDir, templatesDirFlag, "", "(Optional) Directory of the templates overriding the built-in ones of the example files: module.go.tmpl, module_test.go.tmpl, module_bench_test.go.tmpl, examples_test.go.tmpl, Makefile.tmpl and module.md.tmpl. The missing ones use the built-in templates.")
+	newExampleCmd.Flags().BoolVar(&tcModuleVar.WithBenchmarks, withBenchmarksFlag, false, "(Optional) Generate a benchmark test skeleton of the example.")
+	newExampleCmd.Flags().BoolVar(&tcModuleVar.WithExamples, withExamplesFlag, false, "(Optional) Generate the testable examples of the example, as for a module.")
 
 	_ = newExampleCmd.MarkFlagRequired(imageFlag)
 	_ = newExampleCmd.MarkFlagRequired(nameFlag)
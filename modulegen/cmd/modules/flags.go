@@ -1,7 +1,14 @@
 package modules
 
 const (
-	imageFlag = "image"
-	nameFlag  = "name"
-	titleFlag = "title"
+	archFlag           = "arch"
+	imageFlag          = "image"
+	nameFlag           = "name"
+	portFlag           = "port"
+	tcVersionFlag      = "tc-version"
+	templatesDirFlag   = "templates-dir"
+	titleFlag          = "title"
+	waitStrategyFlag   = "wait-strategy"
+	withBenchmarksFlag = "with-benchmarks"
+	withExamplesFlag   = "with-examples"
 )
@@ -3,6 +3,7 @@ package modules
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/testcontainers/testcontainers-go/modulegen/internal"
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
 )
 
@@ -11,7 +12,11 @@ var tcModuleVar = context.TestcontainersModuleVar{}
 var NewCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Create a new Example or Module",
-	Long:  "Create a new Example or Module",
+	Long:  "Create a new Example or Module. If no subcommand is passed, it asks for the values of the new Example or Module in an interactive way.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return internal.GenerateWizard(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
 }
 
 func init() {
@@ -19,6 +19,12 @@ func init() {
 	newModuleCmd.Flags().StringVarP(&tcModuleVar.Name, nameFlag, "n", "", "Name of the module. Only alphabetical characters are allowed.")
 	newModuleCmd.Flags().StringVarP(&tcModuleVar.NameTitle, titleFlag, "t", "", "(Optional) Title of the module name, used to override the name in the case of mixed casing (Mongodb -> MongoDB). Use camel-case when needed. Only alphabetical characters are allowed.")
 	newModuleCmd.Flags().StringVarP(&tcModuleVar.Image, imageFlag, "i", "", "Fully-qualified name of the Docker image to be used by the module")
+	newModuleCmd.Flags().StringSliceVarP(&tcModuleVar.Ports, portFlag, "p", nil, "(Optional) Ports exposed by the container, e.g. 8080/tcp. The first one is used as the default port. Defaults to the ports exposed by the image.")
+	newModuleCmd.Flags().StringVarP(&tcModuleVar.WaitStrategy, waitStrategyFlag, "w", "", "(Optional) Wait strategy of the module: healthcheck, http, log or port. Defaults to healthcheck.")
+	newModuleCmd.Flags().StringSliceVarP(&tcModuleVar.Archs, archFlag, "a", nil, "(Optional) Architectures the tests of the module run on: amd64, arm64. Use it to opt the module out of an architecture its images do not support. Defaults to amd64,arm64.")
+	newModuleCmd.Flags().StringVar(&tcModuleVar.TCVersion, tcVersionFlag, "", "(Optional) Version of testcontainers-go required by the go.mod file of the module, e.g. v0.27.0. It must exist. Defaults to the latest_version of mkdocs.yml.")
+	newModuleCmd.Flags().StringVar(&tcModuleVar.TemplatesDir, templatesDirFlag, "", "(Optional) Directory of the templates overriding the built-in ones of the module files: module.go.tmpl, module_test.go.tmpl, module_bench_test.go.tmpl, examples_test.go.tmpl, Makefile.tmpl and module.md.tmpl. The missing ones use the built-in templates.")
+	newModuleCmd.Flags().BoolVar(&tcModuleVar.WithBenchmarks, withBenchmarksFlag, false, "(Optional) Generate a benchmark test skeleton of the module.")
 
 	_ = newModuleCmd.MarkFlagRequired(imageFlag)
 	_ = newModuleCmd.MarkFlagRequired(nameFlag)
@@ -3,7 +3,9 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/testcontainers/testcontainers-go/modulegen/cmd/lint"
 	"github.com/testcontainers/testcontainers-go/modulegen/cmd/modules"
+	"github.com/testcontainers/testcontainers-go/modulegen/cmd/workflows"
 )
 
 var NewRootCmd = &cobra.Command{
@@ -14,4 +16,6 @@ var NewRootCmd = &cobra.Command{
 
 func init() {
 	NewRootCmd.AddCommand(modules.NewCmd)
+	NewRootCmd.AddCommand(lint.LintCmd)
+	NewRootCmd.AddCommand(workflows.VerifyWorkflowsCmd)
 }
@@ -0,0 +1,54 @@
+package workflows
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/workflow"
+)
+
+var fix bool
+
+var VerifyWorkflowsCmd = &cobra.Command{
+	Use:   "verify-workflows",
+	Short: "Check the CI workflow for drift",
+	Long:  "Check the CI workflow for drift from the one generated by modulegen, with a job for each Example and Module. It fails if any issue is found, reporting the missing, unexpected and modified jobs, unless the --fix flag regenerates the workflow.",
+	Args:  cobra.NoArgs,
+	// the issues are reported in the output, so the usage is not printed on failure
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := context.GetRootContext()
+		if err != nil {
+			return fmt.Errorf(">> could not get the root dir: %w", err)
+		}
+
+		if fix {
+			if err := (workflow.Generator{}).Generate(ctx); err != nil {
+				return fmt.Errorf(">> could not generate the CI workflow: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "The CI workflow has been regenerated.")
+			return nil
+		}
+
+		issues, err := (workflow.Generator{}).Verify(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(issues) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "The CI workflow is up to date.")
+			return nil
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), ".github/workflows/ci.yml:\n  - %s\n", strings.Join(issues, "\n  - "))
+		return fmt.Errorf("found %d issues in the CI workflow, regenerate it with the --fix flag", len(issues))
+	},
+}
+
+func init() {
+	VerifyWorkflowsCmd.Flags().BoolVar(&fix, "fix", false, "Regenerate the CI workflow instead of reporting the issues")
+}
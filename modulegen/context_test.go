@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
@@ -15,3 +16,34 @@ func getTestRootContext(t *testing.T) context.Context {
 	require.NoError(t, err)
 	return context.New(filepath.Dir(current))
 }
+
+func TestGetModuleArchs(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+
+	writeMakefile := func(module string, content string) {
+		dir := filepath.Join(tmpCtx.RootDir, "modules", module)
+		require.NoError(t, os.MkdirAll(dir, 0o777))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Makefile"), []byte(content), 0o644))
+	}
+
+	writeMakefile("foodb", "include ../../commons-test.mk\n\n.PHONY: test\ntest:\n\t$(MAKE) test-foodb\n")
+	writeMakefile("bardb", "include ../../commons-test.mk\n\nARCHS := amd64\n\n.PHONY: test\ntest:\n\t$(MAKE) test-bardb\n")
+
+	t.Run("without-archs", func(t *testing.T) {
+		archs, err := tmpCtx.GetModuleArchs("foodb")
+		require.NoError(t, err)
+		assert.Equal(t, context.Archs, archs)
+	})
+
+	t.Run("with-archs", func(t *testing.T) {
+		archs, err := tmpCtx.GetModuleArchs("bardb")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"amd64"}, archs)
+	})
+
+	t.Run("without-makefile", func(t *testing.T) {
+		archs, err := tmpCtx.GetModuleArchs("bazdb")
+		require.NoError(t, err)
+		assert.Equal(t, context.Archs, archs)
+	})
+}
@@ -1,7 +1,14 @@
 package context
 
 type TestcontainersModuleVar struct {
-	Name      string
-	NameTitle string
-	Image     string
+	Archs          []string
+	Name           string
+	NameTitle      string
+	Image          string
+	Ports          []string
+	TCVersion      string
+	TemplatesDir   string
+	WaitStrategy   string
+	WithBenchmarks bool
+	WithExamples   bool
 }
@@ -1,11 +1,18 @@
 package context
 
 import (
+	"bufio"
+	"errors"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
 )
 
+// archsRegex matches the ARCHS variable of the Makefile of a module, e.g. "ARCHS := amd64 arm64"
+var archsRegex = regexp.MustCompile(`^ARCHS\s*[:?]?=\s*(.*)$`)
+
 type Context struct {
 	RootDir string
 }
@@ -72,6 +79,40 @@ func (ctx Context) GetModules() ([]string, error) {
 	return ctx.getModulesByBaseDir("modules")
 }
 
+// GetModuleArchs returns the architectures the tests of a module run on, read from the ARCHS variable
+// of its Makefile. If the Makefile does not define it, the tests run on all the supported architectures.
+func (ctx Context) GetModuleArchs(module string) ([]string, error) {
+	f, err := os.Open(filepath.Join(ctx.RootDir, "modules", module, "Makefile"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Archs, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		matches := archsRegex.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if matches == nil {
+			continue
+		}
+
+		archs := strings.Fields(matches[1])
+		if len(archs) == 0 {
+			break
+		}
+
+		return archs, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return Archs, nil
+}
+
 func (ctx Context) GetExamplesDocs() ([]string, error) {
 	return ctx.getMarkdownsFromDir("examples")
 }
@@ -2,21 +2,50 @@ package context
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/semver"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
+// Wait strategies supported by the generated code
+const (
+	WaitStrategyHealthCheck = "healthcheck" // waits for the HEALTHCHECK of the image to report the container as healthy
+	WaitStrategyHTTP        = "http"        // waits for the default port to listen and for a placeholder health endpoint
+	WaitStrategyLog         = "log"         // waits for a placeholder log message
+	WaitStrategyPort        = "port"        // waits for the default port to listen
+)
+
+// WaitStrategies is the list of wait strategies supported by the generated code
+var WaitStrategies = []string{WaitStrategyHealthCheck, WaitStrategyHTTP, WaitStrategyLog, WaitStrategyPort}
+
+// Architectures supported by the CI workflow and the test targets of the Makefile
+const (
+	ArchAMD64 = "amd64"
+	ArchARM64 = "arm64"
+)
+
+// Archs is the list of architectures the tests of a module run on by default
+var Archs = []string{ArchAMD64, ArchARM64}
+
 type TestcontainersModule struct {
-	Image     string // fully qualified name of the Docker image
-	IsModule  bool   // if true, the module will be generated as a Go module, otherwise an example
-	Name      string
-	TitleName string // title of the name: m.g. "mongodb" -> "MongoDB"
-	TCVersion string // Testcontainers for Go version
+	Archs          []string // architectures the tests run on, a subset of Archs. Defaults to all of them
+	Image          string   // fully qualified name of the Docker image
+	IsModule       bool     // if true, the module will be generated as a Go module, otherwise an example
+	Name           string
+	TitleName      string   // title of the name: m.g. "mongodb" -> "MongoDB"
+	TCVersion      string   // Testcontainers for Go version required by the go.mod file, e.g. "v0.27.0". Defaults to the latest_version of mkdocs.yml
+	TemplatesDir   string   // directory of the templates overriding the built-in ones of the module files, e.g. "module.go.tmpl"
+	Ports          []string // ports exposed by the container, e.g. "8080/tcp". The first one is the default port. Defaults to the ports exposed by the image
+	WaitStrategy   string   // wait strategy of the generated code, one of WaitStrategies. Defaults to "healthcheck"
+	WithBenchmarks bool     // if true, a benchmark test skeleton is generated
+	WithExamples   bool     // if true, the testable examples are generated for an example too. They are always generated for a module
 }
 
 // ContainerName returns the name of the container, which is the lower-cased title of the example
@@ -34,6 +63,12 @@ func (m *TestcontainersModule) ContainerName() string {
 	return name + "Container"
 }
 
+// HasExamples returns true if the testable examples are generated, which is always the case for a module,
+// as its docs include them, and only if requested for an example.
+func (m *TestcontainersModule) HasExamples() bool {
+	return m.IsModule || m.WithExamples
+}
+
 // Entrypoint returns the name of the entrypoint function, which is the lower-cased title of the example
 // If the example is a module, the entrypoint will be "RunContainer"
 func (m *TestcontainersModule) Entrypoint() string {
@@ -44,6 +79,45 @@ func (m *TestcontainersModule) Entrypoint() string {
 	return "runContainer"
 }
 
+// DefaultPort returns the first port exposed by the container, using TCP if no protocol is set.
+// If no ports are set, it returns an empty string, as the container exposes the ports of the image.
+func (m *TestcontainersModule) DefaultPort() string {
+	if len(m.Ports) == 0 {
+		return ""
+	}
+
+	return withProtocol(m.Ports[0])
+}
+
+// ExtraPorts returns the ports exposed by the container besides the default port,
+// using TCP if no protocol is set
+func (m *TestcontainersModule) ExtraPorts() []string {
+	ports := []string{}
+	for i := 1; i < len(m.Ports); i++ {
+		ports = append(ports, withProtocol(m.Ports[i]))
+	}
+
+	return ports
+}
+
+// GetArchs returns the architectures the tests of the module run on, which are all the supported ones if not set
+func (m *TestcontainersModule) GetArchs() []string {
+	if len(m.Archs) == 0 {
+		return Archs
+	}
+
+	return m.Archs
+}
+
+// GetWaitStrategy returns the wait strategy of the generated code, which is "healthcheck" if not set
+func (m *TestcontainersModule) GetWaitStrategy() string {
+	if m.WaitStrategy == "" {
+		return WaitStrategyHealthCheck
+	}
+
+	return m.WaitStrategy
+}
+
 func (m *TestcontainersModule) Lower() string {
 	return strings.ToLower(m.Name)
 }
@@ -80,5 +154,44 @@ func (m *TestcontainersModule) Validate() error {
 		return fmt.Errorf("invalid title: %s. Only alphanumerical characters are allowed (leading character must be a letter)", m.TitleName)
 	}
 
+	for _, port := range m.Ports {
+		if !regexp.MustCompile(`^[0-9]+(/(tcp|udp|sctp))?$`).MatchString(port) {
+			return fmt.Errorf("invalid port: %s. Only numbers are allowed, optionally followed by the protocol (8080/tcp)", port)
+		}
+	}
+
+	if m.WaitStrategy != "" && !slices.Contains(WaitStrategies, m.WaitStrategy) {
+		return fmt.Errorf("invalid wait strategy: %s. Only %s are allowed", m.WaitStrategy, strings.Join(WaitStrategies, ", "))
+	}
+
+	if (m.WaitStrategy == WaitStrategyHTTP || m.WaitStrategy == WaitStrategyPort) && len(m.Ports) == 0 {
+		return fmt.Errorf("the %s wait strategy requires the ports exposed by the container", m.WaitStrategy)
+	}
+
+	if m.TCVersion != "" && (!semver.IsValid(m.TCVersion) || semver.Canonical(m.TCVersion) != m.TCVersion) {
+		return fmt.Errorf("invalid testcontainers-go version: %s. Only semantic versions prefixed with v are allowed (v0.27.0)", m.TCVersion)
+	}
+
+	for _, arch := range m.Archs {
+		if !slices.Contains(Archs, arch) {
+			return fmt.Errorf("invalid architecture: %s. Only %s are allowed", arch, strings.Join(Archs, ", "))
+		}
+	}
+
+	if m.TemplatesDir != "" {
+		if fi, err := os.Stat(m.TemplatesDir); err != nil || !fi.IsDir() {
+			return fmt.Errorf("invalid templates dir: %s. It must be an existing directory", m.TemplatesDir)
+		}
+	}
+
 	return nil
 }
+
+// withProtocol appends the TCP protocol to the port if it has no protocol
+func withProtocol(port string) string {
+	if strings.Contains(port, "/") {
+		return port
+	}
+
+	return port + "/tcp"
+}
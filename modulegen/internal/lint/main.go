@@ -0,0 +1,311 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/mkdocs"
+)
+
+const (
+	CheckWorkflow   = "workflow"
+	CheckMkdocs     = "mkdocs"
+	CheckGoMod      = "go.mod"
+	CheckDependabot = "dependabot"
+	CheckEntrypoint = "entrypoint"
+
+	tcModulePath = "github.com/testcontainers/testcontainers-go"
+)
+
+var (
+	// moduleEntrypointRegex matches the exported functions starting a module, e.g. Run or RunContainer
+	moduleEntrypointRegex = regexp.MustCompile(`^Run([A-Z]\w*)?$`)
+
+	// exampleEntrypointRegex matches the functions starting an example, e.g. runContainer or startContainer
+	exampleEntrypointRegex = regexp.MustCompile(`^(run|start)[A-Z]\w*$`)
+)
+
+// exemptions lists the checks not applying to a module, by relative path:
+// the compose module is documented as a feature, and does not start a single container.
+var exemptions = map[string][]string{
+	"modules/compose": {CheckMkdocs, CheckEntrypoint},
+}
+
+// Issue represents a drift of a module from the layout generated by modulegen
+type Issue struct {
+	Check   string
+	Message string
+}
+
+// ModuleReport represents the issues found in a module or an example
+type ModuleReport struct {
+	// Path is the path of the module, relative to the root dir, e.g. modules/redis
+	Path   string
+	Issues []Issue
+}
+
+// Report represents the modules and examples with issues, sorted by path
+type Report []ModuleReport
+
+// String returns the report, grouping the issues by module
+func (r Report) String() string {
+	sb := strings.Builder{}
+	for _, m := range r {
+		sb.WriteString(m.Path + ":\n")
+		for _, issue := range m.Issues {
+			sb.WriteString(fmt.Sprintf("  - [%s] %s\n", issue.Check, issue.Message))
+		}
+	}
+	return sb.String()
+}
+
+type ciWorkflow struct {
+	Jobs map[string]struct {
+		With struct {
+			ProjectDirectory string `yaml:"project-directory"`
+		} `yaml:"with"`
+	} `yaml:"jobs"`
+}
+
+type dependabotConfig struct {
+	Updates []struct {
+		PackageEcosystem string `yaml:"package-ecosystem"`
+		Directory        string `yaml:"directory"`
+	} `yaml:"updates"`
+}
+
+type linter struct {
+	ctx       context.Context
+	tcVersion string
+	modules   []string
+	examples  []string
+	navItems  []string
+	// gomodDirs are the directories updated by dependabot, nil if it does not update the Go modules
+	gomodDirs []string
+}
+
+// Lint checks each module and example for drift from the files generated by modulegen:
+// the job of the CI workflow, the nav of the docs, the version of testcontainers-go
+// required by its go.mod, the dependabot entries and the naming of its entrypoint.
+func Lint(ctx context.Context) (Report, error) {
+	l := &linter{ctx: ctx}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+
+	examples, err := ctx.GetExamples()
+	if err != nil {
+		return nil, err
+	}
+	modules, err := ctx.GetModules()
+	if err != nil {
+		return nil, err
+	}
+
+	report := Report{}
+	for _, example := range examples {
+		if issues := l.lint("examples", example); len(issues) > 0 {
+			report = append(report, ModuleReport{Path: "examples/" + example, Issues: issues})
+		}
+	}
+	for _, module := range modules {
+		if issues := l.lint("modules", module); len(issues) > 0 {
+			report = append(report, ModuleReport{Path: "modules/" + module, Issues: issues})
+		}
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Path < report[j].Path })
+
+	return report, nil
+}
+
+// load reads the files shared by all the modules: the mkdocs config, the CI workflow and the dependabot config
+func (l *linter) load() error {
+	config, err := mkdocs.ReadConfig(l.ctx.MkdocsConfigFile())
+	if err != nil {
+		return fmt.Errorf("could not read MkDocs config: %w", err)
+	}
+	l.tcVersion = config.Extra.LatestVersion
+	for _, nav := range config.Nav {
+		l.navItems = append(l.navItems, nav.Modules...)
+		l.navItems = append(l.navItems, nav.Examples...)
+	}
+
+	workflow := ciWorkflow{}
+	if err := readYaml(filepath.Join(l.ctx.GithubWorkflowsDir(), "ci.yml"), &workflow); err != nil {
+		return fmt.Errorf("could not read the CI workflow: %w", err)
+	}
+	// each module and example has its own job, testing its directory
+	for _, job := range workflow.Jobs {
+		dir := job.With.ProjectDirectory
+		if name, ok := strings.CutPrefix(dir, "modules/"); ok {
+			l.modules = append(l.modules, name)
+		} else if name, ok := strings.CutPrefix(dir, "examples/"); ok {
+			l.examples = append(l.examples, name)
+		}
+	}
+
+	dependabot := dependabotConfig{}
+	err = readYaml(filepath.Join(l.ctx.GithubDir(), "dependabot.yml"), &dependabot)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not read the dependabot config: %w", err)
+	}
+	for _, update := range dependabot.Updates {
+		if update.PackageEcosystem == "gomod" {
+			l.gomodDirs = append(l.gomodDirs, update.Directory)
+		}
+	}
+
+	return nil
+}
+
+func (l *linter) lint(parentDir string, name string) []Issue {
+	path := parentDir + "/" + name
+	isModule := parentDir == "modules"
+
+	checks := []struct {
+		name string
+		fn   func() []string
+	}{
+		{CheckWorkflow, func() []string { return l.checkWorkflow(isModule, name) }},
+		{CheckMkdocs, func() []string { return l.checkMkdocs(path) }},
+		{CheckGoMod, func() []string { return l.checkGoMod(path) }},
+		{CheckDependabot, func() []string { return l.checkDependabot(path) }},
+		{CheckEntrypoint, func() []string { return l.checkEntrypoint(isModule, path) }},
+	}
+
+	issues := []Issue{}
+	for _, check := range checks {
+		if slices.Contains(exemptions[path], check.name) {
+			continue
+		}
+		for _, msg := range check.fn() {
+			issues = append(issues, Issue{Check: check.name, Message: msg})
+		}
+	}
+	return issues
+}
+
+func (l *linter) checkWorkflow(isModule bool, name string) []string {
+	items := l.examples
+	if isModule {
+		items = l.modules
+	}
+
+	if slices.Contains(items, name) {
+		return nil
+	}
+	return []string{"missing its job in .github/workflows/ci.yml: regenerate it with modulegen verify-workflows --fix"}
+}
+
+func (l *linter) checkMkdocs(path string) []string {
+	navItem := path + ".md"
+	if slices.Contains(l.navItems, navItem) {
+		return nil
+	}
+	return []string{fmt.Sprintf("missing nav item: add %q to mkdocs.yml, and its docs/%s page", navItem, navItem)}
+}
+
+func (l *linter) checkGoMod(path string) []string {
+	goModFile := filepath.Join(l.ctx.RootDir, path, "go.mod")
+	content, err := os.ReadFile(goModFile)
+	if err != nil {
+		return []string{fmt.Sprintf("could not read %s/go.mod: %v", path, err)}
+	}
+
+	file, err := modfile.Parse(goModFile, content, nil)
+	if err != nil {
+		return []string{fmt.Sprintf("could not parse %s/go.mod: %v", path, err)}
+	}
+
+	msgs := []string{}
+	for _, r := range file.Require {
+		if r.Mod.Path != tcModulePath && !strings.HasPrefix(r.Mod.Path, tcModulePath+"/modules/") {
+			continue
+		}
+		if r.Mod.Version != l.tcVersion {
+			msgs = append(msgs, fmt.Sprintf("stale version of %s: %s, update it to %s, the latest_version in mkdocs.yml", r.Mod.Path, r.Mod.Version, l.tcVersion))
+		}
+	}
+	return msgs
+}
+
+func (l *linter) checkDependabot(path string) []string {
+	// the Go modules are not updated by dependabot, so there are no entries to keep in sync
+	if len(l.gomodDirs) == 0 {
+		return nil
+	}
+
+	if slices.Contains(l.gomodDirs, "/"+path) {
+		return nil
+	}
+	return []string{fmt.Sprintf("missing gomod entry: add the /%s directory to .github/dependabot.yml", path)}
+}
+
+func (l *linter) checkEntrypoint(isModule bool, path string) []string {
+	funcs, err := topLevelFuncs(filepath.Join(l.ctx.RootDir, path))
+	if err != nil {
+		return []string{fmt.Sprintf("could not parse the Go files: %v", err)}
+	}
+
+	re, expected := exampleEntrypointRegex, "an unexported function prefixed with run or start, e.g. runContainer"
+	if isModule {
+		re, expected = moduleEntrypointRegex, "an exported function named Run, or prefixed with Run, e.g. RunContainer"
+	}
+
+	for _, fn := range funcs {
+		if re.MatchString(fn) {
+			return nil
+		}
+	}
+	return []string{"no entrypoint found: declare " + expected}
+}
+
+// topLevelFuncs returns the names of the functions, without receiver, declared in the Go files
+// of the directory, excluding the tests
+func topLevelFuncs(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := []string{}
+	fset := token.NewFileSet()
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, f, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs = append(funcs, fn.Name.Name)
+			}
+		}
+	}
+	return funcs, nil
+}
+
+func readYaml(file string, v any) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(content, v)
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
 
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
@@ -11,6 +12,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/sonar"
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/tools"
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/vscode"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/wizard"
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/workflow"
 )
 
@@ -21,13 +23,65 @@ func Generate(moduleVar context.TestcontainersModuleVar, isModule bool) error {
 	}
 
 	tcModule := context.TestcontainersModule{
-		Image:     moduleVar.Image,
-		IsModule:  isModule,
-		Name:      moduleVar.Name,
-		TitleName: moduleVar.NameTitle,
+		Archs:          moduleVar.Archs,
+		Image:          moduleVar.Image,
+		IsModule:       isModule,
+		Name:           moduleVar.Name,
+		TitleName:      moduleVar.NameTitle,
+		Ports:          moduleVar.Ports,
+		TCVersion:      moduleVar.TCVersion,
+		TemplatesDir:   moduleVar.TemplatesDir,
+		WaitStrategy:   moduleVar.WaitStrategy,
+		WithBenchmarks: moduleVar.WithBenchmarks,
+		WithExamples:   moduleVar.WithExamples,
 	}
 
-	err = GenerateFiles(ctx, tcModule)
+	if tcModule.TCVersion != "" {
+		if err := tcModule.Validate(); err != nil {
+			return err
+		}
+
+		// the version must exist, as the go.mod file of the module requires it
+		if err := tools.GoListModule(ctx.RootDir, tools.TestcontainersModulePath+"@"+tcModule.TCVersion); err != nil {
+			return fmt.Errorf(">> the version %s of testcontainers-go does not exist: %w", tcModule.TCVersion, err)
+		}
+	}
+
+	return GenerateModule(ctx, tcModule)
+}
+
+// GenerateWizard asks for the values of the new module or example in an interactive way,
+// previewing the files to be generated and asking for confirmation before generating them.
+func GenerateWizard(in io.Reader, out io.Writer) error {
+	ctx, err := context.GetRootContext()
+	if err != nil {
+		return fmt.Errorf(">> could not get the root dir: %w", err)
+	}
+
+	w := wizard.New(in, out)
+
+	tcModule, err := w.Run()
+	if err != nil {
+		return err
+	}
+
+	created, updated := Files(ctx, tcModule)
+	ok, err := w.Confirm(created, updated)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		fmt.Fprintln(out, "Aborted, no files were generated.")
+		return nil
+	}
+
+	return GenerateModule(ctx, tcModule)
+}
+
+// GenerateModule generates the files for the module or example, running the lint tools on the generated code.
+func GenerateModule(ctx context.Context, tcModule context.TestcontainersModule) error {
+	err := GenerateFiles(ctx, tcModule)
 	if err != nil {
 		return fmt.Errorf(">> error generating the module: %w", err)
 	}
@@ -60,6 +114,39 @@ type FileGenerator interface {
 	AddModule(context.Context, context.TestcontainersModule) error
 }
 
+// Files returns the paths of the files to be created and updated when generating the module or example,
+// relative to the root dir.
+func Files(ctx context.Context, tcModule context.TestcontainersModule) ([]string, []string) {
+	moduleDir := filepath.Join(tcModule.ParentDir(), tcModule.Lower())
+
+	created := []string{
+		filepath.Join(moduleDir, tcModule.Lower()+".go"),
+		filepath.Join(moduleDir, tcModule.Lower()+"_test.go"),
+	}
+	if tcModule.WithBenchmarks {
+		created = append(created, filepath.Join(moduleDir, tcModule.Lower()+"_bench_test.go"))
+	}
+	if tcModule.HasExamples() {
+		created = append(created, filepath.Join(moduleDir, "examples_test.go"))
+	}
+	created = append(created,
+		filepath.Join(moduleDir, "go.mod"),
+		filepath.Join(moduleDir, "Makefile"),
+		filepath.Join("docs", tcModule.ParentDir(), tcModule.Lower()+".md"),
+	)
+
+	updated := []string{}
+	for _, f := range []string{ctx.MkdocsConfigFile(), filepath.Join(ctx.GithubWorkflowsDir(), "ci.yml"), ctx.VSCodeWorkspaceFile(), ctx.SonarProjectFile()} {
+		rel, err := filepath.Rel(ctx.RootDir, f)
+		if err != nil {
+			rel = f
+		}
+		updated = append(updated, rel)
+	}
+
+	return created, updated
+}
+
 func GenerateFiles(ctx context.Context, tcModule context.TestcontainersModule) error {
 	if err := tcModule.Validate(); err != nil {
 		return err
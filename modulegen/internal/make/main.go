@@ -2,6 +2,7 @@ package make
 
 import (
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
@@ -13,31 +14,29 @@ type Generator struct{}
 // AddModule update Makefile with the new module
 func (g Generator) AddModule(ctx context.Context, tcModule context.TestcontainersModule) error {
 	moduleDir := filepath.Join(ctx.RootDir, tcModule.ParentDir(), tcModule.Lower())
-	moduleName := tcModule.Lower()
 
 	name := "Makefile.tmpl"
-	t, err := template.New(name).ParseFiles(filepath.Join("_template", name))
+	t, err := template.New(name).Funcs(template.FuncMap{"join": strings.Join}).ParseFiles(internal_template.Path(tcModule.TemplatesDir, name))
 	if err != nil {
 		return err
 	}
 
 	moduleFilePath := filepath.Join(moduleDir, "Makefile")
 
-	return internal_template.GenerateFile(t, moduleFilePath, name, moduleName)
+	return internal_template.GenerateFile(t, moduleFilePath, name, &tcModule)
 }
 
 // creates Makefile for example
 func GenerateMakefile(ctx context.Context, tcModule context.TestcontainersModule) error {
 	moduleDir := filepath.Join(ctx.RootDir, tcModule.ParentDir(), tcModule.Lower())
-	moduleName := tcModule.Lower()
 
 	name := "Makefile.tmpl"
-	t, err := template.New(name).ParseFiles(filepath.Join("_template", name))
+	t, err := template.New(name).Funcs(template.FuncMap{"join": strings.Join}).ParseFiles(internal_template.Path(tcModule.TemplatesDir, name))
 	if err != nil {
 		return err
 	}
 
 	moduleFilePath := filepath.Join(moduleDir, "Makefile")
 
-	return internal_template.GenerateFile(t, moduleFilePath, name, moduleName)
+	return internal_template.GenerateFile(t, moduleFilePath, name, &tcModule)
 }
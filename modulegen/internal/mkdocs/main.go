@@ -19,7 +19,7 @@ func (g Generator) AddModule(ctx context.Context, tcModule context.Testcontainer
 		"ToLower":       tcModule.Lower,
 		"Title":         tcModule.Title,
 	}
-	err := GenerateMdFile(moduleMdFile, funcMap, tcModule)
+	err := GenerateMdFile(moduleMdFile, tcModule.TemplatesDir, funcMap, tcModule)
 	if err != nil {
 		return err
 	}
@@ -1,15 +1,16 @@
 package mkdocs
 
 import (
-	"path/filepath"
 	"text/template"
 
 	internal_template "github.com/testcontainers/testcontainers-go/modulegen/internal/template"
 )
 
-func GenerateMdFile(filePath string, funcMap template.FuncMap, example any) error {
+// GenerateMdFile generates the docs of the module from the module.md.tmpl template,
+// which is overridden by the one in the templates dir, if any.
+func GenerateMdFile(filePath string, templatesDir string, funcMap template.FuncMap, example any) error {
 	name := "module.md.tmpl"
-	t, err := template.New(name).Funcs(funcMap).ParseFiles(filepath.Join("_template", name))
+	t, err := template.New(name).Funcs(funcMap).ParseFiles(internal_template.Path(templatesDir, name))
 	if err != nil {
 		return err
 	}
@@ -28,10 +28,13 @@ func generateGoFiles(moduleDir string, tcModule context.TestcontainersModule) er
 	funcMap := template.FuncMap{
 		"Entrypoint":    tcModule.Entrypoint,
 		"ContainerName": tcModule.ContainerName,
+		"DefaultPort":   tcModule.DefaultPort,
+		"ExtraPorts":    tcModule.ExtraPorts,
 		"Image":         func() string { return tcModule.Image },
 		"ParentDir":     tcModule.ParentDir,
 		"ToLower":       tcModule.Lower,
 		"Title":         tcModule.Title,
+		"WaitStrategy":  tcModule.GetWaitStrategy,
 	}
 	return GenerateFiles(moduleDir, tcModule.Lower(), funcMap, tcModule)
 }
@@ -49,6 +52,9 @@ func generateGoModFile(moduleDir string, tcModule context.TestcontainersModule)
 	rootGoModFile := rootCtx.GoModFile()
 	directory := "/" + tcModule.ParentDir() + "/" + tcModule.Lower()
 	tcVersion := mkdocsConfig.Extra.LatestVersion
+	if tcModule.TCVersion != "" {
+		tcVersion = tcModule.TCVersion
+	}
 	return modfile.GenerateModFile(moduleDir, rootGoModFile, directory, tcVersion)
 }
 
@@ -56,13 +62,16 @@ func GenerateFiles(moduleDir string, moduleName string, funcMap template.FuncMap
 	templates := []string{"module_test.go", "module.go"}
 
 	tcModuleCtx := tcModule.(context.TestcontainersModule)
-	if tcModuleCtx.IsModule {
+	if tcModuleCtx.WithBenchmarks {
+		templates = append(templates, "module_bench_test.go")
+	}
+	if tcModuleCtx.HasExamples() {
 		templates = append(templates, "examples_test.go")
 	}
 
 	for _, tmpl := range templates {
 		name := tmpl + ".tmpl"
-		t, err := template.New(name).Funcs(funcMap).ParseFiles(filepath.Join("_template", name))
+		t, err := template.New(name).Funcs(funcMap).ParseFiles(internal_template.Path(tcModuleCtx.TemplatesDir, name))
 		if err != nil {
 			return err
 		}
@@ -7,6 +7,22 @@ import (
 	"text/template"
 )
 
+// BuiltinDir is the directory of the built-in templates, relative to the modulegen directory
+const BuiltinDir = "_template"
+
+// Path returns the path of the template with the given name: the one in the templates dir, if it's set
+// and it contains the template, otherwise the built-in one.
+func Path(templatesDir string, name string) string {
+	if templatesDir != "" {
+		path := filepath.Join(templatesDir, name)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path
+		}
+	}
+
+	return filepath.Join(BuiltinDir, name)
+}
+
 // Generate writes the template to the writer, interpolating the data.
 func Generate(t *template.Template, wr io.Writer, name string, data any) error {
 	err := t.ExecuteTemplate(wr, name, data)
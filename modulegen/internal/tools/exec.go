@@ -5,6 +5,18 @@ import (
 	"os/exec"
 )
 
+// TestcontainersModulePath is the path of the Go module of Testcontainers for Go
+const TestcontainersModulePath = "github.com/testcontainers/testcontainers-go"
+
+// GoListModule checks that a version of a Go module exists, in the module@version format,
+// resolving it with the Go module proxy or the module cache, as configured for the go command.
+func GoListModule(cmdDir string, moduleVersion string) error {
+	if err := runGoCommand(cmdDir, "list", "-m", moduleVersion); err != nil {
+		return fmt.Errorf(">> error resolving %s: %w", moduleVersion, err)
+	}
+	return nil
+}
+
 func GoModTidy(cmdDir string) error {
 	if err := runGoCommand(cmdDir, "mod", "tidy"); err != nil {
 		return fmt.Errorf(">> error synchronizing the dependencies: %w", err)
@@ -0,0 +1,207 @@
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+)
+
+// Wizard asks for the values of a new module or example in an interactive way,
+// reading the answers from the input and writing the questions to the output.
+type Wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New returns a new Wizard reading from in and writing to out
+func New(in io.Reader, out io.Writer) *Wizard {
+	return &Wizard{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Run asks for the type, name, title, image, ports, wait strategy, architectures and the optional tests of the new module or example,
+// asking again for the values that are not valid.
+func (w *Wizard) Run() (context.TestcontainersModule, error) {
+	tcModule := context.TestcontainersModule{}
+
+	moduleType, err := w.ask("Type (module or example)", "module", oneOf("module", "example"))
+	if err != nil {
+		return tcModule, err
+	}
+	tcModule.IsModule = moduleType == "module"
+
+	tcModule.Name, err = w.ask("Name", "", func(name string) error {
+		m := context.TestcontainersModule{Name: name, TitleName: name}
+		return m.Validate()
+	})
+	if err != nil {
+		return tcModule, err
+	}
+
+	title, err := w.ask("Title, used in case of mixed casing (Mongodb -> MongoDB)", tcModule.Title(), func(title string) error {
+		m := context.TestcontainersModule{Name: tcModule.Name, TitleName: title}
+		return m.Validate()
+	})
+	if err != nil {
+		return tcModule, err
+	}
+	tcModule.TitleName = title
+
+	tcModule.Image, err = w.ask("Fully-qualified name of the Docker image", "", func(image string) error {
+		if strings.ContainsAny(image, " \t") {
+			return fmt.Errorf("invalid image: %s", image)
+		}
+		return nil
+	})
+	if err != nil {
+		return tcModule, err
+	}
+
+	ports, err := w.askOptional("Ports exposed by the container, separated by commas. The first one is the default port. Leave it empty to expose the ports of the image", func(ports string) error {
+		m := context.TestcontainersModule{Name: tcModule.Name, TitleName: title, Ports: splitList(ports)}
+		return m.Validate()
+	})
+	if err != nil {
+		return tcModule, err
+	}
+	tcModule.Ports = splitList(ports)
+
+	tcModule.WaitStrategy, err = w.ask("Wait strategy ("+strings.Join(context.WaitStrategies, ", ")+")", context.WaitStrategyHealthCheck, func(waitStrategy string) error {
+		m := context.TestcontainersModule{Name: tcModule.Name, TitleName: title, Ports: tcModule.Ports, WaitStrategy: waitStrategy}
+		return m.Validate()
+	})
+	if err != nil {
+		return tcModule, err
+	}
+
+	archs, err := w.ask("Architectures the tests run on, separated by commas", strings.Join(context.Archs, ", "), func(archs string) error {
+		m := context.TestcontainersModule{Name: tcModule.Name, TitleName: title, Archs: splitList(archs)}
+		return m.Validate()
+	})
+	if err != nil {
+		return tcModule, err
+	}
+	tcModule.Archs = splitList(archs)
+
+	tcModule.WithBenchmarks, err = w.askYesNo("Generate a benchmark test skeleton? (yes or no)", false)
+	if err != nil {
+		return tcModule, err
+	}
+
+	// the testable examples are always generated for a module
+	if !tcModule.IsModule {
+		tcModule.WithExamples, err = w.askYesNo("Generate the testable examples? (yes or no)", false)
+		if err != nil {
+			return tcModule, err
+		}
+	}
+
+	return tcModule, nil
+}
+
+// Confirm prints the files that are going to be created and updated, asking for confirmation before writing them
+func (w *Wizard) Confirm(created []string, updated []string) (bool, error) {
+	fmt.Fprintln(w.out, "The following files will be created:")
+	for _, f := range created {
+		fmt.Fprintln(w.out, "  +", f)
+	}
+
+	fmt.Fprintln(w.out, "The following files will be updated:")
+	for _, f := range updated {
+		fmt.Fprintln(w.out, "  ~", f)
+	}
+
+	return w.askYesNo("Proceed? (yes or no)", true)
+}
+
+// askYesNo asks a yes or no question, returning true if the answer is yes
+func (w *Wizard) askYesNo(question string, defaultValue bool) (bool, error) {
+	def := "no"
+	if defaultValue {
+		def = "yes"
+	}
+
+	answer, err := w.ask(question, def, oneOf("yes", "y", "no", "n"))
+	if err != nil {
+		return false, err
+	}
+
+	return answer == "yes" || answer == "y", nil
+}
+
+// ask writes the question to the output and reads the answer from the input, returning the default value
+// if the answer is empty. The question is asked again until the answer passes the validation.
+func (w *Wizard) ask(question string, defaultValue string, validate func(string) error) (string, error) {
+	return w.read(question, defaultValue, true, validate)
+}
+
+// askOptional is like ask, but an empty answer is valid and returned as is
+func (w *Wizard) askOptional(question string, validate func(string) error) (string, error) {
+	return w.read(question, "", false, validate)
+}
+
+func (w *Wizard) read(question string, defaultValue string, required bool, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", question)
+		}
+
+		line, err := w.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", fmt.Errorf("could not read the answer to %q: %w", question, err)
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		if answer == "" {
+			if !required {
+				return "", nil
+			}
+
+			fmt.Fprintln(w.out, ">> a value is required")
+			continue
+		}
+
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(w.out, ">> %v\n", err)
+			continue
+		}
+
+		return answer, nil
+	}
+}
+
+// oneOf returns a validation function that checks the value is one of the allowed values
+func oneOf(values ...string) func(string) error {
+	return func(value string) error {
+		if !slices.Contains(values, value) {
+			return fmt.Errorf("invalid value: %s. Only %s are allowed", value, strings.Join(values, ", "))
+		}
+		return nil
+	}
+}
+
+// splitPorts splits a comma-separated list of ports, removing the empty values
+func splitList(ports string) []string {
+	result := []string{}
+	for _, port := range strings.Split(ports, ",") {
+		port = strings.TrimSpace(port)
+		if port != "" {
+			result = append(result, port)
+		}
+	}
+
+	return result
+}
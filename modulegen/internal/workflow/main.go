@@ -1,13 +1,22 @@
 package workflow
 
 import (
+	"bytes"
+	"fmt"
+	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"text/template"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
 	internal_template "github.com/testcontainers/testcontainers-go/modulegen/internal/template"
 )
 
+const templateName = "ci.yml.tmpl"
+
 type Generator struct{}
 
 // Generate updates github ci workflow
@@ -16,25 +25,114 @@ func (g Generator) Generate(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	examples, err := rootCtx.GetExamples()
+
+	projectDirectories, err := readProjectDirectories(rootCtx)
 	if err != nil {
 		return err
 	}
-	modules, err := rootCtx.GetModules()
+
+	t, err := template.New(templateName).ParseFiles(filepath.Join("_template", templateName))
 	if err != nil {
 		return err
 	}
 
-	githubWorkflowsDir := ctx.GithubWorkflowsDir()
+	exampleFilePath := filepath.Join(ctx.GithubWorkflowsDir(), "ci.yml")
+
+	return internal_template.GenerateFile(t, exampleFilePath, templateName, projectDirectories)
+}
 
-	projectDirectories := newProjectDirectories(examples, modules)
-	name := "ci.yml.tmpl"
-	t, err := template.New(name).ParseFiles(filepath.Join("_template", name))
+// Verify checks the CI workflow for drift from the one generated for the modules and examples of the context,
+// returning the issues found: the missing, unexpected and modified jobs, or the modified content out of them.
+func (g Generator) Verify(ctx context.Context) ([]string, error) {
+	projectDirectories, err := readProjectDirectories(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	t, err := template.New(templateName).ParseFiles(filepath.Join("_template", templateName))
+	if err != nil {
+		return nil, err
+	}
+
+	expected := bytes.Buffer{}
+	if err := internal_template.Generate(t, &expected, templateName, projectDirectories); err != nil {
+		return nil, err
+	}
+
+	actual, err := os.ReadFile(filepath.Join(ctx.GithubWorkflowsDir(), "ci.yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(expected.Bytes(), actual) {
+		return nil, nil
+	}
+
+	return diffJobs(expected.Bytes(), actual)
+}
+
+// readProjectDirectories reads the modules and examples of the context, with the architectures of the modules
+func readProjectDirectories(ctx context.Context) (*ProjectDirectories, error) {
+	examples, err := ctx.GetExamples()
+	if err != nil {
+		return nil, err
+	}
+	modules, err := ctx.GetModules()
+	if err != nil {
+		return nil, err
+	}
+
+	moduleArchs := map[string][]string{}
+	for _, module := range modules {
+		archs, err := ctx.GetModuleArchs(module)
+		if err != nil {
+			return nil, err
+		}
+		moduleArchs[module] = archs
+	}
+
+	return newProjectDirectories(examples, modules, moduleArchs), nil
+}
+
+type ciWorkflow struct {
+	Jobs map[string]any `yaml:"jobs"`
+}
+
+// diffJobs returns the sorted differences between the jobs of the expected and the actual workflows
+func diffJobs(expected []byte, actual []byte) ([]string, error) {
+	expectedWorkflow := ciWorkflow{}
+	if err := yaml.Unmarshal(expected, &expectedWorkflow); err != nil {
+		return nil, fmt.Errorf("could not parse the generated CI workflow: %w", err)
+	}
+
+	actualWorkflow := ciWorkflow{}
+	if err := yaml.Unmarshal(actual, &actualWorkflow); err != nil {
+		return nil, fmt.Errorf("could not parse the CI workflow: %w", err)
+	}
+
+	issues := []string{}
+	for job, expectedJob := range expectedWorkflow.Jobs {
+		actualJob, ok := actualWorkflow.Jobs[job]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("missing job %s", job))
+			continue
+		}
+
+		if !reflect.DeepEqual(expectedJob, actualJob) {
+			issues = append(issues, fmt.Sprintf("modified job %s", job))
+		}
 	}
 
-	exampleFilePath := filepath.Join(githubWorkflowsDir, "ci.yml")
+	for job := range actualWorkflow.Jobs {
+		if _, ok := expectedWorkflow.Jobs[job]; !ok {
+			issues = append(issues, fmt.Sprintf("unexpected job %s", job))
+		}
+	}
+	sort.Strings(issues)
+
+	if len(issues) == 0 {
+		issues = append(issues, "modified content out of the jobs, e.g. the triggers or the comments")
+	}
 
-	return internal_template.GenerateFile(t, exampleFilePath, name, projectDirectories)
+	return issues, nil
 }
@@ -2,16 +2,47 @@ package workflow
 
 import (
 	"strings"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
 )
 
+// ProjectDirectories represents the modules and examples of the CI workflow, each one with its own job
 type ProjectDirectories struct {
-	Examples string
-	Modules  string
+	Examples []ProjectDirectory
+	Modules  []ProjectDirectory
+}
+
+// ProjectDirectory represents a module or an example, tested by its job in the CI workflow
+type ProjectDirectory struct {
+	Dir   string // the path of the module, relative to the root dir, e.g. modules/redis
+	Archs string // the JSON array of the architectures its tests run on, e.g. ["amd64", "arm64"]
 }
 
-func newProjectDirectories(examples []string, modules []string) *ProjectDirectories {
-	return &ProjectDirectories{
-		Examples: strings.Join(examples, ", "),
-		Modules:  strings.Join(modules, ", "),
+// Job returns the ID of the job testing the module in the CI workflow, e.g. test-modules-redis
+func (p ProjectDirectory) Job() string {
+	return "test-" + strings.ReplaceAll(p.Dir, "/", "-")
+}
+
+func newProjectDirectories(examples []string, modules []string, moduleArchs map[string][]string) *ProjectDirectories {
+	dirs := &ProjectDirectories{}
+
+	for _, example := range examples {
+		dirs.Examples = append(dirs.Examples, ProjectDirectory{Dir: "examples/" + example, Archs: archsJSON([]string{context.ArchAMD64})})
 	}
+
+	for _, module := range modules {
+		dirs.Modules = append(dirs.Modules, ProjectDirectory{Dir: "modules/" + module, Archs: archsJSON(moduleArchs[module])})
+	}
+
+	return dirs
+}
+
+// archsJSON returns the architectures as a JSON array, e.g. ["amd64", "arm64"]
+func archsJSON(archs []string) string {
+	quoted := make([]string, 0, len(archs))
+	for _, arch := range archs {
+		quoted = append(quoted, `"`+arch+`"`)
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/lint"
+)
+
+func TestLint(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+
+	writeFile := func(path string, content string) {
+		file := filepath.Join(tmpCtx.RootDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o777))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+
+	writeFile("mkdocs.yml", `nav:
+  - Home: index.md
+  - Quickstart: quickstart.md
+  - Features:
+      - features/creating_container.md
+  - Modules:
+      - modules/index.md
+      - modules/foodb.md
+  - Examples:
+      - examples/index.md
+      - examples/bar.md
+extra:
+  latest_version: v0.30.0
+`)
+	writeFile(".github/workflows/ci.yml", `jobs:
+  test-modules-foodb:
+    with:
+      project-directory: "modules/foodb"
+  test-examples-bar:
+    with:
+      project-directory: "examples/bar"
+`)
+	writeFile(".github/dependabot.yml", `version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /modules/foodb
+  - package-ecosystem: gomod
+    directory: /examples/bar
+`)
+
+	writeModule := func(path string, tcVersion string, code string) {
+		writeFile(path+"/go.mod", "module github.com/testcontainers/testcontainers-go/"+path+"\n\ngo 1.21\n\nrequire github.com/testcontainers/testcontainers-go "+tcVersion+"\n")
+		writeFile(path+"/"+filepath.Base(path)+".go", "package "+filepath.Base(path)+"\n\n"+code)
+		writeFile(path+"/"+filepath.Base(path)+"_test.go", "package "+filepath.Base(path)+"\n\nfunc RunContainer() {}\n")
+	}
+
+	writeModule("modules/foodb", "v0.30.0", "func RunContainer() {}\n")
+	writeModule("examples/bar", "v0.30.0", "func startContainer() {}\n")
+
+	t.Run("no-issues", func(t *testing.T) {
+		report, err := lint.Lint(tmpCtx)
+		require.NoError(t, err)
+		assert.Empty(t, report)
+	})
+
+	// the entrypoint of the test file is ignored, and the exported function is not prefixed with Run
+	writeModule("modules/bazdb", "v0.29.1", "type Container struct{}\n\nfunc (c *Container) RunQuery() {}\n\nfunc StartContainer() {}\n")
+
+	t.Run("drifted-module", func(t *testing.T) {
+		report, err := lint.Lint(tmpCtx)
+		require.NoError(t, err)
+		require.Len(t, report, 1)
+
+		assert.Equal(t, "modules/bazdb", report[0].Path)
+
+		checks := []string{}
+		for _, issue := range report[0].Issues {
+			checks = append(checks, issue.Check)
+		}
+		assert.Equal(t, []string{lint.CheckWorkflow, lint.CheckMkdocs, lint.CheckGoMod, lint.CheckDependabot, lint.CheckEntrypoint}, checks)
+
+		assert.Contains(t, report.String(), "modules/bazdb:\n")
+		assert.Contains(t, report.String(), "[go.mod] stale version of github.com/testcontainers/testcontainers-go: v0.29.1, update it to v0.30.0")
+	})
+
+	t.Run("without-gomod-updates", func(t *testing.T) {
+		writeFile(".github/dependabot.yml", "version: 2\nupdates:\n  - package-ecosystem: github-actions\n    directory: /\n")
+
+		report, err := lint.Lint(tmpCtx)
+		require.NoError(t, err)
+		require.Len(t, report, 1)
+
+		for _, issue := range report[0].Issues {
+			assert.NotEqual(t, lint.CheckDependabot, issue.Check)
+		}
+	})
+
+	t.Run("compose-exemptions", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(filepath.Join(tmpCtx.RootDir, "modules", "bazdb")))
+		writeFile(".github/workflows/ci.yml", "jobs:\n  test-modules-compose:\n    with:\n      project-directory: modules/compose\n  test-modules-foodb:\n    with:\n      project-directory: modules/foodb\n  test-examples-bar:\n    with:\n      project-directory: examples/bar\n")
+		writeModule("modules/compose", "v0.30.0", "func NewDockerCompose() {}\n")
+
+		report, err := lint.Lint(tmpCtx)
+		require.NoError(t, err)
+		assert.Empty(t, report)
+	})
+}
+
+func TestLint_Repository(t *testing.T) {
+	report, err := lint.Lint(getTestRootContext(t))
+	require.NoError(t, err)
+	assert.Empty(t, report, report.String())
+}
@@ -83,6 +83,41 @@ func TestModule(t *testing.T) {
 	}
 }
 
+func TestModule_Ports(t *testing.T) {
+	t.Run("no-ports", func(t *testing.T) {
+		module := context.TestcontainersModule{}
+
+		assert.Empty(t, module.DefaultPort())
+		assert.Empty(t, module.ExtraPorts())
+		assert.Equal(t, context.WaitStrategyHealthCheck, module.GetWaitStrategy())
+	})
+
+	t.Run("ports", func(t *testing.T) {
+		module := context.TestcontainersModule{
+			Ports:        []string{"5432", "8080/tcp", "9090/udp"},
+			WaitStrategy: context.WaitStrategyLog,
+		}
+
+		assert.Equal(t, "5432/tcp", module.DefaultPort())
+		assert.Equal(t, []string{"8080/tcp", "9090/udp"}, module.ExtraPorts())
+		assert.Equal(t, context.WaitStrategyLog, module.GetWaitStrategy())
+	})
+}
+
+func TestModule_Archs(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		module := context.TestcontainersModule{}
+
+		assert.Equal(t, []string{"amd64", "arm64"}, module.GetArchs())
+	})
+
+	t.Run("opt-out", func(t *testing.T) {
+		module := context.TestcontainersModule{Archs: []string{"amd64"}}
+
+		assert.Equal(t, []string{"amd64"}, module.GetArchs())
+	})
+}
+
 func TestModule_Validate(outer *testing.T) {
 	outer.Parallel()
 
@@ -144,6 +179,109 @@ func TestModule_Validate(outer *testing.T) {
 			},
 			expectedErr: errors.New("invalid title: 1AmazingDB. Only alphanumerical characters are allowed (leading character must be a letter)"),
 		},
+		{
+			name: "ports with and without protocol",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				Ports:     []string{"8080", "9090/udp"},
+			},
+		},
+		{
+			name: "invalid port",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				Ports:     []string{"http"},
+			},
+			expectedErr: errors.New("invalid port: http. Only numbers are allowed, optionally followed by the protocol (8080/tcp)"),
+		},
+		{
+			name: "invalid wait strategy",
+			module: context.TestcontainersModule{
+				Name:         "AmazingDB",
+				TitleName:    "AmazingDB",
+				WaitStrategy: "sql",
+			},
+			expectedErr: errors.New("invalid wait strategy: sql. Only healthcheck, http, log, port are allowed"),
+		},
+		{
+			name: "wait strategy without ports",
+			module: context.TestcontainersModule{
+				Name:         "AmazingDB",
+				TitleName:    "AmazingDB",
+				WaitStrategy: context.WaitStrategyPort,
+			},
+			expectedErr: errors.New("the port wait strategy requires the ports exposed by the container"),
+		},
+		{
+			name: "single architecture",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				Archs:     []string{"amd64"},
+			},
+		},
+		{
+			name: "invalid architecture",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				Archs:     []string{"s390x"},
+			},
+			expectedErr: errors.New("invalid architecture: s390x. Only amd64, arm64 are allowed"),
+		},
+		{
+			name: "pinned testcontainers-go version",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				TCVersion: "v0.27.0",
+			},
+		},
+		{
+			name: "pre-release testcontainers-go version",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				TCVersion: "v0.31.0-rc.1",
+			},
+		},
+		{
+			name: "testcontainers-go version without the v prefix",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				TCVersion: "0.27.0",
+			},
+			expectedErr: errors.New("invalid testcontainers-go version: 0.27.0. Only semantic versions prefixed with v are allowed (v0.27.0)"),
+		},
+		{
+			name: "incomplete testcontainers-go version",
+			module: context.TestcontainersModule{
+				Name:      "AmazingDB",
+				TitleName: "AmazingDB",
+				TCVersion: "v0.27",
+			},
+			expectedErr: errors.New("invalid testcontainers-go version: v0.27. Only semantic versions prefixed with v are allowed (v0.27.0)"),
+		},
+		{
+			name: "existing templates dir",
+			module: context.TestcontainersModule{
+				Name:         "AmazingDB",
+				TitleName:    "AmazingDB",
+				TemplatesDir: "_template",
+			},
+		},
+		{
+			name: "missing templates dir",
+			module: context.TestcontainersModule{
+				Name:         "AmazingDB",
+				TitleName:    "AmazingDB",
+				TemplatesDir: "_missing",
+			},
+			expectedErr: errors.New("invalid templates dir: _missing. It must be an existing directory"),
+		},
 	}
 
 	for _, test := range tests {
@@ -355,6 +493,150 @@ func TestGenerateModule(t *testing.T) {
 	assertMkdocsNavItems(t, module, originalConfig, tmpCtx)
 }
 
+func TestGenerateModule_Ports(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+	modulesTmp := filepath.Join(tmpCtx.RootDir, "modules")
+
+	require.NoError(t, os.MkdirAll(modulesTmp, 0o777))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpCtx.DocsDir(), "modules"), 0o777))
+	require.NoError(t, os.MkdirAll(tmpCtx.GithubWorkflowsDir(), 0o777))
+	require.NoError(t, copyInitialMkdocsConfig(t, tmpCtx))
+
+	module := context.TestcontainersModule{
+		Name:         "foodb",
+		TitleName:    "FooDB",
+		IsModule:     true,
+		Image:        "docker.io/example/foodb:latest",
+		Ports:        []string{"5432", "9090/udp"},
+		WaitStrategy: context.WaitStrategyPort,
+	}
+
+	err := internal.GenerateFiles(tmpCtx, module)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(modulesTmp, module.Lower(), module.Lower()+".go"))
+	require.NoError(t, err)
+
+	data := sanitiseContent(content)
+	assert.Equal(t, "const defaultPort = \"5432/tcp\"", data[10])
+	assert.Equal(t, "\t\tExposedPorts: []string{defaultPort, \"9090/udp\"},", data[21])
+	assert.Equal(t, "\t\tWaitingFor:   wait.ForListeningPort(defaultPort),", data[22])
+	assert.Equal(t, "\treturn c.PortEndpoint(ctx, defaultPort, \"http\")", data[45])
+}
+
+func TestGenerateModule_TCVersion(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+	modulesTmp := filepath.Join(tmpCtx.RootDir, "modules")
+
+	require.NoError(t, os.MkdirAll(modulesTmp, 0o777))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpCtx.DocsDir(), "modules"), 0o777))
+	require.NoError(t, os.MkdirAll(tmpCtx.GithubWorkflowsDir(), 0o777))
+	require.NoError(t, copyInitialMkdocsConfig(t, tmpCtx))
+
+	module := context.TestcontainersModule{
+		Name:      "foodb",
+		TitleName: "FooDB",
+		IsModule:  true,
+		Image:     "docker.io/example/foodb:latest",
+		TCVersion: "v0.27.0",
+	}
+
+	err := internal.GenerateFiles(tmpCtx, module)
+	require.NoError(t, err)
+
+	assertGoModContent(t, module, "v0.27.0", filepath.Join(modulesTmp, module.Lower(), "go.mod"))
+}
+
+func TestGenerateModule_TemplatesDir(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+	modulesTmp := filepath.Join(tmpCtx.RootDir, "modules")
+
+	require.NoError(t, os.MkdirAll(modulesTmp, 0o777))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpCtx.DocsDir(), "modules"), 0o777))
+	require.NoError(t, os.MkdirAll(tmpCtx.GithubWorkflowsDir(), 0o777))
+	require.NoError(t, copyInitialMkdocsConfig(t, tmpCtx))
+
+	originalConfig, err := mkdocs.ReadConfig(tmpCtx.MkdocsConfigFile())
+	require.NoError(t, err)
+
+	// the templates not in the templates dir fall back to the built-in ones
+	templatesDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "module.go.tmpl"), []byte("// Copyright Acme\npackage {{ ToLower }}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "Makefile.tmpl"), []byte("include ../../commons-test.mk\n\nacme-{{ .Lower }}:\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "module.md.tmpl"), []byte("# {{ Title }} by Acme\n"), 0o644))
+
+	module := context.TestcontainersModule{
+		Name:         "foodb",
+		TitleName:    "FooDB",
+		IsModule:     true,
+		Image:        "docker.io/example/foodb:latest",
+		TemplatesDir: templatesDir,
+	}
+
+	err = internal.GenerateFiles(tmpCtx, module)
+	require.NoError(t, err)
+
+	moduleDir := filepath.Join(modulesTmp, module.Lower())
+
+	content, err := os.ReadFile(filepath.Join(moduleDir, module.Lower()+".go"))
+	require.NoError(t, err)
+	assert.Equal(t, "// Copyright Acme\npackage foodb\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(moduleDir, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, "include ../../commons-test.mk\n\nacme-foodb:\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(tmpCtx.DocsDir(), "modules", module.Lower()+".md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# FooDB by Acme\n", string(content))
+
+	assertModuleTestContent(t, module, filepath.Join(moduleDir, module.Lower()+"_test.go"))
+	assertExamplesTestContent(t, module, filepath.Join(moduleDir, "examples_test.go"))
+
+	// the orchestration keeps updating the project files
+	assertMkdocsNavItems(t, module, originalConfig, tmpCtx)
+	assertModuleGithubWorkflowContent(t, filepath.Join(tmpCtx.GithubWorkflowsDir(), "ci.yml"))
+}
+
+func TestGenerate_WithBenchmarksAndExamples(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+	examplesTmp := filepath.Join(tmpCtx.RootDir, "examples")
+
+	require.NoError(t, os.MkdirAll(examplesTmp, 0o777))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpCtx.DocsDir(), "examples"), 0o777))
+	require.NoError(t, os.MkdirAll(tmpCtx.GithubWorkflowsDir(), 0o777))
+	require.NoError(t, copyInitialMkdocsConfig(t, tmpCtx))
+
+	module := context.TestcontainersModule{
+		Name:           "foodb",
+		TitleName:      "FooDB",
+		IsModule:       false,
+		Image:          "docker.io/example/foodb:latest",
+		WithBenchmarks: true,
+		WithExamples:   true,
+	}
+
+	err := internal.GenerateFiles(tmpCtx, module)
+	require.NoError(t, err)
+
+	generatedTemplatesDir := filepath.Join(examplesTmp, module.Lower())
+
+	content, err := os.ReadFile(filepath.Join(generatedTemplatesDir, module.Lower()+"_bench_test.go"))
+	require.NoError(t, err)
+
+	data := sanitiseContent(content)
+	assert.Equal(t, "package "+module.Lower()+"_test", data[0])
+	assert.Equal(t, "func Benchmark"+module.Title()+"(b *testing.B) {", data[12])
+	assert.Equal(t, "		container, err := "+module.Lower()+"."+module.Entrypoint()+"(ctx, testcontainers.WithImage(\""+module.Image+"\"))", data[16])
+
+	_, err = os.Stat(filepath.Join(generatedTemplatesDir, "examples_test.go"))
+	require.NoError(t, err) // error nil implies the file exist
+
+	created, _ := internal.Files(tmpCtx, module)
+	assert.Contains(t, created, filepath.Join("examples", module.Lower(), module.Lower()+"_bench_test.go"))
+	assert.Contains(t, created, filepath.Join("examples", module.Lower(), "examples_test.go"))
+}
+
 // assert content module file in the docs
 func assertModuleDocContent(t *testing.T, module context.TestcontainersModule, moduleDocFile string) {
 	content, err := os.ReadFile(moduleDocFile)
@@ -377,6 +659,7 @@ func assertModuleDocContent(t *testing.T, module context.TestcontainersModule, m
 	assert.Equal(t, data[24], "The "+title+" module exposes one entrypoint function to create the "+title+" container, and this function receives two parameters:")
 	assert.True(t, strings.HasSuffix(data[27], "(*"+title+"Container, error)"))
 	assert.Equal(t, "for "+title+". E.g. `testcontainers.WithImage(\""+module.Image+"\")`.", data[40])
+	assert.Equal(t, "#### ConnectionString", data[48])
 }
 
 // assert content module test
@@ -423,12 +706,15 @@ func assertModuleContent(t *testing.T, module context.TestcontainersModule, exam
 
 	data := sanitiseContent(content)
 	assert.Equal(t, data[0], "package "+lower)
-	assert.Equal(t, data[8], "// "+containerName+" represents the "+exampleName+" container type used in the module")
-	assert.Equal(t, data[9], "type "+containerName+" struct {")
-	assert.Equal(t, data[13], "// "+entrypoint+" creates an instance of the "+exampleName+" container type")
-	assert.Equal(t, data[14], "func "+entrypoint+"(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*"+containerName+", error) {")
-	assert.Equal(t, data[16], "\t\tImage: \""+module.Image+"\",")
-	assert.Equal(t, data[33], "\treturn &"+containerName+"{Container: container}, nil")
+	assert.Equal(t, data[9], "// "+containerName+" represents the "+exampleName+" container type used in the module")
+	assert.Equal(t, data[10], "type "+containerName+" struct {")
+	assert.Equal(t, data[14], "// "+entrypoint+" creates an instance of the "+exampleName+" container type")
+	assert.Equal(t, data[15], "func "+entrypoint+"(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*"+containerName+", error) {")
+	assert.Equal(t, data[17], "\t\tImage: \""+module.Image+"\",")
+	assert.Equal(t, data[20], "\t\tWaitingFor: wait.ForHealthCheck(),")
+	assert.Equal(t, data[37], "\treturn &"+containerName+"{Container: container}, nil")
+	assert.Equal(t, data[42], "func (c *"+containerName+") ConnectionString(ctx context.Context) (string, error) {")
+	assert.Equal(t, data[43], "\treturn c.Endpoint(ctx, \"http\")")
 }
 
 // assert content GitHub workflow for the module
@@ -436,16 +722,28 @@ func assertModuleGithubWorkflowContent(t *testing.T, moduleWorkflowFile string)
 	content, err := os.ReadFile(moduleWorkflowFile)
 	require.NoError(t, err)
 
-	data := sanitiseContent(content)
+	data := strings.Join(sanitiseContent(content), "\n")
 	ctx := getTestRootContext(t)
 
+	// each module has its own job, running on the architectures of its Makefile
 	modulesList, err := ctx.GetModules()
 	require.NoError(t, err)
-	assert.Equal(t, "        module: ["+strings.Join(modulesList, ", ")+"]", data[96])
+	for _, module := range modulesList {
+		archs, err := ctx.GetModuleArchs(module)
+		require.NoError(t, err)
+
+		assert.Contains(t, data, "  test-modules-"+module+":\n    needs: test\n    uses: ./.github/workflows/ci-test-module.yml\n"+
+			"    with:\n      project-directory: \"modules/"+module+"\"\n      archs: '[\""+strings.Join(archs, "\", \"")+"\"]'\n")
+		assert.Contains(t, data, "      - test-modules-"+module+"\n")
+	}
 
 	examplesList, err := ctx.GetExamples()
 	require.NoError(t, err)
-	assert.Equal(t, "        module: ["+strings.Join(examplesList, ", ")+"]", data[111])
+	for _, example := range examplesList {
+		assert.Contains(t, data, "  test-examples-"+example+":\n    needs: test\n    uses: ./.github/workflows/ci-test-module.yml\n"+
+			"    with:\n      project-directory: \"examples/"+example+"\"\n      archs: '[\"amd64\"]'\n")
+		assert.Contains(t, data, "      - test-examples-"+example+"\n")
+	}
 }
 
 // assert content go.mod
@@ -465,7 +763,8 @@ func assertMakefileContent(t *testing.T, module context.TestcontainersModule, ma
 	require.NoError(t, err)
 
 	data := sanitiseContent(content)
-	assert.Equal(t, data[4], "\t$(MAKE) test-"+module.Lower())
+	assert.Equal(t, data[4], "ARCHS := "+strings.Join(module.GetArchs(), " "))
+	assert.Equal(t, data[8], "\t$(MAKE) test-"+module.Lower())
 }
 
 // assert content in the nav items from mkdocs.yml
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/wizard"
+)
+
+func TestWizard_Run(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		in := strings.NewReader("\nfoodb\n\nfoodb:latest\n\n\n\n\n")
+		out := &bytes.Buffer{}
+
+		tcModule, err := wizard.New(in, out).Run()
+		require.NoError(t, err)
+
+		assert.Equal(t, context.TestcontainersModule{
+			IsModule:     true,
+			Name:         "foodb",
+			TitleName:    "Foodb",
+			Image:        "foodb:latest",
+			Ports:        []string{},
+			WaitStrategy: context.WaitStrategyHealthCheck,
+			Archs:        []string{"amd64", "arm64"},
+		}, tcModule)
+	})
+
+	t.Run("values", func(t *testing.T) {
+		in := strings.NewReader("example\nfoodb\nFooDB\nfoodb:1.0\n5432, 9090/udp\nport\namd64\nyes\ny\n")
+		out := &bytes.Buffer{}
+
+		tcModule, err := wizard.New(in, out).Run()
+		require.NoError(t, err)
+
+		assert.Equal(t, context.TestcontainersModule{
+			IsModule:       false,
+			Name:           "foodb",
+			TitleName:      "FooDB",
+			Image:          "foodb:1.0",
+			Ports:          []string{"5432", "9090/udp"},
+			WaitStrategy:   context.WaitStrategyPort,
+			Archs:          []string{"amd64"},
+			WithBenchmarks: true,
+			WithExamples:   true,
+		}, tcModule)
+	})
+
+	t.Run("asks-again-for-invalid-values", func(t *testing.T) {
+		in := strings.NewReader("library\nmodule\nfoo db\nfoodb\n\n\nfoodb:latest\nhttp\n8080\nsql\nlog\ns390x\narm64\nmaybe\nno\n")
+		out := &bytes.Buffer{}
+
+		tcModule, err := wizard.New(in, out).Run()
+		require.NoError(t, err)
+
+		assert.True(t, tcModule.IsModule)
+		assert.Equal(t, "foodb", tcModule.Name)
+		assert.Equal(t, []string{"8080"}, tcModule.Ports)
+		assert.Equal(t, context.WaitStrategyLog, tcModule.WaitStrategy)
+		assert.Equal(t, []string{"arm64"}, tcModule.Archs)
+
+		assert.Contains(t, out.String(), ">> invalid value: library. Only module, example are allowed")
+		assert.Contains(t, out.String(), ">> invalid name: foo db.")
+		assert.Contains(t, out.String(), ">> a value is required")
+		assert.Contains(t, out.String(), ">> invalid port: http.")
+		assert.Contains(t, out.String(), ">> invalid wait strategy: sql.")
+		assert.Contains(t, out.String(), ">> invalid architecture: s390x.")
+		assert.Contains(t, out.String(), ">> invalid value: maybe. Only yes, y, no, n are allowed")
+	})
+
+	t.Run("input-closed", func(t *testing.T) {
+		in := strings.NewReader("module\nfoodb\n")
+		out := &bytes.Buffer{}
+
+		_, err := wizard.New(in, out).Run()
+		require.Error(t, err)
+	})
+}
+
+func TestWizard_Confirm(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+	tcModule := context.TestcontainersModule{
+		IsModule:  true,
+		Name:      "foodb",
+		TitleName: "FooDB",
+		Image:     "foodb:latest",
+	}
+
+	created, updated := internal.Files(tmpCtx, tcModule)
+
+	t.Run("yes", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		ok, err := wizard.New(strings.NewReader("\n"), out).Confirm(created, updated)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		assert.Contains(t, out.String(), "  + modules/foodb/foodb.go\n")
+		assert.Contains(t, out.String(), "  + modules/foodb/examples_test.go\n")
+		assert.Contains(t, out.String(), "  + docs/modules/foodb.md\n")
+		assert.Contains(t, out.String(), "  ~ mkdocs.yml\n")
+		assert.Contains(t, out.String(), "  ~ .github/workflows/ci.yml\n")
+	})
+
+	t.Run("no", func(t *testing.T) {
+		ok, err := wizard.New(strings.NewReader("no\n"), &bytes.Buffer{}).Confirm(created, updated)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/workflow"
+)
+
+func TestVerifyWorkflows(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+
+	writeFile := func(path string, content string) {
+		file := filepath.Join(tmpCtx.RootDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o777))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+
+	writeFile("modules/foodb/Makefile", "include ../../commons-test.mk\n\nARCHS := amd64\n")
+	writeFile("modules/bazdb/Makefile", "include ../../commons-test.mk\n")
+	writeFile("examples/bar/Makefile", "include ../../commons-test.mk\n")
+	writeFile(".github/workflows/ci.yml", `jobs:
+  test:
+    uses: ./.github/workflows/ci-test-go.yml
+  test-modules-foodb:
+    needs: test
+    uses: ./.github/workflows/ci-test-module.yml
+    with:
+      project-directory: "modules/foodb"
+      archs: '["amd64", "arm64"]'
+  test-modules-olddb:
+    needs: test
+    uses: ./.github/workflows/ci-test-module.yml
+    with:
+      project-directory: "modules/olddb"
+`)
+
+	t.Run("drifted", func(t *testing.T) {
+		issues, err := workflow.Generator{}.Verify(tmpCtx)
+		require.NoError(t, err)
+
+		assert.Contains(t, issues, "missing job test-examples-bar")
+		assert.Contains(t, issues, "missing job test-modules-bazdb")
+		// the architectures of the Makefile are not the ones of the job
+		assert.Contains(t, issues, "modified job test-modules-foodb")
+		assert.Contains(t, issues, "unexpected job test-modules-olddb")
+	})
+
+	t.Run("repository", func(t *testing.T) {
+		issues, err := workflow.Generator{}.Verify(getTestRootContext(t))
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+}
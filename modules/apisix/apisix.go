@@ -0,0 +1,138 @@
+package apisix
+
+import (
+	"context"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage       = "apache/apisix:3.8.0-debian"
+	defaultProxyPort   = "9080/tcp"
+	defaultControlPort = "9090/tcp"
+
+	// configFile is the path of the APISIX config file in the container
+	configFile = "/usr/local/apisix/conf/config.yaml"
+
+	// routesFile is the path of the file defining the routes, upstreams and plugins
+	// when APISIX runs in standalone mode
+	routesFile = "/usr/local/apisix/conf/apisix.yaml"
+
+	// endMark is the mark APISIX needs at the end of the routes file to load it
+	endMark = "#END"
+)
+
+// config runs APISIX in standalone mode, reading the routes from a file instead of etcd,
+// and exposes the Control API
+const config = `apisix:
+  enable_control: true
+  control:
+    ip: "0.0.0.0"
+    port: 9090
+deployment:
+  role: data_plane
+  role_data_plane:
+    config_provider: yaml
+`
+
+// defaultRoutes is the routes file used when no routes file is set
+const defaultRoutes = "routes: []\n" + endMark + "\n"
+
+// APISIXContainer represents the APISIX container type used in the module
+type APISIXContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the APISIX container type, running APISIX in standalone mode,
+// so the routes, upstreams and plugins are defined by a YAML file instead of the Admin API
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*APISIXContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultProxyPort, defaultControlPort},
+		Files: []testcontainers.ContainerFile{
+			{
+				Reader:            strings.NewReader(config),
+				ContainerFilePath: configFile,
+				FileMode:          0o644,
+			},
+		},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(defaultProxyPort),
+			wait.ForHTTP("/v1/schema").WithPort(defaultControlPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	if !hasRoutesFile(genericContainerReq.Files) {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(defaultRoutes),
+			ContainerFilePath: routesFile,
+			FileMode:          0o644,
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APISIXContainer{Container: container}, nil
+}
+
+// WithRoutesFile loads the routes file (apisix.yaml) at startup, defining the routes, upstreams
+// and plugins of the gateway. As required by APISIX, the file must end with the "#END" line.
+func WithRoutesFile(routesFilePath string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      routesFilePath,
+			ContainerFilePath: routesFile,
+			FileMode:          0o644,
+		})
+	}
+}
+
+// ProxyURL returns the URL of the proxy, where the consumers send their requests,
+// with the format http://<host>:<port>
+func (c *APISIXContainer) ProxyURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultProxyPort, "http")
+}
+
+// ControlURL returns the URL of the Control API, which exposes the internal state of APISIX,
+// e.g. the loaded routes at /v1/routes, with the format http://<host>:<port>.
+// The Admin API is not available in standalone mode: use SetRoutes to modify the routes.
+func (c *APISIXContainer) ControlURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultControlPort, "http")
+}
+
+// SetRoutes replaces the routes file of the running gateway with the given content, so routes
+// and plugins can be added at test time. The "#END" line is added if missing. APISIX checks the
+// file for changes every second, so the new routes are not available immediately.
+func (c *APISIXContainer) SetRoutes(ctx context.Context, routes []byte) error {
+	content := strings.TrimRight(string(routes), "\n")
+	if !strings.HasSuffix(content, endMark) {
+		content += "\n" + endMark
+	}
+
+	return c.CopyToContainer(ctx, []byte(content+"\n"), routesFile, 0o644)
+}
+
+// hasRoutesFile returns true if the routes file is copied to the container
+func hasRoutesFile(files []testcontainers.ContainerFile) bool {
+	for _, f := range files {
+		if f.ContainerFilePath == routesFile {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package apisix_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/apisix"
+)
+
+func TestAPISIX(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := apisix.RunContainer(ctx, apisix.WithRoutesFile(filepath.Join("testdata", "apisix.yaml")))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// proxyURL {
+	proxyURL, err := container.ProxyURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	status, body := get(t, proxyURL+"/hello")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "hello", body)
+
+	controlURL, err := container.ControlURL(ctx)
+	require.NoError(t, err)
+
+	status, body = get(t, controlURL+"/v1/routes")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, `"/hello"`)
+}
+
+func TestAPISIX_noRoutes(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := apisix.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	proxyURL, err := container.ProxyURL(ctx)
+	require.NoError(t, err)
+
+	status, _ := get(t, proxyURL+"/hello")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPISIX_setRoutes(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := apisix.RunContainer(ctx, apisix.WithRoutesFile(filepath.Join("testdata", "apisix.yaml")))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// setRoutes {
+	routes, err := os.ReadFile(filepath.Join("testdata", "bye.yaml"))
+	require.NoError(t, err)
+
+	err = container.SetRoutes(ctx, routes)
+	// }
+	require.NoError(t, err)
+
+	proxyURL, err := container.ProxyURL(ctx)
+	require.NoError(t, err)
+
+	// APISIX reloads the routes file every second
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(proxyURL + "/bye")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		return err == nil && resp.StatusCode == http.StatusOK && string(body) == "bye"
+	}, 10*time.Second, 500*time.Millisecond)
+
+	// the routes are replaced, so the previous routes are removed
+	status, _ := get(t, proxyURL+"/hello")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
@@ -0,0 +1,42 @@
+package apisix_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/apisix"
+)
+
+func ExampleRunContainer() {
+	// runAPISIXContainer {
+	ctx := context.Background()
+
+	apisixContainer, err := apisix.RunContainer(ctx,
+		testcontainers.WithImage("apache/apisix:3.8.0-debian"),
+		apisix.WithRoutesFile(filepath.Join("testdata", "apisix.yaml")),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := apisixContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := apisixContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
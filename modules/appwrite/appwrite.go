@@ -0,0 +1,277 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/testcontainers/testcontainers-go/modules/compose"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	apiPort    = "80/tcp"
+	apiService = "appwrite"
+
+	// consoleProject is the ID of the project of the console, used to create the projects
+	consoleProject = "console"
+)
+
+//go:embed stack
+var stackFS embed.FS
+
+// AppwriteStack represents the Appwrite stack used in the module, with a project
+// and an API key to access it.
+type AppwriteStack struct {
+	compose.ComposeStack
+	dir       string
+	projectID string
+	apiKey    string
+}
+
+// Run starts an Appwrite stack, using Docker Compose, and bootstraps a project in it:
+// it signs up the console account, creates a team and the project, and an API key for the project.
+// The stack is removed if it fails to start.
+func Run(ctx context.Context, opts ...Option) (*AppwriteStack, error) {
+	settings := defaultOptions()
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	dir, err := os.MkdirTemp("", "testcontainers-appwrite-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating the stack directory: %w", err)
+	}
+
+	s := &AppwriteStack{
+		dir:       dir,
+		projectID: settings.projectID,
+	}
+
+	err = writeStack(dir, stackData{
+		Images:     settings.images,
+		OpenSSLKey: defaultOpenSSLKey,
+	})
+	if err != nil {
+		return nil, errors.Join(err, os.RemoveAll(dir))
+	}
+
+	stack, err := compose.NewDockerComposeWith(compose.WithStackFiles(filepath.Join(dir, "docker-compose.yml")))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error creating the stack: %w", err), os.RemoveAll(dir))
+	}
+	s.ComposeStack = stack
+
+	// the API server creates the console database on startup, before serving the requests
+	stack.WaitForService(apiService, wait.ForAll(
+		wait.ForLog("Server database init completed"),
+		wait.ForHTTP("/v1/health/version").WithPort(apiPort),
+	))
+
+	if err := stack.Up(ctx, compose.Wait(true)); err != nil {
+		return nil, errors.Join(fmt.Errorf("error starting the stack: %w", err), s.Terminate(ctx))
+	}
+
+	if err := s.bootstrap(ctx, settings); err != nil {
+		return nil, errors.Join(fmt.Errorf("error bootstrapping the project: %w", err), s.Terminate(ctx))
+	}
+
+	return s, nil
+}
+
+// Endpoint returns the endpoint of the API, e.g. http://localhost:32768/v1,
+// as expected by the Appwrite SDKs.
+func (s *AppwriteStack) Endpoint(ctx context.Context) (string, error) {
+	c, err := s.ServiceContainer(ctx, apiService)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := c.PortEndpoint(ctx, apiPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/v1", nil
+}
+
+// ProjectID returns the ID of the project created in the stack.
+func (s *AppwriteStack) ProjectID() string {
+	return s.projectID
+}
+
+// APIKey returns the secret of the API key of the project, with the scopes set with WithAPIKeyScopes.
+func (s *AppwriteStack) APIKey() string {
+	return s.apiKey
+}
+
+// Terminate removes the containers, networks and volumes of the stack, and its temporary files.
+func (s *AppwriteStack) Terminate(ctx context.Context) error {
+	var err error
+	if s.ComposeStack != nil {
+		err = s.Down(ctx, compose.RemoveOrphans(true), compose.RemoveVolumes(true))
+	}
+
+	return errors.Join(err, os.RemoveAll(s.dir))
+}
+
+// bootstrap creates the console account, signs in, and creates a team, the project in the team,
+// and the API key of the project, using the console API
+func (s *AppwriteStack) bootstrap(ctx context.Context, settings options) error {
+	endpoint, err := s.Endpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	client := &consoleClient{endpoint: endpoint}
+
+	err = client.post(ctx, "/account", map[string]any{
+		"userId":   "unique()",
+		"email":    settings.consoleEmail,
+		"password": settings.consolePassword,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("error creating the console account: %w", err)
+	}
+
+	err = client.post(ctx, "/account/sessions/email", map[string]any{
+		"email":    settings.consoleEmail,
+		"password": settings.consolePassword,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("error signing in the console account: %w", err)
+	}
+
+	var team struct {
+		ID string `json:"$id"`
+	}
+	err = client.post(ctx, "/teams", map[string]any{
+		"teamId": "unique()",
+		"name":   settings.projectName,
+	}, &team)
+	if err != nil {
+		return fmt.Errorf("error creating the team: %w", err)
+	}
+
+	err = client.post(ctx, "/projects", map[string]any{
+		"projectId": settings.projectID,
+		"name":      settings.projectName,
+		"teamId":    team.ID,
+		"region":    "default",
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("error creating the project: %w", err)
+	}
+
+	var key struct {
+		Secret string `json:"secret"`
+	}
+	err = client.post(ctx, "/projects/"+settings.projectID+"/keys", map[string]any{
+		"name":   "testcontainers",
+		"scopes": settings.apiKeyScopes,
+	}, &key)
+	if err != nil {
+		return fmt.Errorf("error creating the API key: %w", err)
+	}
+
+	s.apiKey = key.Secret
+
+	return nil
+}
+
+// consoleClient calls the console API, keeping the session cookies of the console account
+type consoleClient struct {
+	endpoint string
+	cookies  []*http.Cookie
+}
+
+// post sends the body as JSON to the path, decoding the response into the result, if not nil.
+// The session cookies are sent explicitly, as the domain of the cookies set by Appwrite
+// may not match the host of the Docker daemon.
+func (c *consoleClient) post(ctx context.Context, path string, body any, result any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Appwrite-Project", consoleProject)
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	c.cookies = append(c.cookies, resp.Cookies()...)
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// stackData is the data used to render the templates of the stack
+type stackData struct {
+	Images     map[string]string
+	OpenSSLKey string
+}
+
+// writeStack writes the files of the stack to the directory, rendering the templates
+func writeStack(dir string, data stackData) error {
+	entries, err := stackFS.ReadDir("stack")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		content, err := stackFS.ReadFile("stack/" + entry.Name())
+		if err != nil {
+			return err
+		}
+
+		name := entry.Name()
+		if strings.HasSuffix(name, ".tmpl") {
+			t, err := template.New(name).Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
+
+			var sb strings.Builder
+			if err := t.Execute(&sb, data); err != nil {
+				return fmt.Errorf("error rendering %s: %w", name, err)
+			}
+
+			name = strings.TrimSuffix(name, ".tmpl")
+			content = []byte(sb.String())
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package appwrite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/appwrite"
+)
+
+func TestAppwrite(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithProject {
+	stack, err := appwrite.Run(ctx,
+		appwrite.WithProject("todos", "Todos"),
+		appwrite.WithAPIKeyScopes("databases.read", "databases.write", "health.read"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := stack.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate stack: %s", err)
+		}
+	})
+
+	assert.Equal(t, "todos", stack.ProjectID())
+	require.NotEmpty(t, stack.APIKey())
+
+	endpoint, err := stack.Endpoint(ctx)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(endpoint, "/v1"))
+
+	// newRequest sends a request to the API of the project, authenticated with its API key
+	newRequest := func(method string, path string, body string) *http.Response {
+		req, err := http.NewRequest(method, endpoint+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Appwrite-Project", stack.ProjectID())
+		req.Header.Set("X-Appwrite-Key", stack.APIKey())
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("databases", func(t *testing.T) {
+		resp := newRequest(http.MethodPost, "/databases", `{"databaseId": "app", "name": "App"}`)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var database struct {
+			ID string `json:"$id"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&database))
+		assert.Equal(t, "app", database.ID)
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		// the API key has no users scopes
+		resp := newRequest(http.MethodGet, "/users", "")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
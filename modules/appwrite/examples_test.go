@@ -0,0 +1,54 @@
+package appwrite_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/testcontainers/testcontainers-go/modules/appwrite"
+)
+
+func ExampleRun() {
+	// runAppwriteStack {
+	ctx := context.Background()
+
+	stack, err := appwrite.Run(ctx, appwrite.WithConsoleAccount("jane@example.com", "s3cr3t-passw0rd"))
+	if err != nil {
+		log.Fatalf("failed to start stack: %s", err)
+	}
+
+	// Clean up the stack
+	defer func() {
+		if err := stack.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate stack: %s", err)
+		}
+	}()
+	// }
+
+	// endpoint {
+	endpoint, err := stack.Endpoint(ctx)
+	if err != nil {
+		log.Fatalf("failed to get the endpoint: %s", err) // nolint:gocritic
+	}
+
+	// the endpoint, the project ID and the API key are the settings of the Appwrite server SDKs
+	req, err := http.NewRequest(http.MethodGet, endpoint+"/health", nil)
+	if err != nil {
+		log.Fatalf("failed to create request: %s", err)
+	}
+	req.Header.Set("X-Appwrite-Project", stack.ProjectID())
+	req.Header.Set("X-Appwrite-Key", stack.APIKey())
+	// }
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatalf("failed to get the health: %s", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Println(resp.StatusCode)
+
+	// Output:
+	// 200
+}
@@ -0,0 +1,95 @@
+package appwrite
+
+const (
+	defaultConsoleEmail    = "admin@testcontainers.org"
+	defaultConsolePassword = "testcontainers"
+	defaultProjectID       = "testcontainers"
+	defaultProjectName     = "Testcontainers"
+	defaultOpenSSLKey      = "testcontainers-openssl-key"
+)
+
+// defaultImages are the images of the services of the stack
+var defaultImages = map[string]string{
+	"appwrite": "appwrite/appwrite:1.5.4",
+	"mariadb":  "mariadb:10.11",
+	"redis":    "redis:7.2.4-alpine",
+}
+
+// DefaultAPIKeyScopes are the scopes of the API key created for the project, granting access
+// to the users, teams, databases, storage, functions and health APIs.
+var DefaultAPIKeyScopes = []string{
+	"users.read", "users.write",
+	"teams.read", "teams.write",
+	"databases.read", "databases.write",
+	"collections.read", "collections.write",
+	"attributes.read", "attributes.write",
+	"indexes.read", "indexes.write",
+	"documents.read", "documents.write",
+	"files.read", "files.write",
+	"buckets.read", "buckets.write",
+	"functions.read", "functions.write",
+	"execution.read", "execution.write",
+	"locale.read", "avatars.read", "health.read",
+}
+
+type options struct {
+	images          map[string]string
+	consoleEmail    string
+	consolePassword string
+	projectID       string
+	projectName     string
+	apiKeyScopes    []string
+}
+
+func defaultOptions() options {
+	images := make(map[string]string, len(defaultImages))
+	for service, image := range defaultImages {
+		images[service] = image
+	}
+
+	return options{
+		images:          images,
+		consoleEmail:    defaultConsoleEmail,
+		consolePassword: defaultConsolePassword,
+		projectID:       defaultProjectID,
+		projectName:     defaultProjectName,
+		apiKeyScopes:    DefaultAPIKeyScopes,
+	}
+}
+
+// Option is an option for the Appwrite stack.
+type Option func(*options)
+
+// WithImage sets the Docker image of a service of the stack: appwrite, mariadb or redis.
+// Unknown services are ignored.
+func WithImage(service string, image string) Option {
+	return func(o *options) {
+		if _, ok := o.images[service]; ok {
+			o.images[service] = image
+		}
+	}
+}
+
+// WithConsoleAccount sets the credentials of the console account, which owns the project.
+// The password must be at least 8 characters long.
+func WithConsoleAccount(email string, password string) Option {
+	return func(o *options) {
+		o.consoleEmail = email
+		o.consolePassword = password
+	}
+}
+
+// WithProject sets the ID and the name of the project created in the stack.
+func WithProject(id string, name string) Option {
+	return func(o *options) {
+		o.projectID = id
+		o.projectName = name
+	}
+}
+
+// WithAPIKeyScopes sets the scopes of the API key created for the project. Defaults to DefaultAPIKeyScopes.
+func WithAPIKeyScopes(scopes ...string) Option {
+	return func(o *options) {
+		o.apiKeyScopes = scopes
+	}
+}
@@ -0,0 +1,107 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage is the default MongoDB Atlas Local container image
+	defaultImage = "mongodb/mongodb-atlas-local:7.0.9"
+	defaultPort  = "27017/tcp"
+
+	envUsername = "MONGODB_INITDB_ROOT_USERNAME"
+	envPassword = "MONGODB_INITDB_ROOT_PASSWORD"
+)
+
+// AtlasContainer represents the MongoDB Atlas Local container type used in the module
+type AtlasContainer struct {
+	testcontainers.Container
+	username string
+	password string
+}
+
+// RunContainer creates an instance of the MongoDB Atlas Local container type, a single node replica set
+// with Atlas Search and Atlas Vector Search enabled. It's ready once the healthcheck of the image reports
+// that both the database and the search engine are up.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*AtlasContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(defaultPort),
+			wait.ForHealthCheck(),
+		),
+		Env: map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	username := genericContainerReq.Env[envUsername]
+	password := genericContainerReq.Env[envPassword]
+	if username != "" && password == "" || username == "" && password != "" {
+		return nil, fmt.Errorf("if you specify username or password, you must provide both of them")
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AtlasContainer{Container: container, username: username, password: password}, nil
+}
+
+// WithUsername sets the initial username to be created when the container starts
+// It is used in conjunction with WithPassword to set a username and its password.
+// It will create the specified user with the root role.
+func WithUsername(username string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[envUsername] = username
+	}
+}
+
+// WithPassword sets the initial password of the user to be created when the container starts
+// It is used in conjunction with WithUsername to set a username and its password.
+func WithPassword(password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[envPassword] = password
+	}
+}
+
+// ConnectionString returns the connection string for the MongoDB Atlas Local container,
+// connecting directly to the node of the replica set, as its advertised address is not reachable
+// from the host. If you provide a username and a password, the connection string will also include them.
+func (c *AtlasContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{
+		Scheme:   "mongodb",
+		Host:     fmt.Sprintf("%s:%s", host, port.Port()),
+		Path:     "/",
+		RawQuery: "directConnection=true",
+	}
+	if c.username != "" && c.password != "" {
+		u.User = url.UserPassword(c.username, c.password)
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,70 @@
+package atlas_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/testcontainers/testcontainers-go/modules/atlas"
+)
+
+func TestAtlas(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := atlas.RunContainer(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connStr))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(ctx))
+	})
+
+	coll := client.Database("test").Collection("movies")
+
+	_, err = coll.InsertOne(ctx, bson.D{{Key: "title", Value: "Back to the Future"}})
+	require.NoError(t, err)
+
+	t.Run("search-index", func(t *testing.T) {
+		// createSearchIndex {
+		name, err := coll.SearchIndexes().CreateOne(ctx, mongo.SearchIndexModel{
+			Definition: bson.D{{Key: "mappings", Value: bson.D{{Key: "dynamic", Value: true}}}},
+			Options:    options.SearchIndexes().SetName("default"),
+		})
+		// }
+		require.NoError(t, err)
+		require.Equal(t, "default", name)
+
+		// the index is built asynchronously by the search engine
+		require.Eventually(t, func() bool {
+			cursor, err := coll.SearchIndexes().List(ctx, options.SearchIndexes().SetName(name))
+			if err != nil {
+				return false
+			}
+
+			var indexes []bson.M
+			if err := cursor.All(ctx, &indexes); err != nil || len(indexes) != 1 {
+				return false
+			}
+
+			return indexes[0]["queryable"] == true
+		}, time.Minute, time.Second)
+	})
+}
+
+func TestAtlas_invalidCredentials(t *testing.T) {
+	_, err := atlas.RunContainer(context.Background(), atlas.WithUsername("root"))
+	require.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package atlas_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/atlas"
+)
+
+func ExampleRunContainer() {
+	// runAtlasContainer {
+	ctx := context.Background()
+
+	atlasContainer, err := atlas.RunContainer(ctx, testcontainers.WithImage("mongodb/mongodb-atlas-local:7.0.9"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := atlasContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := atlasContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
+
+func ExampleRunContainer_withCredentials() {
+	// connectToAtlas {
+	ctx := context.Background()
+
+	atlasContainer, err := atlas.RunContainer(ctx,
+		atlas.WithUsername("root"),
+		atlas.WithPassword("password"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := atlasContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+
+	connStr, err := atlasContainer.ConnectionString(ctx)
+	if err != nil {
+		log.Fatalf("failed to get connection string: %s", err) // nolint:gocritic
+	}
+
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(connStr))
+	if err != nil {
+		log.Fatalf("failed to connect to MongoDB Atlas: %s", err) // nolint:gocritic
+	}
+	// }
+
+	defer func() {
+		if err := mongoClient.Disconnect(ctx); err != nil {
+			log.Fatalf("failed to disconnect from MongoDB Atlas: %s", err) // nolint:gocritic
+		}
+	}()
+
+	err = mongoClient.Ping(ctx, nil)
+	if err != nil {
+		log.Fatalf("failed to ping MongoDB Atlas: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(strings.Split(connStr, "@")[0])
+
+	// Output:
+	// mongodb://root:password
+}
@@ -0,0 +1,324 @@
+package authentik
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/modules/compose"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	apiPort    = "9000/tcp"
+	apiService = "server"
+
+	// AdminUsername is the username of the admin user bootstrapped by authentik
+	AdminUsername = "akadmin"
+
+	// authorizationFlow is the slug of the flow authorizing the OAuth2 providers, created by the default blueprints
+	authorizationFlow = "default-provider-authorization-implicit-consent"
+)
+
+//go:embed stack
+var stackFS embed.FS
+
+// slugRegex matches the characters not allowed in the slugs of the applications
+var slugRegex = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// AuthentikStack represents the authentik stack used in the module: the server and the worker,
+// with Postgres and Redis, bootstrapped with the admin user and its API token.
+type AuthentikStack struct {
+	compose.ComposeStack
+	dir           string
+	adminPassword string
+	apiToken      string
+}
+
+// MachineClient represents an OAuth2 application, and the service account to request access tokens
+// for it with the client credentials grant, sending the username and password of the service account
+// along with the client ID.
+type MachineClient struct {
+	Slug         string
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+}
+
+// Run starts an authentik stack, using Docker Compose, and waits for the admin user and its API token
+// to be bootstrapped by the worker. The stack is removed if it fails to start.
+func Run(ctx context.Context, opts ...Option) (*AuthentikStack, error) {
+	settings := defaultOptions()
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	secretKey, err := randomString(25)
+	if err != nil {
+		return nil, fmt.Errorf("error generating the secret key: %w", err)
+	}
+
+	apiToken, err := randomString(30)
+	if err != nil {
+		return nil, fmt.Errorf("error generating the API token: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "testcontainers-authentik-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating the stack directory: %w", err)
+	}
+
+	s := &AuthentikStack{
+		dir:           dir,
+		adminPassword: settings.adminPassword,
+		apiToken:      apiToken,
+	}
+
+	err = writeStack(dir, stackData{
+		Images:        settings.images,
+		SecretKey:     secretKey,
+		AdminEmail:    settings.adminEmail,
+		AdminPassword: settings.adminPassword,
+		APIToken:      apiToken,
+	})
+	if err != nil {
+		return nil, errors.Join(err, os.RemoveAll(dir))
+	}
+
+	stack, err := compose.NewDockerComposeWith(compose.WithStackFiles(filepath.Join(dir, "docker-compose.yml")))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error creating the stack: %w", err), os.RemoveAll(dir))
+	}
+	s.ComposeStack = stack
+
+	// the API token is only valid once the worker has applied the bootstrap blueprint
+	stack.WaitForService(apiService, wait.ForHTTP("/api/v3/core/users/me/").
+		WithPort(apiPort).
+		WithHeaders(map[string]string{"Authorization": "Bearer " + apiToken}).
+		WithStartupTimeout(3*time.Minute).
+		WithPollInterval(time.Second))
+
+	if err := stack.Up(ctx, compose.Wait(true)); err != nil {
+		return nil, errors.Join(fmt.Errorf("error starting the stack: %w", err), s.Terminate(ctx))
+	}
+
+	return s, nil
+}
+
+// URL returns the URL of the server, e.g. http://localhost:32768, serving the admin interface,
+// the API at /api/v3, and the OAuth2 endpoints at /application/o.
+func (s *AuthentikStack) URL(ctx context.Context) (string, error) {
+	c, err := s.ServiceContainer(ctx, apiService)
+	if err != nil {
+		return "", err
+	}
+
+	return c.PortEndpoint(ctx, apiPort, "http")
+}
+
+// IssuerURL returns the URL of the OpenID Connect issuer of the application with the given slug,
+// e.g. http://localhost:32768/application/o/orders/. The discovery document is served
+// at .well-known/openid-configuration, relative to it.
+func (s *AuthentikStack) IssuerURL(ctx context.Context, slug string) (string, error) {
+	u, err := s.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return u + "/application/o/" + slug + "/", nil
+}
+
+// AdminPassword returns the password of the akadmin user.
+func (s *AuthentikStack) AdminPassword() string {
+	return s.adminPassword
+}
+
+// APIToken returns the API token of the akadmin user, to be sent as a bearer token to the API.
+func (s *AuthentikStack) APIToken() string {
+	return s.apiToken
+}
+
+// CreateMachineClient creates an OAuth2 provider, and an application using it, named after name,
+// and a service account to request access tokens with the client credentials grant.
+func (s *AuthentikStack) CreateMachineClient(ctx context.Context, name string) (*MachineClient, error) {
+	var flows struct {
+		Results []struct {
+			PK string `json:"pk"`
+		} `json:"results"`
+	}
+	err := s.call(ctx, http.MethodGet, "/api/v3/flows/instances/?slug="+url.QueryEscape(authorizationFlow), nil, &flows)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the authorization flow: %w", err)
+	}
+	if len(flows.Results) == 0 {
+		return nil, fmt.Errorf("authorization flow %s not found", authorizationFlow)
+	}
+
+	var provider struct {
+		PK           int    `json:"pk"`
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	err = s.call(ctx, http.MethodPost, "/api/v3/providers/oauth2/", map[string]any{
+		"name":               name,
+		"authorization_flow": flows.Results[0].PK,
+		"client_type":        "confidential",
+	}, &provider)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the OAuth2 provider: %w", err)
+	}
+
+	slug := strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	err = s.call(ctx, http.MethodPost, "/api/v3/core/applications/", map[string]any{
+		"name":     name,
+		"slug":     slug,
+		"provider": provider.PK,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the application: %w", err)
+	}
+
+	var account struct {
+		Username string `json:"username"`
+		Token    string `json:"token"`
+	}
+	err = s.call(ctx, http.MethodPost, "/api/v3/core/users/service_account/", map[string]any{
+		"name":         name,
+		"create_group": false,
+		"expiring":     false,
+	}, &account)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the service account: %w", err)
+	}
+
+	return &MachineClient{
+		Slug:         slug,
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		Username:     account.Username,
+		Password:     account.Token,
+	}, nil
+}
+
+// Terminate removes the containers, networks and volumes of the stack, and its temporary files.
+func (s *AuthentikStack) Terminate(ctx context.Context) error {
+	var err error
+	if s.ComposeStack != nil {
+		err = s.Down(ctx, compose.RemoveOrphans(true), compose.RemoveVolumes(true))
+	}
+
+	return errors.Join(err, os.RemoveAll(s.dir))
+}
+
+// call sends the body, if not nil, as JSON to the path of the API, authenticated with the API token,
+// decoding the response into the result, if not nil.
+func (s *AuthentikStack) call(ctx context.Context, method string, path string, body any, result any) error {
+	u, err := s.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// randomString returns a random hex string, from n random bytes
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// stackData is the data used to render the templates of the stack
+type stackData struct {
+	Images        map[string]string
+	SecretKey     string
+	AdminEmail    string
+	AdminPassword string
+	APIToken      string
+}
+
+// writeStack writes the files of the stack to the directory, rendering the templates
+func writeStack(dir string, data stackData) error {
+	entries, err := stackFS.ReadDir("stack")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		content, err := stackFS.ReadFile("stack/" + entry.Name())
+		if err != nil {
+			return err
+		}
+
+		name := entry.Name()
+		if strings.HasSuffix(name, ".tmpl") {
+			t, err := template.New(name).Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
+
+			var sb strings.Builder
+			if err := t.Execute(&sb, data); err != nil {
+				return fmt.Errorf("error rendering %s: %w", name, err)
+			}
+
+			name = strings.TrimSuffix(name, ".tmpl")
+			content = []byte(sb.String())
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package authentik_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/authentik"
+)
+
+func TestAuthentik(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithAdmin {
+	stack, err := authentik.Run(ctx, authentik.WithAdmin("jane@example.com", "s3cr3t-passw0rd"))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := stack.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate stack: %s", err)
+		}
+	})
+
+	assert.Equal(t, "s3cr3t-passw0rd", stack.AdminPassword())
+	require.NotEmpty(t, stack.APIToken())
+
+	baseURL, err := stack.URL(ctx)
+	require.NoError(t, err)
+
+	t.Run("api", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, baseURL+"/api/v3/core/users/me/", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+stack.APIToken())
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var me struct {
+			User struct {
+				Username string `json:"username"`
+				Email    string `json:"email"`
+			} `json:"user"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&me))
+		assert.Equal(t, authentik.AdminUsername, me.User.Username)
+		assert.Equal(t, "jane@example.com", me.User.Email)
+	})
+
+	t.Run("machine-client", func(t *testing.T) {
+		// createMachineClient {
+		client, err := stack.CreateMachineClient(ctx, "Orders Service")
+		// }
+		require.NoError(t, err)
+		assert.Equal(t, "orders-service", client.Slug)
+		require.NotEmpty(t, client.ClientID)
+		require.NotEmpty(t, client.Username)
+		require.NotEmpty(t, client.Password)
+
+		issuer, err := stack.IssuerURL(ctx, client.Slug)
+		require.NoError(t, err)
+		assert.Equal(t, baseURL+"/application/o/orders-service/", issuer)
+
+		form := url.Values{
+			"grant_type": {"client_credentials"},
+			"client_id":  {client.ClientID},
+			"username":   {client.Username},
+			"password":   {client.Password},
+			"scope":      {"openid"},
+		}
+		resp, err := http.Post(baseURL+"/application/o/token/", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var token struct {
+			AccessToken string `json:"access_token"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+		require.NotEmpty(t, token.AccessToken)
+	})
+}
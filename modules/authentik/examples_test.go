@@ -0,0 +1,52 @@
+package authentik_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/testcontainers/testcontainers-go/modules/authentik"
+)
+
+func ExampleRun() {
+	// runAuthentikStack {
+	ctx := context.Background()
+
+	stack, err := authentik.Run(ctx)
+	if err != nil {
+		log.Fatalf("failed to start stack: %s", err)
+	}
+
+	// Clean up the stack
+	defer func() {
+		if err := stack.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate stack: %s", err)
+		}
+	}()
+	// }
+
+	// callAPI {
+	baseURL, err := stack.URL(ctx)
+	if err != nil {
+		log.Fatalf("failed to get the URL: %s", err) // nolint:gocritic
+	}
+
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/api/v3/core/users/me/", nil)
+	if err != nil {
+		log.Fatalf("failed to create request: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+stack.APIToken())
+	// }
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatalf("failed to call the API: %s", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Println(resp.StatusCode)
+
+	// Output:
+	// 200
+}
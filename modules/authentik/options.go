@@ -0,0 +1,53 @@
+package authentik
+
+const (
+	defaultAdminEmail    = "admin@testcontainers.org"
+	defaultAdminPassword = "testcontainers"
+)
+
+// defaultImages are the images of the services of the stack
+var defaultImages = map[string]string{
+	"authentik": "ghcr.io/goauthentik/server:2024.2.2",
+	"postgres":  "postgres:16-alpine",
+	"redis":     "redis:7.2.4-alpine",
+}
+
+type options struct {
+	images        map[string]string
+	adminEmail    string
+	adminPassword string
+}
+
+func defaultOptions() options {
+	images := make(map[string]string, len(defaultImages))
+	for service, image := range defaultImages {
+		images[service] = image
+	}
+
+	return options{
+		images:        images,
+		adminEmail:    defaultAdminEmail,
+		adminPassword: defaultAdminPassword,
+	}
+}
+
+// Option is an option for the authentik stack.
+type Option func(*options)
+
+// WithImage sets the Docker image of a service of the stack: authentik, used by the server and
+// the worker, postgres or redis. Unknown services are ignored.
+func WithImage(service string, image string) Option {
+	return func(o *options) {
+		if _, ok := o.images[service]; ok {
+			o.images[service] = image
+		}
+	}
+}
+
+// WithAdmin sets the email and the password of the akadmin user, bootstrapped on first start.
+func WithAdmin(email string, password string) Option {
+	return func(o *options) {
+		o.adminEmail = email
+		o.adminPassword = password
+	}
+}
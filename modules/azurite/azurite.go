@@ -0,0 +1,179 @@
+package azurite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "mcr.microsoft.com/azure-storage/azurite:3.28.0"
+
+	// AccountName is the well-known name of the storage account of Azurite
+	AccountName = "devstoreaccount1"
+	// AccountKey is the well-known key of the storage account of Azurite
+	AccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+// Service represents a storage service emulated by Azurite
+type Service string
+
+const (
+	BlobService  Service = "blob"
+	QueueService Service = "queue"
+	TableService Service = "table"
+)
+
+// services are the storage services emulated by Azurite, in the order of their ports
+var services = []Service{BlobService, QueueService, TableService}
+
+// servicePorts are the ports of the storage services
+var servicePorts = map[Service]nat.Port{
+	BlobService:  "10000/tcp",
+	QueueService: "10001/tcp",
+	TableService: "10002/tcp",
+}
+
+// AzuriteContainer represents the Azurite container type used in the module
+type AzuriteContainer struct {
+	testcontainers.Container
+	services []Service
+}
+
+// RunContainer creates an instance of the Azurite container type, emulating the blob, queue and table
+// storage services of Azure, unless WithServices enables only some of them.
+// The services are accessed with the well-known account of Azurite, see AccountName and AccountKey.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*AzuriteContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: defaultImage,
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{services: services}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if len(settings.services) == 0 {
+		return nil, errors.New("no storage services enabled")
+	}
+
+	strategies := make([]wait.Strategy, 0, len(settings.services))
+	for _, service := range settings.services {
+		port := servicePorts[service]
+		genericContainerReq.ExposedPorts = append(genericContainerReq.ExposedPorts, string(port))
+		strategies = append(strategies, wait.ForListeningPort(port))
+	}
+
+	genericContainerReq.Cmd = serviceCmd(settings.services)
+
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForAll(strategies...)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzuriteContainer{Container: container, services: settings.services}, nil
+}
+
+// serviceCmd returns the command running the services, listening on all the interfaces. Azurite runs
+// all of them with the azurite executable, or a single one with its own executable, e.g. azurite-blob,
+// so a subset of them runs as several processes.
+func serviceCmd(enabled []Service) []string {
+	// the API version of the newer SDKs is accepted
+	flags := []string{"--location", "/data", "--skipApiVersionCheck"}
+
+	args := func(service Service) []string {
+		return []string{"--" + string(service) + "Host", "0.0.0.0", "--" + string(service) + "Port", servicePorts[service].Port()}
+	}
+
+	if len(enabled) == len(services) {
+		cmd := []string{"azurite"}
+		for _, service := range enabled {
+			cmd = append(cmd, args(service)...)
+		}
+
+		return append(cmd, flags...)
+	}
+
+	if len(enabled) == 1 {
+		cmd := append([]string{"azurite-" + string(enabled[0])}, args(enabled[0])...)
+		return append(cmd, flags...)
+	}
+
+	cmds := make([]string, 0, len(enabled))
+	for _, service := range enabled {
+		cmd := append([]string{"azurite-" + string(service)}, args(service)...)
+		cmds = append(cmds, strings.Join(append(cmd, flags...), " "))
+	}
+
+	return []string{"sh", "-c", strings.Join(cmds, " & ") + " & wait"}
+}
+
+// Services returns the enabled storage services
+func (c *AzuriteContainer) Services() []Service {
+	return c.services
+}
+
+// ServiceURL returns the URL of the storage service for the well-known account, with the format
+// http://<host>:<port>/devstoreaccount1, or an error if the service is not enabled.
+func (c *AzuriteContainer) ServiceURL(ctx context.Context, service Service) (string, error) {
+	if !c.enabled(service) {
+		return "", fmt.Errorf("the %s service is not enabled", service)
+	}
+
+	endpoint, err := c.PortEndpoint(ctx, servicePorts[service], "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/" + AccountName, nil
+}
+
+// ConnectionString returns the connection string of the well-known account, including the endpoints
+// of the enabled services, as expected by the Azure SDKs, e.g. azblob.NewClientFromConnectionString.
+func (c *AzuriteContainer) ConnectionString(ctx context.Context) (string, error) {
+	parts := []string{
+		"DefaultEndpointsProtocol=http",
+		"AccountName=" + AccountName,
+		"AccountKey=" + AccountKey,
+	}
+
+	for _, service := range c.services {
+		url, err := c.ServiceURL(ctx, service)
+		if err != nil {
+			return "", err
+		}
+
+		// e.g. BlobEndpoint
+		parts = append(parts, strings.ToUpper(string(service[:1]))+string(service[1:])+"Endpoint="+url)
+	}
+
+	return strings.Join(parts, ";") + ";", nil
+}
+
+func (c *AzuriteContainer) enabled(service Service) bool {
+	for _, s := range c.services {
+		if s == service {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package azurite_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/azurite"
+)
+
+func TestAzurite(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := azurite.RunContainer(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// the servers of the services answer, rejecting the anonymous requests
+	for service, server := range map[azurite.Service]string{
+		azurite.BlobService:  "Azurite-Blob",
+		azurite.QueueService: "Azurite-Queue",
+		azurite.TableService: "Azurite-Table",
+	} {
+		t.Run(string(service), func(t *testing.T) {
+			// serviceURL {
+			url, err := container.ServiceURL(ctx, service)
+			// }
+			require.NoError(t, err)
+			assert.True(t, strings.HasSuffix(url, "/"+azurite.AccountName))
+
+			resp, err := http.Get(url + "?comp=list")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.True(t, strings.HasPrefix(resp.Header.Get("Server"), server), resp.Header.Get("Server"))
+		})
+	}
+
+	t.Run("connection-string", func(t *testing.T) {
+		// connectionString {
+		connectionString, err := container.ConnectionString(ctx)
+		// }
+		require.NoError(t, err)
+
+		assert.Contains(t, connectionString, "AccountName="+azurite.AccountName+";AccountKey="+azurite.AccountKey+";")
+		assert.Contains(t, connectionString, "BlobEndpoint=http://")
+		assert.Contains(t, connectionString, "QueueEndpoint=http://")
+		assert.Contains(t, connectionString, "TableEndpoint=http://")
+	})
+}
+
+func TestAzurite_withServices(t *testing.T) {
+	ctx := context.Background()
+
+	// withServices {
+	container, err := azurite.RunContainer(ctx, azurite.WithServices(azurite.BlobService, azurite.QueueService))
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, []azurite.Service{azurite.BlobService, azurite.QueueService}, container.Services())
+
+	url, err := container.ServiceURL(ctx, azurite.QueueService)
+	require.NoError(t, err)
+
+	resp, err := http.Get(url + "?comp=list")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.True(t, strings.HasPrefix(resp.Header.Get("Server"), "Azurite-Queue"))
+
+	_, err = container.ServiceURL(ctx, azurite.TableService)
+	require.Error(t, err)
+
+	connectionString, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, connectionString, "BlobEndpoint=http://")
+	assert.NotContains(t, connectionString, "TableEndpoint")
+}
+
+func TestAzurite_withoutServices(t *testing.T) {
+	_, err := azurite.RunContainer(context.Background(), azurite.WithServices())
+	require.Error(t, err)
+}
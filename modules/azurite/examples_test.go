@@ -0,0 +1,40 @@
+package azurite_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/azurite"
+)
+
+func ExampleRunContainer() {
+	// runAzuriteContainer {
+	ctx := context.Background()
+
+	azuriteContainer, err := azurite.RunContainer(ctx,
+		testcontainers.WithImage("mcr.microsoft.com/azure-storage/azurite:3.28.0"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := azuriteContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := azuriteContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
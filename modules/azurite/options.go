@@ -0,0 +1,37 @@
+package azurite
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	services []Service
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Azurite container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithServices enables only the given storage services, e.g. WithServices(azurite.BlobService),
+// exposing and waiting for their ports only. All the services are enabled by default.
+func WithServices(enabled ...Service) Option {
+	return func(o *options) {
+		o.services = []Service{}
+		// keep the order of the ports, ignoring the duplicates
+		for _, service := range services {
+			for _, s := range enabled {
+				if s == service {
+					o.services = append(o.services, service)
+					break
+				}
+			}
+		}
+	}
+}
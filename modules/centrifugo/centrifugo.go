@@ -0,0 +1,213 @@
+package centrifugo
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "centrifugo/centrifugo:v5.4.0"
+	defaultPort  = "8000/tcp"
+
+	// configFile is the configuration file of Centrifugo, generated from the options
+	configFile = "/centrifugo/config.json"
+
+	// defaultAPIKey and defaultTokenHMACSecret are the secrets used when deterministic credentials are enabled
+	defaultAPIKey          = "api-key"
+	defaultTokenHMACSecret = "token-hmac-secret"
+)
+
+// CentrifugoContainer represents the Centrifugo container type used in the module
+type CentrifugoContainer struct {
+	testcontainers.Container
+	apiKey          string
+	tokenHMACSecret string
+}
+
+// RunContainer creates an instance of the Centrifugo container type, a realtime messaging server.
+// The clients connect with the WebSocket endpoint, authenticated with the tokens of ConnectionToken,
+// and the messages are published with the server API, see Publish.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CentrifugoContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Cmd:          []string{"centrifugo", "--config", configFile},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.apiKey == "" {
+		apiKey, err := credentials.Token(defaultAPIKey)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the API key: %w", err)
+		}
+
+		settings.apiKey = apiKey
+	}
+
+	if settings.tokenHMACSecret == "" {
+		secret, err := credentials.Token(defaultTokenHMACSecret)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the token HMAC secret: %w", err)
+		}
+
+		settings.tokenHMACSecret = secret
+	}
+
+	config := map[string]any{
+		"api_key":               settings.apiKey,
+		"token_hmac_secret_key": settings.tokenHMACSecret,
+		"health":                true,
+	}
+	for k, v := range settings.config {
+		config[k] = v
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering the configuration: %w", err)
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            bytes.NewReader(b),
+		ContainerFilePath: configFile,
+		FileMode:          0o644,
+	})
+
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForHTTP("/health").WithPort(defaultPort)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CentrifugoContainer{
+		Container:       container,
+		apiKey:          settings.apiKey,
+		tokenHMACSecret: settings.tokenHMACSecret,
+	}, nil
+}
+
+// HTTPEndpoint returns the URL of the HTTP endpoint of the Centrifugo container, with the format http://<host>:<port>,
+// serving the server API under /api.
+func (c *CentrifugoContainer) HTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
+
+// WebSocketEndpoint returns the URL of the WebSocket endpoint of the clients, with the format
+// ws://<host>:<port>/connection/websocket.
+func (c *CentrifugoContainer) WebSocketEndpoint(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, defaultPort, "ws")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/connection/websocket", nil
+}
+
+// APIKey returns the key of the server API
+func (c *CentrifugoContainer) APIKey() string {
+	return c.apiKey
+}
+
+// ConnectionToken returns a connection token for the user, valid for the given duration,
+// signed with the token HMAC secret of the container.
+func (c *CentrifugoContainer) ConnectionToken(user string, ttl time.Duration) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{"sub": user, "exp": time.Now().Add(ttl).Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, []byte(c.tokenHMACSecret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Publish publishes the data, encoded as JSON, to the channel, with the server API.
+func (c *CentrifugoContainer) Publish(ctx context.Context, channel string, data any) error {
+	return c.API(ctx, "publish", map[string]any{"channel": channel, "data": data}, nil)
+}
+
+// API calls the method of the server API with the params, decoding its result into result, if not nil,
+// e.g. API(ctx, "history", map[string]any{"channel": "news", "limit": 10}, &history).
+func (c *CentrifugoContainer) API(ctx context.Context, method string, params any, result any) error {
+	endpoint, err := c.HTTPEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("error encoding the params of %s: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/api/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error calling %s: unexpected status code %d", method, resp.StatusCode)
+	}
+
+	var reply struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return fmt.Errorf("error decoding the reply of %s: %w", method, err)
+	}
+
+	if reply.Error != nil {
+		return fmt.Errorf("error calling %s: %s (code %d)", method, reply.Error.Message, reply.Error.Code)
+	}
+
+	if result == nil || len(reply.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(reply.Result, result)
+}
@@ -0,0 +1,86 @@
+package centrifugo_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/centrifugo"
+)
+
+func TestCentrifugo(t *testing.T) {
+	ctx := context.Background()
+
+	// withConfig {
+	container, err := centrifugo.RunContainer(ctx,
+		centrifugo.WithTokenHMACSecret("s3cr3t"),
+		centrifugo.WithConfig(map[string]any{
+			"history_size": 10,
+			"history_ttl":  "300s",
+		}),
+	)
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	require.NotEmpty(t, container.APIKey())
+
+	t.Run("publish", func(t *testing.T) {
+		// publish {
+		err := container.Publish(ctx, "news", map[string]string{"text": "hello"})
+		// }
+		require.NoError(t, err)
+
+		var history struct {
+			Publications []struct {
+				Data map[string]string `json:"data"`
+			} `json:"publications"`
+		}
+		err = container.API(ctx, "history", map[string]any{"channel": "news", "limit": 10}, &history)
+		require.NoError(t, err)
+
+		require.Len(t, history.Publications, 1)
+		assert.Equal(t, "hello", history.Publications[0].Data["text"])
+	})
+
+	t.Run("missing-channel", func(t *testing.T) {
+		err := container.API(ctx, "history", map[string]any{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("connection-token", func(t *testing.T) {
+		token, err := container.ConnectionToken("user-1", time.Minute)
+		require.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		require.Len(t, parts, 3)
+
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(claims, &decoded))
+		assert.Equal(t, "user-1", decoded["sub"])
+	})
+
+	t.Run("endpoints", func(t *testing.T) {
+		ws, err := container.WebSocketEndpoint(ctx)
+		require.NoError(t, err)
+		assert.Regexp(t, `^ws://.+:\d+/connection/websocket$`, ws)
+	})
+}
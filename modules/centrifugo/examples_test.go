@@ -0,0 +1,41 @@
+package centrifugo_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/centrifugo"
+)
+
+func ExampleRunContainer() {
+	// runCentrifugoContainer {
+	ctx := context.Background()
+
+	centrifugoContainer, err := centrifugo.RunContainer(ctx,
+		testcontainers.WithImage("centrifugo/centrifugo:v5.4.0"),
+		centrifugo.WithAPIKey("api-key"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := centrifugoContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := centrifugoContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
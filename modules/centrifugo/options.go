@@ -0,0 +1,50 @@
+package centrifugo
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	apiKey          string
+	tokenHMACSecret string
+	config          map[string]any
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Centrifugo container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithAPIKey sets the key of the server API, used to publish messages. It's random by default.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithTokenHMACSecret sets the secret of the HMAC SHA-256 connection tokens of the clients. It's random by default.
+func WithTokenHMACSecret(secret string) Option {
+	return func(o *options) {
+		o.tokenHMACSecret = secret
+	}
+}
+
+// WithConfig adds the options to the configuration file of Centrifugo, e.g. "history_size" and "history_ttl",
+// or "allow_anonymous_connect_without_token". The keys override the ones generated by the module.
+func WithConfig(config map[string]any) Option {
+	return func(o *options) {
+		if o.config == nil {
+			o.config = map[string]any{}
+		}
+
+		for k, v := range config {
+			o.config[k] = v
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -39,6 +41,17 @@ type ClickHouseContainer struct {
 	DbName   string
 	User     string
 	Password string
+
+	// coordinator and network are the ZooKeeper or ClickHouse Keeper container started with
+	// WithZookeeperContainer or WithKeeperContainer, and the network shared with it
+	coordinator testcontainers.Container
+	network     *testcontainers.DockerNetwork
+}
+
+// Terminate terminates the ClickHouse container, and then the ZooKeeper or ClickHouse Keeper
+// container started with it, removing their network.
+func (c *ClickHouseContainer) Terminate(ctx context.Context) error {
+	return errors.Join(c.Container.Terminate(ctx), c.terminateCoordinator(ctx))
 }
 
 // ConnectionHost returns the host and port of the clickhouse container, using the default, native 9000 port, and
@@ -79,6 +92,29 @@ func (c *ClickHouseContainer) ConnectionString(ctx context.Context, args ...stri
 	return connectionString, nil
 }
 
+// HTTPConnectionString returns the dsn string for the clickhouse container, using the HTTP 8123 port, and
+// obtaining the host and exposed port from the container. The extra arguments are appended to the dsn string
+// as in ConnectionString, e.g. "compress=lz4".
+func (c *ClickHouseContainer) HTTPConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	extraArgs := ""
+	if len(args) > 0 {
+		extraArgs = "?" + strings.Join(args, "&")
+	}
+
+	connectionString := fmt.Sprintf("http://%s:%s@%s:%s/%s%s", c.User, c.Password, host, port.Port(), c.DbName, extraArgs)
+	return connectionString, nil
+}
+
 // ZookeeperOptions arguments for zookeeper in clickhouse
 type ZookeeperOptions struct {
 	Host, Port string
@@ -170,6 +206,19 @@ func WithYamlConfigFile(configFile string) testcontainers.CustomizeRequestOption
 	}
 }
 
+// WithUserConfigFile sets a XML or YAML file, with the users, profiles and quotas, to be copied to the users.d
+// directory of the clickhouse container, keeping its base name. It can be called multiple times, one per file.
+func WithUserConfigFile(configFile string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		cf := testcontainers.ContainerFile{
+			HostFilePath:      configFile,
+			ContainerFilePath: "/etc/clickhouse-server/users.d/" + filepath.Base(configFile),
+			FileMode:          0o755,
+		}
+		req.Files = append(req.Files, cf)
+	}
+}
+
 // WithDatabase sets the initial database to be created when the container starts
 // It can be used to define a different name for the default database that is created when the image is first started.
 // If it is not specified, then the default value("clickhouse") will be used.
@@ -224,18 +273,74 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		opt.Customize(&genericContainerReq)
 	}
 
+	c := &ClickHouseContainer{}
+	if settings.coordinatorImage != "" {
+		if err := c.startCoordinator(ctx, settings, &genericContainerReq); err != nil {
+			return nil, errors.Join(err, c.terminateCoordinator(ctx))
+		}
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, c.terminateCoordinator(ctx))
 	}
 
-	user := req.Env["CLICKHOUSE_USER"]
-	password := req.Env["CLICKHOUSE_PASSWORD"]
-	dbName := req.Env["CLICKHOUSE_DB"]
+	c.Container = container
+	c.User = req.Env["CLICKHOUSE_USER"]
+	c.Password = req.Env["CLICKHOUSE_PASSWORD"]
+	c.DbName = req.Env["CLICKHOUSE_DB"]
+
+	return c, nil
+}
+
+// startCoordinator starts the ZooKeeper or ClickHouse Keeper container on a new network,
+// attaching the clickhouse container to it and rendering the zookeeper configuration
+func (c *ClickHouseContainer) startCoordinator(ctx context.Context, settings options, req *testcontainers.GenericContainerRequest) error {
+	nw, err := network.New(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create the network: %w", err)
+	}
+	c.network = nw
+
+	coordinator, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          settings.coordinatorImage,
+			ExposedPorts:   []string{string(settings.coordinatorPort)},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {coordinatorAlias}},
+			WaitingFor:     wait.ForListeningPort(settings.coordinatorPort),
+		},
+		Started: true,
+	})
+	// the container is returned, although not started, if the start fails, so it must be terminated too
+	c.coordinator = coordinator
+	if err != nil {
+		return fmt.Errorf("failed to start the coordinator container: %w", err)
+	}
+
+	network.WithNetwork([]string{"clickhouse"}, nw)(req)
+	WithZookeeper(coordinatorAlias, settings.coordinatorPort.Port())(req)
+
+	return nil
+}
+
+// terminateCoordinator terminates the ZooKeeper or ClickHouse Keeper container and removes its network, if any
+func (c *ClickHouseContainer) terminateCoordinator(ctx context.Context) error {
+	var errs []error
+	if c.coordinator != nil {
+		errs = append(errs, c.coordinator.Terminate(ctx))
+	}
+	if c.network != nil {
+		errs = append(errs, c.network.Remove(ctx))
+	}
 
-	return &ClickHouseContainer{Container: container, DbName: dbName, Password: password, User: user}, nil
+	return errors.Join(errs...)
 }
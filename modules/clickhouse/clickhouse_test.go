@@ -131,6 +131,79 @@ func TestClickHouseDSN(t *testing.T) {
 	assert.Len(t, data, 1)
 }
 
+func TestClickHouseHTTPConnectionString(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := clickhouse.RunContainer(ctx, clickhouse.WithUsername(user), clickhouse.WithPassword(password), clickhouse.WithDatabase(dbname))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// httpConnectionString {
+	connectionString, err := container.HTTPConnectionString(ctx, "dial_timeout=10s")
+	// }
+	require.NoError(t, err)
+
+	opts, err := ch.ParseDSN(connectionString)
+	require.NoError(t, err)
+	assert.Equal(t, ch.HTTP, opts.Protocol)
+
+	conn, err := ch.Open(opts)
+	require.NoError(t, err)
+	assert.NotNil(t, conn)
+	defer conn.Close()
+
+	// perform assertions
+	data, err := performCRUD(conn)
+	require.NoError(t, err)
+	assert.Len(t, data, 1)
+}
+
+func TestClickHouseWithUserConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	// withUserConfigFile {
+	container, err := clickhouse.RunContainer(ctx,
+		clickhouse.WithUsername(user),
+		clickhouse.WithPassword(password),
+		clickhouse.WithDatabase(dbname),
+		clickhouse.WithUserConfigFile(filepath.Join("testdata", "users.xml")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	connectionHost, err := container.ConnectionHost(ctx)
+	require.NoError(t, err)
+
+	conn, err := ch.Open(&ch.Options{
+		Addr: []string{connectionHost},
+		Auth: ch.Auth{
+			Database: dbname,
+			Username: "reader",
+			Password: "reader",
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, conn)
+	defer conn.Close()
+
+	// the user of the users.d file can read, but not write
+	require.NoError(t, conn.Ping(ctx))
+	require.Error(t, conn.Exec(ctx, "CREATE TABLE IF NOT EXISTS forbidden (id UInt64) ENGINE = MergeTree ORDER BY id"))
+}
+
 func TestClickHouseWithInitScripts(t *testing.T) {
 	ctx := context.Background()
 
@@ -283,6 +356,54 @@ func TestClickHouseWithZookeeper(t *testing.T) {
 	assert.Len(t, data, 1)
 }
 
+func TestClickHouseWithZookeeperContainer(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc   string
+		option clickhouse.Option
+	}{
+		{"ZooKeeper", clickhouse.WithZookeeperContainer("")},
+		{"ClickHouse_Keeper", clickhouse.WithKeeperContainer("")},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// withZookeeperContainer {
+			container, err := clickhouse.RunContainer(ctx,
+				clickhouse.WithUsername(user),
+				clickhouse.WithPassword(password),
+				clickhouse.WithDatabase(dbname),
+				tC.option,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// }
+
+			// Clean up the containers and the network after the test is complete
+			t.Cleanup(func() {
+				require.NoError(t, container.Terminate(ctx))
+			})
+
+			connectionString, err := container.ConnectionString(ctx)
+			require.NoError(t, err)
+
+			opts, err := ch.ParseDSN(connectionString)
+			require.NoError(t, err)
+
+			conn, err := ch.Open(opts)
+			require.NoError(t, err)
+			assert.NotNil(t, conn)
+			defer conn.Close()
+
+			// perform assertions
+			data, err := performReplicatedCRUD(conn)
+			require.NoError(t, err)
+			assert.Len(t, data, 1)
+		})
+	}
+}
+
 func performReplicatedCRUD(conn driver.Conn) ([]Test, error) {
 	var (
 		err error
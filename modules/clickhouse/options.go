@@ -0,0 +1,62 @@
+package clickhouse
+
+import (
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultZookeeperImage = "zookeeper:3.7"
+	defaultKeeperImage    = "clickhouse/clickhouse-keeper:23.3.8.21-alpine"
+
+	zookeeperPort = nat.Port("2181/tcp")
+	keeperPort    = nat.Port("9181/tcp")
+
+	// coordinatorAlias is the network alias of the ZooKeeper or ClickHouse Keeper container started with the ClickHouse container
+	coordinatorAlias = "zookeeper"
+)
+
+type options struct {
+	coordinatorImage string
+	coordinatorPort  nat.Port
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the ClickHouse container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithZookeeperContainer starts a ZooKeeper container with the ClickHouse container, on a new network shared
+// by both, and configures ClickHouse to use it, e.g. to create replicated tables. If the image is empty,
+// "zookeeper:3.7" is used. The ZooKeeper container and the network are removed when the ClickHouse container
+// is terminated.
+func WithZookeeperContainer(image string) Option {
+	return func(o *options) {
+		if image == "" {
+			image = defaultZookeeperImage
+		}
+
+		o.coordinatorImage = image
+		o.coordinatorPort = zookeeperPort
+	}
+}
+
+// WithKeeperContainer is like WithZookeeperContainer, but starts a ClickHouse Keeper container instead.
+// If the image is empty, "clickhouse/clickhouse-keeper:23.3.8.21-alpine" is used.
+func WithKeeperContainer(image string) Option {
+	return func(o *options) {
+		if image == "" {
+			image = defaultKeeperImage
+		}
+
+		o.coordinatorImage = image
+		o.coordinatorPort = keeperPort
+	}
+}
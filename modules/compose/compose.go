@@ -3,6 +3,7 @@ package compose
 import (
 	"context"
 	"errors"
+	"io"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -28,6 +29,8 @@ var ErrNoStackConfigured = errors.New("no stack files configured")
 type composeStackOptions struct {
 	Identifier string
 	Paths      []string
+	Profiles   []string
+	EnvFiles   []string
 	Logger     testcontainers.Logging
 }
 
@@ -71,6 +74,8 @@ type ComposeStack interface {
 	WithEnv(m map[string]string) ComposeStack
 	WithOsEnv() ComposeStack
 	ServiceContainer(ctx context.Context, svcName string) (*testcontainers.DockerContainer, error)
+	ServiceLogs(ctx context.Context, svcName string) (io.ReadCloser, error)
+	WithLogConsumers(svcName string, consumers ...testcontainers.LogConsumer) ComposeStack
 }
 
 // Deprecated: DockerCompose is the old shell escape based API
@@ -94,6 +99,19 @@ func WithStackFiles(filePaths ...string) ComposeStackOption {
 	return ComposeStackFiles(filePaths)
 }
 
+// WithProfiles activates the given compose profiles, so that the services
+// assigned to them are part of the stack, besides the services without profiles.
+func WithProfiles(profiles ...string) ComposeStackOption {
+	return ComposeProfiles(profiles)
+}
+
+// WithEnvFiles sets the env files used to interpolate the variables of the stack files,
+// without mutating the OS environment. Files later in the list override the earlier ones,
+// and the environment set with WithEnv or WithOsEnv takes precedence over all of them.
+func WithEnvFiles(filePaths ...string) ComposeStackOption {
+	return ComposeEnvFiles(filePaths)
+}
+
 func NewDockerCompose(filePaths ...string) (*dockerCompose, error) {
 	return NewDockerComposeWith(WithStackFiles(filePaths...))
 }
@@ -124,10 +142,13 @@ func NewDockerComposeWith(opts ...ComposeStackOption) (*dockerCompose, error) {
 	composeAPI := &dockerCompose{
 		name:           composeOptions.Identifier,
 		configs:        composeOptions.Paths,
+		profiles:       composeOptions.Profiles,
+		envFiles:       composeOptions.EnvFiles,
 		logger:         composeOptions.Logger,
 		composeService: compose.NewComposeService(dockerCli),
 		dockerClient:   dockerCli.Client(),
 		waitStrategies: make(map[string]wait.Strategy),
+		logConsumers:   make(map[string][]testcontainers.LogConsumer),
 		containers:     make(map[string]*testcontainers.DockerContainer),
 	}
 
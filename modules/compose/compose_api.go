@@ -2,7 +2,9 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
@@ -92,6 +94,18 @@ func (f ComposeStackFiles) applyToComposeStack(o *composeStackOptions) {
 	o.Paths = f
 }
 
+type ComposeProfiles []string
+
+func (p ComposeProfiles) applyToComposeStack(o *composeStackOptions) {
+	o.Profiles = append(o.Profiles, p...)
+}
+
+type ComposeEnvFiles []string
+
+func (f ComposeEnvFiles) applyToComposeStack(o *composeStackOptions) {
+	o.EnvFiles = append(o.EnvFiles, f...)
+}
+
 type StackIdentifier string
 
 func (f StackIdentifier) applyToComposeStack(o *composeStackOptions) {
@@ -120,6 +134,12 @@ type dockerCompose struct {
 	// paths to stack files that will be considered when compiling the final compose project
 	configs []string
 
+	// profiles to activate when compiling the compose project
+	profiles []string
+
+	// paths to env files used to interpolate the stack files
+	envFiles []string
+
 	// used to set logger in DockerContainer
 	logger testcontainers.Logging
 
@@ -127,6 +147,13 @@ type dockerCompose struct {
 	// only one strategy can be added to a service, to use multiple use wait.ForAll(...)
 	waitStrategies map[string]wait.Strategy
 
+	// log consumers that are attached per service when starting the stack
+	logConsumers map[string][]testcontainers.LogConsumer
+
+	// containers of the stack producing logs to the log consumers,
+	// used to stop the log production when tearing down the stack
+	logProducers []*testcontainers.DockerContainer
+
 	// used to synchronise writes to the containers map
 	containersLock sync.RWMutex
 
@@ -156,6 +183,20 @@ func (d *dockerCompose) ServiceContainer(ctx context.Context, svcName string) (*
 	return d.lookupContainer(ctx, svcName)
 }
 
+// ServiceLogs returns the logs of the container of the given service, both STDOUT and STDERR.
+// It's up to the caller to read and close the returned ReadCloser.
+func (d *dockerCompose) ServiceLogs(ctx context.Context, svcName string) (io.ReadCloser, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	container, err := d.lookupContainer(ctx, svcName)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Logs(ctx)
+}
+
 func (d *dockerCompose) Services() []string {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -177,7 +218,19 @@ func (d *dockerCompose) Down(ctx context.Context, opts ...StackDownOption) error
 		opts[i].applyToStackDown(&options)
 	}
 
-	return d.composeService.Down(ctx, d.name, options.DownOptions)
+	var errs []error
+	for _, c := range d.logProducers {
+		if err := c.StopLogProducer(); err != nil {
+			errs = append(errs, fmt.Errorf("error stopping the log production of %s: %w", c.GetContainerID(), err))
+		}
+	}
+	d.logProducers = nil
+
+	if err := d.composeService.Down(ctx, d.name, options.DownOptions); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }
 
 func (d *dockerCompose) Up(ctx context.Context, opts ...StackUpOption) error {
@@ -235,6 +288,13 @@ func (d *dockerCompose) Up(ctx context.Context, opts ...StackUpOption) error {
 		return err
 	}
 
+	// start the log production before waiting, so that the consumers receive the startup logs too
+	for svc, consumers := range d.logConsumers {
+		if err := d.followServiceLogs(ctx, svc, consumers); err != nil {
+			return err
+		}
+	}
+
 	if len(d.waitStrategies) == 0 {
 		return nil
 	}
@@ -265,6 +325,16 @@ func (d *dockerCompose) WaitForService(s string, strategy wait.Strategy) Compose
 	return d
 }
 
+// WithLogConsumers attaches the given log consumers to the container of the given service,
+// which receive its logs once the stack is started with Up, until the stack is torn down with Down.
+func (d *dockerCompose) WithLogConsumers(svcName string, consumers ...testcontainers.LogConsumer) ComposeStack {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.logConsumers[svcName] = append(d.logConsumers[svcName], consumers...)
+	return d
+}
+
 func (d *dockerCompose) WithEnv(m map[string]string) ComposeStack {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -281,6 +351,30 @@ func (d *dockerCompose) WithOsEnv() ComposeStack {
 	return d
 }
 
+// followServiceLogs starts producing the logs of the container of the given service to the consumers.
+func (d *dockerCompose) followServiceLogs(ctx context.Context, svcName string, consumers []testcontainers.LogConsumer) error {
+	if len(consumers) == 0 {
+		return nil
+	}
+
+	container, err := d.lookupContainer(ctx, svcName)
+	if err != nil {
+		return err
+	}
+
+	for _, consumer := range consumers {
+		container.FollowOutput(consumer) //nolint:staticcheck
+	}
+
+	if err := container.StartLogProducer(ctx); err != nil { //nolint:staticcheck
+		return fmt.Errorf("error starting the log production of service %s: %w", svcName, err)
+	}
+
+	d.logProducers = append(d.logProducers, container)
+
+	return nil
+}
+
 func (d *dockerCompose) lookupContainer(ctx context.Context, svcName string) (*testcontainers.DockerContainer, error) {
 	d.containersLock.Lock()
 	defer d.containersLock.Unlock()
@@ -328,11 +422,18 @@ func (d *dockerCompose) lookupContainer(ctx context.Context, svcName string) (*t
 }
 
 func (d *dockerCompose) compileProject(ctx context.Context) (*types.Project, error) {
-	const nameAndDefaultConfigPath = 2
-	projectOptions := make([]cli.ProjectOptionsFn, len(d.projectOptions), len(d.projectOptions)+nameAndDefaultConfigPath)
+	const nameDefaultConfigPathAndProfiles = 3
+	const envFilesAndDotEnv = 2
+	projectOptions := make([]cli.ProjectOptionsFn, len(d.projectOptions), len(d.projectOptions)+nameDefaultConfigPathAndProfiles+envFilesAndDotEnv)
 
 	copy(projectOptions, d.projectOptions)
-	projectOptions = append(projectOptions, cli.WithName(d.name), cli.WithDefaultConfigPath)
+	projectOptions = append(projectOptions, cli.WithName(d.name), cli.WithDefaultConfigPath, cli.WithProfiles(d.profiles))
+
+	if len(d.envFiles) > 0 {
+		// the env files are loaded after the environment set with WithEnv or WithOsEnv,
+		// which takes precedence, as the variables already set are not overridden
+		projectOptions = append(projectOptions, cli.WithEnvFiles(d.envFiles...), cli.WithDotEnv)
+	}
 
 	compiledOptions, err := cli.NewProjectOptions(d.configs, projectOptions...)
 	if err != nil {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -370,6 +373,171 @@ func TestDockerComposeAPIWithEnvironment(t *testing.T) {
 	assertContainerEnvironmentVariables(t, identifier.String(), "nginx", present, absent)
 }
 
+func TestDockerComposeAPIWithProfiles(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-profiles.yml")
+
+	tests := []struct {
+		name     string
+		profiles []string
+		expected []string
+	}{
+		{
+			name:     "no-profiles",
+			expected: []string{"nginx"},
+		},
+		{
+			name:     "debug-profile",
+			profiles: []string{"debug"},
+			expected: []string{"nginx", "nginx-debug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identifier := testNameHash(t.Name())
+
+			// composeWithProfiles {
+			compose, err := NewDockerComposeWith(WithStackFiles(path), WithProfiles(tt.profiles...), identifier)
+			// }
+			require.NoError(t, err, "NewDockerCompose()")
+
+			t.Cleanup(func() {
+				require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			err = compose.Up(ctx, Wait(true))
+			require.NoError(t, err, "compose.Up()")
+
+			assert.ElementsMatch(t, tt.expected, compose.Services())
+		})
+	}
+}
+
+func TestDockerComposeAPIWithEnvFiles(t *testing.T) {
+	identifier := testNameHash(t.Name())
+
+	path := filepath.Join(testdataPackage, simpleCompose)
+
+	// composeWithEnvFiles {
+	compose, err := NewDockerComposeWith(
+		WithStackFiles(path),
+		WithEnvFiles(
+			filepath.Join(testdataPackage, "docker-compose.env"),
+			filepath.Join(testdataPackage, "docker-compose-override.env"),
+		),
+		identifier,
+	)
+	// }
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// the environment set programmatically takes precedence over the env files
+	err = compose.
+		WithEnv(map[string]string{
+			"bar": "BAR",
+		}).
+		Up(ctx, Wait(true))
+	require.NoError(t, err, "compose.Up()")
+
+	present := map[string]string{
+		"bar": "BAR",
+		"foo": "FOO_OVERRIDE",
+	}
+	absent := map[string]string{}
+	assertContainerEnvironmentVariables(t, identifier.String(), "nginx", present, absent)
+}
+
+type serviceLogConsumer struct {
+	mtx  sync.Mutex
+	msgs []string
+}
+
+func (lc *serviceLogConsumer) Accept(l testcontainers.Log) {
+	lc.mtx.Lock()
+	defer lc.mtx.Unlock()
+
+	lc.msgs = append(lc.msgs, string(l.Content))
+}
+
+func (lc *serviceLogConsumer) Messages() []string {
+	lc.mtx.Lock()
+	defer lc.mtx.Unlock()
+
+	return append([]string{}, lc.msgs...)
+}
+
+func TestDockerComposeAPIWithLogConsumers(t *testing.T) {
+	path := filepath.Join(testdataPackage, complexCompose)
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// composeWithLogConsumers {
+	consumer := &serviceLogConsumer{}
+
+	err = compose.
+		WithLogConsumers("nginx", consumer).
+		WaitForService("nginx", wait.ForLog("Configuration complete; ready for start up")).
+		Up(ctx, Wait(true))
+	// }
+	require.NoError(t, err, "compose.Up()")
+
+	require.Eventually(t, func() bool {
+		for _, msg := range consumer.Messages() {
+			if strings.Contains(msg, "Configuration complete; ready for start up") {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestDockerComposeAPIServiceLogs(t *testing.T) {
+	path := filepath.Join(testdataPackage, simpleCompose)
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	err = compose.
+		WaitForService("nginx", wait.ForLog("Configuration complete; ready for start up")).
+		Up(ctx, Wait(true))
+	require.NoError(t, err, "compose.Up()")
+
+	// composeServiceLogs {
+	logs, err := compose.ServiceLogs(ctx, "nginx")
+	require.NoError(t, err, "compose.ServiceLogs()")
+	defer logs.Close()
+
+	content, err := io.ReadAll(logs)
+	require.NoError(t, err)
+	// }
+	assert.Contains(t, string(content), "Configuration complete; ready for start up")
+
+	_, err = compose.ServiceLogs(ctx, "mysql")
+	require.Error(t, err, "expected error for a service not in the stack")
+}
+
 func TestDockerComposeAPIWithMultipleComposeFiles(t *testing.T) {
 	composeFiles := ComposeStackFiles{
 		filepath.Join(testdataPackage, simpleCompose),
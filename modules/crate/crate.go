@@ -0,0 +1,203 @@
+package crate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "crate:5.6.5"
+	httpPort     = "4200/tcp"
+	pgPort       = "5432/tcp"
+
+	defaultClusterName = "testcontainers"
+
+	// superuser is the superuser of CrateDB, trusted without a password
+	superuser = "crate"
+
+	// defaultSchema is the schema of the tables created without one
+	defaultSchema = "doc"
+)
+
+// CrateContainer represents the CrateDB container type used in the module
+type CrateContainer struct {
+	testcontainers.Container
+	user     string
+	password string
+}
+
+// RunContainer creates an instance of the CrateDB container type, running a single node cluster.
+// The "crate" superuser is trusted without a password, while the user created with WithCredentials
+// authenticates with its password, both on the HTTP and the PostgreSQL wire protocol ports.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CrateContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{httpPort, pgPort},
+		Env: map[string]string{
+			"CRATE_HEAP_SIZE": "512m",
+		},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{clusterName: defaultClusterName}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	genericContainerReq.Cmd = []string{
+		"-Cdiscovery.type=single-node",
+		"-Ccluster.name=" + settings.clusterName,
+		// the superuser is trusted from any address, the rest of the users authenticate with their password
+		"-Cauth.host_based.enabled=true",
+		"-Cauth.host_based.config.0.user=" + superuser,
+		"-Cauth.host_based.config.0.method=trust",
+		"-Cauth.host_based.config.99.method=password",
+	}
+
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForAll(
+			wait.ForHTTP("/").WithPort(httpPort),
+			wait.ForListeningPort(pgPort),
+		)
+	}
+
+	user, password := superuser, ""
+	if settings.user != "" && settings.user != superuser {
+		user, password = settings.user, settings.password
+
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return createUser(ctx, c, settings.user, settings.password)
+				},
+			},
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CrateContainer{Container: container, user: user, password: password}, nil
+}
+
+// createUser creates the user with its password as the superuser, granting it all the privileges
+func createUser(ctx context.Context, c testcontainers.Container, user string, password string) error {
+	endpoint, err := c.PortEndpoint(ctx, httpPort, "http")
+	if err != nil {
+		return err
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE USER %s WITH (password = %s)", quoteIdentifier(user), quoteLiteral(password)),
+		"GRANT ALL PRIVILEGES TO " + quoteIdentifier(user),
+	}
+
+	for _, stmt := range statements {
+		if err := execSQL(ctx, endpoint, stmt); err != nil {
+			return fmt.Errorf("error creating the user %s: %w", user, err)
+		}
+	}
+
+	return nil
+}
+
+// execSQL runs the statement with the HTTP endpoint of CrateDB, as the superuser
+func execSQL(ctx context.Context, endpoint string, stmt string) error {
+	body, err := json.Marshal(map[string]string{"stmt": stmt})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/_sql", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
+
+// quoteIdentifier quotes the identifier, escaping its double quotes
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quoteLiteral quotes the string literal, escaping its single quotes
+func quoteLiteral(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+// ConnectionString returns the PostgreSQL connection string for the CrateDB container, using the "doc" schema,
+// the default one of CrateDB. The extra arguments are appended as query parameters, e.g. "application_name=test".
+func (c *CrateContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, pgPort)
+	if err != nil {
+		return "", err
+	}
+
+	user := url.User(c.user)
+	if c.password != "" {
+		user = url.UserPassword(c.user, c.password)
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     user,
+		Host:     fmt.Sprintf("%s:%s", host, port.Port()),
+		Path:     "/" + defaultSchema,
+		RawQuery: strings.Join(append([]string{"sslmode=disable"}, args...), "&"),
+	}
+
+	return u.String(), nil
+}
+
+// HTTPEndpoint returns the URL of the HTTP endpoint of the CrateDB container, with the format http://<host>:<port>,
+// serving the Admin UI and the /_sql endpoint. The requests of the user created with WithCredentials
+// must use basic authentication.
+func (c *CrateContainer) HTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, httpPort, "http")
+}
+
+// User returns the user of the connection string, which is "crate" unless WithCredentials is used
+func (c *CrateContainer) User() string {
+	return c.user
+}
+
+// Password returns the password of the user, which is empty for the "crate" superuser
+func (c *CrateContainer) Password() string {
+	return c.password
+}
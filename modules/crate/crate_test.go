@@ -0,0 +1,108 @@
+package crate_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/crate"
+)
+
+func TestCrate(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := crate.RunContainer(ctx, crate.WithClusterName("tc-cluster"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "crate", container.User())
+	assert.Empty(t, container.Password())
+
+	endpoint, err := container.HTTPEndpoint(ctx)
+	require.NoError(t, err)
+
+	rows := query(t, endpoint, "", "", "SELECT name FROM sys.cluster")
+	require.Equal(t, [][]any{{"tc-cluster"}}, rows)
+
+	connStr, err := container.ConnectionString(ctx, "application_name=test")
+	require.NoError(t, err)
+	assert.Regexp(t, `^postgres://crate@.+:\d+/doc\?sslmode=disable&application_name=test$`, connStr)
+}
+
+func TestCrateWithCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	// withCredentials {
+	container, err := crate.RunContainer(ctx, crate.WithCredentials("tc", "s3cr3t"))
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	endpoint, err := container.HTTPEndpoint(ctx)
+	require.NoError(t, err)
+
+	query(t, endpoint, "tc", "s3cr3t", "CREATE TABLE items (name TEXT)")
+	query(t, endpoint, "tc", "s3cr3t", "INSERT INTO items (name) VALUES ('crate')")
+	query(t, endpoint, "tc", "s3cr3t", "REFRESH TABLE items")
+
+	rows := query(t, endpoint, "tc", "s3cr3t", "SELECT name FROM items")
+	require.Equal(t, [][]any{{"crate"}}, rows)
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	assert.Regexp(t, `^postgres://tc:s3cr3t@.+:\d+/doc\?sslmode=disable$`, connStr)
+
+	t.Run("invalid-credentials", func(t *testing.T) {
+		resp := post(t, endpoint, "tc", "wrong", "SELECT 1")
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+// query runs the statement with the /_sql endpoint, returning the rows of the result
+func query(t *testing.T, endpoint string, user string, password string, stmt string) [][]any {
+	t.Helper()
+
+	resp := post(t, endpoint, user, password, stmt)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Rows [][]any `json:"rows"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	return result.Rows
+}
+
+func post(t *testing.T, endpoint string, user string, password string, stmt string) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"stmt": stmt})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, endpoint+"/_sql", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,41 @@
+package crate_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/crate"
+)
+
+func ExampleRunContainer() {
+	// runCrateContainer {
+	ctx := context.Background()
+
+	crateContainer, err := crate.RunContainer(ctx,
+		testcontainers.WithImage("crate:5.6.5"),
+		crate.WithClusterName("tc-cluster"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := crateContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := crateContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,38 @@
+package crate
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	clusterName string
+	user        string
+	password    string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the CrateDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithClusterName sets the name of the single node cluster. Defaults to "testcontainers".
+func WithClusterName(name string) Option {
+	return func(o *options) {
+		o.clusterName = name
+	}
+}
+
+// WithCredentials creates a user authenticated with a password, with all the privileges,
+// which the connection string and the HTTP endpoint use instead of the "crate" superuser.
+func WithCredentials(user string, password string) Option {
+	return func(o *options) {
+		o.user = user
+		o.password = password
+	}
+}
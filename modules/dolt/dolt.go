@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -40,12 +41,18 @@ func WithDefaultCredentials() testcontainers.CustomizeRequestOption {
 
 // RunContainer creates an instance of the Dolt container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*DoltContainer, error) {
+	// the default password is random, unless deterministic credentials are enabled
+	generatedPassword, err := credentials.Password(defaultPassword)
+	if err != nil {
+		return nil, fmt.Errorf("error generating the default password: %w", err)
+	}
+
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
 		ExposedPorts: []string{"3306/tcp", "33060/tcp"},
 		Env: map[string]string{
 			"DOLT_USER":     defaultUser,
-			"DOLT_PASSWORD": defaultPassword,
+			"DOLT_PASSWORD": generatedPassword,
 			"DOLT_DATABASE": defaultDatabaseName,
 		},
 		WaitingFor: wait.ForLog("Server ready. Accepting connections."),
@@ -0,0 +1,158 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "eventstore/eventstore:23.10.1-bookworm-slim"
+	defaultPort  = "2113/tcp"
+
+	// defaultUser and defaultPassword are the credentials of the admin user, required in secure mode
+	defaultUser     = "admin"
+	defaultPassword = "changeit"
+
+	// certsDir is the directory of the certificates in the container
+	certsDir = "/etc/eventstore/certs"
+)
+
+// EventStoreContainer represents the EventStoreDB container type used in the module
+type EventStoreContainer struct {
+	testcontainers.Container
+	caCertFile string
+}
+
+// RunContainer creates an instance of the EventStoreDB container type, running a single node
+// in insecure mode, with an in-memory database. Use WithTLS to run it in secure mode.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*EventStoreContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env: map[string]string{
+			"EVENTSTORE_CLUSTER_SIZE":               "1",
+			"EVENTSTORE_MEM_DB":                     "true",
+			"EVENTSTORE_INSECURE":                   "true",
+			"EVENTSTORE_ENABLE_ATOM_PUB_OVER_HTTP":  "true",
+			"EVENTSTORE_RUN_PROJECTIONS":            "None",
+			"EVENTSTORE_START_STANDARD_PROJECTIONS": "false",
+		},
+		WaitingFor: wait.ForHTTP("/health/live").WithPort(defaultPort).WithStatusCodeMatcher(isLive),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.tls != nil {
+		applyTLSSettings(settings.tls)(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &EventStoreContainer{Container: container}
+	if settings.tls != nil {
+		c.caCertFile = settings.tls.CACertFile
+	}
+
+	return c, nil
+}
+
+// WithProjections enables the projections subsystem, starting the system projections,
+// like $by_category or $by_event_type, so user-defined projections can be created.
+func WithProjections() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["EVENTSTORE_RUN_PROJECTIONS"] = "All"
+		req.Env["EVENTSTORE_START_STANDARD_PROJECTIONS"] = "true"
+	}
+}
+
+// applyTLSSettings copies the certificates to the container, running it in secure mode,
+// and waits for the health endpoint over HTTPS.
+func applyTLSSettings(settings *TLSSettings) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.CACertFile,
+				ContainerFilePath: certsDir + "/ca/ca.crt",
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.CertFile,
+				ContainerFilePath: certsDir + "/node.crt",
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.KeyFile,
+				ContainerFilePath: certsDir + "/node.key",
+				FileMode:          0o644,
+			},
+		)
+
+		req.Env["EVENTSTORE_INSECURE"] = "false"
+		req.Env["EVENTSTORE_TRUSTED_ROOT_CERTIFICATES_PATH"] = certsDir + "/ca"
+		req.Env["EVENTSTORE_CERTIFICATE_FILE"] = certsDir + "/node.crt"
+		req.Env["EVENTSTORE_CERTIFICATE_PRIVATE_KEY_FILE"] = certsDir + "/node.key"
+
+		// the health endpoint is only checked for liveness, so the certificate is not verified
+		req.WaitingFor = wait.ForHTTP("/health/live").
+			WithPort(defaultPort).
+			WithTLS(true, &tls.Config{InsecureSkipVerify: true}). // nolint:gosec
+			WithStatusCodeMatcher(isLive)
+	}
+}
+
+// ConnectionString returns the connection string for the esdb Go client, with the format
+// esdb://<host>:<port>?tls=false in insecure mode, and esdb://admin:changeit@<host>:<port>?tls=true&tlsCAFile=<path>
+// in secure mode, using the credentials of the admin user and the CA certificate passed to WithTLS.
+func (c *EventStoreContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	connStr := url.URL{
+		Scheme: "esdb",
+		Host:   fmt.Sprintf("%s:%s", host, port.Port()),
+	}
+
+	params := url.Values{}
+	if c.caCertFile == "" {
+		params.Set("tls", "false")
+	} else {
+		connStr.User = url.UserPassword(defaultUser, defaultPassword)
+		params.Set("tls", "true")
+		params.Set("tlsCAFile", c.caCertFile)
+	}
+	connStr.RawQuery = params.Encode()
+
+	return connStr.String(), nil
+}
+
+// isLive returns true if the status code of the health endpoint means the node is live
+func isLive(status int) bool {
+	return status == http.StatusNoContent || status == http.StatusOK
+}
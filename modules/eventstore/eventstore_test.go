@@ -0,0 +1,218 @@
+package eventstore_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/eventstore"
+)
+
+func TestEventStore(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := eventstore.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connStr, err := container.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+
+	u, err := url.Parse(connStr)
+	require.NoError(t, err)
+	assert.Equal(t, "esdb", u.Scheme)
+	assert.Equal(t, "false", u.Query().Get("tls"))
+
+	// append an event to a stream and read it back, using the HTTP API
+	baseURL := "http://" + u.Host
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/streams/orders-1", strings.NewReader(`[{
+		"eventId": "fbf4a1a1-b4a3-4dfe-a01f-ec52c34e16e4",
+		"eventType": "OrderPlaced",
+		"data": {"total": 42}
+	}]`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/vnd.eventstore.events+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodGet, baseURL+"/streams/orders-1/0", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/vnd.eventstore.atom+json")
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "OrderPlaced")
+}
+
+func TestEventStore_withProjections(t *testing.T) {
+	ctx := context.Background()
+
+	// withProjections {
+	container, err := eventstore.RunContainer(ctx, eventstore.WithProjections())
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	endpoint, err := container.PortEndpoint(ctx, "2113/tcp", "http")
+	require.NoError(t, err)
+
+	// the system projections are started in the background
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(endpoint + "/projection/$by_category")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		return err == nil && resp.StatusCode == http.StatusOK && strings.Contains(string(body), "Running")
+	}, 30*time.Second, 500*time.Millisecond)
+}
+
+func TestEventStore_withTLS(t *testing.T) {
+	ctx := context.Background()
+
+	settings := generateCertificates(t)
+
+	// withTLS {
+	container, err := eventstore.RunContainer(ctx, eventstore.WithTLS(settings))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	u, err := url.Parse(connStr)
+	require.NoError(t, err)
+	assert.Equal(t, "true", u.Query().Get("tls"))
+	assert.Equal(t, settings.CACertFile, u.Query().Get("tlsCAFile"))
+
+	password, _ := u.User.Password()
+	assert.Equal(t, "admin", u.User.Username())
+	assert.Equal(t, "changeit", password)
+
+	// the node certificate is signed by the CA
+	caCert, err := os.ReadFile(settings.CACertFile)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caCert))
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, ServerName: "localhost"}}}
+
+	req, err := http.NewRequest(http.MethodGet, "https://"+u.Host+"/info", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth(u.User.Username(), password)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// generateCertificates generates a CA and a node certificate signed by it, valid for localhost,
+// writing them to a temporary directory
+func generateCertificates(t *testing.T) eventstore.TLSSettings {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "EventStoreDB CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	nodeKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	nodeTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "eventstoredb-node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost", "eventstoredb-node"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	nodeDER, err := x509.CreateCertificate(rand.Reader, nodeTemplate, caCert, &nodeKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	nodeKeyDER, err := x509.MarshalPKCS8PrivateKey(nodeKey)
+	require.NoError(t, err)
+
+	settings := eventstore.TLSSettings{
+		CACertFile: filepath.Join(dir, "ca.crt"),
+		CertFile:   filepath.Join(dir, "node.crt"),
+		KeyFile:    filepath.Join(dir, "node.key"),
+	}
+
+	writePEM(t, settings.CACertFile, "CERTIFICATE", caDER)
+	writePEM(t, settings.CertFile, "CERTIFICATE", nodeDER)
+	writePEM(t, settings.KeyFile, "PRIVATE KEY", nodeKeyDER)
+
+	return settings
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o644)
+	require.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+package eventstore_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/eventstore"
+)
+
+func ExampleRunContainer() {
+	// runEventStoreContainer {
+	ctx := context.Background()
+
+	eventstoreContainer, err := eventstore.RunContainer(ctx,
+		testcontainers.WithImage("eventstore/eventstore:23.10.1-bookworm-slim"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := eventstoreContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := eventstoreContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
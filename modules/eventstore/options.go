@@ -0,0 +1,46 @@
+package eventstore
+
+import (
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	tls *TLSSettings
+}
+
+// TLSSettings defines the certificates used to run EventStoreDB in secure mode
+type TLSSettings struct {
+	// CACertFile is the path of the CA certificate file, which signed the node certificate
+	CACertFile string
+	// CertFile is the path of the node certificate file. It must be valid for the host of the container,
+	// usually localhost, and its common name must be "eventstoredb-node"
+	CertFile string
+	// KeyFile is the path of the node private key file
+	KeyFile string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the EventStoreDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithTLS runs EventStoreDB in secure mode, with the given certificates. In secure mode,
+// the clients must authenticate, e.g. with the credentials of the admin user, admin:changeit.
+func WithTLS(settings TLSSettings) Option {
+	return func(o *options) {
+		// the CA certificate file is used in the connection string, so it must be absolute
+		if absPath, err := filepath.Abs(settings.CACertFile); err == nil {
+			settings.CACertFile = absPath
+		}
+
+		o.tls = &settings
+	}
+}
@@ -0,0 +1,96 @@
+package ferretdb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/ferretdb"
+)
+
+func ExampleRunContainer() {
+	// runFerretDBContainer {
+	ctx := context.Background()
+
+	ferretdbContainer, err := ferretdb.RunContainer(ctx, testcontainers.WithImage("ghcr.io/ferretdb/all-in-one:1.21.0"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := ferretdbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := ferretdbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
+
+func ExampleRunContainer_connect() {
+	// connectToFerretDB {
+	ctx := context.Background()
+
+	ferretdbContainer, err := ferretdb.RunContainer(ctx,
+		ferretdb.WithCredentials("user", "s3cr3t"),
+		ferretdb.WithDatabase("shop"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := ferretdbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+
+	connStr, err := ferretdbContainer.ConnectionString(ctx)
+	if err != nil {
+		log.Fatalf("failed to get connection string: %s", err) // nolint:gocritic
+	}
+
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(connStr))
+	if err != nil {
+		log.Fatalf("failed to connect to FerretDB: %s", err) // nolint:gocritic
+	}
+	// }
+
+	defer func() {
+		if err := mongoClient.Disconnect(ctx); err != nil {
+			log.Fatalf("failed to disconnect from FerretDB: %s", err) // nolint:gocritic
+		}
+	}()
+
+	coll := mongoClient.Database("shop").Collection("products")
+
+	_, err = coll.InsertOne(ctx, bson.D{{Key: "name", Value: "ferret"}})
+	if err != nil {
+		log.Fatalf("failed to insert document: %s", err) // nolint:gocritic
+	}
+
+	count, err := coll.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		log.Fatalf("failed to count documents: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(count)
+
+	// Output:
+	// 1
+}
@@ -0,0 +1,128 @@
+package ferretdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage is the default FerretDB container image, bundling a PostgreSQL server as the storage backend
+	defaultImage = "ghcr.io/ferretdb/all-in-one:1.21.0"
+	defaultPort  = "27017/tcp"
+
+	defaultDatabase = "ferretdb"
+
+	// defaultUser and defaultPassword are the credentials used when deterministic credentials are enabled
+	defaultUser     = "ferretdb"
+	defaultPassword = "ferretdb"
+)
+
+// FerretDBContainer represents the FerretDB container type used in the module
+type FerretDBContainer struct {
+	testcontainers.Container
+	user     string
+	password string
+	database string
+}
+
+// RunContainer creates an instance of the FerretDB container type, a MongoDB-compatible database backed
+// by the PostgreSQL server running in the same container. The credentials are the ones of the PostgreSQL
+// user, random unless WithCredentials is used.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*FerretDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env:          map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{database: defaultDatabase}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.user == "" || settings.password == "" {
+		user, err := credentials.Username(defaultUser)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the user: %w", err)
+		}
+
+		password, err := credentials.Password(defaultPassword)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the password: %w", err)
+		}
+
+		settings.user, settings.password = user, password
+	}
+
+	genericContainerReq.Env["POSTGRES_USER"] = settings.user
+	genericContainerReq.Env["POSTGRES_PASSWORD"] = settings.password
+	genericContainerReq.Env["POSTGRES_DB"] = settings.database
+	genericContainerReq.Env["FERRETDB_POSTGRESQL_URL"] = "postgres://127.0.0.1:5432/" + settings.database
+
+	if genericContainerReq.WaitingFor == nil {
+		// FerretDB listens before the PostgreSQL server accepts connections
+		genericContainerReq.WaitingFor = wait.ForAll(
+			wait.ForListeningPort(defaultPort),
+			wait.ForExec([]string{"pg_isready", "-h", "127.0.0.1", "-U", settings.user, "-d", settings.database}),
+		)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FerretDBContainer{
+		Container: container,
+		user:      settings.user,
+		password:  settings.password,
+		database:  settings.database,
+	}, nil
+}
+
+// ConnectionString returns the MongoDB connection string for the FerretDB container,
+// authenticating with the PLAIN mechanism, as FerretDB passes the credentials to PostgreSQL.
+func (c *FerretDBContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{
+		Scheme:   "mongodb",
+		User:     url.UserPassword(c.user, c.password),
+		Host:     fmt.Sprintf("%s:%s", host, port.Port()),
+		Path:     "/" + c.database,
+		RawQuery: "authMechanism=PLAIN",
+	}
+
+	return u.String(), nil
+}
+
+// User returns the user of the FerretDB container
+func (c *FerretDBContainer) User() string {
+	return c.user
+}
+
+// Password returns the password of the user of the FerretDB container
+func (c *FerretDBContainer) Password() string {
+	return c.password
+}
@@ -0,0 +1,62 @@
+package ferretdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/testcontainers/testcontainers-go/modules/ferretdb"
+)
+
+func TestFerretDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := ferretdb.RunContainer(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// the credentials are random by default
+	require.NotEmpty(t, container.User())
+	require.NotEmpty(t, container.Password())
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connStr))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(ctx))
+	})
+
+	coll := client.Database("ferretdb").Collection("test")
+
+	_, err = coll.InsertOne(ctx, bson.D{{Key: "name", Value: "ferret"}})
+	require.NoError(t, err)
+
+	var doc bson.M
+	err = coll.FindOne(ctx, bson.D{{Key: "name", Value: "ferret"}}).Decode(&doc)
+	require.NoError(t, err)
+	require.Equal(t, "ferret", doc["name"])
+
+	t.Run("invalid-credentials", func(t *testing.T) {
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(connStr).SetAuth(options.Credential{
+			AuthMechanism: "PLAIN",
+			Username:      container.User(),
+			Password:      "wrong",
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, client.Disconnect(ctx))
+		})
+
+		err = client.Ping(ctx, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,39 @@
+package ferretdb
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	user     string
+	password string
+	database string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the FerretDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithCredentials sets the user and the password of the PostgreSQL backend, used to authenticate
+// the MongoDB clients. Both are random by default.
+func WithCredentials(user string, password string) Option {
+	return func(o *options) {
+		o.user = user
+		o.password = password
+	}
+}
+
+// WithDatabase sets the PostgreSQL database storing the data, which is also the default
+// database of the connection string. Defaults to "ferretdb".
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
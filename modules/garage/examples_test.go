@@ -0,0 +1,41 @@
+package garage_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/garage"
+)
+
+func ExampleRunContainer() {
+	// runGarageContainer {
+	ctx := context.Background()
+
+	garageContainer, err := garage.RunContainer(ctx,
+		testcontainers.WithImage("dxflrs/garage:v1.0.0"),
+		garage.WithBuckets("testcontainers"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := garageContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := garageContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,229 @@
+package garage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "dxflrs/garage:v1.0.0"
+	s3Port       = "3900/tcp"
+	adminPort    = "3903/tcp"
+
+	// the default path of the configuration file, read by the garage binary, both the server and the CLI
+	configFile = "/etc/garage.toml"
+
+	// the name of the S3 key in Garage
+	keyName = "testcontainers"
+
+	// defaultAccessKey and defaultSecretKey are the credentials used when deterministic credentials are enabled
+	defaultAccessKey = "GK746573746361696e6572732d"
+	defaultSecretKey = "7465737463616e7461696e6572732d7465737463616e7461696e6572732d2d2d"
+
+	// Region is the region of the S3 API, which the clients must use to sign their requests.
+	Region = "garage"
+)
+
+// GarageContainer represents the Garage container type used in the module
+type GarageContainer struct {
+	testcontainers.Container
+	AccessKey string
+	SecretKey string
+}
+
+// RunContainer creates an instance of the Garage container type, running a single node cluster,
+// whose layout is applied once the container is ready. An S3 key is created with random
+// credentials, unless WithCredentials is used.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GarageContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{s3Port, adminPort},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(s3Port),
+			wait.ForListeningPort(adminPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.accessKey == "" || settings.secretKey == "" {
+		settings.accessKey, settings.secretKey = defaultAccessKey, defaultSecretKey
+
+		if !credentials.Deterministic() {
+			accessKey, err := randomHex(12)
+			if err != nil {
+				return nil, fmt.Errorf("error generating the access key: %w", err)
+			}
+
+			secretKey, err := randomHex(32)
+			if err != nil {
+				return nil, fmt.Errorf("error generating the secret key: %w", err)
+			}
+
+			settings.accessKey, settings.secretKey = "GK"+accessKey, secretKey
+		}
+	}
+
+	config, err := configuration()
+	if err != nil {
+		return nil, err
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            strings.NewReader(config),
+		ContainerFilePath: configFile,
+		FileMode:          0o644,
+	})
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, c testcontainers.Container) error {
+				return bootstrap(ctx, c, settings)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GarageContainer{Container: container, AccessKey: settings.accessKey, SecretKey: settings.secretKey}, nil
+}
+
+// ConnectionString returns the host and port of the S3 API of the Garage container,
+// e.g. to be used as the endpoint of the minio-go client.
+func (c *GarageContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, s3Port)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, port.Port()), nil
+}
+
+// S3Endpoint returns the URL of the S3 API of the Garage container, with the format http://<host>:<port>,
+// e.g. to be used as the base endpoint of the AWS SDK, with path-style addressing and the Region of Garage.
+func (c *GarageContainer) S3Endpoint(ctx context.Context) (string, error) {
+	hostPort, err := c.ConnectionString(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + hostPort, nil
+}
+
+// configuration returns the configuration of a single node cluster, with a random RPC secret
+func configuration() (string, error) {
+	rpcSecret, err := randomHex(32)
+	if err != nil {
+		return "", fmt.Errorf("error generating the RPC secret: %w", err)
+	}
+
+	return fmt.Sprintf(`metadata_dir = "/var/lib/garage/meta"
+data_dir = "/var/lib/garage/data"
+db_engine = "sqlite"
+
+replication_factor = 1
+
+rpc_bind_addr = "0.0.0.0:3901"
+rpc_public_addr = "127.0.0.1:3901"
+rpc_secret = "%s"
+
+[s3_api]
+s3_region = "%s"
+api_bind_addr = "0.0.0.0:3900"
+
+[admin]
+api_bind_addr = "0.0.0.0:3903"
+`, rpcSecret, Region), nil
+}
+
+// bootstrap applies the layout of the single node cluster, imports the S3 key, allowing it to create buckets,
+// and creates the buckets, with the Garage CLI. The image does not include a shell, so each command is executed on its own.
+func bootstrap(ctx context.Context, c testcontainers.Container, settings options) error {
+	output, err := garage(ctx, c, "node", "id", "-q")
+	if err != nil {
+		return err
+	}
+
+	// the output is <node id>@<rpc public address>
+	nodeID, _, _ := strings.Cut(strings.TrimSpace(output), "@")
+
+	commands := [][]string{
+		{"layout", "assign", "-z", "dc1", "-c", "1G", nodeID},
+		{"layout", "apply", "--version", "1"},
+		{"key", "import", "--yes", "-n", keyName, settings.accessKey, settings.secretKey},
+		{"key", "allow", "--create-bucket", keyName},
+	}
+
+	for _, bucket := range settings.buckets {
+		commands = append(commands,
+			[]string{"bucket", "create", bucket},
+			[]string{"bucket", "allow", "--read", "--write", "--owner", bucket, "--key", keyName},
+		)
+	}
+
+	for _, command := range commands {
+		if _, err := garage(ctx, c, command...); err != nil {
+			return err
+		}
+	}
+
+	// the cluster is healthy once the layout is applied
+	return wait.ForHTTP("/health").WithPort(adminPort).WaitUntilReady(ctx, c)
+}
+
+// garage runs a command of the Garage CLI in the container, returning its output
+func garage(ctx context.Context, c testcontainers.Container, args ...string) (string, error) {
+	code, reader, err := c.Exec(ctx, append([]string{"/garage"}, args...), tcexec.Multiplexed())
+	if err != nil {
+		return "", fmt.Errorf("error running garage %s: %w", strings.Join(args, " "), err)
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("error reading the output of garage %s: %w", strings.Join(args, " "), err)
+	}
+
+	if code != 0 {
+		return "", fmt.Errorf("error running garage %s, exit code %d: %s", strings.Join(args, " "), code, output)
+	}
+
+	return string(output), nil
+}
+
+// randomHex returns n random bytes, encoded as hexadecimal
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
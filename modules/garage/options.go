@@ -0,0 +1,40 @@
+package garage
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	accessKey string
+	secretKey string
+	buckets   []string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Garage container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithCredentials sets the access key and the secret key of the S3 key, which can create buckets and owns
+// the ones created with WithBuckets. Garage requires the access key to be "GK" followed by 24 hexadecimal characters,
+// and the secret key to be 64 hexadecimal characters. Both are random by default.
+func WithCredentials(accessKey string, secretKey string) Option {
+	return func(o *options) {
+		o.accessKey = accessKey
+		o.secretKey = secretKey
+	}
+}
+
+// WithBuckets creates the given buckets once the container is ready, allowing the S3 key
+// to read and write them.
+func WithBuckets(buckets ...string) Option {
+	return func(o *options) {
+		o.buckets = append(o.buckets, buckets...)
+	}
+}
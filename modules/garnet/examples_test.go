@@ -0,0 +1,41 @@
+package garnet_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/garnet"
+)
+
+func ExampleRunContainer() {
+	// runGarnetContainer {
+	ctx := context.Background()
+
+	garnetContainer, err := garnet.RunContainer(ctx,
+		testcontainers.WithImage("ghcr.io/microsoft/garnet:1.0.6"),
+		garnet.WithMemory("512m"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := garnetContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := garnetContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
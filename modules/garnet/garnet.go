@@ -0,0 +1,106 @@
+package garnet
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage is the default image used for the Garnet container
+	defaultImage = "ghcr.io/microsoft/garnet:1.0.6"
+
+	// defaultPort is the port where Garnet listens for Redis protocol connections
+	defaultPort = "6379/tcp"
+)
+
+// GarnetContainer represents the Garnet container type used in the module
+type GarnetContainer struct {
+	testcontainers.Container
+	password string
+}
+
+// ConnectionString returns the Redis protocol connection string for the Garnet container,
+// with the format redis://[:password@]host:port
+func (c *GarnetContainer) ConnectionString(ctx context.Context) (string, error) {
+	mappedPort, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	hostIP, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	uri := url.URL{
+		Scheme: "redis",
+		Host:   fmt.Sprintf("%s:%s", hostIP, mappedPort.Port()),
+	}
+	if c.password != "" {
+		uri.User = url.UserPassword("", c.password)
+	}
+
+	return uri.String(), nil
+}
+
+// RunContainer creates an instance of the Garnet container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GarnetContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor:   wait.ForListeningPort(defaultPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GarnetContainer{Container: container, password: serverArg(genericContainerReq.Cmd, "--password")}, nil
+}
+
+// WithMemory sets the total log memory used by the Garnet store, e.g. "1g" or "512m".
+func WithMemory(size string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "--memory", size)
+	}
+}
+
+// WithAOF enables the append-only file, so that the Garnet store is persisted.
+func WithAOF() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "--aof")
+	}
+}
+
+// WithPassword sets the password required to authenticate against the Garnet server.
+func WithPassword(password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "--auth", "Password", "--password", password)
+	}
+}
+
+// serverArg returns the value of the last occurrence of the given argument of the server process
+func serverArg(cmd []string, name string) string {
+	value := ""
+	for i := 0; i < len(cmd)-1; i++ {
+		if cmd[i] == name {
+			value = cmd[i+1]
+		}
+	}
+
+	return value
+}
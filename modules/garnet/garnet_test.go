@@ -0,0 +1,78 @@
+package garnet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/garnet"
+)
+
+func TestGarnet(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := garnet.RunContainer(ctx, garnet.WithMemory("512m"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	uri, err := container.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+
+	client := newClient(t, uri)
+
+	require.NoError(t, client.Set(ctx, "key", "value", 0).Err())
+
+	value, err := client.Get(ctx, "key").Result()
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+}
+
+func TestGarnet_withPassword(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := garnet.RunContainer(ctx, garnet.WithPassword("s3cr3t"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.Contains(t, uri, ":s3cr3t@")
+
+	client := newClient(t, uri)
+	require.NoError(t, client.Ping(ctx).Err())
+
+	// the connection without password is rejected
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+	options.Password = ""
+
+	anonymous := redis.NewClient(options)
+	defer anonymous.Close()
+	require.Error(t, anonymous.Set(ctx, "key", "value", 0).Err())
+}
+
+func newClient(t *testing.T, uri string) *redis.Client {
+	t.Helper()
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	client := redis.NewClient(options)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return client
+}
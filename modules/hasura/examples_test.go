@@ -0,0 +1,79 @@
+package hasura_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/hasura"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func ExampleRunContainer() {
+	// runHasuraContainer {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		log.Fatalf("failed to create network: %s", err)
+	}
+
+	// Clean up the network
+	defer func() {
+		if err := nw.Remove(ctx); err != nil {
+			log.Fatalf("failed to remove network: %s", err)
+		}
+	}()
+
+	// the Postgres container is reachable with the "db" alias in the network
+	pgReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "postgres:16-alpine",
+			Env:        map[string]string{"POSTGRES_PASSWORD": "postgres"},
+			WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	}
+	network.WithNetwork([]string{"db"}, nw).Customize(&pgReq)
+
+	pgContainer, err := testcontainers.GenericContainer(ctx, pgReq)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err) // nolint:gocritic
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := pgContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+
+	hasuraContainer, err := hasura.RunContainer(ctx,
+		testcontainers.WithImage("hasura/graphql-engine:v2.38.0"),
+		network.WithNetwork([]string{"hasura"}, nw),
+		hasura.WithDatabaseURL("postgres://postgres:postgres@db:5432/postgres?sslmode=disable"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := hasuraContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := hasuraContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err)
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
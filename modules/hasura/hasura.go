@@ -0,0 +1,146 @@
+package hasura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "hasura/graphql-engine:v2.38.0"
+	defaultPort  = "8080/tcp"
+
+	// adminSecretEnv is the environment variable holding the admin secret
+	adminSecretEnv = "HASURA_GRAPHQL_ADMIN_SECRET"
+)
+
+// HasuraContainer represents the Hasura container type used in the module
+type HasuraContainer struct {
+	testcontainers.Container
+	adminSecret string
+}
+
+// RunContainer creates an instance of the Hasura container type, serving a GraphQL API for the database
+// set with WithDatabaseURL. It waits for Hasura to load the metadata and introspect the database.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*HasuraContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env: map[string]string{
+			"HASURA_GRAPHQL_ENABLE_CONSOLE": "false",
+			"HASURA_GRAPHQL_DEV_MODE":       "true",
+		},
+		// the health endpoint succeeds once the metadata is loaded and the sources are introspected
+		WaitingFor: wait.ForHTTP("/healthz").WithPort(defaultPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HasuraContainer{Container: container, adminSecret: genericContainerReq.Env[adminSecretEnv]}, nil
+}
+
+// WithDatabaseURL sets the connection string of the Postgres database to serve as the "default" source,
+// which must be reachable from the container, e.g. postgres://user:password@db:5432/app, where db is
+// the network alias of a Postgres container in the same network.
+func WithDatabaseURL(url string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["HASURA_GRAPHQL_DATABASE_URL"] = url
+	}
+}
+
+// WithAdminSecret sets the admin secret, which is required to access the GraphQL API as admin
+// and the Metadata API. The requests without it use the unauthorized role, if any.
+func WithAdminSecret(secret string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[adminSecretEnv] = secret
+	}
+}
+
+// WithUnauthorizedRole sets the role used for the requests without the admin secret.
+func WithUnauthorizedRole(role string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["HASURA_GRAPHQL_UNAUTHORIZED_ROLE"] = role
+	}
+}
+
+// URL returns the base URL of Hasura, with the format http://<host>:<port>
+func (c *HasuraContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
+
+// GraphQLEndpoint returns the URL of the GraphQL API, with the format http://<host>:<port>/v1/graphql
+func (c *HasuraContainer) GraphQLEndpoint(ctx context.Context) (string, error) {
+	url, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return url + "/v1/graphql", nil
+}
+
+// TrackTable exposes the given table of the "default" source in the GraphQL API, using the Metadata API.
+func (c *HasuraContainer) TrackTable(ctx context.Context, schema string, table string) error {
+	return c.metadata(ctx, map[string]any{
+		"type": "pg_track_table",
+		"args": map[string]any{
+			"source": "default",
+			"table": map[string]string{
+				"schema": schema,
+				"name":   table,
+			},
+		},
+	})
+}
+
+// metadata sends the given query to the Metadata API, authenticated with the admin secret, if any
+func (c *HasuraContainer) metadata(ctx context.Context, query any) error {
+	url, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/v1/metadata", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.adminSecret != "" {
+		req.Header.Set("X-Hasura-Admin-Secret", c.adminSecret)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d calling the metadata API: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package hasura_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/hasura"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	databaseURL = "postgres://postgres:postgres@db:5432/postgres?sslmode=disable"
+	adminSecret = "my-secret"
+)
+
+func TestHasura(t *testing.T) {
+	ctx := context.Background()
+
+	nw := startPostgres(t)
+
+	// runWithDatabase {
+	container, err := hasura.RunContainer(ctx,
+		network.WithNetwork([]string{"hasura"}, nw),
+		hasura.WithDatabaseURL(databaseURL),
+		hasura.WithAdminSecret(adminSecret),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// trackTable {
+	err = container.TrackTable(ctx, "public", "todos")
+	// }
+	require.NoError(t, err)
+
+	// graphQLEndpoint {
+	endpoint, err := container.GraphQLEndpoint(ctx)
+	// }
+	require.NoError(t, err)
+
+	status, body := query(t, endpoint, adminSecret, `{ todos(where: {done: {_eq: true}}) { id title } }`)
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"data": {"todos": [{"id": 1, "title": "write the tests"}]}}`, body)
+
+	// without the admin secret the requests are rejected, as there is no unauthorized role
+	_, body = query(t, endpoint, "", `{ todos { id } }`)
+	require.Contains(t, body, "access-denied")
+}
+
+func TestHasura_trackTableNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	nw := startPostgres(t)
+
+	container, err := hasura.RunContainer(ctx,
+		network.WithNetwork([]string{"hasura"}, nw),
+		hasura.WithDatabaseURL(databaseURL),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	err = container.TrackTable(ctx, "public", "missing")
+	require.ErrorContains(t, err, "unexpected status code 400")
+}
+
+// startPostgres starts a Postgres container with the todos table, reachable with the "db" alias
+// in the returned network
+func startPostgres(t *testing.T) *testcontainers.DockerNetwork {
+	t.Helper()
+
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "postgres:16-alpine",
+			Env: map[string]string{
+				"POSTGRES_PASSWORD": "postgres",
+			},
+			Files: []testcontainers.ContainerFile{
+				{
+					HostFilePath:      filepath.Join("testdata", "init.sql"),
+					ContainerFilePath: "/docker-entrypoint-initdb.d/init.sql",
+					FileMode:          0o644,
+				},
+			},
+			// the server is restarted after running the init scripts
+			WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	}
+	network.WithNetwork([]string{"db"}, nw).Customize(&req)
+
+	pg, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := pg.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	return nw
+}
+
+// query sends the GraphQL query to the endpoint, with the admin secret if not empty
+func query(t *testing.T, endpoint string, secret string, q string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{"query": "`+strings.ReplaceAll(q, `"`, `\"`)+`"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set("X-Hasura-Admin-Secret", secret)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
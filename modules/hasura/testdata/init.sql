@@ -0,0 +1,7 @@
+CREATE TABLE todos (
+    id SERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+INSERT INTO todos (title, done) VALUES ('write the tests', TRUE), ('ship the module', FALSE);
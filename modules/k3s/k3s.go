@@ -13,6 +13,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -156,7 +157,15 @@ func kubeConfigWithServerUrl(kubeConfigYaml, server string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to unmarshal kubeconfig: %w", err)
 	}
 
-	kubeConfig.Clusters[0].Cluster.Server = server
+	if len(kubeConfig.Clusters) == 0 {
+		return nil, fmt.Errorf("no clusters in kubeconfig")
+	}
+
+	// k3s generates a single cluster, but every cluster is rewritten in case a custom image adds more
+	for i := range kubeConfig.Clusters {
+		kubeConfig.Clusters[i].Cluster.Server = server
+	}
+
 	modifiedKubeConfig, err := marshal(kubeConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal kubeconfig: %w", err)
@@ -198,21 +207,26 @@ func (c *K3sContainer) LoadImages(ctx context.Context, images ...string) error {
 		_ = os.Remove(imagesTar.Name())
 	}()
 
-	err = provider.SaveImages(context.Background(), imagesTar.Name(), images...)
+	err = provider.SaveImages(ctx, imagesTar.Name(), images...)
 	if err != nil {
 		return fmt.Errorf("saving images %w", err)
 	}
 
 	containerPath := fmt.Sprintf("/tmp/%s", filepath.Base(imagesTar.Name()))
-	err = c.Container.CopyFileToContainer(ctx, imagesTar.Name(), containerPath, 0x644)
+	err = c.Container.CopyFileToContainer(ctx, imagesTar.Name(), containerPath, 0o644)
 	if err != nil {
 		return fmt.Errorf("copying image to container %w", err)
 	}
 
-	_, _, err = c.Container.Exec(ctx, []string{"ctr", "-n=k8s.io", "images", "import", containerPath})
+	code, reader, err := c.Container.Exec(ctx, []string{"ctr", "-n=k8s.io", "images", "import", containerPath}, tcexec.Multiplexed())
 	if err != nil {
 		return fmt.Errorf("importing image %w", err)
 	}
 
+	if code != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("importing image: exit code %d: %s", code, output)
+	}
+
 	return nil
 }
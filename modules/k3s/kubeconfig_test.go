@@ -0,0 +1,59 @@
+package k3s
+
+import (
+	"testing"
+)
+
+func TestKubeConfigWithServerUrl(t *testing.T) {
+	kubeConfigYaml := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    certificate-authority-data: Y2VydA==
+    server: https://127.0.0.1:6443
+  name: default
+contexts:
+- context:
+    cluster: default
+    user: default
+  name: default
+current-context: default
+users:
+- name: default
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`
+
+	b, err := kubeConfigWithServerUrl(kubeConfigYaml, "https://localhost:32768")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kubeConfig, err := unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(kubeConfig.Clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %d", len(kubeConfig.Clusters))
+	}
+
+	if server := kubeConfig.Clusters[0].Cluster.Server; server != "https://localhost:32768" {
+		t.Fatalf("expected the server to be rewritten, got %s", server)
+	}
+
+	// the rest of the kubeconfig is kept
+	if ca := kubeConfig.Clusters[0].Cluster.CertificateAuthorityData; ca != "Y2VydA==" {
+		t.Fatalf("expected the certificate authority to be kept, got %s", ca)
+	}
+
+	if key := kubeConfig.Users[0].User.ClientKeyData; key != "a2V5" {
+		t.Fatalf("expected the client key to be kept, got %s", key)
+	}
+
+	_, err = kubeConfigWithServerUrl("apiVersion: v1\nkind: Config\n", "https://localhost:32768")
+	if err == nil {
+		t.Fatal("expected an error for a kubeconfig without clusters")
+	}
+}
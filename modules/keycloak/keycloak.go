@@ -0,0 +1,169 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "quay.io/keycloak/keycloak:24.0"
+	defaultPort  = nat.Port("8080/tcp")
+
+	defaultAdminUsername = "admin"
+	// defaultAdminPassword is the password of the admin used when deterministic credentials are enabled
+	defaultAdminPassword = "admin"
+
+	// realmImportDir is the directory of the realm files imported by Keycloak at startup
+	realmImportDir = "/opt/keycloak/data/import"
+
+	// masterRealm is the realm of the admin user
+	masterRealm = "master"
+)
+
+// KeycloakContainer represents the Keycloak container type used in the module
+type KeycloakContainer struct {
+	testcontainers.Container
+	adminUsername string
+	adminPassword string
+}
+
+// RunContainer creates an instance of the Keycloak container type, an identity provider implementing OpenID Connect,
+// running in development mode. The realms of the tests are imported at startup with WithRealmImportFile,
+// and the admin user of the master realm manages them with the Admin REST API, see AdminToken.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KeycloakContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{string(defaultPort)},
+		Env: map[string]string{
+			// the health endpoints are used by the wait strategy
+			"KC_HEALTH_ENABLED": "true",
+		},
+		Cmd: []string{"start-dev"},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{adminUsername: defaultAdminUsername}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.adminPassword == "" {
+		password, err := credentials.Token(defaultAdminPassword)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the admin password: %w", err)
+		}
+
+		settings.adminPassword = password
+	}
+
+	genericContainerReq.Env["KEYCLOAK_ADMIN"] = settings.adminUsername
+	genericContainerReq.Env["KEYCLOAK_ADMIN_PASSWORD"] = settings.adminPassword
+
+	for _, realmFile := range settings.realmFiles {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			HostFilePath:      realmFile,
+			ContainerFilePath: realmImportDir + "/" + filepath.Base(realmFile),
+			FileMode:          0o644,
+		})
+	}
+
+	if len(settings.realmFiles) > 0 {
+		genericContainerReq.Cmd = append(genericContainerReq.Cmd, "--import-realm")
+	}
+
+	if genericContainerReq.WaitingFor == nil {
+		// the realms are imported before the server is ready
+		genericContainerReq.WaitingFor = wait.ForHTTP("/health/ready").
+			WithPort(defaultPort).
+			WithStartupTimeout(2 * time.Minute)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeycloakContainer{
+		Container:     container,
+		adminUsername: settings.adminUsername,
+		adminPassword: settings.adminPassword,
+	}, nil
+}
+
+// GetAuthServerURL returns the URL of the Keycloak server, with the format http://<host>:<port>,
+// which is the base of the URLs of the realms, e.g. the issuer of a realm is <url>/realms/<realm>.
+func (c *KeycloakContainer) GetAuthServerURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
+
+// AdminUsername returns the username of the admin user of the master realm
+func (c *KeycloakContainer) AdminUsername() string {
+	return c.adminUsername
+}
+
+// AdminPassword returns the password of the admin user of the master realm
+func (c *KeycloakContainer) AdminPassword() string {
+	return c.adminPassword
+}
+
+// AdminToken returns an access token of the admin user, obtained from the master realm with the admin-cli client,
+// to be sent as a bearer token to the Admin REST API, e.g. <url>/admin/realms/<realm>/users.
+func (c *KeycloakContainer) AdminToken(ctx context.Context) (string, error) {
+	serverURL, err := c.GetAuthServerURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("client_id", "admin-cli")
+	form.Set("username", c.adminUsername)
+	form.Set("password", c.adminPassword)
+
+	tokenURL := serverURL + "/realms/" + masterRealm + "/protocol/openid-connect/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting the admin token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error requesting the admin token: unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("error decoding the admin token: %w", err)
+	}
+
+	return token.AccessToken, nil
+}
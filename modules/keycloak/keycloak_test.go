@@ -0,0 +1,105 @@
+package keycloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/keycloak"
+)
+
+func TestKeycloak(t *testing.T) {
+	ctx := context.Background()
+
+	// withRealmImportFile {
+	container, err := keycloak.RunContainer(ctx,
+		keycloak.WithAdminCredentials("admin", "s3cr3t"),
+		keycloak.WithRealmImportFile(filepath.Join("testdata", "realm.json")),
+	)
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// authServerURL {
+	serverURL, err := container.GetAuthServerURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	t.Run("discovery", func(t *testing.T) {
+		resp, err := http.Get(serverURL + "/realms/testcontainers/.well-known/openid-configuration")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var discovery struct {
+			Issuer string `json:"issuer"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&discovery))
+		assert.Equal(t, serverURL+"/realms/testcontainers", discovery.Issuer)
+	})
+
+	t.Run("client-credentials", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_id", "app")
+		form.Set("client_secret", "app-secret")
+
+		resp, err := http.Post(serverURL+"/realms/testcontainers/protocol/openid-connect/token", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("admin-token", func(t *testing.T) {
+		assert.Equal(t, "admin", container.AdminUsername())
+		assert.Equal(t, "s3cr3t", container.AdminPassword())
+
+		// adminToken {
+		token, err := container.AdminToken(ctx)
+		// }
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+"/admin/realms/testcontainers/clients?clientId=app", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var clients []struct {
+			ClientID string `json:"clientId"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&clients))
+		require.Len(t, clients, 1)
+		assert.Equal(t, "app", clients[0].ClientID)
+	})
+}
+
+func TestKeycloak_generatedAdminPassword(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := keycloak.RunContainer(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "admin", container.AdminUsername())
+	assert.NotEmpty(t, container.AdminPassword())
+
+	_, err = container.AdminToken(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+package keycloak
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	adminUsername string
+	adminPassword string
+	realmFiles    []string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Keycloak container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithAdminCredentials sets the username and password of the admin user of the master realm.
+// The username is "admin" by default, and the password is generated.
+func WithAdminCredentials(username string, password string) Option {
+	return func(o *options) {
+		o.adminUsername = username
+		o.adminPassword = password
+	}
+}
+
+// WithRealmImportFile imports the realm of the JSON file at startup, e.g. the one exported from the
+// admin console, with its clients, roles and users. It can be called multiple times, one per realm.
+// The realm is imported only if it does not exist, which is always the case in a new container.
+func WithRealmImportFile(realmFile string) Option {
+	return func(o *options) {
+		o.realmFiles = append(o.realmFiles, realmFile)
+	}
+}
@@ -0,0 +1,41 @@
+package keydb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/keydb"
+)
+
+func ExampleRunContainer() {
+	// runKeyDBContainer {
+	ctx := context.Background()
+
+	keydbContainer, err := keydb.RunContainer(ctx,
+		testcontainers.WithImage("docker.io/eqalpha/keydb:latest"),
+		keydb.WithServerThreads(2),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := keydbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := keydbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
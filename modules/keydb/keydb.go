@@ -0,0 +1,138 @@
+package keydb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage is the default image used for the KeyDB container. The versioned tags
+	// of KeyDB are architecture specific, so the multi-arch latest tag is used
+	defaultImage = "docker.io/eqalpha/keydb:latest"
+
+	// defaultPort is the port where KeyDB listens for Redis protocol connections
+	defaultPort = "6379/tcp"
+
+	// keydbServerProcess is the name of the KeyDB server process
+	keydbServerProcess = "keydb-server"
+
+	// defaultConfigFile is the config file included in the KeyDB image, which is kept
+	// when passing arguments to the KeyDB server process
+	defaultConfigFile = "/etc/keydb/keydb.conf"
+)
+
+// KeyDBContainer represents the KeyDB container type used in the module
+type KeyDBContainer struct {
+	testcontainers.Container
+	password string
+}
+
+// ConnectionString returns the Redis protocol connection string for the KeyDB container,
+// with the format redis://[:password@]host:port
+func (c *KeyDBContainer) ConnectionString(ctx context.Context) (string, error) {
+	mappedPort, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	hostIP, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	uri := url.URL{
+		Scheme: "redis",
+		Host:   fmt.Sprintf("%s:%s", hostIP, mappedPort.Port()),
+	}
+	if c.password != "" {
+		uri.User = url.UserPassword("", c.password)
+	}
+
+	return uri.String(), nil
+}
+
+// RunContainer creates an instance of the KeyDB container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KeyDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Ready to accept connections"),
+			wait.ForListeningPort(defaultPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyDBContainer{Container: container, password: serverArg(genericContainerReq.Cmd, "--requirepass")}, nil
+}
+
+// WithServerThreads sets the number of threads used by KeyDB to serve the clients.
+// See https://docs.keydb.dev/docs/multithreading for more information.
+func WithServerThreads(threads int) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		processKeyDBServerArgs(req, []string{"--server-threads", strconv.Itoa(threads)})
+	}
+}
+
+// WithActiveReplicaOf makes the KeyDB server an active replica of the given KeyDB server,
+// which accepts writes and replicates them back to its master. Both servers must share a
+// Docker network, so the host is usually a network alias of the other container.
+// See https://docs.keydb.dev/docs/active-rep for more information.
+func WithActiveReplicaOf(host string, port int) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		processKeyDBServerArgs(req, []string{"--active-replica", "yes", "--replicaof", host, strconv.Itoa(port)})
+	}
+}
+
+// WithPassword sets the password required to authenticate against the KeyDB server.
+func WithPassword(password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		processKeyDBServerArgs(req, []string{"--requirepass", password})
+	}
+}
+
+func processKeyDBServerArgs(req *testcontainers.GenericContainerRequest, args []string) {
+	if len(req.Cmd) == 0 {
+		req.Cmd = append([]string{keydbServerProcess, defaultConfigFile}, args...)
+		return
+	}
+
+	if req.Cmd[0] == keydbServerProcess {
+		// KeyDB server is already set as the first argument, so just append the args
+		req.Cmd = append(req.Cmd, args...)
+	} else {
+		// KeyDB server is not set as the first argument, so prepend it alongside the config file
+		req.Cmd = append([]string{keydbServerProcess, defaultConfigFile}, req.Cmd...)
+		req.Cmd = append(req.Cmd, args...)
+	}
+}
+
+// serverArg returns the value of the last occurrence of the given argument of the server process
+func serverArg(cmd []string, name string) string {
+	value := ""
+	for i := 0; i < len(cmd)-1; i++ {
+		if cmd[i] == name {
+			value = cmd[i+1]
+		}
+	}
+
+	return value
+}
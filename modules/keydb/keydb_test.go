@@ -0,0 +1,125 @@
+package keydb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/keydb"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+func TestKeyDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := keydb.RunContainer(ctx, keydb.WithServerThreads(2))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	uri, err := container.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+
+	client := newClient(t, uri)
+
+	require.NoError(t, client.Set(ctx, "key", "value", 0).Err())
+
+	value, err := client.Get(ctx, "key").Result()
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	threads, err := client.ConfigGet(ctx, "server-threads").Result()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"server-threads", "2"}, threads)
+}
+
+func TestKeyDB_withPassword(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := keydb.RunContainer(ctx, keydb.WithPassword("s3cr3t"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.Contains(t, uri, ":s3cr3t@")
+
+	client := newClient(t, uri)
+	require.NoError(t, client.Ping(ctx).Err())
+}
+
+func TestKeyDB_activeReplica(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	// activeReplicas {
+	replica1, err := keydb.RunContainer(ctx,
+		network.WithNetwork([]string{"keydb1"}, nw),
+		keydb.WithActiveReplicaOf("keydb2", 6379),
+	)
+	require.NoError(t, err)
+
+	replica2, err := keydb.RunContainer(ctx,
+		network.WithNetwork([]string{"keydb2"}, nw),
+		keydb.WithActiveReplicaOf("keydb1", 6379),
+	)
+	require.NoError(t, err)
+	// }
+	t.Cleanup(func() {
+		if err := replica1.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+		if err := replica2.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri1, err := replica1.ConnectionString(ctx)
+	require.NoError(t, err)
+	uri2, err := replica2.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	client1 := newClient(t, uri1)
+	client2 := newClient(t, uri2)
+
+	// writes are accepted by both replicas, and replicated to each other
+	require.NoError(t, client1.Set(ctx, "key1", "value1", 0).Err())
+	require.NoError(t, client2.Set(ctx, "key2", "value2", 0).Err())
+
+	require.Eventually(t, func() bool {
+		return client2.Get(ctx, "key1").Val() == "value1" && client1.Get(ctx, "key2").Val() == "value2"
+	}, 30*time.Second, 500*time.Millisecond)
+}
+
+func newClient(t *testing.T, uri string) *redis.Client {
+	t.Helper()
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	client := redis.NewClient(options)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return client
+}
@@ -0,0 +1,77 @@
+package kibana_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/kibana"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func ExampleRunContainer() {
+	// runKibanaContainer {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		log.Fatalf("failed to create network: %s", err)
+	}
+
+	defer func() {
+		if err := nw.Remove(ctx); err != nil {
+			log.Fatalf("failed to remove network: %s", err)
+		}
+	}()
+
+	elasticsearchContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.elastic.co/elasticsearch/elasticsearch:8.9.0",
+			Env: map[string]string{
+				"discovery.type":         "single-node",
+				"xpack.security.enabled": "false",
+			},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"elasticsearch"}},
+			WaitingFor:     wait.ForHTTP("/").WithPort("9200/tcp"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		log.Fatalf("failed to start Elasticsearch: %s", err) // nolint:gocritic
+	}
+
+	defer func() {
+		if err := elasticsearchContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate Elasticsearch: %s", err)
+		}
+	}()
+
+	kibanaContainer, err := kibana.RunContainer(ctx,
+		network.WithNetwork([]string{"kibana"}, nw),
+		kibana.WithElasticsearchHosts("http://elasticsearch:9200"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := kibanaContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := kibanaContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err)
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,213 @@
+package kibana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "docker.elastic.co/kibana/kibana:8.9.0"
+	defaultPort  = "5601/tcp"
+
+	// defaultElasticsearchHost is the URL of Elasticsearch, reachable by its network alias
+	defaultElasticsearchHost = "http://elasticsearch:9200"
+)
+
+// KibanaContainer represents the Kibana container type used in the module
+type KibanaContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the Kibana container type. Kibana connects to Elasticsearch
+// by its network alias, so both containers must share a network, e.g. with network.WithNetwork.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KibanaContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env: map[string]string{
+			"TELEMETRY_ENABLED": "false",
+		},
+		WaitingFor: wait.ForHTTP("/api/status").
+			WithPort(defaultPort).
+			WithStartupTimeout(3 * time.Minute).
+			WithStatusCodeMatcher(func(status int) bool {
+				return status == http.StatusOK
+			}).
+			WithResponseMatcher(isAvailable),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	hosts, err := json.Marshal(settings.elasticsearchHosts)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the Elasticsearch hosts: %w", err)
+	}
+	genericContainerReq.Env["ELASTICSEARCH_HOSTS"] = string(hosts)
+
+	if settings.username != "" {
+		genericContainerReq.Env["ELASTICSEARCH_USERNAME"] = settings.username
+		genericContainerReq.Env["ELASTICSEARCH_PASSWORD"] = settings.password
+	}
+
+	if len(settings.savedObjects) > 0 {
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					k := &KibanaContainer{Container: c}
+					for _, file := range settings.savedObjects {
+						if err := k.importSavedObjectsFile(ctx, file); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KibanaContainer{Container: container}, nil
+}
+
+// URL returns the URL of the Kibana UI and API, e.g. http://localhost:32768
+func (c *KibanaContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
+
+// ImportSavedObjects imports the saved objects, e.g. dashboards, visualizations or data views,
+// of an NDJSON export, overwriting the existing objects with the same IDs.
+func (c *KibanaContainer) ImportSavedObjects(ctx context.Context, ndjson io.Reader) error {
+	u, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("file", "export.ndjson")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, ndjson); err != nil {
+		return fmt.Errorf("error reading the saved objects: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u+"/api/saved_objects/_import?overwrite=true", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("kbn-xsrf", "true")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error importing the saved objects: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading the response of the import: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error importing the saved objects: status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result importResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("error decoding the response of the import: %w", err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf("error importing the saved objects: %s", result.errors())
+	}
+
+	return nil
+}
+
+// importSavedObjectsFile imports the saved objects of an NDJSON file
+func (c *KibanaContainer) importSavedObjectsFile(ctx context.Context, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("error opening the saved objects file: %w", err)
+	}
+	defer f.Close()
+
+	if err := c.ImportSavedObjects(ctx, f); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(file), err)
+	}
+
+	return nil
+}
+
+// importResult represents the response of the saved objects import API
+type importResult struct {
+	Success      bool `json:"success"`
+	SuccessCount int  `json:"successCount"`
+	Errors       []struct {
+		ID    string `json:"id"`
+		Type  string `json:"type"`
+		Error struct {
+			Type string `json:"type"`
+		} `json:"error"`
+	} `json:"errors"`
+}
+
+// errors returns the objects failed to import, e.g. "dashboard/my-dashboard: missing_references"
+func (r importResult) errors() string {
+	errs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		errs = append(errs, fmt.Sprintf("%s/%s: %s", e.Type, e.ID, e.Error.Type))
+	}
+
+	return strings.Join(errs, ", ")
+}
+
+// isAvailable checks the overall status of Kibana: its level in Kibana 8, and its state in Kibana 7
+func isAvailable(body io.Reader) bool {
+	var status struct {
+		Status struct {
+			Overall struct {
+				Level string `json:"level"`
+				State string `json:"state"`
+			} `json:"overall"`
+		} `json:"status"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&status); err != nil {
+		return false
+	}
+
+	return status.Status.Overall.Level == "available" || status.Status.Overall.State == "green"
+}
@@ -0,0 +1,54 @@
+package kibana
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	elasticsearchHosts []string
+	username           string
+	password           string
+	savedObjects       []string
+}
+
+func defaultOptions() options {
+	return options{
+		elasticsearchHosts: []string{defaultElasticsearchHost},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Kibana container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithElasticsearchHosts sets the URLs of the Elasticsearch nodes, reachable from the Kibana container,
+// e.g. "http://search:9200" for a container with the "search" network alias. Defaults to http://elasticsearch:9200.
+func WithElasticsearchHosts(hosts ...string) Option {
+	return func(o *options) {
+		o.elasticsearchHosts = hosts
+	}
+}
+
+// WithElasticsearchCredentials sets the credentials used by Kibana to connect to Elasticsearch,
+// e.g. the kibana_system user. The elastic superuser is not allowed by Kibana 8.
+func WithElasticsearchCredentials(username string, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithSavedObjects imports the saved objects of the NDJSON files, e.g. dashboards exported from
+// another Kibana instance, once Kibana is ready.
+func WithSavedObjects(files ...string) Option {
+	return func(o *options) {
+		o.savedObjects = append(o.savedObjects, files...)
+	}
+}
@@ -0,0 +1,42 @@
+package kong_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/kong"
+)
+
+func ExampleRunContainer() {
+	// runKongContainer {
+	ctx := context.Background()
+
+	kongContainer, err := kong.RunContainer(ctx,
+		testcontainers.WithImage("kong:3.6.1"),
+		kong.WithConfigFile(filepath.Join("testdata", "kong.yml")),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := kongContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := kongContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,134 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage     = "kong:3.6.1"
+	defaultProxyPort = "8000/tcp"
+	defaultAdminPort = "8001/tcp"
+
+	// declarativeConfigFile is the path of the declarative config file in the container
+	declarativeConfigFile = "/kong/declarative/kong.yml"
+)
+
+// KongContainer represents the Kong container type used in the module
+type KongContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the Kong container type, running Kong in DB-less mode,
+// so the routes, services and plugins are defined by a declarative config
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KongContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultProxyPort, defaultAdminPort},
+		Env: map[string]string{
+			"KONG_DATABASE":         "off",
+			"KONG_PROXY_LISTEN":     "0.0.0.0:8000",
+			"KONG_ADMIN_LISTEN":     "0.0.0.0:8001",
+			"KONG_PROXY_ACCESS_LOG": "/dev/stdout",
+			"KONG_PROXY_ERROR_LOG":  "/dev/stderr",
+			"KONG_ADMIN_ACCESS_LOG": "/dev/stdout",
+			"KONG_ADMIN_ERROR_LOG":  "/dev/stderr",
+		},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(defaultProxyPort),
+			wait.ForHTTP("/status").WithPort(defaultAdminPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KongContainer{Container: container}, nil
+}
+
+// WithConfigFile loads the declarative config file (kong.yml) at startup,
+// defining the services, routes, consumers and plugins of the gateway
+func WithConfigFile(configFile string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      configFile,
+			ContainerFilePath: declarativeConfigFile,
+			FileMode:          0o644,
+		})
+
+		req.Env["KONG_DECLARATIVE_CONFIG"] = declarativeConfigFile
+	}
+}
+
+// WithPlugins enables the given plugins, besides the plugins bundled with Kong, e.g. custom plugins
+// copied into the container. The plugins still need to be configured in the declarative config.
+func WithPlugins(plugins ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["KONG_PLUGINS"] = strings.Join(append([]string{"bundled"}, plugins...), ",")
+	}
+}
+
+// ProxyURL returns the URL of the proxy, where the consumers send their requests,
+// with the format http://<host>:<port>
+func (c *KongContainer) ProxyURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultProxyPort, "http")
+}
+
+// AdminURL returns the URL of the Admin API, with the format http://<host>:<port>
+func (c *KongContainer) AdminURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultAdminPort, "http")
+}
+
+// ApplyConfig replaces the declarative config of the running gateway with the given one,
+// using the Admin API, so routes and plugins can be added at test time. In DB-less mode the
+// entities cannot be modified one by one, so the config must contain all the entities.
+func (c *KongContainer) ApplyConfig(ctx context.Context, config io.Reader) error {
+	adminURL, err := c.AdminURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	content, err := io.ReadAll(config)
+	if err != nil {
+		return fmt.Errorf("failed to read the config: %w", err)
+	}
+
+	form := url.Values{"config": []string{string(content)}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, adminURL+"/config", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d applying the config: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package kong_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/kong"
+)
+
+func TestKong(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := kong.RunContainer(ctx, kong.WithConfigFile(filepath.Join("testdata", "kong.yml")))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// proxyURL {
+	proxyURL, err := container.ProxyURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	status, body := get(t, proxyURL+"/hello")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, "hello")
+
+	status, _ = get(t, proxyURL+"/bye")
+	require.Equal(t, http.StatusNotFound, status)
+
+	adminURL, err := container.AdminURL(ctx)
+	require.NoError(t, err)
+
+	status, body = get(t, adminURL+"/routes/hello")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, `"/hello"`)
+}
+
+func TestKong_applyConfig(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := kong.RunContainer(ctx, kong.WithConfigFile(filepath.Join("testdata", "kong.yml")))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// applyConfig {
+	config, err := os.Open(filepath.Join("testdata", "bye.yml"))
+	require.NoError(t, err)
+	defer config.Close()
+
+	err = container.ApplyConfig(ctx, config)
+	// }
+	require.NoError(t, err)
+
+	proxyURL, err := container.ProxyURL(ctx)
+	require.NoError(t, err)
+
+	status, body := get(t, proxyURL+"/bye")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, "bye")
+
+	// the config is replaced, so the previous routes are removed
+	status, _ = get(t, proxyURL+"/hello")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestKong_applyConfigInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := kong.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	err = container.ApplyConfig(ctx, strings.NewReader("_format_version: \"3.0\"\nservices:\n  - name: 1\n"))
+	require.ErrorContains(t, err, "unexpected status code 400")
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
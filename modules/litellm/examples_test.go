@@ -0,0 +1,45 @@
+package litellm_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/litellm"
+)
+
+func ExampleRunContainer() {
+	// runLiteLLMContainer {
+	ctx := context.Background()
+
+	litellmContainer, err := litellm.RunContainer(ctx,
+		testcontainers.WithImage("ghcr.io/berriai/litellm:main-v1.40.0"),
+		litellm.WithModel(litellm.Model{
+			Name:         "gpt-4o",
+			Model:        "openai/gpt-4o",
+			MockResponse: "Hello!",
+		}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := litellmContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := litellmContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
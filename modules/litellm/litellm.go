@@ -0,0 +1,154 @@
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "ghcr.io/berriai/litellm:main-v1.40.0"
+	defaultPort  = "4000/tcp"
+
+	// configFile is the path of the configuration file of the proxy, generated from the options.
+	// It's written as JSON, which is valid YAML.
+	configFile = "/app/config.yaml"
+
+	// defaultMasterKey is the master key used when deterministic credentials are enabled
+	defaultMasterKey = "sk-test"
+)
+
+// LiteLLMContainer represents the LiteLLM proxy container type used in the module
+type LiteLLMContainer struct {
+	testcontainers.Container
+	masterKey string
+}
+
+// RunContainer creates an instance of the LiteLLM proxy container type, serving an OpenAI-compatible API
+// for the models added with WithModel. It's ready once the models are listed by the /v1/models endpoint.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*LiteLLMContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Cmd:          []string{"--config", configFile, "--port", "4000"},
+		Env:          map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.masterKey == "" {
+		token, err := credentials.Token(defaultMasterKey)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the master key: %w", err)
+		}
+
+		settings.masterKey = token
+		if !credentials.Deterministic() {
+			settings.masterKey = "sk-" + token
+		}
+	}
+	genericContainerReq.Env["LITELLM_MASTER_KEY"] = settings.masterKey
+
+	config, err := renderConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            bytes.NewReader(config),
+		ContainerFilePath: configFile,
+		FileMode:          0o644,
+	})
+
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForHTTP("/v1/models").
+			WithPort(defaultPort).
+			WithHeaders(map[string]string{"Authorization": "Bearer " + settings.masterKey}).
+			WithStartupTimeout(2 * time.Minute)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LiteLLMContainer{Container: container, masterKey: settings.masterKey}, nil
+}
+
+// renderConfig returns the configuration file of the proxy for the models and fallbacks of the settings
+func renderConfig(settings options) ([]byte, error) {
+	type litellmParams struct {
+		Model        string `json:"model"`
+		APIBase      string `json:"api_base,omitempty"`
+		APIKey       string `json:"api_key,omitempty"`
+		MockResponse string `json:"mock_response,omitempty"`
+	}
+
+	type modelEntry struct {
+		ModelName     string        `json:"model_name"`
+		LiteLLMParams litellmParams `json:"litellm_params"`
+	}
+
+	config := struct {
+		ModelList       []modelEntry   `json:"model_list"`
+		LiteLLMSettings map[string]any `json:"litellm_settings,omitempty"`
+	}{
+		ModelList: []modelEntry{},
+	}
+
+	for _, model := range settings.models {
+		config.ModelList = append(config.ModelList, modelEntry{
+			ModelName: model.Name,
+			LiteLLMParams: litellmParams{
+				Model:        model.Model,
+				APIBase:      model.APIBase,
+				APIKey:       model.APIKey,
+				MockResponse: model.MockResponse,
+			},
+		})
+	}
+
+	if len(settings.fallbacks) > 0 {
+		config.LiteLLMSettings = map[string]any{"fallbacks": settings.fallbacks}
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering the configuration: %w", err)
+	}
+
+	return b, nil
+}
+
+// BaseURL returns the base URL of the OpenAI-compatible API of the proxy, with the format http://<host>:<port>/v1,
+// which is the base URL expected by the OpenAI clients.
+func (c *LiteLLMContainer) BaseURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, defaultPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/v1", nil
+}
+
+// APIKey returns the master key of the proxy, which the clients send as API key.
+func (c *LiteLLMContainer) APIKey() string {
+	return c.masterKey
+}
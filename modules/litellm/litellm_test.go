@@ -0,0 +1,104 @@
+package litellm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/litellm"
+)
+
+func TestLiteLLM(t *testing.T) {
+	ctx := context.Background()
+
+	// withModels {
+	container, err := litellm.RunContainer(ctx,
+		litellm.WithMasterKey("sk-1234"),
+		litellm.WithModel(litellm.Model{
+			Name:         "fake",
+			Model:        "openai/fake",
+			MockResponse: "Hello from the fake model",
+		}),
+		// the provider is not reachable, so the requests fall back to the fake model
+		litellm.WithModel(litellm.Model{
+			Name:    "unreachable",
+			Model:   "openai/unreachable",
+			APIBase: "http://localhost:1/v1",
+			APIKey:  "sk-unused",
+		}),
+		litellm.WithFallbacks("unreachable", "fake"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// baseURL {
+	baseURL, err := container.BaseURL(ctx)
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, "sk-1234", container.APIKey())
+
+	t.Run("models", func(t *testing.T) {
+		var models struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		request(t, http.MethodGet, baseURL+"/models", container.APIKey(), "", &models)
+
+		ids := []string{}
+		for _, model := range models.Data {
+			ids = append(ids, model.ID)
+		}
+		assert.ElementsMatch(t, []string{"fake", "unreachable"}, ids)
+	})
+
+	t.Run("chat-completion", func(t *testing.T) {
+		assert.Equal(t, "Hello from the fake model", chat(t, baseURL, container.APIKey(), "fake"))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		assert.Equal(t, "Hello from the fake model", chat(t, baseURL, container.APIKey(), "unreachable"))
+	})
+}
+
+func chat(t *testing.T, baseURL string, apiKey string, model string) string {
+	t.Helper()
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	request(t, http.MethodPost, baseURL+"/chat/completions", apiKey, `{"model": "`+model+`", "messages": [{"role": "user", "content": "Hi"}]}`, &completion)
+	require.NotEmpty(t, completion.Choices)
+
+	return completion.Choices[0].Message.Content
+}
+
+func request(t *testing.T, method string, url string, apiKey string, body string, target any) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(target))
+}
@@ -0,0 +1,65 @@
+package litellm
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Model is a model served by the LiteLLM proxy, routing the requests to a provider.
+type Model struct {
+	// Name is the name of the model for the clients of the proxy, e.g. "gpt-4o".
+	// Several models can share the same name, so the proxy balances the requests between them.
+	Name string
+
+	// Model is the model of the provider, prefixed with the provider, e.g. "openai/gpt-4o" or "ollama/llama3".
+	Model string
+
+	// APIBase is the base URL of the provider, e.g. the one of a vLLM or Ollama container
+	// in the same network as the proxy. Optional for the hosted providers.
+	APIBase string
+
+	// APIKey is the API key of the provider. Optional.
+	APIKey string
+
+	// MockResponse is returned by the proxy instead of calling the provider, to test the routing
+	// without a real model. Optional.
+	MockResponse string
+}
+
+type options struct {
+	masterKey string
+	models    []Model
+	fallbacks []map[string][]string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the LiteLLM container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithMasterKey sets the master key of the proxy, which the clients send as API key.
+// LiteLLM requires it to start with "sk-". It's random by default.
+func WithMasterKey(key string) Option {
+	return func(o *options) {
+		o.masterKey = key
+	}
+}
+
+// WithModel adds a model to the ones served by the proxy.
+func WithModel(model Model) Option {
+	return func(o *options) {
+		o.models = append(o.models, model)
+	}
+}
+
+// WithFallbacks sets the models the proxy falls back to, in order, when the requests to the given model fail.
+func WithFallbacks(model string, fallbacks ...string) Option {
+	return func(o *options) {
+		o.fallbacks = append(o.fallbacks, map[string][]string{model: fallbacks})
+	}
+}
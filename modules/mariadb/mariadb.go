@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -112,12 +113,18 @@ func WithScripts(scripts ...string) testcontainers.CustomizeRequestOption {
 
 // RunContainer creates an instance of the MariaDB container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MariaDBContainer, error) {
+	// the default password is random, unless deterministic credentials are enabled
+	generatedPassword, err := credentials.Password(defaultPassword)
+	if err != nil {
+		return nil, fmt.Errorf("error generating the default password: %w", err)
+	}
+
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
 		ExposedPorts: []string{"3306/tcp", "33060/tcp"},
 		Env: map[string]string{
 			"MARIADB_USER":     defaultUser,
-			"MARIADB_PASSWORD": defaultPassword,
+			"MARIADB_PASSWORD": generatedPassword,
 			"MARIADB_DATABASE": defaultDatabaseName,
 		},
 		WaitingFor: wait.ForLog("port: 3306  mariadb.org binary distribution"),
@@ -0,0 +1,41 @@
+package monetdb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/monetdb"
+)
+
+func ExampleRunContainer() {
+	// runMonetDBContainer {
+	ctx := context.Background()
+
+	monetdbContainer, err := monetdb.RunContainer(ctx,
+		testcontainers.WithImage("monetdb/monetdb:Dec2023"),
+		monetdb.WithDatabase("analytics"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := monetdbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := monetdbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,123 @@
+package monetdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "monetdb/monetdb:Dec2023"
+	defaultPort  = "50000/tcp"
+
+	defaultDatabase = "monetdb"
+
+	// user is the administrator of the databases created by the image
+	user = "monetdb"
+
+	// defaultPassword is the password used when deterministic credentials are enabled
+	defaultPassword = "monetdb"
+
+	// dotMonetDBFile is the configuration file of mclient, with the credentials of the administrator,
+	// so that the commands executed in the container don't ask for the password
+	dotMonetDBFile = "/tmp/.monetdb"
+)
+
+// MonetDBContainer represents the MonetDB container type used in the module
+type MonetDBContainer struct {
+	testcontainers.Container
+	password string
+	database string
+}
+
+// RunContainer creates an instance of the MonetDB container type, creating the database set with WithDatabase,
+// administered by the "monetdb" user. It's ready once the database answers a query.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MonetDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env:          map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{database: defaultDatabase}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.password == "" {
+		password, err := credentials.Password(defaultPassword)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the password: %w", err)
+		}
+
+		settings.password = password
+	}
+
+	genericContainerReq.Env["MDB_DB_ADMIN_PASS"] = settings.password
+	genericContainerReq.Env["MDB_CREATE_DBS"] = settings.database
+	genericContainerReq.Env["DOTMONETDBFILE"] = dotMonetDBFile
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            strings.NewReader(fmt.Sprintf("user=%s\npassword=%s\n", user, settings.password)),
+		ContainerFilePath: dotMonetDBFile,
+		FileMode:          0o644,
+	})
+
+	if genericContainerReq.WaitingFor == nil {
+		// the port is listened by the daemon before the database is created
+		genericContainerReq.WaitingFor = wait.ForAll(
+			wait.ForListeningPort(defaultPort),
+			wait.ForExec([]string{"mclient", "-d", settings.database, "-s", "SELECT 1"}),
+		)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MonetDBContainer{Container: container, password: settings.password, database: settings.database}, nil
+}
+
+// ConnectionString returns the connection string for the MonetDB container, with the format
+// <user>:<password>@<host>:<port>/<database> expected by the MonetDB Go driver.
+func (c *MonetDBContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s@%s:%s/%s", user, c.password, host, port.Port(), c.database), nil
+}
+
+// User returns the administrator of the database, which is always "monetdb"
+func (c *MonetDBContainer) User() string {
+	return user
+}
+
+// Password returns the password of the administrator of the database
+func (c *MonetDBContainer) Password() string {
+	return c.password
+}
+
+// Database returns the name of the database
+func (c *MonetDBContainer) Database() string {
+	return c.database
+}
@@ -0,0 +1,74 @@
+package monetdb_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/modules/monetdb"
+)
+
+func TestMonetDB(t *testing.T) {
+	ctx := context.Background()
+
+	// withDatabase {
+	container, err := monetdb.RunContainer(ctx,
+		monetdb.WithDatabase("analytics"),
+		monetdb.WithPassword("s3cr3t"),
+	)
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "monetdb", container.User())
+	assert.Equal(t, "s3cr3t", container.Password())
+	assert.Equal(t, "analytics", container.Database())
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	assert.Regexp(t, `^monetdb:s3cr3t@.+:\d+/analytics$`, connStr)
+
+	mclient(t, container, "CREATE TABLE items (name VARCHAR(32))")
+	mclient(t, container, "INSERT INTO items VALUES ('monet')")
+
+	output := mclient(t, container, "SELECT name FROM items")
+	assert.Contains(t, output, "monet")
+}
+
+func TestMonetDB_randomPassword(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := monetdb.RunContainer(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	require.NotEmpty(t, container.Password())
+	assert.Equal(t, "monetdb", container.Database())
+
+	output := mclient(t, container, "SELECT 1")
+	assert.Contains(t, output, "1")
+}
+
+// mclient runs the statement with the mclient CLI of the container, returning its output
+func mclient(t *testing.T, container *monetdb.MonetDBContainer, stmt string) string {
+	t.Helper()
+
+	code, reader, err := container.Exec(context.Background(), []string{"mclient", "-d", container.Database(), "-s", stmt}, exec.Multiplexed())
+	require.NoError(t, err)
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Zero(t, code, string(output))
+
+	return string(output)
+}
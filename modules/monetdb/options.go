@@ -0,0 +1,35 @@
+package monetdb
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	password string
+	database string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the MonetDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithPassword sets the password of the "monetdb" administrator of the database. It's random by default.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithDatabase sets the database created when the container starts. Defaults to "monetdb".
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
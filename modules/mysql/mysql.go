@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -30,6 +31,8 @@ type MySQLContainer struct {
 	database string
 }
 
+// WithDefaultCredentials sets the root password from the password of the user, allowing an empty
+// root password only for the root user. It is applied automatically by RunContainer.
 func WithDefaultCredentials() testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		username := req.Env["MYSQL_USER"]
@@ -48,15 +51,26 @@ func WithDefaultCredentials() testcontainers.CustomizeRequestOption {
 
 // RunContainer creates an instance of the MySQL container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MySQLContainer, error) {
+	// the default password is random, unless deterministic credentials are enabled
+	generatedPassword, err := credentials.Password(defaultPassword)
+	if err != nil {
+		return nil, fmt.Errorf("error generating the default password: %w", err)
+	}
+
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
 		ExposedPorts: []string{"3306/tcp", "33060/tcp"},
 		Env: map[string]string{
 			"MYSQL_USER":     defaultUser,
-			"MYSQL_PASSWORD": defaultPassword,
+			"MYSQL_PASSWORD": generatedPassword,
 			"MYSQL_DATABASE": defaultDatabaseName,
 		},
-		WaitingFor: wait.ForLog("port: 3306  MySQL Community Server"),
+		// the entrypoint starts a temporary server without networking to run the init scripts,
+		// and then the real server, which is the only one logging the 3306 port
+		WaitingFor: wait.ForAll(
+			wait.ForLog("port: 3306  MySQL Community Server"),
+			wait.ForListeningPort("3306/tcp"),
+		),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -70,11 +84,11 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		opt.Customize(&genericContainerReq)
 	}
 
-	username, ok := req.Env["MYSQL_USER"]
+	username, ok := genericContainerReq.Env["MYSQL_USER"]
 	if !ok {
 		username = rootUser
 	}
-	password := req.Env["MYSQL_PASSWORD"]
+	password := genericContainerReq.Env["MYSQL_PASSWORD"]
 
 	if len(password) == 0 && password == "" && !strings.EqualFold(rootUser, username) {
 		return nil, fmt.Errorf("empty password can be used only with the root user")
@@ -85,20 +99,22 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		return nil, err
 	}
 
-	database := req.Env["MYSQL_DATABASE"]
+	database := genericContainerReq.Env["MYSQL_DATABASE"]
 
 	return &MySQLContainer{container, username, password, database}, nil
 }
 
 // MustConnectionString panics if the address cannot be determined.
 func (c *MySQLContainer) MustConnectionString(ctx context.Context, args ...string) string {
-	addr, err := c.ConnectionString(ctx,args...)
+	addr, err := c.ConnectionString(ctx, args...)
 	if err != nil {
 		panic(err)
 	}
 	return addr
 }
 
+// ConnectionString returns the connection string for the MySQL container, in the format of the go-sql-driver/mysql
+// driver, e.g. test:test@tcp(localhost:55000)/test. The given args are appended as query parameters, e.g. "tls=skip-verify".
 func (c *MySQLContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
 	containerPort, err := c.MappedPort(ctx, "3306/tcp")
 	if err != nil {
@@ -122,24 +138,57 @@ func (c *MySQLContainer) ConnectionString(ctx context.Context, args ...string) (
 	return connectionString, nil
 }
 
+// SetEnv exports the connection info of the container as environment variables with the given setter,
+// see testcontainers.DockerContainer.SetEnv, adding the credentials of the database: given the "DB" prefix,
+// DB_USER, DB_PASSWORD, DB_NAME, and DB_URL with the connection string. The setter is usually the
+// *testing.T of the test, which restores the variables when the test completes.
+func (c *MySQLContainer) SetEnv(ctx context.Context, env testcontainers.EnvSetter, prefix string) error {
+	dc, ok := c.Container.(*testcontainers.DockerContainer)
+	if !ok {
+		return fmt.Errorf("unsupported container type %T", c.Container)
+	}
+
+	if err := dc.SetEnv(ctx, env, prefix); err != nil {
+		return err
+	}
+
+	connStr, err := c.ConnectionString(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the connection string: %w", err)
+	}
+
+	env.Setenv(testcontainers.EnvName(prefix, "USER"), c.username)
+	env.Setenv(testcontainers.EnvName(prefix, "PASSWORD"), c.password)
+	env.Setenv(testcontainers.EnvName(prefix, "NAME"), c.database)
+	env.Setenv(testcontainers.EnvName(prefix, "URL"), connStr)
+
+	return nil
+}
+
+// WithUsername sets the user created at startup, which is granted all privileges on the database.
+// If the username is "root", no other user is created.
 func WithUsername(username string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Env["MYSQL_USER"] = username
 	}
 }
 
+// WithPassword sets the password of the user, which is also used as the root password.
+// An empty password is only allowed for the root user.
 func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Env["MYSQL_PASSWORD"] = password
 	}
 }
 
+// WithDatabase sets the name of the database created at startup
 func WithDatabase(database string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Env["MYSQL_DATABASE"] = database
 	}
 }
 
+// WithConfigFile copies the given MySQL config file to the container, in the /etc/mysql/conf.d directory
 func WithConfigFile(configFile string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		cf := testcontainers.ContainerFile{
@@ -151,6 +200,8 @@ func WithConfigFile(configFile string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithScripts copies the given *.sql, *.sql.gz or *.sh scripts to the container, so they are run
+// in alphabetical order when the database is initialised, before the container is ready
 func WithScripts(scripts ...string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		var initScripts []testcontainers.ContainerFile
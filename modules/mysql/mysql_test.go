@@ -3,7 +3,9 @@ package mysql_test
 import (
 	"context"
 	"database/sql"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	// Import mysql into the scope of this package (required)
@@ -34,10 +36,13 @@ func TestMySQL(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	mustConnectionString := container.MustConnectionString(ctx,"tls=skip-verify")
-	if mustConnectionString!=connectionString{
+	mustConnectionString := container.MustConnectionString(ctx, "tls=skip-verify")
+	if mustConnectionString != connectionString {
 		t.Errorf("ConnectionString was not equal to MustConnectionString")
 	}
+	if strings.HasPrefix(connectionString, "test:test@") {
+		t.Errorf("expected a random default password, got %s", connectionString)
+	}
 
 	db, err := sql.Open("mysql", connectionString)
 	if err != nil {
@@ -153,3 +158,89 @@ func TestMySQLWithScripts(t *testing.T) {
 		t.Fatal("The expected record was not found in the database.")
 	}
 }
+
+func TestMySQLWithConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	// withConfigFile {
+	container, err := mysql.RunContainer(ctx, mysql.WithConfigFile(filepath.Join("testdata", "custom.cnf")))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("mysql", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// the server must be ready to accept connections as soon as the container is started,
+	// so no retries are needed
+	var name, value string
+	if err := db.QueryRow("SHOW VARIABLES LIKE 'max_connections'").Scan(&name, &value); err != nil {
+		t.Fatalf("error fetching the variable: %s", err)
+	}
+
+	if value != "42" {
+		t.Fatalf("expected max_connections to be 42, got %s", value)
+	}
+}
+
+func TestMySQLSetEnv(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := mysql.RunContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if err := container.SetEnv(ctx, t, "MYSQL"); err != nil {
+		t.Fatal(err)
+	}
+
+	if os.Getenv("MYSQL_USER") != "test" || os.Getenv("MYSQL_NAME") != "test" {
+		t.Errorf("unexpected credentials: %s/%s", os.Getenv("MYSQL_USER"), os.Getenv("MYSQL_NAME"))
+	}
+
+	// the default password is random, so it's compared with the one of the container
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if os.Getenv("MYSQL_PASSWORD") == "" || !strings.HasPrefix(connectionString, "test:"+os.Getenv("MYSQL_PASSWORD")+"@") {
+		t.Errorf("expected MYSQL_PASSWORD to be the password of the container, got %s", os.Getenv("MYSQL_PASSWORD"))
+	}
+	if os.Getenv("MYSQL_PORT") != os.Getenv("MYSQL_PORT_3306") {
+		t.Errorf("expected MYSQL_PORT to be the mapped port of 3306/tcp, got %s", os.Getenv("MYSQL_PORT"))
+	}
+
+	db, err := sql.Open("mysql", os.Getenv("MYSQL_URL"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err = db.Ping(); err != nil {
+		t.Errorf("error pinging db: %+v\n", err)
+	}
+}
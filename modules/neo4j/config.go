@@ -1,13 +1,24 @@
 package neo4j
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
 )
 
+const (
+	// pluginsEnv is the environment variable of the plugins since Neo4j 5
+	pluginsEnv = "NEO4J_PLUGINS"
+	// labsPluginsEnv is the environment variable of the plugins before Neo4j 5, renamed to pluginsEnv
+	labsPluginsEnv = "NEO4JLABS_PLUGINS"
+	// licenseAgreementEnv is the environment variable accepting the license agreement of the Enterprise Edition
+	licenseAgreementEnv = "NEO4J_ACCEPT_LICENSE_AGREEMENT"
+)
+
 type LabsPlugin string
 
 const (
@@ -42,18 +53,32 @@ func WithAdminPassword(adminPassword string) testcontainers.CustomizeRequestOpti
 	}
 }
 
-// WithLabsPlugin registers one or more Neo4jLabsPlugin for download and server startup.
-// There might be plugins not supported by your selected version of Neo4j.
+// WithLabsPlugin registers one or more Neo4jLabsPlugin for download and server startup,
+// with the NEO4J_PLUGINS environment variable, and NEO4JLABS_PLUGINS for the images before Neo4j 5.
+// This function can be called multiple times, adding the plugins to the ones already registered.
+// There might be plugins not supported by your selected version of Neo4j, and the plugins of
+// the Enterprise Edition, e.g. Bloom, require accepting its license agreement,
+// see WithAcceptCommercialLicenseAgreement.
 func WithLabsPlugin(plugins ...LabsPlugin) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
-		rawPluginValues := make([]string, len(plugins))
-		for i := 0; i < len(plugins); i++ {
-			rawPluginValues[i] = string(plugins[i])
+		if len(plugins) == 0 {
+			return
+		}
+
+		rawPluginValues := []string{}
+		if registered, ok := req.Env[pluginsEnv]; ok {
+			_ = json.Unmarshal([]byte(registered), &rawPluginValues)
 		}
 
-		if len(plugins) > 0 {
-			req.Env["NEO4JLABS_PLUGINS"] = fmt.Sprintf(`["%s"]`, strings.Join(rawPluginValues, `","`))
+		for _, plugin := range plugins {
+			if !slices.Contains(rawPluginValues, string(plugin)) {
+				rawPluginValues = append(rawPluginValues, string(plugin))
+			}
 		}
+
+		value, _ := json.Marshal(rawPluginValues)
+		req.Env[pluginsEnv] = string(value)
+		req.Env[labsPluginsEnv] = string(value)
 	}
 }
 
@@ -109,6 +134,12 @@ func validate(req *testcontainers.GenericContainerRequest) error {
 	if req.Logger == nil {
 		return errors.New("nil logger is not permitted")
 	}
+
+	// the container of the Enterprise Edition exits at once if its license agreement is not accepted
+	if strings.Contains(req.Image, "enterprise") && req.Env[licenseAgreementEnv] == "" {
+		return errors.New("the license agreement of the Neo4j Enterprise Edition must be accepted: use WithAcceptCommercialLicenseAgreement or WithAcceptEvaluationLicenseAgreement")
+	}
+
 	return nil
 }
 
@@ -124,7 +155,7 @@ func formatNeo4jConfig(name string) string {
 // agreement is available at https://neo4j.com/terms/licensing/.
 func WithAcceptCommercialLicenseAgreement() testcontainers.CustomizeRequestOption {
 	return testcontainers.WithEnv(map[string]string{
-		"NEO4J_ACCEPT_LICENSE_AGREEMENT": "yes",
+		licenseAgreementEnv: "yes",
 	})
 }
 
@@ -135,6 +166,6 @@ func WithAcceptCommercialLicenseAgreement() testcontainers.CustomizeRequestOptio
 // read the terms of the evaluation agreement before you accept.
 func WithAcceptEvaluationLicenseAgreement() testcontainers.CustomizeRequestOption {
 	return testcontainers.WithEnv(map[string]string{
-		"NEO4J_ACCEPT_LICENSE_AGREEMENT": "eval",
+		licenseAgreementEnv: "eval",
 	})
 }
@@ -0,0 +1,27 @@
+package neo4j_test
+
+import (
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/neo4j"
+)
+
+func TestWithLabsPlugin(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Env: map[string]string{},
+		},
+	}
+
+	neo4j.WithLabsPlugin(neo4j.Apoc).Customize(&req)
+	neo4j.WithLabsPlugin(neo4j.Apoc, neo4j.NeoSemantics).Customize(&req)
+
+	expected := `["apoc","n10s"]`
+	if req.Env["NEO4J_PLUGINS"] != expected {
+		t.Fatalf("expected NEO4J_PLUGINS to be %s but was %s", expected, req.Env["NEO4J_PLUGINS"])
+	}
+	if req.Env["NEO4JLABS_PLUGINS"] != expected {
+		t.Fatalf("expected NEO4JLABS_PLUGINS to be %s but was %s", expected, req.Env["NEO4JLABS_PLUGINS"])
+	}
+}
@@ -32,7 +32,14 @@ type Neo4jContainer struct {
 }
 
 // BoltUrl returns the bolt url for the Neo4j container, using the bolt port, in the format of neo4j://host:port
+//
+// Deprecated: use BoltURL instead.
 func (c Neo4jContainer) BoltUrl(ctx context.Context) (string, error) {
+	return c.BoltURL(ctx)
+}
+
+// BoltURL returns the bolt url for the Neo4j container, using the bolt port, in the format of neo4j://host:port
+func (c Neo4jContainer) BoltURL(ctx context.Context) (string, error) {
 	host, err := c.Host(ctx)
 	if err != nil {
 		return "", err
@@ -166,6 +166,20 @@ func TestNeo4jWithWrongSettings(outer *testing.T) {
 		}
 	})
 
+	outer.Run("rejects enterprise edition without license agreement", func(t *testing.T) {
+		container, err := neo4j.RunContainer(ctx,
+			testcontainers.WithImage("docker.io/neo4j:4.4-enterprise"),
+			neo4j.WithAdminPassword(testPassword),
+		)
+
+		if container != nil {
+			t.Fatalf("container must not be created without accepting the license agreement")
+		}
+		if err == nil || !strings.Contains(err.Error(), "license agreement") {
+			t.Fatalf("expected license agreement validation error but got: %v", err)
+		}
+	})
+
 	outer.Run("rejects nil logger", func(t *testing.T) {
 		container, err := neo4j.RunContainer(ctx, neo4j.WithLogger(nil))
 
@@ -194,12 +208,12 @@ func setupNeo4j(ctx context.Context, t *testing.T) *neo4j.Neo4jContainer {
 
 func createDriver(t *testing.T, ctx context.Context, container *neo4j.Neo4jContainer) neo.DriverWithContext {
 	// boltURL {
-	boltUrl, err := container.BoltUrl(ctx)
+	boltURL, err := container.BoltURL(ctx)
 	// }
 	if err != nil {
 		t.Fatal(err)
 	}
-	driver, err := neo.NewDriverWithContext(boltUrl, neo.BasicAuth("neo4j", testPassword, ""))
+	driver, err := neo.NewDriverWithContext(boltURL, neo.BasicAuth("neo4j", testPassword, ""))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,107 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// modelPullTimeout bounds the pull of each model, which can be several gigabytes
+const modelPullTimeout = 30 * time.Minute
+
+// pullProgress is a line of the streamed response of the /api/pull endpoint
+type pullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// pullModel pulls the model with the /api/pull endpoint of the Ollama server, logging the progress
+// every time the status changes, and every 10% of each downloaded layer.
+func pullModel(ctx context.Context, c testcontainers.Container, model string, logger testcontainers.Logging) error {
+	ctx, cancel := context.WithTimeout(ctx, modelPullTimeout)
+	defer cancel()
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := c.MappedPort(ctx, "11434/tcp")
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]string{"name": model})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s:%d/api/pull", host, port.Int()), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("pulling model %s: %w", model, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("pulling model %s: unexpected status code %d: %s", model, resp.StatusCode, b)
+	}
+
+	logger.Printf("⏳ Pulling model %s", model)
+
+	var lastStatus string
+	lastPercent := -10
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var progress pullProgress
+		if err := json.Unmarshal(scanner.Bytes(), &progress); err != nil {
+			return fmt.Errorf("pulling model %s: decoding the progress: %w", model, err)
+		}
+
+		if progress.Error != "" {
+			return fmt.Errorf("pulling model %s: %s", model, progress.Error)
+		}
+
+		if progress.Status != lastStatus {
+			lastStatus = progress.Status
+			lastPercent = -10
+			if progress.Total == 0 {
+				logger.Printf("⏳ Pulling model %s: %s", model, progress.Status)
+			}
+		}
+
+		if progress.Total > 0 {
+			if percent := int(progress.Completed * 100 / progress.Total); percent/10 > lastPercent/10 {
+				lastPercent = percent
+				logger.Printf("⏳ Pulling model %s: %s %d%% of %d MB", model, progress.Status, percent, progress.Total/1024/1024)
+			}
+		}
+
+		if progress.Status == "success" {
+			logger.Printf("✅ Model %s pulled", model)
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("pulling model %s: %w", model, err)
+	}
+
+	return fmt.Errorf("pulling model %s: the pull ended without succeeding", model)
+}
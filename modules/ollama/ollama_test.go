@@ -128,6 +128,46 @@ func assertLoadedModel(t *testing.T, c *ollama.OllamaContainer) {
 	}
 }
 
+func TestOllamaWithModel(t *testing.T) {
+	ctx := context.Background()
+
+	// withModel {
+	container, err := ollama.RunContainer(ctx,
+		testcontainers.WithImage("ollama/ollama:0.1.25"),
+		ollama.WithModel("all-minilm"),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assertLoadedModel(t, container)
+}
+
+func TestOllamaWithModel_error(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := ollama.RunContainer(ctx,
+		testcontainers.WithImage("ollama/ollama:0.1.25"),
+		ollama.WithModel("non-existent"),
+	)
+	if container != nil {
+		t.Cleanup(func() {
+			if err := container.Terminate(ctx); err != nil {
+				t.Fatalf("failed to terminate container: %s", err)
+			}
+		})
+	}
+	if err == nil || !strings.Contains(err.Error(), "pulling model non-existent") {
+		t.Fatalf("expected the pull of the model to fail, got %v", err)
+	}
+}
+
 func TestRunContainer_withModel_error(t *testing.T) {
 	ctx := context.Background()
 
@@ -37,3 +37,30 @@ func withGpu() testcontainers.CustomizeRequestOption {
 		}
 	})
 }
+
+// WithModel pulls the given models, e.g. "llama3", once the Ollama server is ready, logging the progress
+// of the downloads. As the models can be several gigabytes, each pull can take up to 30 minutes.
+// The container fails to start if a model cannot be pulled, e.g. if it does not exist.
+// Use Commit to bake the pulled models into an image, so that the next containers don't pull them again.
+func WithModel(models ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					logger := req.Logger
+					if logger == nil {
+						logger = testcontainers.Logger
+					}
+
+					for _, model := range models {
+						if err := pullModel(ctx, c, model, logger); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package opensearchdashboards_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/opensearchdashboards"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func ExampleRunContainer() {
+	// runOpenSearchDashboardsContainer {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		log.Fatalf("failed to create network: %s", err)
+	}
+
+	defer func() {
+		if err := nw.Remove(ctx); err != nil {
+			log.Fatalf("failed to remove network: %s", err)
+		}
+	}()
+
+	opensearchContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "opensearchproject/opensearch:2.11.1",
+			Env: map[string]string{
+				"discovery.type":              "single-node",
+				"DISABLE_INSTALL_DEMO_CONFIG": "true",
+				"DISABLE_SECURITY_PLUGIN":     "true",
+			},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"opensearch"}},
+			WaitingFor:     wait.ForHTTP("/").WithPort("9200/tcp"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		log.Fatalf("failed to start OpenSearch: %s", err) // nolint:gocritic
+	}
+
+	defer func() {
+		if err := opensearchContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate OpenSearch: %s", err)
+		}
+	}()
+
+	dashboardsContainer, err := opensearchdashboards.RunContainer(ctx,
+		network.WithNetwork([]string{"dashboards"}, nw),
+		opensearchdashboards.WithOpenSearchHosts("http://opensearch:9200"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := dashboardsContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := dashboardsContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err)
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
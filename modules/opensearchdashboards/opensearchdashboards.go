@@ -0,0 +1,213 @@
+package opensearchdashboards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "opensearchproject/opensearch-dashboards:2.11.1"
+	defaultPort  = "5601/tcp"
+
+	// defaultOpenSearchHost is the URL of OpenSearch, reachable by its network alias
+	defaultOpenSearchHost = "http://opensearch:9200"
+)
+
+// DashboardsContainer represents the OpenSearch Dashboards container type used in the module
+type DashboardsContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the OpenSearch Dashboards container type. OpenSearch Dashboards connects
+// to OpenSearch by its network alias, so both containers must share a network, e.g. with network.WithNetwork.
+// The security plugin of OpenSearch Dashboards is disabled, as in the OpenSearch module.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*DashboardsContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env: map[string]string{
+			"DISABLE_SECURITY_DASHBOARDS_PLUGIN": "true",
+		},
+		WaitingFor: wait.ForHTTP("/api/status").
+			WithPort(defaultPort).
+			WithStartupTimeout(3 * time.Minute).
+			WithStatusCodeMatcher(func(status int) bool {
+				return status == http.StatusOK
+			}).
+			WithResponseMatcher(isAvailable),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	hosts, err := json.Marshal(settings.openSearchHosts)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the OpenSearch hosts: %w", err)
+	}
+	genericContainerReq.Env["OPENSEARCH_HOSTS"] = string(hosts)
+
+	if settings.username != "" {
+		genericContainerReq.Env["OPENSEARCH_USERNAME"] = settings.username
+		genericContainerReq.Env["OPENSEARCH_PASSWORD"] = settings.password
+	}
+
+	if len(settings.savedObjects) > 0 {
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					d := &DashboardsContainer{Container: c}
+					for _, file := range settings.savedObjects {
+						if err := d.importSavedObjectsFile(ctx, file); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DashboardsContainer{Container: container}, nil
+}
+
+// URL returns the URL of the OpenSearch Dashboards UI and API, e.g. http://localhost:32768
+func (c *DashboardsContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
+
+// ImportSavedObjects imports the saved objects, e.g. dashboards, visualizations or index patterns,
+// of an NDJSON export, overwriting the existing objects with the same IDs.
+func (c *DashboardsContainer) ImportSavedObjects(ctx context.Context, ndjson io.Reader) error {
+	u, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("file", "export.ndjson")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, ndjson); err != nil {
+		return fmt.Errorf("error reading the saved objects: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u+"/api/saved_objects/_import?overwrite=true", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("osd-xsrf", "true")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error importing the saved objects: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading the response of the import: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error importing the saved objects: status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result importResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("error decoding the response of the import: %w", err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf("error importing the saved objects: %s", result.errors())
+	}
+
+	return nil
+}
+
+// importSavedObjectsFile imports the saved objects of an NDJSON file
+func (c *DashboardsContainer) importSavedObjectsFile(ctx context.Context, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("error opening the saved objects file: %w", err)
+	}
+	defer f.Close()
+
+	if err := c.ImportSavedObjects(ctx, f); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(file), err)
+	}
+
+	return nil
+}
+
+// importResult represents the response of the saved objects import API
+type importResult struct {
+	Success      bool `json:"success"`
+	SuccessCount int  `json:"successCount"`
+	Errors       []struct {
+		ID    string `json:"id"`
+		Type  string `json:"type"`
+		Error struct {
+			Type string `json:"type"`
+		} `json:"error"`
+	} `json:"errors"`
+}
+
+// errors returns the objects failed to import, e.g. "dashboard/my-dashboard: missing_references"
+func (r importResult) errors() string {
+	errs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		errs = append(errs, fmt.Sprintf("%s/%s: %s", e.Type, e.ID, e.Error.Type))
+	}
+
+	return strings.Join(errs, ", ")
+}
+
+// isAvailable checks the overall state of OpenSearch Dashboards
+func isAvailable(body io.Reader) bool {
+	var status struct {
+		Status struct {
+			Overall struct {
+				State string `json:"state"`
+			} `json:"overall"`
+		} `json:"status"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&status); err != nil {
+		return false
+	}
+
+	return status.Status.Overall.State == "green"
+}
@@ -0,0 +1,92 @@
+package opensearchdashboards_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/opensearchdashboards"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestOpenSearchDashboards(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	search, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "opensearchproject/opensearch:2.11.1",
+			Env: map[string]string{
+				"discovery.type":              "single-node",
+				"DISABLE_INSTALL_DEMO_CONFIG": "true",
+				"DISABLE_SECURITY_PLUGIN":     "true",
+			},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"search"}},
+			WaitingFor:     wait.ForHTTP("/").WithPort("9200/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, search.Terminate(ctx))
+	})
+
+	// withSavedObjects {
+	container, err := opensearchdashboards.RunContainer(ctx,
+		network.WithNetwork([]string{"dashboards"}, nw),
+		opensearchdashboards.WithOpenSearchHosts("http://search:9200"),
+		opensearchdashboards.WithSavedObjects("testdata/dashboard.ndjson"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// dashboardsURL {
+	u, err := container.URL(ctx)
+	// }
+	require.NoError(t, err)
+
+	t.Run("saved-objects-are-imported", func(t *testing.T) {
+		resp, err := http.Get(u + "/api/saved_objects/dashboard/orders")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("import-saved-objects", func(t *testing.T) {
+		ndjson := `{"attributes":{"title":"orders-*"},"id":"orders-view","references":[],"type":"index-pattern"}`
+
+		// importSavedObjects {
+		err := container.ImportSavedObjects(ctx, strings.NewReader(ndjson))
+		// }
+		require.NoError(t, err)
+
+		resp, err := http.Get(u + "/api/saved_objects/index-pattern/orders-view")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("import-invalid-saved-objects", func(t *testing.T) {
+		ndjson := `{"attributes":{"title":"Broken"},"id":"broken","references":[{"id":"missing","name":"panel_0","type":"visualization"}],"type":"dashboard"}`
+
+		err := container.ImportSavedObjects(ctx, strings.NewReader(ndjson))
+		require.ErrorContains(t, err, "dashboard/broken: missing_references")
+	})
+}
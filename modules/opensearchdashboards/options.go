@@ -0,0 +1,54 @@
+package opensearchdashboards
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	openSearchHosts []string
+	username        string
+	password        string
+	savedObjects    []string
+}
+
+func defaultOptions() options {
+	return options{
+		openSearchHosts: []string{defaultOpenSearchHost},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the OpenSearch Dashboards container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithOpenSearchHosts sets the URLs of the OpenSearch nodes, reachable from the OpenSearch Dashboards container,
+// e.g. "http://search:9200" for a container with the "search" network alias. Defaults to http://opensearch:9200.
+func WithOpenSearchHosts(hosts ...string) Option {
+	return func(o *options) {
+		o.openSearchHosts = hosts
+	}
+}
+
+// WithOpenSearchCredentials sets the credentials used by OpenSearch Dashboards to connect to OpenSearch,
+// when its security plugin is enabled.
+func WithOpenSearchCredentials(username string, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithSavedObjects imports the saved objects of the NDJSON files, e.g. dashboards exported from
+// another OpenSearch Dashboards instance, once it's ready.
+func WithSavedObjects(files ...string) Option {
+	return func(o *options) {
+		o.savedObjects = append(o.savedObjects, files...)
+	}
+}
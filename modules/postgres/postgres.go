@@ -56,6 +56,33 @@ func (c *PostgresContainer) ConnectionString(ctx context.Context, args ...string
 	return connStr, nil
 }
 
+// SetEnv exports the connection info of the container as environment variables with the given setter,
+// see testcontainers.DockerContainer.SetEnv, adding the credentials of the database: given the "DB" prefix,
+// DB_USER, DB_PASSWORD, DB_NAME, and DB_URL with the connection string. The setter is usually the
+// *testing.T of the test, which restores the variables when the test completes.
+func (c *PostgresContainer) SetEnv(ctx context.Context, env testcontainers.EnvSetter, prefix string) error {
+	dc, ok := c.Container.(*testcontainers.DockerContainer)
+	if !ok {
+		return fmt.Errorf("unsupported container type %T", c.Container)
+	}
+
+	if err := dc.SetEnv(ctx, env, prefix); err != nil {
+		return err
+	}
+
+	connStr, err := c.ConnectionString(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the connection string: %w", err)
+	}
+
+	env.Setenv(testcontainers.EnvName(prefix, "USER"), c.user)
+	env.Setenv(testcontainers.EnvName(prefix, "PASSWORD"), c.password)
+	env.Setenv(testcontainers.EnvName(prefix, "NAME"), c.dbName)
+	env.Setenv(testcontainers.EnvName(prefix, "URL"), connStr)
+
+	return nil
+}
+
 // WithConfigFile sets the config file to be used for the postgres container
 // It will also set the "config_file" parameter to the path of the config file
 // as a command line argument to the container
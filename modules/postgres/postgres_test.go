@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -327,3 +328,42 @@ func TestSnapshot(t *testing.T) {
 	})
 	// }
 }
+
+func TestSetEnv(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := postgres.RunContainer(ctx,
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// setEnv {
+	err = container.SetEnv(ctx, t, "DB")
+	// }
+	require.NoError(t, err)
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, user, os.Getenv("DB_USER"))
+	assert.Equal(t, password, os.Getenv("DB_PASSWORD"))
+	assert.Equal(t, dbname, os.Getenv("DB_NAME"))
+	assert.Equal(t, connStr, os.Getenv("DB_URL"))
+	assert.NotEmpty(t, os.Getenv("DB_HOST"))
+	assert.Equal(t, os.Getenv("DB_PORT"), os.Getenv("DB_PORT_5432"))
+
+	db, err := sql.Open("postgres", os.Getenv("DB_URL")+"sslmode=disable")
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Ping())
+}
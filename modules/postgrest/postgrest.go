@@ -0,0 +1,89 @@
+package postgrest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage     = "postgrest/postgrest:v12.0.2"
+	defaultPort      = "3000/tcp"
+	defaultAdminPort = "3001/tcp"
+)
+
+// PostgRESTContainer represents the PostgREST container type used in the module
+type PostgRESTContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the PostgREST container type, serving a REST API for the database
+// set with WithDatabaseURL. It waits for PostgREST to load the schema cache of the database.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*PostgRESTContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort, defaultAdminPort},
+		Env: map[string]string{
+			"PGRST_DB_SCHEMAS":        "public",
+			"PGRST_ADMIN_SERVER_PORT": "3001",
+		},
+		// the ready endpoint of the admin server succeeds once the database is reachable
+		// and the schema cache is loaded
+		WaitingFor: wait.ForHTTP("/ready").WithPort(defaultAdminPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgRESTContainer{Container: container}, nil
+}
+
+// WithDatabaseURL sets the connection string of the Postgres database to serve, which must be reachable
+// from the container, e.g. postgres://user:password@db:5432/app, where db is the network alias
+// of a Postgres container in the same network.
+func WithDatabaseURL(url string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["PGRST_DB_URI"] = url
+	}
+}
+
+// WithSchemas sets the database schemas exposed by the REST API. Defaults to "public".
+func WithSchemas(schemas ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["PGRST_DB_SCHEMAS"] = strings.Join(schemas, ",")
+	}
+}
+
+// WithAnonRole sets the database role used for the requests without a JWT.
+// If not set, the anonymous requests are rejected.
+func WithAnonRole(role string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["PGRST_DB_ANON_ROLE"] = role
+	}
+}
+
+// WithJWTSecret sets the secret used to verify the JWTs of the requests, which set the database role
+// of the authenticated requests. It must be at least 32 characters long.
+func WithJWTSecret(secret string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["PGRST_JWT_SECRET"] = secret
+	}
+}
+
+// RESTEndpoint returns the URL of the REST API, with the format http://<host>:<port>
+func (c *PostgRESTContainer) RESTEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
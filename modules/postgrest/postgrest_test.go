@@ -0,0 +1,129 @@
+package postgrest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgrest"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const databaseURL = "postgres://postgres:postgres@db:5432/postgres?sslmode=disable"
+
+func TestPostgREST(t *testing.T) {
+	ctx := context.Background()
+
+	nw := startPostgres(t)
+
+	// runWithDatabase {
+	container, err := postgrest.RunContainer(ctx,
+		network.WithNetwork([]string{"postgrest"}, nw),
+		postgrest.WithDatabaseURL(databaseURL),
+		postgrest.WithAnonRole("postgres"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// restEndpoint {
+	endpoint, err := container.RESTEndpoint(ctx)
+	// }
+	require.NoError(t, err)
+
+	status, body := get(t, endpoint+"/todos?done=is.true")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `[{"id": 1, "title": "write the tests", "done": true}]`, body)
+}
+
+func TestPostgREST_withoutAnonRole(t *testing.T) {
+	ctx := context.Background()
+
+	nw := startPostgres(t)
+
+	container, err := postgrest.RunContainer(ctx,
+		network.WithNetwork([]string{"postgrest"}, nw),
+		postgrest.WithDatabaseURL(databaseURL),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	endpoint, err := container.RESTEndpoint(ctx)
+	require.NoError(t, err)
+
+	status, _ := get(t, endpoint+"/todos")
+	require.Equal(t, http.StatusUnauthorized, status)
+}
+
+// startPostgres starts a Postgres container with the todos table, reachable with the "db" alias
+// in the returned network
+func startPostgres(t *testing.T) *testcontainers.DockerNetwork {
+	t.Helper()
+
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "postgres:16-alpine",
+			Env: map[string]string{
+				"POSTGRES_PASSWORD": "postgres",
+			},
+			Files: []testcontainers.ContainerFile{
+				{
+					HostFilePath:      filepath.Join("testdata", "init.sql"),
+					ContainerFilePath: "/docker-entrypoint-initdb.d/init.sql",
+					FileMode:          0o644,
+				},
+			},
+			// the server is restarted after running the init scripts
+			WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	}
+	network.WithNetwork([]string{"db"}, nw).Customize(&req)
+
+	pg, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := pg.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	return nw
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
@@ -30,27 +30,24 @@ var defaultWaitStrategies = wait.ForAll(
 	wait.ForLog("Successfully updated the policies on namespace public/default"),
 )
 
+// Container represents the Pulsar container type used in the module
 type Container struct {
 	testcontainers.Container
 	LogConsumers []testcontainers.LogConsumer // Deprecated. Use the ContainerRequest instead. Needs to be exported to control the stop from the caller
 }
 
+// BrokerURL returns the URL of the broker, using the pulsar protocol and the binary 6650 port, e.g. "pulsar://localhost:6650"
 func (c *Container) BrokerURL(ctx context.Context) (string, error) {
 	return c.resolveURL(ctx, defaultPulsarPort)
 }
 
+// HTTPServiceURL returns the URL of the admin API, using the http protocol and the 8080 port, e.g. "http://localhost:8080"
 func (c *Container) HTTPServiceURL(ctx context.Context) (string, error) {
 	return c.resolveURL(ctx, defaultPulsarAdminPort)
 }
 
 func (c *Container) resolveURL(ctx context.Context, port nat.Port) (string, error) {
-	provider, err := testcontainers.NewDockerProvider()
-	if err != nil {
-		return "", err
-	}
-	defer provider.Close()
-
-	host, err := provider.DaemonHost(ctx)
+	host, err := c.Host(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -69,19 +66,30 @@ func (c *Container) resolveURL(ctx context.Context, port nat.Port) (string, erro
 }
 
 // WithFunctionsWorker enables the functions worker, which will override the default pulsar command
-// and add a waiting strategy for the functions worker
+// and add a waiting strategy for the functions worker. It can be combined with WithTransactions.
 func WithFunctionsWorker() testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Cmd = []string{"/bin/bash", "-c", defaultPulsarCmd}
 
-		ss := []wait.Strategy{
-			wait.ForLog("Function worker service started"),
-		}
+		addWaitStrategy(req, wait.ForLog("Function worker service started"))
+	}
+}
 
-		ss = append(ss, defaultWaitStrategies.Strategies...)
+// addWaitStrategy adds a wait strategy to the ones of the request, instead of replacing them,
+// so that the options adding their own strategy can be combined
+func addWaitStrategy(req *testcontainers.GenericContainerRequest, strategy wait.Strategy) {
+	ss := []wait.Strategy{strategy}
 
-		req.WaitingFor = wait.ForAll(ss...)
+	switch w := req.WaitingFor.(type) {
+	case *wait.MultiStrategy:
+		ss = append(ss, w.Strategies...)
+	case nil:
+		ss = append(ss, defaultWaitStrategies.Strategies...)
+	default:
+		ss = append(ss, w)
 	}
+
+	req.WaitingFor = wait.ForAll(ss...)
 }
 
 // Deprecated: use the testcontainers.WithLogConsumers functional option instead
@@ -101,24 +109,22 @@ func (c *Container) WithLogConsumers(ctx context.Context, consumer ...testcontai
 // WithPulsarEnv allows to use the native APIs and set each variable with PULSAR_PREFIX_ as prefix.
 func WithPulsarEnv(configVar string, configValue string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
+		if req.Env == nil {
+			req.Env = map[string]string{}
+		}
 		req.Env["PULSAR_PREFIX_"+configVar] = configValue
 	}
 }
 
+// WithTransactions enables the transaction coordinator, adding a waiting strategy for the partitions
+// of its topic to be assigned. It can be combined with WithFunctionsWorker.
 func WithTransactions() testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		WithPulsarEnv("transactionCoordinatorEnabled", "true")(req)
 
-		// clone defaultWaitStrategies
-		ss := []wait.Strategy{
-			wait.ForHTTP(transactionTopicEndpoint).WithPort(defaultPulsarAdminPort).WithStatusCodeMatcher(func(statusCode int) bool {
-				return statusCode == 200
-			}),
-		}
-
-		ss = append(ss, defaultWaitStrategies.Strategies...)
-
-		req.WaitingFor = wait.ForAll(ss...)
+		addWaitStrategy(req, wait.ForHTTP(transactionTopicEndpoint).WithPort(defaultPulsarAdminPort).WithStatusCodeMatcher(func(statusCode int) bool {
+			return statusCode == 200
+		}))
 	}
 }
 
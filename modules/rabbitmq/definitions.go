@@ -0,0 +1,185 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const defaultDefinitionsPath = "/tmp/testcontainers-definitions"
+
+// Definitions represents the RabbitMQ definitions, i.e. the topology and the users, imported
+// once the container is ready. The fields are serialised with the format of the definitions files
+// exported by RabbitMQ. See https://www.rabbitmq.com/docs/definitions.
+type Definitions struct {
+	Users        []User        `json:"users,omitempty"`
+	VirtualHosts []VirtualHost `json:"vhosts,omitempty"`
+	Permissions  []Permission  `json:"permissions,omitempty"`
+	Exchanges    []Exchange    `json:"exchanges,omitempty"`
+	Queues       []Queue       `json:"queues,omitempty"`
+	Bindings     []Binding     `json:"bindings,omitempty"`
+}
+
+// User represents a RabbitMQ user, with its plain text password and its tags, e.g. "administrator".
+type User struct {
+	Name     string   `json:"name"`
+	Password string   `json:"password"`
+	Tags     []string `json:"tags"`
+}
+
+// VirtualHost represents a RabbitMQ virtual host.
+type VirtualHost struct {
+	Name string `json:"name"`
+}
+
+// Permission represents the permissions of a user in a virtual host, as regular expressions
+// matching the names of the resources, e.g. ".*" for all of them. An empty expression matches none.
+type Permission struct {
+	User      string `json:"user"`
+	VHost     string `json:"vhost"`
+	Configure string `json:"configure"`
+	Write     string `json:"write"`
+	Read      string `json:"read"`
+}
+
+// Exchange represents a RabbitMQ exchange. The virtual host defaults to "/".
+type Exchange struct {
+	Name       string                 `json:"name"`
+	VHost      string                 `json:"vhost"`
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+// Queue represents a RabbitMQ queue. The virtual host defaults to "/". The type of the queue,
+// e.g. "quorum" or "stream", is set with the "x-queue-type" argument.
+type Queue struct {
+	Name       string                 `json:"name"`
+	VHost      string                 `json:"vhost"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+// Binding represents a RabbitMQ binding, from an exchange to a queue or to another exchange.
+// The virtual host defaults to "/", and the destination type to "queue".
+type Binding struct {
+	Source          string                 `json:"source"`
+	VHost           string                 `json:"vhost"`
+	Destination     string                 `json:"destination"`
+	DestinationType string                 `json:"destination_type"`
+	RoutingKey      string                 `json:"routing_key"`
+	Arguments       map[string]interface{} `json:"arguments"`
+}
+
+// isEmpty returns true if there are no definitions to be imported
+func (d Definitions) isEmpty() bool {
+	return len(d.Users) == 0 && len(d.VirtualHosts) == 0 && len(d.Permissions) == 0 &&
+		len(d.Exchanges) == 0 && len(d.Queues) == 0 && len(d.Bindings) == 0
+}
+
+// merge appends the given definitions to the current ones
+func (d *Definitions) merge(other Definitions) {
+	d.Users = append(d.Users, other.Users...)
+	d.VirtualHosts = append(d.VirtualHosts, other.VirtualHosts...)
+	d.Permissions = append(d.Permissions, other.Permissions...)
+	d.Exchanges = append(d.Exchanges, other.Exchanges...)
+	d.Queues = append(d.Queues, other.Queues...)
+	d.Bindings = append(d.Bindings, other.Bindings...)
+}
+
+// withDefaults returns a copy of the definitions, setting the defaults RabbitMQ requires to import them.
+func (d Definitions) withDefaults() Definitions {
+	out := Definitions{
+		Users:        append([]User(nil), d.Users...),
+		VirtualHosts: append([]VirtualHost(nil), d.VirtualHosts...),
+		Permissions:  append([]Permission(nil), d.Permissions...),
+		Exchanges:    append([]Exchange(nil), d.Exchanges...),
+		Queues:       append([]Queue(nil), d.Queues...),
+		Bindings:     append([]Binding(nil), d.Bindings...),
+	}
+
+	for i := range out.Users {
+		if out.Users[i].Tags == nil {
+			out.Users[i].Tags = []string{}
+		}
+	}
+	for i := range out.Permissions {
+		out.Permissions[i].VHost = vhostOrDefault(out.Permissions[i].VHost)
+	}
+	for i := range out.Exchanges {
+		out.Exchanges[i].VHost = vhostOrDefault(out.Exchanges[i].VHost)
+		if out.Exchanges[i].Arguments == nil {
+			out.Exchanges[i].Arguments = map[string]interface{}{}
+		}
+	}
+	for i := range out.Queues {
+		out.Queues[i].VHost = vhostOrDefault(out.Queues[i].VHost)
+		if out.Queues[i].Arguments == nil {
+			out.Queues[i].Arguments = map[string]interface{}{}
+		}
+	}
+	for i := range out.Bindings {
+		out.Bindings[i].VHost = vhostOrDefault(out.Bindings[i].VHost)
+		if out.Bindings[i].DestinationType == "" {
+			out.Bindings[i].DestinationType = "queue"
+		}
+		if out.Bindings[i].Arguments == nil {
+			out.Bindings[i].Arguments = map[string]interface{}{}
+		}
+	}
+
+	return out
+}
+
+func vhostOrDefault(vhost string) string {
+	if vhost == "" {
+		return "/"
+	}
+	return vhost
+}
+
+// importDefinitions returns the hook importing the definitions files and the definitions,
+// in this order, once the container is ready, so the default admin user already exists.
+func importDefinitions(files []string, defs Definitions) testcontainers.ContainerLifecycleHooks {
+	return testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, c testcontainers.Container) error {
+				for i, f := range files {
+					path := fmt.Sprintf("%s-%d.json", defaultDefinitionsPath, i)
+					if err := c.CopyFileToContainer(ctx, f, path, 0o644); err != nil {
+						return fmt.Errorf("error copying the definitions file %s: %w", f, err)
+					}
+
+					if err := execWithOutput(ctx, c, []string{"rabbitmqctl", "import_definitions", path}); err != nil {
+						return fmt.Errorf("error importing the definitions file %s: %w", f, err)
+					}
+				}
+
+				if defs.isEmpty() {
+					return nil
+				}
+
+				bs, err := json.Marshal(defs.withDefaults())
+				if err != nil {
+					return fmt.Errorf("error marshalling the definitions: %w", err)
+				}
+
+				path := defaultDefinitionsPath + ".json"
+				if err := c.CopyToContainer(ctx, bs, path, 0o644); err != nil {
+					return fmt.Errorf("error copying the definitions: %w", err)
+				}
+
+				if err := execWithOutput(ctx, c, []string{"rabbitmqctl", "import_definitions", path}); err != nil {
+					return fmt.Errorf("error importing the definitions: %w", err)
+				}
+
+				return nil
+			},
+		},
+	}
+}
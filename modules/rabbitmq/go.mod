@@ -8,6 +8,8 @@ require (
 	github.com/testcontainers/testcontainers-go v0.30.0
 )
 
+require golang.org/x/crypto v0.18.0 // indirect
+
 require (
 	dario.cat/mergo v1.0.0 // indirect
 	github.com/Azure/go-ansiterm v0.0.0-20210617225240-d185dfc1b5a1 // indirect
@@ -13,6 +13,11 @@ type options struct {
 	AdminUsername string
 	AdminPassword string
 	SSLSettings   *SSLSettings
+	Streams       bool
+	Plugins       []string
+	Definitions   Definitions
+	// DefinitionsFiles are the paths of the definitions files on the host
+	DefinitionsFiles []string
 }
 
 func defaultOptions() options {
@@ -68,3 +73,39 @@ func WithSSL(settings SSLSettings) Option {
 		o.SSLSettings = &settings
 	}
 }
+
+// WithStreams enables the RabbitMQ Streams protocol, exposing the stream port and
+// enabling the stream plugins once the container is ready. The advertised host and port
+// of the stream listener are set to the ones mapped on the host, so that stream clients
+// are able to connect to the container from the tests.
+func WithStreams() Option {
+	return func(o *options) {
+		o.Streams = true
+	}
+}
+
+// WithPlugins enables the given plugins at startup, e.g. "rabbitmq_mqtt" or "rabbitmq_shovel",
+// besides the management and Prometheus plugins enabled by the management images.
+// The ports of the MQTT, STOMP, Web MQTT and Web STOMP plugins are exposed when they are enabled.
+func WithPlugins(plugins ...string) Option {
+	return func(o *options) {
+		o.Plugins = append(o.Plugins, plugins...)
+	}
+}
+
+// WithDefinitions declares the given users, virtual hosts, permissions, exchanges, queues and bindings
+// once the container is ready, importing them as RabbitMQ definitions. It can be used multiple times,
+// and the definitions are merged.
+func WithDefinitions(definitions Definitions) Option {
+	return func(o *options) {
+		o.Definitions.merge(definitions)
+	}
+}
+
+// WithDefinitionsFile imports the RabbitMQ definitions file at the given host path once the container is ready,
+// e.g. a file exported from the management UI, before the definitions declared with WithDefinitions.
+func WithDefinitionsFile(hostPath string) Option {
+	return func(o *options) {
+		o.DefinitionsFiles = append(o.DefinitionsFiles, hostPath)
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"time"
 
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -21,11 +25,24 @@ const (
 	DefaultAMQPPort       = "5672/tcp"
 	DefaultHTTPSPort      = "15671/tcp"
 	DefaultHTTPPort       = "15672/tcp"
+	DefaultStreamPort     = "5552/tcp"
+	DefaultMQTTPort       = "1883/tcp"
+	DefaultSTOMPPort      = "61613/tcp"
+	DefaultWebMQTTPort    = "15675/tcp"
+	DefaultWebSTOMPPort   = "15674/tcp"
 	defaultPassword       = "guest"
 	defaultUser           = "guest"
 	defaultCustomConfPath = "/etc/rabbitmq/rabbitmq-testcontainers.conf"
 )
 
+// pluginPorts are the ports of the plugins, exposed when the plugins are enabled with WithPlugins
+var pluginPorts = map[string]string{
+	"rabbitmq_mqtt":      DefaultMQTTPort,
+	"rabbitmq_stomp":     DefaultSTOMPPort,
+	"rabbitmq_web_mqtt":  DefaultWebMQTTPort,
+	"rabbitmq_web_stomp": DefaultWebSTOMPPort,
+}
+
 //go:embed mounts/rabbitmq-testcontainers.conf.tpl
 var customConfigTpl string
 
@@ -66,6 +83,43 @@ func (c *RabbitMQContainer) HttpsURL(ctx context.Context) (string, error) {
 	return c.PortEndpoint(ctx, nat.Port(DefaultHTTPSPort), "https")
 }
 
+// StreamURL returns the URL for RabbitMQ Streams clients.
+// The container must be started with the WithStreams option.
+func (c *RabbitMQContainer) StreamURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, nat.Port(DefaultStreamPort), "")
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("rabbitmq-stream://%s:%s@%s", c.AdminUsername, c.AdminPassword, endpoint), nil
+}
+
+// CreateStream declares a stream with the given name in the default virtual host,
+// using the management CLI inside the container. The arguments, if any, are passed
+// as the stream arguments, e.g. "x-max-length-bytes" or "x-stream-max-segment-size-bytes".
+func (c *RabbitMQContainer) CreateStream(ctx context.Context, name string, args map[string]interface{}) error {
+	cmd := []string{
+		"rabbitmqadmin",
+		"--username=" + c.AdminUsername,
+		"--password=" + c.AdminPassword,
+		"declare", "queue",
+		"name=" + name,
+		"durable=true",
+		"queue_type=stream",
+	}
+
+	if len(args) > 0 {
+		bs, err := json.Marshal(args)
+		if err != nil {
+			return fmt.Errorf("failed to marshal stream arguments: %w", err)
+		}
+
+		cmd = append(cmd, "arguments="+string(bs))
+	}
+
+	return execWithOutput(ctx, c, cmd)
+}
+
 // RunContainer creates an instance of the RabbitMQ container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RabbitMQContainer, error) {
 	req := testcontainers.ContainerRequest{
@@ -107,6 +161,18 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		applySSLSettings(settings.SSLSettings)(&genericContainerReq)
 	}
 
+	if settings.Streams {
+		applyStreamSettings()(&genericContainerReq)
+	}
+
+	if len(settings.Plugins) > 0 {
+		applyPlugins(settings.Plugins)(&genericContainerReq)
+	}
+
+	if len(settings.DefinitionsFiles) > 0 || !settings.Definitions.isEmpty() {
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, importDefinitions(settings.DefinitionsFiles, settings.Definitions))
+	}
+
 	nodeConfig, err := renderRabbitMQConfig(settings)
 	if err != nil {
 		return nil, err
@@ -177,6 +243,80 @@ func applySSLSettings(sslSettings *SSLSettings) testcontainers.CustomizeRequestO
 	}
 }
 
+// applyStreamSettings exposes the stream port and enables the stream plugins once the container is ready.
+// The plugins are enabled after the container is ready because the advertised host and port
+// of the stream listener must be the ones mapped on the host, which are not known before.
+func applyStreamSettings() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.ExposedPorts = append(req.ExposedPorts, DefaultStreamPort)
+
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					host, err := c.Host(ctx)
+					if err != nil {
+						return err
+					}
+
+					port, err := c.MappedPort(ctx, DefaultStreamPort)
+					if err != nil {
+						return err
+					}
+
+					setAdvertised := fmt.Sprintf(
+						`application:set_env(rabbitmq_stream, advertised_host, "%s"), application:set_env(rabbitmq_stream, advertised_port, %d).`,
+						host, port.Int(),
+					)
+
+					if err := execWithOutput(ctx, c, []string{"rabbitmqctl", "eval", setAdvertised}); err != nil {
+						return err
+					}
+
+					return execWithOutput(ctx, c, []string{"rabbitmq-plugins", "enable", "rabbitmq_stream", "rabbitmq_stream_management"})
+				},
+			},
+		})
+	}
+}
+
+// applyPlugins enables the plugins at startup, writing the enabled plugins file, which keeps
+// the management and Prometheus plugins enabled by the management images, and exposes their known ports.
+func applyPlugins(plugins []string) testcontainers.CustomizeRequestOption {
+	const enabledPluginsPath = "/etc/rabbitmq/enabled_plugins"
+
+	return func(req *testcontainers.GenericContainerRequest) {
+		enabled := append([]string{"rabbitmq_management", "rabbitmq_prometheus"}, plugins...)
+
+		for _, plugin := range plugins {
+			if port, ok := pluginPorts[plugin]; ok {
+				req.ExposedPorts = append(req.ExposedPorts, port)
+			}
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader("[" + strings.Join(enabled, ",") + "]."),
+			ContainerFilePath: enabledPluginsPath,
+			FileMode:          0o644,
+		})
+	}
+}
+
+// execWithOutput executes the command in the container, returning an error including
+// the output of the command if it does not exit successfully.
+func execWithOutput(ctx context.Context, c testcontainers.Container, cmd []string) error {
+	code, reader, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("command %q failed with exit code %d: %s", cmd[0], code, output)
+	}
+
+	return nil
+}
+
 func renderRabbitMQConfig(opts options) ([]byte, error) {
 	rabbitCustomConfigTpl, err := template.New("rabbitmq-testcontainers.conf").Parse(customConfigTpl)
 	if err != nil {
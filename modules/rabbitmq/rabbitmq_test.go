@@ -6,9 +6,12 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mdelapenya/tlscert"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -271,6 +274,164 @@ func TestRunContainer_withAllSettings(t *testing.T) {
 	}
 }
 
+func TestRunContainer_withStreams(t *testing.T) {
+	ctx := context.Background()
+
+	// enableStreams {
+	rabbitmqContainer, err := rabbitmq.RunContainer(ctx, rabbitmq.WithStreams())
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := rabbitmqContainer.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if !assertPluginIsEnabled(t, rabbitmqContainer, "rabbitmq_stream", "rabbitmq_stream_management") {
+		t.Fatal("stream plugins are not enabled")
+	}
+
+	streamURL, err := rabbitmqContainer.StreamURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(streamURL, "rabbitmq-stream://guest:guest@") {
+		t.Fatalf("unexpected stream URL: %s", streamURL)
+	}
+
+	// createStream {
+	err = rabbitmqContainer.CreateStream(ctx, "stream1", map[string]interface{}{"x-max-length-bytes": 2000000})
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assertEntity(t, rabbitmqContainer, "queues", "stream1") {
+		t.Fatal("stream1 was not created")
+	}
+}
+
+func TestRunContainer_withPlugins(t *testing.T) {
+	ctx := context.Background()
+
+	// withPlugins {
+	rabbitmqContainer, err := rabbitmq.RunContainer(ctx, rabbitmq.WithPlugins("rabbitmq_mqtt", "rabbitmq_shovel"))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := rabbitmqContainer.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if !assertPluginIsEnabled(t, rabbitmqContainer, "rabbitmq_mqtt", "rabbitmq_shovel", "rabbitmq_management") {
+		t.Fatal("plugins are not enabled")
+	}
+
+	// the MQTT port is exposed when the plugin is enabled
+	endpoint, err := rabbitmqContainer.PortEndpoint(ctx, rabbitmq.DefaultMQTTPort, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.DialTimeout("tcp", endpoint, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.Close()
+}
+
+func TestRunContainer_withDefinitions(t *testing.T) {
+	ctx := context.Background()
+
+	// withDefinitions {
+	rabbitmqContainer, err := rabbitmq.RunContainer(ctx,
+		rabbitmq.WithDefinitionsFile(filepath.Join("testdata", "definitions.json")),
+		rabbitmq.WithDefinitions(rabbitmq.Definitions{
+			Users: []rabbitmq.User{
+				{Name: "orders-app", Password: "orders-password"},
+			},
+			VirtualHosts: []rabbitmq.VirtualHost{
+				{Name: "orders"},
+			},
+			Permissions: []rabbitmq.Permission{
+				{User: "orders-app", VHost: "orders", Configure: ".*", Write: ".*", Read: ".*"},
+			},
+			Exchanges: []rabbitmq.Exchange{
+				{Name: "orders", VHost: "orders", Type: "topic", Durable: true},
+			},
+			Queues: []rabbitmq.Queue{
+				{Name: "orders.created", VHost: "orders", Durable: true, Arguments: map[string]interface{}{"x-queue-type": "quorum"}},
+			},
+			Bindings: []rabbitmq.Binding{
+				{Source: "orders", VHost: "orders", Destination: "orders.created", RoutingKey: "order.created"},
+			},
+		}),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := rabbitmqContainer.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	endpoint, err := rabbitmqContainer.PortEndpoint(ctx, rabbitmq.DefaultAMQPPort, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the declared user can publish to the declared exchange, routed to the declared queue
+	conn, err := amqp.Dial(fmt.Sprintf("amqp://orders-app:orders-password@%s/orders", endpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ch.Close()
+
+	err = ch.PublishWithContext(ctx, "orders", "order.created", true, false, amqp.Publishing{Body: []byte("order-1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var msg amqp.Delivery
+	for i := 0; i < 50; i++ {
+		var ok bool
+		msg, ok, err = ch.Get("orders.created", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if string(msg.Body) != "order-1" {
+		t.Fatalf("expected message %q, got %q", "order-1", msg.Body)
+	}
+
+	// the definitions file is imported too
+	if !assertEntity(t, rabbitmqContainer, "queues", "invoices") {
+		t.Fatal("invoices queue was not created")
+	}
+}
+
 func assertEntity(t *testing.T, container testcontainers.Container, listCommand string, entities ...string) bool {
 	t.Helper()
 
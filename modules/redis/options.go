@@ -0,0 +1,40 @@
+package redis
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	tls *TLSSettings
+}
+
+// TLSSettings defines the certificates used to run Redis with TLS
+type TLSSettings struct {
+	// CACertFile is the path of the CA certificate file, which signed the server certificate
+	CACertFile string
+	// CertFile is the path of the server certificate file. It must be valid for the host of the container,
+	// usually localhost
+	CertFile string
+	// KeyFile is the path of the server private key file
+	KeyFile string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Redis container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithTLS runs Redis accepting TLS connections only, on the default port, with the given certificates.
+// The clients are not required to authenticate with a certificate, so the CA certificate is enough to connect.
+// See https://redis.io/docs/management/security/encryption/ for more information.
+func WithTLS(settings TLSSettings) Option {
+	return func(o *options) {
+		o.tls = &settings
+	}
+}
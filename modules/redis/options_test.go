@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 func TestWithConfigFile(t *testing.T) {
@@ -136,3 +137,41 @@ func TestWithSnapshotting(t *testing.T) {
 		})
 	}
 }
+
+func TestWithTLS(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Cmd: []string{redisServerProcess, "--loglevel", "debug"},
+		},
+	}
+
+	settings := options{}
+	WithTLS(TLSSettings{CACertFile: "ca.crt", CertFile: "server.crt", KeyFile: "server.key"})(&settings)
+	require.NotNil(t, settings.tls)
+
+	applyTLSSettings(settings.tls)(req)
+
+	require.Equal(t, []string{
+		redisServerProcess, "--loglevel", "debug",
+		"--port", "0",
+		"--tls-port", "6379",
+		"--tls-cert-file", "/tls/server.crt",
+		"--tls-key-file", "/tls/server.key",
+		"--tls-ca-cert-file", "/tls/ca.crt",
+		"--tls-auth-clients", "no",
+	}, req.Cmd)
+
+	require.Len(t, req.Files, 3)
+	require.Equal(t, "ca.crt", req.Files[0].HostFilePath)
+	require.Equal(t, "/tls/ca.crt", req.Files[0].ContainerFilePath)
+
+	require.IsType(t, &wait.ExecStrategy{}, req.WaitingFor)
+}
+
+func TestWaitForPing(t *testing.T) {
+	strategy := waitForPing()
+
+	require.True(t, strategy.OutputMatcher([]byte("PONG\n")))
+	require.True(t, strategy.OutputMatcher([]byte("NOAUTH Authentication required.\n")))
+	require.False(t, strategy.OutputMatcher([]byte("LOADING Redis is loading the dataset in memory\n")))
+}
@@ -1,8 +1,12 @@
 package redis
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -14,6 +18,16 @@ const defaultImage = "docker.io/redis:7"
 // redisServerProcess is the name of the redis server process
 const redisServerProcess = "redis-server"
 
+// redisCliProcess is the name of the redis client, used to wait for the server
+const redisCliProcess = "redis-cli"
+
+const (
+	defaultPort = "6379/tcp"
+
+	// tlsDir is the directory of the certificates in the container
+	tlsDir = "/tls"
+)
+
 type LogLevel string
 
 const (
@@ -29,10 +43,13 @@ const (
 
 type RedisContainer struct {
 	testcontainers.Container
+	settings options
 }
 
+// ConnectionString returns the connection string for the Redis container, with the format redis://<host>:<port>,
+// or rediss://<host>:<port> if it runs with TLS. In that case, use TLSConfig to configure the client.
 func (c *RedisContainer) ConnectionString(ctx context.Context) (string, error) {
-	mappedPort, err := c.MappedPort(ctx, "6379/tcp")
+	mappedPort, err := c.MappedPort(ctx, defaultPort)
 	if err != nil {
 		return "", err
 	}
@@ -42,16 +59,44 @@ func (c *RedisContainer) ConnectionString(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	uri := fmt.Sprintf("redis://%s:%s", hostIP, mappedPort.Port())
+	schema := "redis"
+	if c.settings.tls != nil {
+		schema = "rediss"
+	}
+
+	uri := fmt.Sprintf("%s://%s:%s", schema, hostIP, mappedPort.Port())
 	return uri, nil
 }
 
+// TLSConfig returns the TLS config to connect to the Redis container, trusting the CA certificate passed to WithTLS,
+// or nil if it does not run with TLS.
+func (c *RedisContainer) TLSConfig() (*tls.Config, error) {
+	if c.settings.tls == nil {
+		return nil, nil
+	}
+
+	caCert, err := os.ReadFile(c.settings.tls.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificate found in %s", c.settings.tls.CACertFile)
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
 // RunContainer creates an instance of the Redis container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RedisContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
-		ExposedPorts: []string{"6379/tcp"},
-		WaitingFor:   wait.ForLog("* Ready to accept connections"),
+		ExposedPorts: []string{defaultPort},
+		WaitingFor:   waitForPing(),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -59,16 +104,70 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Started:          true,
 	}
 
+	settings := options{}
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		opt.Customize(&genericContainerReq)
 	}
 
+	if settings.tls != nil {
+		applyTLSSettings(settings.tls)(&genericContainerReq)
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &RedisContainer{Container: container}, nil
+	return &RedisContainer{Container: container, settings: settings}, nil
+}
+
+// waitForPing waits for the server to reply to the PING command, sent with redis-cli from inside the container.
+// A NOAUTH error means the server is ready too, but requires a password, e.g. the one of a config file.
+func waitForPing(cliArgs ...string) *wait.ExecStrategy {
+	cmd := append([]string{redisCliProcess}, cliArgs...)
+	cmd = append(cmd, "ping")
+
+	return wait.ForExec(cmd).WithOutputMatcher(func(stdout []byte) bool {
+		return bytes.Contains(stdout, []byte("PONG")) || bytes.Contains(stdout, []byte("NOAUTH"))
+	})
+}
+
+// applyTLSSettings copies the certificates to the container, replacing the plain port with the TLS one,
+// and waits for the PING command over TLS.
+func applyTLSSettings(settings *TLSSettings) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.CACertFile,
+				ContainerFilePath: tlsDir + "/ca.crt",
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.CertFile,
+				ContainerFilePath: tlsDir + "/server.crt",
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.KeyFile,
+				ContainerFilePath: tlsDir + "/server.key",
+				FileMode:          0o644,
+			},
+		)
+
+		processRedisServerArgs(req, []string{
+			"--port", "0",
+			"--tls-port", "6379",
+			"--tls-cert-file", tlsDir + "/server.crt",
+			"--tls-key-file", tlsDir + "/server.key",
+			"--tls-ca-cert-file", tlsDir + "/ca.crt",
+			"--tls-auth-clients", "no",
+		})
+
+		req.WaitingFor = waitForPing("--tls", "--cacert", tlsDir+"/ca.crt")
+	}
 }
 
 // WithConfigFile sets the config file to be used for the redis container, and sets the command to run the redis server
@@ -2,8 +2,17 @@ package redis_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -115,6 +124,74 @@ func TestRedisWithSnapshotting(t *testing.T) {
 	assertSetsGets(t, ctx, redisContainer, 10)
 }
 
+func TestRedisWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	settings := generateCertificates(t)
+
+	// withTLS {
+	redisContainer, err := tcredis.RunContainer(ctx, tcredis.WithTLS(settings))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(uri, "rediss://"))
+
+	// tlsConfig {
+	tlsConfig, err := redisContainer.TLSConfig()
+	// }
+	require.NoError(t, err)
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	// the server name is the host of the connection string, set by ParseURL
+	tlsConfig.ServerName = options.TLSConfig.ServerName
+	options.TLSConfig = tlsConfig
+
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	pong, err := client.Ping(ctx).Result()
+	require.NoError(t, err)
+	require.Equal(t, "PONG", pong)
+}
+
+func TestRedisWithPassword(t *testing.T) {
+	ctx := context.Background()
+
+	// the server is ready once it replies NOAUTH to the PING command
+	redisContainer, err := tcredis.RunContainer(ctx, testcontainers.CustomizeRequest(testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Cmd: []string{"redis-server", "--requirepass", "secret"},
+		},
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+	options.Password = "secret"
+
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	require.NoError(t, client.Ping(ctx).Err())
+}
+
 func assertSetsGets(t *testing.T, ctx context.Context, redisContainer *tcredis.RedisContainer, keyCount int) {
 	// connectionString {
 	uri, err := redisContainer.ConnectionString(ctx)
@@ -164,3 +241,69 @@ func assertSetsGets(t *testing.T, ctx context.Context, redisContainer *tcredis.R
 func flushRedis(ctx context.Context, client redis.Client) error {
 	return client.FlushAll(ctx).Err()
 }
+
+// generateCertificates generates a CA and a server certificate signed by it, valid for localhost,
+// writing them to a temporary directory
+func generateCertificates(t *testing.T) tcredis.TLSSettings {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Redis CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caCert, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverKeyDER, err := x509.MarshalPKCS8PrivateKey(serverKey)
+	require.NoError(t, err)
+
+	settings := tcredis.TLSSettings{
+		CACertFile: filepath.Join(dir, "ca.crt"),
+		CertFile:   filepath.Join(dir, "server.crt"),
+		KeyFile:    filepath.Join(dir, "server.key"),
+	}
+
+	writePEM(t, settings.CACertFile, "CERTIFICATE", caDER)
+	writePEM(t, settings.CertFile, "CERTIFICATE", serverDER)
+	writePEM(t, settings.KeyFile, "PRIVATE KEY", serverKeyDER)
+
+	return settings
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o644)
+	require.NoError(t, err)
+}
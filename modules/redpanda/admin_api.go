@@ -59,14 +59,72 @@ func (cl *AdminAPIClient) CreateUser(ctx context.Context, username, password str
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-		}
+	defer resp.Body.Close()
 
-		return fmt.Errorf("unexpected status code in response: %d. Response body is: %q", resp.StatusCode, body)
+	return checkResponse(resp)
+}
+
+// ListUsers returns the usernames of the SASL users of the cluster.
+func (cl *AdminAPIClient) ListUsers(ctx context.Context) ([]string, error) {
+	endpoint, err := url.JoinPath(cl.BaseURL, "/v1/security/users")
+	if err != nil {
+		return nil, fmt.Errorf("failed to join url path: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build http request: %w", err)
+	}
+
+	resp, err := cl.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var users []string
+	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+		return nil, fmt.Errorf("failed to decode list users response: %w", err)
+	}
+
+	return users, nil
+}
+
+// DeleteUser deletes a SASL user of the cluster.
+func (cl *AdminAPIClient) DeleteUser(ctx context.Context, username string) error {
+	endpoint, err := url.JoinPath(cl.BaseURL, "/v1/security/users", url.PathEscape(username))
+	if err != nil {
+		return fmt.Errorf("failed to join url path: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build http request: %w", err)
+	}
+
+	resp, err := cl.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+// checkResponse returns an error including the response body if the status code is not 200 OK.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	return fmt.Errorf("unexpected status code in response: %d. Response body is: %q", resp.StatusCode, body)
 }
@@ -189,6 +189,72 @@ func TestRedpandaWithAuthentication(t *testing.T) {
 	}
 }
 
+func TestRedpandaClients(t *testing.T) {
+	ctx := context.Background()
+	container, err := redpanda.RunContainer(ctx,
+		redpanda.WithEnableSASL(),
+		redpanda.WithNewServiceAccount("superuser-1", "test"),
+		redpanda.WithNewServiceAccount("temporary", "test"),
+		redpanda.WithSuperusers("superuser-1"),
+		redpanda.WithEnableSchemaRegistryHTTPBasicAuth(),
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	t.Run("schema-registry", func(t *testing.T) {
+		// schemaRegistryClient {
+		schemaRegistryURL, err := container.SchemaRegistryAddress(ctx)
+		require.NoError(t, err)
+
+		schemaRegistryCl := redpanda.NewSchemaRegistryClient(schemaRegistryURL).WithBasicAuth("superuser-1", "test")
+
+		id, err := schemaRegistryCl.RegisterSchema(ctx, "users-value", `{"type":"record","name":"User","fields":[{"name":"name","type":"string"}]}`, redpanda.SchemaTypeAvro)
+		// }
+		require.NoError(t, err)
+		assert.Positive(t, id)
+
+		// the same schema is registered only once
+		sameID, err := schemaRegistryCl.RegisterSchema(ctx, "users-value", `{"type":"record","name":"User","fields":[{"name":"name","type":"string"}]}`, redpanda.SchemaTypeAvro)
+		require.NoError(t, err)
+		assert.Equal(t, id, sameID)
+
+		_, err = schemaRegistryCl.RegisterSchema(ctx, "orders-value", `{"type":"object","properties":{"id":{"type":"string"}}}`, redpanda.SchemaTypeJSON)
+		require.NoError(t, err)
+
+		subjects, err := schemaRegistryCl.Subjects(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"users-value", "orders-value"}, subjects)
+
+		_, err = redpanda.NewSchemaRegistryClient(schemaRegistryURL).Subjects(ctx)
+		require.ErrorContains(t, err, "unexpected status code in response: 401")
+	})
+
+	t.Run("admin-api", func(t *testing.T) {
+		// adminAPIClient {
+		adminAPIURL, err := container.AdminAPIAddress(ctx)
+		require.NoError(t, err)
+
+		adminCl := redpanda.NewAdminAPIClient(adminAPIURL)
+
+		users, err := adminCl.ListUsers(ctx)
+		// }
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"superuser-1", "temporary"}, users)
+
+		require.NoError(t, adminCl.DeleteUser(ctx, "temporary"))
+
+		users, err = adminCl.ListUsers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"superuser-1"}, users)
+	})
+}
+
 func TestRedpandaWithOldVersionAndWasm(t *testing.T) {
 	ctx := context.Background()
 	// redpandaCreateContainer {
@@ -0,0 +1,136 @@
+package redpanda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// schemaRegistryContentType is the content type of the requests to the Schema Registry API
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// Schema types supported by the Schema Registry API. AVRO is the default one.
+const (
+	SchemaTypeAvro     = "AVRO"
+	SchemaTypeJSON     = "JSON"
+	SchemaTypeProtobuf = "PROTOBUF"
+)
+
+// SchemaRegistryClient is a client of the Schema Registry API of Redpanda,
+// to register the schemas used by the tests, e.g. before producing records.
+type SchemaRegistryClient struct {
+	BaseURL  string
+	client   *http.Client
+	username string
+	password string
+}
+
+// NewSchemaRegistryClient returns a client of the Schema Registry API, given its address,
+// as returned by the SchemaRegistryAddress method of the container.
+func NewSchemaRegistryClient(baseURL string) *SchemaRegistryClient {
+	return &SchemaRegistryClient{
+		BaseURL: baseURL,
+		client:  http.DefaultClient,
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the API, e.g. to trust the certificate of the container when TLS is enabled.
+func (cl *SchemaRegistryClient) WithHTTPClient(c *http.Client) *SchemaRegistryClient {
+	cl.client = c
+	return cl
+}
+
+// WithBasicAuth sets the credentials of a service account, required when the HTTP basic authentication
+// of the Schema Registry is enabled with WithEnableSchemaRegistryHTTPBasicAuth.
+func (cl *SchemaRegistryClient) WithBasicAuth(username, password string) *SchemaRegistryClient {
+	cl.username = username
+	cl.password = password
+	return cl
+}
+
+type registerSchemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type registerSchemaResponse struct {
+	ID int `json:"id"`
+}
+
+// RegisterSchema registers a schema under the subject, and returns its ID.
+// The schema type is one of SchemaTypeAvro, SchemaTypeJSON or SchemaTypeProtobuf,
+// defaulting to SchemaTypeAvro if empty. Registering the same schema again returns the same ID.
+func (cl *SchemaRegistryClient) RegisterSchema(ctx context.Context, subject, schema, schemaType string) (int, error) {
+	if schemaType == SchemaTypeAvro {
+		// the default type is omitted, as older versions of the API do not support the field
+		schemaType = ""
+	}
+
+	jsonReq, err := json.Marshal(registerSchemaRequest{Schema: schema, SchemaType: schemaType})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal register schema request: %w", err)
+	}
+
+	endpoint, err := url.JoinPath(cl.BaseURL, "subjects", url.PathEscape(subject), "versions")
+	if err != nil {
+		return 0, fmt.Errorf("failed to join url path: %w", err)
+	}
+
+	var schemaResp registerSchemaResponse
+	if err := cl.do(ctx, http.MethodPost, endpoint, bytes.NewReader(jsonReq), &schemaResp); err != nil {
+		return 0, err
+	}
+
+	return schemaResp.ID, nil
+}
+
+// Subjects returns the subjects of the registered schemas.
+func (cl *SchemaRegistryClient) Subjects(ctx context.Context) ([]string, error) {
+	endpoint, err := url.JoinPath(cl.BaseURL, "subjects")
+	if err != nil {
+		return nil, fmt.Errorf("failed to join url path: %w", err)
+	}
+
+	var subjects []string
+	if err := cl.do(ctx, http.MethodGet, endpoint, nil, &subjects); err != nil {
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
+// do sends a request to the API, decoding the JSON response into out
+func (cl *SchemaRegistryClient) do(ctx context.Context, method, endpoint string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to build http request: %w", err)
+	}
+
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", schemaRegistryContentType)
+	}
+	if cl.username != "" {
+		req.SetBasicAuth(cl.username, cl.password)
+	}
+
+	resp, err := cl.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
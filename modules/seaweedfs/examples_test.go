@@ -0,0 +1,41 @@
+package seaweedfs_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/seaweedfs"
+)
+
+func ExampleRunContainer() {
+	// runSeaweedFSContainer {
+	ctx := context.Background()
+
+	seaweedfsContainer, err := seaweedfs.RunContainer(ctx,
+		testcontainers.WithImage("chrislusf/seaweedfs:3.64"),
+		seaweedfs.WithBuckets("testcontainers"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := seaweedfsContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := seaweedfsContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
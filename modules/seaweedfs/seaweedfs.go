@@ -0,0 +1,188 @@
+package seaweedfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "chrislusf/seaweedfs:3.64"
+	s3Port       = "8333/tcp"
+
+	// the S3 configuration of the identities, read by the S3 gateway at startup
+	s3ConfigFile = "/etc/seaweedfs/s3.json"
+
+	// defaultAccessKey and defaultSecretKey are the credentials used when deterministic credentials are enabled
+	defaultAccessKey = "seaweedfs"
+	defaultSecretKey = "seaweedfs"
+
+	// Region is the region of the S3 API. SeaweedFS does not check it, so any region is accepted.
+	Region = "us-east-1"
+)
+
+// SeaweedFSContainer represents the SeaweedFS container type used in the module
+type SeaweedFSContainer struct {
+	testcontainers.Container
+	AccessKey string
+	SecretKey string
+}
+
+// RunContainer creates an instance of the SeaweedFS container type, running the master, a volume server,
+// the filer and the S3 gateway in a single process. The S3 identity has admin permissions, and random
+// credentials unless WithCredentials is used.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*SeaweedFSContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{s3Port},
+		Cmd:          []string{"server", "-dir=/data", "-s3", "-s3.config=" + s3ConfigFile},
+		WaitingFor:   wait.ForHTTP("/status").WithPort(s3Port),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.accessKey == "" || settings.secretKey == "" {
+		accessKey, err := credentials.Username(defaultAccessKey)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the access key: %w", err)
+		}
+
+		secretKey, err := credentials.Token(defaultSecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the secret key: %w", err)
+		}
+
+		settings.accessKey, settings.secretKey = accessKey, secretKey
+	}
+
+	s3Config, err := s3Configuration(settings.accessKey, settings.secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            strings.NewReader(s3Config),
+		ContainerFilePath: s3ConfigFile,
+		FileMode:          0o644,
+	})
+
+	if len(settings.buckets) > 0 {
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return createBuckets(ctx, c, settings.buckets)
+				},
+			},
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeaweedFSContainer{Container: container, AccessKey: settings.accessKey, SecretKey: settings.secretKey}, nil
+}
+
+// ConnectionString returns the host and port of the S3 API of the SeaweedFS container,
+// e.g. to be used as the endpoint of the minio-go client.
+func (c *SeaweedFSContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, s3Port)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, port.Port()), nil
+}
+
+// S3Endpoint returns the URL of the S3 API of the SeaweedFS container, with the format http://<host>:<port>,
+// e.g. to be used as the base endpoint of the AWS SDK, with path-style addressing.
+func (c *SeaweedFSContainer) S3Endpoint(ctx context.Context) (string, error) {
+	hostPort, err := c.ConnectionString(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + hostPort, nil
+}
+
+// s3Configuration returns the S3 configuration of SeaweedFS, with a single admin identity
+func s3Configuration(accessKey string, secretKey string) (string, error) {
+	type credential struct {
+		AccessKey string `json:"accessKey"`
+		SecretKey string `json:"secretKey"`
+	}
+
+	type identity struct {
+		Name        string       `json:"name"`
+		Credentials []credential `json:"credentials"`
+		Actions     []string     `json:"actions"`
+	}
+
+	config := struct {
+		Identities []identity `json:"identities"`
+	}{
+		Identities: []identity{
+			{
+				Name:        "testcontainers",
+				Credentials: []credential{{AccessKey: accessKey, SecretKey: secretKey}},
+				Actions:     []string{"Admin", "Read", "List", "Tagging", "Write"},
+			},
+		},
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling the S3 configuration: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// createBuckets creates the buckets with the SeaweedFS shell, which reads the commands from its standard input
+func createBuckets(ctx context.Context, c testcontainers.Container, buckets []string) error {
+	var commands strings.Builder
+	for _, bucket := range buckets {
+		commands.WriteString("s3.bucket.create -name " + bucket + "\n")
+	}
+
+	code, reader, err := c.Exec(ctx, []string{"weed", "shell"}, tcexec.WithStdin(strings.NewReader(commands.String())), tcexec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("error creating the buckets: %w", err)
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("error reading the output of the shell: %w", err)
+	}
+
+	// the shell does not fail when a command fails, so its output is checked too
+	if code != 0 || strings.Contains(strings.ToLower(string(output)), "error") {
+		return fmt.Errorf("error creating the buckets %v: %s", buckets, output)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package seaweedfs_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/seaweedfs"
+)
+
+func TestSeaweedFS(t *testing.T) {
+	ctx := context.Background()
+
+	// withBuckets {
+	container, err := seaweedfs.RunContainer(ctx,
+		seaweedfs.WithCredentials("testcontainers", "testcontainers-secret"),
+		seaweedfs.WithBuckets("bucket-1", "bucket-2"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assert.Equal(t, "testcontainers", container.AccessKey)
+	assert.Equal(t, "testcontainers-secret", container.SecretKey)
+
+	// connectionString {
+	hostPort, err := container.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+
+	client, err := minio.New(hostPort, &minio.Options{
+		Creds:  credentials.NewStaticV4(container.AccessKey, container.SecretKey, ""),
+		Secure: false,
+		Region: seaweedfs.Region,
+	})
+	require.NoError(t, err)
+
+	buckets, err := client.ListBuckets(ctx)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, bucket := range buckets {
+		names = append(names, bucket.Name)
+	}
+	assert.ElementsMatch(t, []string{"bucket-1", "bucket-2"}, names)
+
+	content := "Hello, SeaweedFS!"
+	_, err = client.PutObject(ctx, "bucket-1", "hello.txt", strings.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	require.NoError(t, err)
+
+	object, err := client.GetObject(ctx, "bucket-1", "hello.txt", minio.GetObjectOptions{})
+	require.NoError(t, err)
+	defer object.Close()
+
+	b, err := io.ReadAll(object)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(b))
+}
+
+func TestSeaweedFS_randomCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := seaweedfs.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	require.NotEmpty(t, container.AccessKey)
+	require.NotEmpty(t, container.SecretKey)
+
+	// s3Endpoint {
+	endpoint, err := container.S3Endpoint(ctx)
+	// }
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(endpoint, "http://"), endpoint)
+
+	client, err := minio.New(strings.TrimPrefix(endpoint, "http://"), &minio.Options{
+		Creds: credentials.NewStaticV4(container.AccessKey, container.SecretKey, ""),
+	})
+	require.NoError(t, err)
+
+	err = client.MakeBucket(ctx, "testcontainers", minio.MakeBucketOptions{Region: seaweedfs.Region})
+	require.NoError(t, err)
+
+	// the requests signed with other credentials are rejected
+	anonymous, err := minio.New(strings.TrimPrefix(endpoint, "http://"), &minio.Options{
+		Creds: credentials.NewStaticV4("unknown", "unknown-secret", ""),
+	})
+	require.NoError(t, err)
+
+	_, err = anonymous.ListBuckets(ctx)
+	require.Error(t, err)
+}
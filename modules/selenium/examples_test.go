@@ -0,0 +1,39 @@
+package selenium_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/selenium"
+)
+
+func ExampleRunContainer() {
+	// runSeleniumContainer {
+	ctx := context.Background()
+
+	seleniumContainer, err := selenium.RunContainer(ctx,
+		selenium.WithBrowser(selenium.Chrome),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := seleniumContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := seleniumContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
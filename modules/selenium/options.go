@@ -0,0 +1,68 @@
+package selenium
+
+import (
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Browser is the browser run by the standalone Selenium image.
+type Browser string
+
+const (
+	Chrome  Browser = "chrome"
+	Firefox Browser = "firefox"
+	Edge    Browser = "edge"
+)
+
+// image returns the standalone Selenium image of the browser
+func (b Browser) image() string {
+	return "selenium/standalone-" + string(b) + ":" + imageTag
+}
+
+type options struct {
+	recording     *recording
+	recorderImage string
+}
+
+// recording is the recording of the screen of the browser by a companion container
+type recording struct {
+	tb       testing.TB
+	hostPath string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Selenium container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithBrowser sets the standalone Selenium image of the browser, e.g. WithBrowser(selenium.Firefox).
+// It's Chrome by default. Use testcontainers.WithImage to run another version of the image.
+func WithBrowser(browser Browser) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Image = browser.image()
+	}
+}
+
+// WithRecording records the screen of the browser in an MP4 video, with a companion container running the
+// video image of Selenium. The video is copied to the hostPath when the container is terminated, only if tb
+// failed, so the failed tests can be debugged. If tb is nil, the video is always copied.
+func WithRecording(tb testing.TB, hostPath string) Option {
+	return func(o *options) {
+		o.recording = &recording{tb: tb, hostPath: hostPath}
+	}
+}
+
+// WithRecorderImage sets the image of the container recording the screen of the browser, enabled with
+// WithRecording. Its version must be compatible with the one of the image of the browser.
+func WithRecorderImage(image string) Option {
+	return func(o *options) {
+		o.recorderImage = image
+	}
+}
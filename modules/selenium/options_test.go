@@ -0,0 +1,34 @@
+package selenium
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserImage(t *testing.T) {
+	assert.Equal(t, "selenium/standalone-chrome:"+imageTag, Chrome.image())
+	assert.Equal(t, "selenium/standalone-firefox:"+imageTag, Firefox.image())
+	assert.Equal(t, "selenium/standalone-edge:"+imageTag, Edge.image())
+}
+
+func TestIsReady(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		ready, err := isReady(strings.NewReader(`{"value": {"ready": true, "message": "Selenium Grid ready."}}`))
+		require.NoError(t, err)
+		assert.True(t, ready)
+	})
+
+	t.Run("not-ready", func(t *testing.T) {
+		ready, err := isReady(strings.NewReader(`{"value": {"ready": false, "message": "Selenium Grid not ready."}}`))
+		require.NoError(t, err)
+		assert.False(t, ready)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := isReady(strings.NewReader(`<html></html>`))
+		require.Error(t, err)
+	})
+}
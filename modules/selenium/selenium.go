@@ -0,0 +1,212 @@
+package selenium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// imageTag is the tag of the standalone Selenium images of the browsers
+	imageTag             = "4.22.0-20240621"
+	defaultRecorderImage = "selenium/video:ffmpeg-7.0.1-20240621"
+
+	webDriverPort = "4444/tcp"
+	noVNCPort     = "7900/tcp"
+
+	// vncPassword is the default password of the VNC server of the standalone Selenium images
+	vncPassword = "secret"
+
+	// shmSize is the size of /dev/shm recommended for the browsers, which crash with the default one
+	shmSize = 2 * 1024 * 1024 * 1024
+
+	// browserAlias is the network alias of the browser container, on the network shared with the recorder
+	browserAlias = "browser"
+
+	// videoPath is the path of the video in the recorder container
+	videoPath = "/videos/video.mp4"
+
+	// recorderStopTimeout is the time the recorder has to finalize the video when it's stopped
+	recorderStopTimeout = 30 * time.Second
+)
+
+// SeleniumContainer represents the Selenium container type used in the module
+type SeleniumContainer struct {
+	testcontainers.Container
+
+	// recorder is the container recording the screen of the browser, if any
+	recorder  testcontainers.Container
+	network   *testcontainers.DockerNetwork
+	recording *recording
+}
+
+// RunContainer creates an instance of the Selenium container type, running a standalone Selenium image,
+// with Chrome by default, see WithBrowser. The WebDriver clients connect to its WebDriverURL.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*SeleniumContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        Chrome.image(),
+		ExposedPorts: []string{webDriverPort, noVNCPort},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.ShmSize = shmSize
+		},
+		WaitingFor: wait.ForHTTP("/status").WithPort(webDriverPort).WithResponseMatcher(func(body io.Reader) bool {
+			ready, err := isReady(body)
+			return err == nil && ready
+		}),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{
+		recorderImage: defaultRecorderImage,
+	}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.recording == nil {
+		c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SeleniumContainer{Container: c}, nil
+	}
+
+	// the recorder connects to the display of the browser through a dedicated network
+	nw, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the network of the recorder: %w", err)
+	}
+	network.WithNetwork([]string{browserAlias}, nw).Customize(&genericContainerReq)
+
+	c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, errors.Join(err, nw.Remove(ctx))
+	}
+
+	recorder, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:    settings.recorderImage,
+			Networks: []string{nw.Name},
+			Env: map[string]string{
+				"DISPLAY_CONTAINER_NAME": browserAlias,
+				"SE_VIDEO_FILE_NAME":     filepath.Base(videoPath),
+			},
+			WaitingFor: wait.ForLog("video-recording entered RUNNING state"),
+		},
+		Started: true,
+		Logger:  genericContainerReq.Logger,
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error starting the recorder: %w", err), c.Terminate(ctx), nw.Remove(ctx))
+	}
+
+	return &SeleniumContainer{
+		Container: c,
+		recorder:  recorder,
+		network:   nw,
+		recording: settings.recording,
+	}, nil
+}
+
+// isReady returns whether the response of the status endpoint of WebDriver reports it's ready to create sessions
+func isReady(body io.Reader) (bool, error) {
+	var status struct {
+		Value struct {
+			Ready bool `json:"ready"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(body).Decode(&status); err != nil {
+		return false, err
+	}
+
+	return status.Value.Ready, nil
+}
+
+// WebDriverURL returns the URL of the WebDriver endpoint of the Selenium container, with the format
+// http://<host>:<port>, to be used as the remote URL of the WebDriver clients.
+func (c *SeleniumContainer) WebDriverURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, webDriverPort, "http")
+}
+
+// NoVNCURL returns the URL of the noVNC web client of the Selenium container, connecting to its VNC server
+// with the default password, to watch the browser while debugging a test.
+func (c *SeleniumContainer) NoVNCURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, noVNCPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/?autoconnect=1&resize=scale&password=" + vncPassword, nil
+}
+
+// Terminate terminates the recorder, copying the video to the host path set with WithRecording,
+// if the test failed, and then the Selenium container.
+func (c *SeleniumContainer) Terminate(ctx context.Context) error {
+	if c.recorder == nil {
+		return c.Container.Terminate(ctx)
+	}
+
+	return errors.Join(
+		c.saveRecording(ctx),
+		c.recorder.Terminate(ctx),
+		c.Container.Terminate(ctx),
+		c.network.Remove(ctx),
+	)
+}
+
+// saveRecording stops the recorder, so that it finalizes the video, and copies the video to the host path,
+// unless the test succeeded
+func (c *SeleniumContainer) saveRecording(ctx context.Context) error {
+	timeout := recorderStopTimeout
+	if err := c.recorder.Stop(ctx, &timeout); err != nil {
+		return fmt.Errorf("error stopping the recorder: %w", err)
+	}
+
+	if c.recording.tb != nil && !c.recording.tb.Failed() {
+		return nil
+	}
+
+	r, err := c.recorder.CopyFileFromContainer(ctx, videoPath)
+	if err != nil {
+		return fmt.Errorf("error copying the video from the recorder: %w", err)
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(c.recording.hostPath), 0o755); err != nil {
+		return fmt.Errorf("error creating the directory of the video: %w", err)
+	}
+
+	f, err := os.Create(c.recording.hostPath)
+	if err != nil {
+		return fmt.Errorf("error creating the video: %w", err)
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Join(fmt.Errorf("error writing the video: %w", err), f.Close())
+	}
+
+	if c.recording.tb != nil {
+		c.recording.tb.Logf("The video of the browser was saved to %s", c.recording.hostPath)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,132 @@
+package selenium_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/selenium"
+)
+
+func TestSelenium(t *testing.T) {
+	ctx := context.Background()
+
+	for _, browser := range []selenium.Browser{selenium.Chrome, selenium.Firefox} {
+		browser := browser
+		t.Run(string(browser), func(t *testing.T) {
+			// withBrowser {
+			container, err := selenium.RunContainer(ctx, selenium.WithBrowser(browser))
+			// }
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				require.NoError(t, container.Terminate(ctx))
+			})
+
+			// webDriverURL {
+			webDriverURL, err := container.WebDriverURL(ctx)
+			// }
+			require.NoError(t, err)
+
+			sessionID := newSession(t, webDriverURL, browser)
+			deleteSession(t, webDriverURL, sessionID)
+		})
+	}
+}
+
+func TestSelenium_recording(t *testing.T) {
+	ctx := context.Background()
+
+	videoPath := filepath.Join(t.TempDir(), "videos", "video.mp4")
+
+	// a nil testing.TB copies the video even if the test succeeds
+	container, err := selenium.RunContainer(ctx, selenium.WithRecording(nil, videoPath))
+	require.NoError(t, err)
+
+	webDriverURL, err := container.WebDriverURL(ctx)
+	require.NoError(t, err)
+
+	sessionID := newSession(t, webDriverURL, selenium.Chrome)
+	deleteSession(t, webDriverURL, sessionID)
+
+	require.NoError(t, container.Terminate(ctx))
+
+	info, err := os.Stat(videoPath)
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+}
+
+func TestSelenium_recordingOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	videoPath := filepath.Join(t.TempDir(), "video.mp4")
+
+	// withRecording {
+	container, err := selenium.RunContainer(ctx, selenium.WithRecording(t, videoPath))
+	// }
+	require.NoError(t, err)
+
+	require.NoError(t, container.Terminate(ctx))
+
+	// the test did not fail
+	_, err = os.Stat(videoPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// newSession creates a WebDriver session of the browser, returning its ID
+func newSession(t *testing.T, webDriverURL string, browser selenium.Browser) string {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{
+		"capabilities": map[string]any{
+			"alwaysMatch": map[string]any{"browserName": browserName(browser)},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(webDriverURL+"/session", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var session struct {
+		Value struct {
+			SessionID string `json:"sessionId"`
+		} `json:"value"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&session))
+	require.NotEmpty(t, session.Value.SessionID)
+
+	return session.Value.SessionID
+}
+
+// deleteSession deletes the WebDriver session, closing the browser
+func deleteSession(t *testing.T, webDriverURL string, sessionID string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodDelete, webDriverURL+"/session/"+sessionID, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// browserName returns the WebDriver name of the browser
+func browserName(browser selenium.Browser) string {
+	if browser == selenium.Edge {
+		return "MicrosoftEdge"
+	}
+
+	return string(browser)
+}
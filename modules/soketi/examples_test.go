@@ -0,0 +1,41 @@
+package soketi_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/soketi"
+)
+
+func ExampleRunContainer() {
+	// runSoketiContainer {
+	ctx := context.Background()
+
+	soketiContainer, err := soketi.RunContainer(ctx,
+		testcontainers.WithImage("quay.io/soketi/soketi:1.6-16-debian"),
+		soketi.WithApp("app-id", "app-key", "app-secret"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := soketiContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := soketiContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
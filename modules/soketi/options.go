@@ -0,0 +1,32 @@
+package soketi
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	appID     string
+	appKey    string
+	appSecret string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Soketi container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithApp sets the ID, the key and the secret of the Pusher app served by Soketi.
+// The ID defaults to "app-id", while the key and the secret are random by default.
+func WithApp(id string, key string, secret string) Option {
+	return func(o *options) {
+		o.appID = id
+		o.appKey = key
+		o.appSecret = secret
+	}
+}
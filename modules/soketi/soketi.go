@@ -0,0 +1,198 @@
+package soketi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "quay.io/soketi/soketi:1.6-16-debian"
+	defaultPort  = "6001/tcp"
+
+	defaultAppID = "app-id"
+
+	// defaultAppKey and defaultAppSecret are the credentials of the app used when deterministic credentials are enabled
+	defaultAppKey    = "app-key"
+	defaultAppSecret = "app-secret"
+)
+
+// SoketiContainer represents the Soketi container type used in the module
+type SoketiContainer struct {
+	testcontainers.Container
+	appID     string
+	appKey    string
+	appSecret string
+}
+
+// RunContainer creates an instance of the Soketi container type, a WebSocket server implementing the Pusher protocol,
+// serving a single app. The clients connect with the WebSocket endpoint, using the key of the app,
+// and the events are triggered with the HTTP API, see Trigger.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*SoketiContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env:          map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{appID: defaultAppID}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.appKey == "" {
+		key, err := credentials.Token(defaultAppKey)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the app key: %w", err)
+		}
+
+		settings.appKey = key
+	}
+
+	if settings.appSecret == "" {
+		secret, err := credentials.Token(defaultAppSecret)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the app secret: %w", err)
+		}
+
+		settings.appSecret = secret
+	}
+
+	genericContainerReq.Env["SOKETI_DEFAULT_APP_ID"] = settings.appID
+	genericContainerReq.Env["SOKETI_DEFAULT_APP_KEY"] = settings.appKey
+	genericContainerReq.Env["SOKETI_DEFAULT_APP_SECRET"] = settings.appSecret
+
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForHTTP("/ready").WithPort(defaultPort)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SoketiContainer{
+		Container: container,
+		appID:     settings.appID,
+		appKey:    settings.appKey,
+		appSecret: settings.appSecret,
+	}, nil
+}
+
+// HTTPEndpoint returns the URL of the HTTP API of the Soketi container, with the format http://<host>:<port>,
+// which is the host and port of the Pusher server SDKs.
+func (c *SoketiContainer) HTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultPort, "http")
+}
+
+// WebSocketEndpoint returns the URL of the WebSocket endpoint of the clients of the app, with the format
+// ws://<host>:<port>/app/<key>?protocol=7.
+func (c *SoketiContainer) WebSocketEndpoint(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, defaultPort, "ws")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/app/" + c.appKey + "?protocol=7", nil
+}
+
+// AppID returns the ID of the app
+func (c *SoketiContainer) AppID() string {
+	return c.appID
+}
+
+// AppKey returns the key of the app, used by the clients to connect
+func (c *SoketiContainer) AppKey() string {
+	return c.appKey
+}
+
+// AppSecret returns the secret of the app, used to sign the requests to the HTTP API
+func (c *SoketiContainer) AppSecret() string {
+	return c.appSecret
+}
+
+// Trigger triggers the event on the channels, with the HTTP API, signing the request with the secret of the app.
+// The data is sent as is if it's a string, or encoded as JSON otherwise.
+func (c *SoketiContainer) Trigger(ctx context.Context, event string, data any, channels ...string) error {
+	payload, ok := data.(string)
+	if !ok {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("error encoding the data of %s: %w", event, err)
+		}
+		payload = string(b)
+	}
+
+	body, err := json.Marshal(map[string]any{"name": event, "channels": channels, "data": payload})
+	if err != nil {
+		return fmt.Errorf("error encoding the event %s: %w", event, err)
+	}
+
+	endpoint, err := c.HTTPEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	path := "/apps/" + c.appID + "/events"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path+"?"+c.sign(http.MethodPost, path, body), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error triggering the event %s: %w", event, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error triggering the event %s: unexpected status code %d: %s", event, resp.StatusCode, msg)
+	}
+
+	return nil
+}
+
+// sign returns the query of the request to the HTTP API, including the authentication parameters
+// of the Pusher protocol: the key of the app, the MD5 of the body and the HMAC SHA-256 signature of the request.
+func (c *SoketiContainer) sign(method string, path string, body []byte) string {
+	bodyMD5 := md5.Sum(body)
+
+	query := url.Values{}
+	query.Set("auth_key", c.appKey)
+	query.Set("auth_timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	query.Set("auth_version", "1.0")
+	query.Set("body_md5", hex.EncodeToString(bodyMD5[:]))
+
+	// the parameters are sorted by key, as the signed string expects
+	params := query.Encode()
+
+	mac := hmac.New(sha256.New, []byte(c.appSecret))
+	mac.Write([]byte(method + "\n" + path + "\n" + params))
+	query.Set("auth_signature", hex.EncodeToString(mac.Sum(nil)))
+
+	return query.Encode()
+}
@@ -0,0 +1,72 @@
+package soketi_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/soketi"
+)
+
+func TestSoketi(t *testing.T) {
+	ctx := context.Background()
+
+	// withApp {
+	container, err := soketi.RunContainer(ctx, soketi.WithApp("shop", "shop-key", "shop-secret"))
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "shop", container.AppID())
+	assert.Equal(t, "shop-key", container.AppKey())
+	assert.Equal(t, "shop-secret", container.AppSecret())
+
+	t.Run("trigger", func(t *testing.T) {
+		// trigger {
+		err := container.Trigger(ctx, "order-created", map[string]string{"id": "42"}, "orders")
+		// }
+		require.NoError(t, err)
+	})
+
+	t.Run("unsigned-request", func(t *testing.T) {
+		endpoint, err := container.HTTPEndpoint(ctx)
+		require.NoError(t, err)
+
+		body := `{"name": "order-created", "channels": ["orders"], "data": "{}"}`
+		resp, err := http.Post(endpoint+"/apps/shop/events", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("websocket-endpoint", func(t *testing.T) {
+		ws, err := container.WebSocketEndpoint(ctx)
+		require.NoError(t, err)
+		assert.Regexp(t, `^ws://.+:\d+/app/shop-key\?protocol=7$`, ws)
+	})
+}
+
+func TestSoketi_randomApp(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := soketi.RunContainer(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "app-id", container.AppID())
+	require.NotEmpty(t, container.AppKey())
+	require.NotEmpty(t, container.AppSecret())
+
+	require.NoError(t, container.Trigger(ctx, "ping", "pong", "health"))
+}
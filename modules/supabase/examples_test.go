@@ -0,0 +1,53 @@
+package supabase_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/testcontainers/testcontainers-go/modules/supabase"
+)
+
+func ExampleRun() {
+	// runSupabaseStack {
+	ctx := context.Background()
+
+	stack, err := supabase.Run(ctx, supabase.WithJWTSecret("my-super-secret-jwt-token-for-the-tests"))
+	if err != nil {
+		log.Fatalf("failed to start stack: %s", err)
+	}
+
+	// Clean up the stack
+	defer func() {
+		if err := stack.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate stack: %s", err)
+		}
+	}()
+	// }
+
+	// apiURL {
+	apiURL, err := stack.APIURL(ctx)
+	if err != nil {
+		log.Fatalf("failed to get the API URL: %s", err) // nolint:gocritic
+	}
+
+	// the URL and the anon key are the settings of the Supabase client libraries
+	req, err := http.NewRequest(http.MethodGet, apiURL+"/auth/v1/settings", nil)
+	if err != nil {
+		log.Fatalf("failed to create request: %s", err)
+	}
+	req.Header.Set("apikey", stack.AnonKey())
+	// }
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatalf("failed to get the auth settings: %s", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Println(resp.StatusCode)
+
+	// Output:
+	// 200
+}
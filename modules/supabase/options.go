@@ -0,0 +1,74 @@
+package supabase
+
+const (
+	defaultJWTSecret        = "super-secret-jwt-token-with-at-least-32-characters-long"
+	defaultDatabasePassword = "postgres"
+)
+
+// defaultImages are the images of the services of the stack
+var defaultImages = map[string]string{
+	"db":      "supabase/postgres:15.1.0.147",
+	"auth":    "supabase/gotrue:v2.143.0",
+	"rest":    "postgrest/postgrest:v12.0.1",
+	"storage": "supabase/storage-api:v0.46.4",
+	"kong":    "kong:2.8.1",
+}
+
+type options struct {
+	images           map[string]string
+	jwtSecret        string
+	databasePassword string
+	initScripts      []string
+}
+
+func defaultOptions() options {
+	images := make(map[string]string, len(defaultImages))
+	for service, image := range defaultImages {
+		images[service] = image
+	}
+
+	return options{
+		images:           images,
+		jwtSecret:        defaultJWTSecret,
+		databasePassword: defaultDatabasePassword,
+	}
+}
+
+// Option is an option for the Supabase stack.
+type Option func(*options)
+
+// WithImage sets the Docker image of a service of the stack: db, auth, rest, storage or kong.
+// Unknown services are ignored.
+func WithImage(service string, image string) Option {
+	return func(o *options) {
+		if _, ok := o.images[service]; ok {
+			o.images[service] = image
+		}
+	}
+}
+
+// WithJWTSecret sets the secret used to sign the API keys and the access tokens of the users.
+// It must be at least 32 characters long.
+func WithJWTSecret(secret string) Option {
+	return func(o *options) {
+		o.jwtSecret = secret
+	}
+}
+
+// WithDatabasePassword sets the password of the postgres user, also used by the services
+// to connect to the database.
+func WithDatabasePassword(password string) Option {
+	return func(o *options) {
+		o.databasePassword = password
+	}
+}
+
+// WithInitScripts sets the SQL scripts executed, in order, once the database is created,
+// e.g. to bootstrap the schema and the row level security policies of the project.
+// They are executed by a superuser, and the new tables of the public schema are available
+// in the REST API, for the anon, authenticated and service_role roles.
+func WithInitScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.initScripts = append(o.initScripts, scripts...)
+	}
+}
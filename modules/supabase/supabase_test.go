@@ -0,0 +1,107 @@
+package supabase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/supabase"
+)
+
+func TestSupabase(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithInitScripts {
+	stack, err := supabase.Run(ctx, supabase.WithInitScripts(filepath.Join("testdata", "init.sql")))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := stack.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate stack: %s", err)
+		}
+	})
+
+	apiURL, err := stack.APIURL(ctx)
+	require.NoError(t, err)
+
+	t.Run("rest", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, apiURL+"/rest/v1/todos?select=title&order=id", nil)
+		require.NoError(t, err)
+		req.Header.Set("apikey", stack.AnonKey())
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var todos []struct {
+			Title string `json:"title"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&todos))
+		require.Len(t, todos, 2)
+		assert.Equal(t, "write the tests", todos[0].Title)
+	})
+
+	t.Run("row-level-security", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, apiURL+"/rest/v1/todos", strings.NewReader(`{"title": "hack it"}`))
+		require.NoError(t, err)
+		req.Header.Set("apikey", stack.AnonKey())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("auth", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, apiURL+"/auth/v1/signup", strings.NewReader(`{"email": "jane@example.com", "password": "s3cr3t-passw0rd"}`))
+		require.NoError(t, err)
+		req.Header.Set("apikey", stack.AnonKey())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// the emails are confirmed automatically, so the user is signed in
+		var session struct {
+			AccessToken string `json:"access_token"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&session))
+		assert.NotEmpty(t, session.AccessToken)
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, apiURL+"/storage/v1/bucket", strings.NewReader(`{"name": "avatars", "public": true}`))
+		require.NoError(t, err)
+		req.Header.Set("apikey", stack.ServiceRoleKey())
+		req.Header.Set("Authorization", "Bearer "+stack.ServiceRoleKey())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("database", func(t *testing.T) {
+		// databaseURL {
+		dbURL, err := stack.DatabaseURL(ctx)
+		// }
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(dbURL, "postgres://postgres:postgres@"))
+	})
+}
+
+func TestSupabase_invalidJWTSecret(t *testing.T) {
+	_, err := supabase.Run(context.Background(), supabase.WithJWTSecret("too-short"))
+	require.ErrorContains(t, err, "at least 32 characters long")
+}
@@ -0,0 +1,11 @@
+create table todos (
+  id serial primary key,
+  title text not null,
+  done boolean not null default false
+);
+
+insert into todos (title) values ('write the tests'), ('ship it');
+
+-- the anon role can read the todos, but not modify them
+alter table todos enable row level security;
+create policy "todos are public" on todos for select using (true);
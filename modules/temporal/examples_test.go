@@ -0,0 +1,41 @@
+package temporal_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/temporal"
+)
+
+func ExampleRunContainer() {
+	// runTemporalContainer {
+	ctx := context.Background()
+
+	temporalContainer, err := temporal.RunContainer(ctx,
+		testcontainers.WithImage("temporalio/temporal:1.1.2"),
+		temporal.WithDefaultNamespace("orders"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := temporalContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := temporalContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,41 @@
+package temporal
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	namespace     string
+	dynamicConfig map[string]any
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Temporal container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithDefaultNamespace sets the namespace registered by the dev server, and returned by Namespace.
+// The container is ready once it's registered. It's "default" by default.
+func WithDefaultNamespace(name string) Option {
+	return func(o *options) {
+		o.namespace = name
+	}
+}
+
+// WithDynamicConfigValue overrides the value of a key of the dynamic config of the server,
+// e.g. WithDynamicConfigValue("frontend.enableUpdateWorkflowExecution", true). The value is encoded as JSON.
+func WithDynamicConfigValue(key string, value any) Option {
+	return func(o *options) {
+		if o.dynamicConfig == nil {
+			o.dynamicConfig = map[string]any{}
+		}
+
+		o.dynamicConfig[key] = value
+	}
+}
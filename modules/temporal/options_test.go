@@ -0,0 +1,54 @@
+package temporal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevServerCmd(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		cmd, err := devServerCmd(options{namespace: defaultNamespace})
+		require.NoError(t, err)
+		require.Equal(t, []string{"server", "start-dev", "--ip", "0.0.0.0"}, cmd)
+	})
+
+	t.Run("with-options", func(t *testing.T) {
+		settings := options{namespace: defaultNamespace}
+		for _, opt := range []Option{
+			WithDefaultNamespace("orders"),
+			WithDynamicConfigValue("limit.maxIDLength", 255),
+			WithDynamicConfigValue("frontend.enableUpdateWorkflowExecution", true),
+			WithDynamicConfigValue("system.forceSearchAttributesCacheRefreshOnRead", true),
+		} {
+			opt(&settings)
+		}
+
+		cmd, err := devServerCmd(settings)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"server", "start-dev", "--ip", "0.0.0.0",
+			"--namespace", "orders",
+			"--dynamic-config-value", "frontend.enableUpdateWorkflowExecution=true",
+			"--dynamic-config-value", "limit.maxIDLength=255",
+			"--dynamic-config-value", "system.forceSearchAttributesCacheRefreshOnRead=true",
+		}, cmd)
+	})
+
+	t.Run("string-value", func(t *testing.T) {
+		settings := options{namespace: defaultNamespace}
+		WithDynamicConfigValue("history.defaultActivityRetryPolicy", "none")(&settings)
+
+		cmd, err := devServerCmd(settings)
+		require.NoError(t, err)
+		require.Contains(t, cmd, `history.defaultActivityRetryPolicy="none"`)
+	})
+
+	t.Run("invalid-value", func(t *testing.T) {
+		settings := options{namespace: defaultNamespace}
+		WithDynamicConfigValue("invalid", make(chan int))(&settings)
+
+		_, err := devServerCmd(settings)
+		require.Error(t, err)
+	})
+}
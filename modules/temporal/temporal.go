@@ -0,0 +1,116 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage     = "temporalio/temporal:1.1.2"
+	defaultGRPCPort  = "7233/tcp"
+	defaultWebUIPort = "8233/tcp"
+
+	// defaultNamespace is the namespace always registered by the dev server
+	defaultNamespace = "default"
+)
+
+// TemporalContainer represents the Temporal container type used in the module
+type TemporalContainer struct {
+	testcontainers.Container
+	namespace string
+}
+
+// RunContainer creates an instance of the Temporal container type, running the dev server of the Temporal CLI,
+// with an in-memory persistence, for testing workflows and activities. The workers and the clients connect to
+// its frontend gRPC address, see GRPCHostAddress.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*TemporalContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultGRPCPort, defaultWebUIPort},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{
+		namespace: defaultNamespace,
+	}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	cmd, err := devServerCmd(settings)
+	if err != nil {
+		return nil, err
+	}
+	genericContainerReq.Cmd = cmd
+
+	if genericContainerReq.WaitingFor == nil {
+		// the web UI answers once the frontend is serving the namespace
+		genericContainerReq.WaitingFor = wait.ForAll(
+			wait.ForListeningPort(defaultGRPCPort),
+			wait.ForHTTP("/api/v1/namespaces/"+url.PathEscape(settings.namespace)).WithPort(defaultWebUIPort),
+		)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemporalContainer{Container: container, namespace: settings.namespace}, nil
+}
+
+// devServerCmd returns the command running the dev server, listening on all the interfaces,
+// registering the namespace and overriding the dynamic config with the settings.
+func devServerCmd(settings options) ([]string, error) {
+	cmd := []string{"server", "start-dev", "--ip", "0.0.0.0"}
+
+	if settings.namespace != defaultNamespace {
+		cmd = append(cmd, "--namespace", settings.namespace)
+	}
+
+	keys := make([]string, 0, len(settings.dynamicConfig))
+	for k := range settings.dynamicConfig {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		value, err := json.Marshal(settings.dynamicConfig[k])
+		if err != nil {
+			return nil, fmt.Errorf("error encoding the dynamic config value of %s: %w", k, err)
+		}
+
+		cmd = append(cmd, "--dynamic-config-value", k+"="+string(value))
+	}
+
+	return cmd, nil
+}
+
+// GRPCHostAddress returns the address of the frontend gRPC service of the Temporal container,
+// with the format <host>:<port>, to be used as HostPort of the client options of the Temporal SDK.
+func (c *TemporalContainer) GRPCHostAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultGRPCPort, "")
+}
+
+// WebUIAddress returns the URL of the web UI of the Temporal container, with the format http://<host>:<port>.
+func (c *TemporalContainer) WebUIAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultWebUIPort, "http")
+}
+
+// Namespace returns the namespace registered by the dev server, set with WithDefaultNamespace.
+func (c *TemporalContainer) Namespace() string {
+	return c.namespace
+}
@@ -0,0 +1,58 @@
+package temporal_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/temporal"
+)
+
+func TestTemporal(t *testing.T) {
+	ctx := context.Background()
+
+	// withDefaultNamespace {
+	container, err := temporal.RunContainer(ctx,
+		temporal.WithDefaultNamespace("orders"),
+		temporal.WithDynamicConfigValue("frontend.enableUpdateWorkflowExecution", true),
+	)
+	// }
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "orders", container.Namespace())
+
+	t.Run("grpc-address", func(t *testing.T) {
+		// grpcHostAddress {
+		address, err := container.GRPCHostAddress(ctx)
+		// }
+		require.NoError(t, err)
+		assert.Regexp(t, `^.+:\d+$`, address)
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		uiAddress, err := container.WebUIAddress(ctx)
+		require.NoError(t, err)
+
+		resp, err := http.Get(uiAddress + "/api/v1/namespaces/" + container.Namespace())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var namespace struct {
+			NamespaceInfo struct {
+				Name string `json:"name"`
+			} `json:"namespaceInfo"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&namespace))
+		assert.Equal(t, "orders", namespace.NamespaceInfo.Name)
+	})
+}
@@ -0,0 +1,37 @@
+package tunnel_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/tunnel"
+)
+
+func ExampleRunContainer() {
+	// runTunnelContainer {
+	ctx := context.Background()
+
+	tunnelContainer, err := tunnel.RunContainer(ctx, tunnel.WithMaxTunnels(5))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container, and the tunnels still open
+	defer func() {
+		if err := tunnelContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := tunnelContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,49 @@
+package tunnel
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultClientImage = "fatedier/frpc:v0.57.0"
+	defaultMaxTunnels  = 10
+)
+
+type options struct {
+	clientImage string
+	maxTunnels  int
+}
+
+func defaultOptions() options {
+	return options{
+		clientImage: defaultClientImage,
+		maxTunnels:  defaultMaxTunnels,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the tunnel container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithClientImage sets the Docker image of the frp client containers, started for each tunnel.
+// It must be compatible with the image of the server, set with testcontainers.WithImage.
+func WithClientImage(image string) Option {
+	return func(o *options) {
+		o.clientImage = image
+	}
+}
+
+// WithMaxTunnels sets the maximum number of tunnels open at the same time, each one using a port
+// of the server. Defaults to 10.
+func WithMaxTunnels(n int) Option {
+	return func(o *options) {
+		o.maxTunnels = n
+	}
+}
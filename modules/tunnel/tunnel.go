@@ -0,0 +1,317 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "fatedier/frps:v0.57.0"
+
+	// serverPort is the port the frp clients connect to
+	serverPort = "7000/tcp"
+
+	// firstRemotePort is the first port of the server used by the tunnels
+	firstRemotePort = 10000
+
+	// hostGateway is the name the frp clients resolve to the host running the tests
+	hostGateway = "host.docker.internal"
+)
+
+// TunnelContainer represents the frp server container type used in the module.
+// Each tunnel opened with Expose or ExposeHostPort starts an frp client container,
+// forwarding a port of the server to its target.
+type TunnelContainer struct {
+	testcontainers.Container
+	token       string
+	clientImage string
+	maxTunnels  int
+
+	mtx     sync.Mutex
+	tunnels map[int]*Tunnel
+}
+
+// Tunnel represents an open tunnel: an frp client container forwarding a port of the server to the target.
+type Tunnel struct {
+	testcontainers.Container
+	server     *TunnelContainer
+	remotePort int
+}
+
+// RunContainer creates an instance of the frp server container type. The server must share a network
+// with the containers targeted by the tunnels, e.g. with network.WithNetwork.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*TunnelContainer, error) {
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+	}
+
+	if settings.maxTunnels < 1 {
+		return nil, fmt.Errorf("invalid number of tunnels: %d", settings.maxTunnels)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating the token: %w", err)
+	}
+
+	exposedPorts := []string{serverPort}
+	for i := 0; i < settings.maxTunnels; i++ {
+		exposedPorts = append(exposedPorts, fmt.Sprintf("%d/tcp", firstRemotePort+i))
+	}
+
+	config := fmt.Sprintf(`bindPort = %d
+auth.token = %q
+allowPorts = [{ start = %d, end = %d }]
+`, nat.Port(serverPort).Int(), token, firstRemotePort, firstRemotePort+settings.maxTunnels-1)
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: exposedPorts,
+		Cmd:          []string{"-c", "/etc/frp/frps.toml"},
+		Files: []testcontainers.ContainerFile{
+			{
+				Reader:            strings.NewReader(config),
+				ContainerFilePath: "/etc/frp/frps.toml",
+				FileMode:          0o644,
+			},
+		},
+		WaitingFor: wait.ForLog("frps started successfully"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TunnelContainer{
+		Container:   c,
+		token:       token,
+		clientImage: settings.clientImage,
+		maxTunnels:  settings.maxTunnels,
+		tunnels:     map[int]*Tunnel{},
+	}, nil
+}
+
+// Expose opens a tunnel to the target, in the host:port format, reachable from the networks of the server,
+// e.g. the network alias of a container and its port: "app:8080".
+func (c *TunnelContainer) Expose(ctx context.Context, target string) (*Tunnel, error) {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %s: %w", target, err)
+	}
+
+	localPort, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port of target %s: %w", target, err)
+	}
+
+	return c.expose(ctx, host, localPort, nil)
+}
+
+// ExposeHostPort opens a tunnel to a port of the host running the tests, e.g. the port of an HTTP server
+// receiving webhooks. The server must listen on all the interfaces of the host, not only on the loopback one.
+func (c *TunnelContainer) ExposeHostPort(ctx context.Context, port int) (*Tunnel, error) {
+	return c.expose(ctx, hostGateway, port, func(hostConfig *container.HostConfig) {
+		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, hostGateway+":host-gateway")
+	})
+}
+
+// Terminate terminates the open tunnels, and then the server container.
+func (c *TunnelContainer) Terminate(ctx context.Context) error {
+	c.mtx.Lock()
+	tunnels := make([]*Tunnel, 0, len(c.tunnels))
+	for _, t := range c.tunnels {
+		tunnels = append(tunnels, t)
+	}
+	c.mtx.Unlock()
+
+	var errs []error
+	for _, t := range tunnels {
+		errs = append(errs, t.Terminate(ctx))
+	}
+
+	return errors.Join(append(errs, c.Container.Terminate(ctx))...)
+}
+
+// expose starts an frp client container, forwarding a free port of the server to the local host and port
+func (c *TunnelContainer) expose(ctx context.Context, localHost string, localPort int, modifier func(*container.HostConfig)) (*Tunnel, error) {
+	networks, err := c.Networks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the networks of the server: %w", err)
+	}
+
+	serverIP, err := c.ip(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the IP address of the server: %w", err)
+	}
+
+	t := &Tunnel{server: c}
+	if err := c.reservePort(t); err != nil {
+		return nil, err
+	}
+
+	config := fmt.Sprintf(`serverAddr = %q
+serverPort = %d
+auth.token = %q
+loginFailExit = true
+
+[[proxies]]
+name = "tunnel-%d"
+type = "tcp"
+localIP = %q
+localPort = %d
+remotePort = %d
+`, serverIP, nat.Port(serverPort).Int(), c.token, t.remotePort, localHost, localPort, t.remotePort)
+
+	client, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:    c.clientImage,
+			Networks: networks,
+			Cmd:      []string{"-c", "/etc/frp/frpc.toml"},
+			Files: []testcontainers.ContainerFile{
+				{
+					Reader:            strings.NewReader(config),
+					ContainerFilePath: "/etc/frp/frpc.toml",
+					FileMode:          0o644,
+				},
+			},
+			HostConfigModifier: modifier,
+			WaitingFor:         wait.ForLog("start proxy success"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		c.releasePort(t.remotePort)
+		if client != nil {
+			err = errors.Join(err, client.Terminate(ctx))
+		}
+		return nil, fmt.Errorf("error starting the tunnel to %s: %w", net.JoinHostPort(localHost, strconv.Itoa(localPort)), err)
+	}
+	t.Container = client
+
+	return t, nil
+}
+
+// ip returns the IP address of the server in the first of its networks, by name, joined by the clients
+func (c *TunnelContainer) ip(ctx context.Context) (string, error) {
+	networks, err := c.Networks(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(networks) == 0 {
+		return "", errors.New("the server is not attached to any network")
+	}
+	sort.Strings(networks)
+
+	ips, err := c.Container.(*testcontainers.DockerContainer).ContainerIPsByNetwork(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ip := ips[networks[0]]
+	if ip == "" {
+		return "", fmt.Errorf("the server has no IP address in network %s", networks[0])
+	}
+
+	return ip, nil
+}
+
+// reservePort assigns the lowest free port of the server to the tunnel
+func (c *TunnelContainer) reservePort(t *Tunnel) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for port := firstRemotePort; port < firstRemotePort+c.maxTunnels; port++ {
+		if _, ok := c.tunnels[port]; !ok {
+			t.remotePort = port
+			c.tunnels[port] = t
+			return nil
+		}
+	}
+
+	return fmt.Errorf("all the %d tunnels are in use, see WithMaxTunnels", c.maxTunnels)
+}
+
+// releasePort frees the port of the server used by a tunnel
+func (c *TunnelContainer) releasePort(port int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.tunnels, port)
+}
+
+// Endpoint returns the host:port string, reachable from the host running the tests,
+// forwarded to the target of the tunnel.
+func (t *Tunnel) Endpoint(ctx context.Context) (string, error) {
+	return t.server.PortEndpoint(ctx, nat.Port(fmt.Sprintf("%d/tcp", t.remotePort)), "")
+}
+
+// URL returns the HTTP URL, reachable from the host running the tests, forwarded to the target
+// of the tunnel, e.g. http://localhost:32768.
+func (t *Tunnel) URL(ctx context.Context) (string, error) {
+	endpoint, err := t.Endpoint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + endpoint, nil
+}
+
+// InternalURL returns the HTTP URL, reachable from the containers sharing a network with the server,
+// forwarded to the target of the tunnel, e.g. http://172.18.0.2:10000.
+func (t *Tunnel) InternalURL(ctx context.Context) (string, error) {
+	ip, err := t.server.ip(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + net.JoinHostPort(ip, strconv.Itoa(t.remotePort)), nil
+}
+
+// Terminate closes the tunnel, terminating its frp client container, and frees its port of the server.
+func (t *Tunnel) Terminate(ctx context.Context) error {
+	defer t.server.releasePort(t.remotePort)
+
+	// the client container is not set while the tunnel is being opened
+	if t.Container == nil {
+		return nil
+	}
+
+	return t.Container.Terminate(ctx)
+}
+
+// randomToken returns a random hex token, used by the clients to authenticate to the server
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
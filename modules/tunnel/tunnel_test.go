@@ -0,0 +1,173 @@
+package tunnel_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/tunnel"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+func TestExpose(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	// the container is not exposed to the host, it's only reachable through the tunnel
+	app, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          "nginx:alpine",
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"app"}},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, app.Terminate(ctx))
+	})
+
+	server, err := tunnel.RunContainer(ctx, network.WithNetwork([]string{"tunnel"}, nw))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Terminate(ctx))
+	})
+
+	// exposeContainer {
+	tun, err := server.Expose(ctx, "app:80")
+	require.NoError(t, err)
+
+	u, err := tun.URL(ctx)
+	require.NoError(t, err)
+	// }
+
+	resp, err := http.Get(u)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	t.Run("internal-url", func(t *testing.T) {
+		internalURL, err := tun.InternalURL(ctx)
+		require.NoError(t, err)
+
+		// the tunnel is reachable from other containers, through the networks of the server
+		client, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:    "curlimages/curl:8.7.1",
+				Networks: []string{nw.Name},
+				Cmd:      []string{"sleep", "infinity"},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, client.Terminate(ctx))
+		})
+
+		code, _, err := client.Exec(ctx, []string{"curl", "-sf", internalURL})
+		require.NoError(t, err)
+		assert.Equal(t, 0, code)
+	})
+}
+
+func TestExposeHostPort(t *testing.T) {
+	ctx := context.Background()
+
+	// the server listens on all the interfaces, to be reachable from the tunnel
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+
+	received := make(chan string, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received <- string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	server, err := tunnel.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Terminate(ctx))
+	})
+
+	// exposeHostPort {
+	tun, err := server.ExposeHostPort(ctx, l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, err)
+
+	// the URL to configure as webhook in the third party under test
+	webhookURL, err := tun.InternalURL(ctx)
+	require.NoError(t, err)
+	// }
+
+	// a container calling the webhook, as a third party would do
+	caller, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "curlimages/curl:8.7.1",
+			Cmd:   []string{"curl", "-sf", "-d", "payment.succeeded", webhookURL},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, caller.Terminate(ctx))
+	})
+
+	assert.Equal(t, "payment.succeeded", <-received)
+}
+
+func TestMaxTunnels(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	server, err := tunnel.RunContainer(ctx, network.WithNetwork([]string{"tunnel"}, nw), tunnel.WithMaxTunnels(1))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Terminate(ctx))
+	})
+
+	tun, err := server.Expose(ctx, "tunnel:7000")
+	require.NoError(t, err)
+
+	_, err = server.Expose(ctx, "tunnel:7000")
+	require.ErrorContains(t, err, fmt.Sprintf("all the %d tunnels are in use", 1))
+
+	// the port of the tunnel is freed when it's terminated
+	require.NoError(t, tun.Terminate(ctx))
+
+	tun, err = server.Expose(ctx, "tunnel:7000")
+	require.NoError(t, err)
+	require.NoError(t, tun.Terminate(ctx))
+}
+
+func TestExpose_invalidTarget(t *testing.T) {
+	server := &tunnel.TunnelContainer{}
+
+	_, err := server.Expose(context.Background(), "app")
+	require.ErrorContains(t, err, "invalid target app")
+}
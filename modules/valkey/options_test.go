@@ -0,0 +1,177 @@
+package valkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWithConfigFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		cmds         []string
+		expectedCmds []string
+	}{
+		{
+			name:         "no existing command",
+			cmds:         []string{},
+			expectedCmds: []string{valkeyServerProcess, "/usr/local/valkey.conf"},
+		},
+		{
+			name:         "existing valkey-server command as first argument",
+			cmds:         []string{valkeyServerProcess, "a", "b", "c"},
+			expectedCmds: []string{valkeyServerProcess, "/usr/local/valkey.conf", "a", "b", "c"},
+		},
+		{
+			name:         "non existing valkey-server command",
+			cmds:         []string{"a", "b", "c"},
+			expectedCmds: []string{valkeyServerProcess, "/usr/local/valkey.conf", "a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					Cmd: tt.cmds,
+				},
+			}
+
+			WithConfigFile("valkey.conf")(req)
+
+			require.Equal(t, tt.expectedCmds, req.Cmd)
+		})
+	}
+}
+
+func TestWithLogLevel(t *testing.T) {
+	tests := []struct {
+		name         string
+		cmds         []string
+		expectedCmds []string
+	}{
+		{
+			name:         "no existing command",
+			cmds:         []string{},
+			expectedCmds: []string{valkeyServerProcess, "--loglevel", "debug"},
+		},
+		{
+			name:         "existing valkey-server command as first argument",
+			cmds:         []string{valkeyServerProcess, "a", "b", "c"},
+			expectedCmds: []string{valkeyServerProcess, "a", "b", "c", "--loglevel", "debug"},
+		},
+		{
+			name:         "non existing valkey-server command",
+			cmds:         []string{"a", "b", "c"},
+			expectedCmds: []string{valkeyServerProcess, "a", "b", "c", "--loglevel", "debug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					Cmd: tt.cmds,
+				},
+			}
+
+			WithLogLevel(LogLevelDebug)(req)
+
+			require.Equal(t, tt.expectedCmds, req.Cmd)
+		})
+	}
+}
+
+func TestWithSnapshotting(t *testing.T) {
+	tests := []struct {
+		name         string
+		cmds         []string
+		expectedCmds []string
+		seconds      int
+		changedKeys  int
+	}{
+		{
+			name:         "no existing command",
+			cmds:         []string{},
+			seconds:      60,
+			changedKeys:  100,
+			expectedCmds: []string{valkeyServerProcess, "--save", "60", "100"},
+		},
+		{
+			name:         "existing valkey-server command as first argument",
+			cmds:         []string{valkeyServerProcess, "a", "b", "c"},
+			seconds:      60,
+			changedKeys:  100,
+			expectedCmds: []string{valkeyServerProcess, "a", "b", "c", "--save", "60", "100"},
+		},
+		{
+			name:         "non existing valkey-server command",
+			cmds:         []string{"a", "b", "c"},
+			seconds:      60,
+			changedKeys:  100,
+			expectedCmds: []string{valkeyServerProcess, "a", "b", "c", "--save", "60", "100"},
+		},
+		{
+			name:         "existing valkey-server command as first argument",
+			cmds:         []string{valkeyServerProcess, "a", "b", "c"},
+			seconds:      0,
+			changedKeys:  0,
+			expectedCmds: []string{valkeyServerProcess, "a", "b", "c", "--save", "1", "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					Cmd: tt.cmds,
+				},
+			}
+
+			WithSnapshotting(tt.seconds, tt.changedKeys)(req)
+
+			require.Equal(t, tt.expectedCmds, req.Cmd)
+		})
+	}
+}
+
+func TestWithTLS(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Cmd: []string{valkeyServerProcess, "--loglevel", "debug"},
+		},
+	}
+
+	settings := options{}
+	WithTLS(TLSSettings{CACertFile: "ca.crt", CertFile: "server.crt", KeyFile: "server.key"})(&settings)
+	require.NotNil(t, settings.tls)
+
+	applyTLSSettings(settings.tls)(req)
+
+	require.Equal(t, []string{
+		valkeyServerProcess, "--loglevel", "debug",
+		"--port", "0",
+		"--tls-port", "6379",
+		"--tls-cert-file", "/tls/server.crt",
+		"--tls-key-file", "/tls/server.key",
+		"--tls-ca-cert-file", "/tls/ca.crt",
+		"--tls-auth-clients", "no",
+	}, req.Cmd)
+
+	require.Len(t, req.Files, 3)
+	require.Equal(t, "ca.crt", req.Files[0].HostFilePath)
+	require.Equal(t, "/tls/ca.crt", req.Files[0].ContainerFilePath)
+
+	require.IsType(t, &wait.ExecStrategy{}, req.WaitingFor)
+}
+
+func TestWaitForPing(t *testing.T) {
+	strategy := waitForPing()
+
+	require.True(t, strategy.OutputMatcher([]byte("PONG\n")))
+	require.True(t, strategy.OutputMatcher([]byte("NOAUTH Authentication required.\n")))
+	require.False(t, strategy.OutputMatcher([]byte("LOADING Valkey is loading the dataset in memory\n")))
+}
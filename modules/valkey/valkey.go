@@ -0,0 +1,242 @@
+package valkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// defaultImage is the default image used for the valkey container
+const defaultImage = "docker.io/valkey/valkey:7.2.5"
+
+// valkeyServerProcess is the name of the valkey server process
+const valkeyServerProcess = "valkey-server"
+
+// valkeyCliProcess is the name of the valkey client, used to wait for the server
+const valkeyCliProcess = "valkey-cli"
+
+const (
+	defaultPort = "6379/tcp"
+
+	// tlsDir is the directory of the certificates in the container
+	tlsDir = "/tls"
+)
+
+type LogLevel string
+
+const (
+	// LogLevelDebug is the debug log level
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelVerbose is the verbose log level
+	LogLevelVerbose LogLevel = "verbose"
+	// LogLevelNotice is the notice log level
+	LogLevelNotice LogLevel = "notice"
+	// LogLevelWarning is the warning log level
+	LogLevelWarning LogLevel = "warning"
+)
+
+type ValkeyContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// ConnectionString returns the connection string for the Valkey container, with the format redis://<host>:<port>,
+// or rediss://<host>:<port> if it runs with TLS, which the Redis clients understand too. In that case, use TLSConfig
+// to configure the client.
+func (c *ValkeyContainer) ConnectionString(ctx context.Context) (string, error) {
+	mappedPort, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	hostIP, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	schema := "redis"
+	if c.settings.tls != nil {
+		schema = "rediss"
+	}
+
+	uri := fmt.Sprintf("%s://%s:%s", schema, hostIP, mappedPort.Port())
+	return uri, nil
+}
+
+// TLSConfig returns the TLS config to connect to the Valkey container, trusting the CA certificate passed to WithTLS,
+// or nil if it does not run with TLS.
+func (c *ValkeyContainer) TLSConfig() (*tls.Config, error) {
+	if c.settings.tls == nil {
+		return nil, nil
+	}
+
+	caCert, err := os.ReadFile(c.settings.tls.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificate found in %s", c.settings.tls.CACertFile)
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// RunContainer creates an instance of the Valkey container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ValkeyContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor:   waitForPing(),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.tls != nil {
+		applyTLSSettings(settings.tls)(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValkeyContainer{Container: container, settings: settings}, nil
+}
+
+// waitForPing waits for the server to reply to the PING command, sent with valkey-cli from inside the container.
+// A NOAUTH error means the server is ready too, but requires a password, e.g. the one of a config file.
+func waitForPing(cliArgs ...string) *wait.ExecStrategy {
+	cmd := append([]string{valkeyCliProcess}, cliArgs...)
+	cmd = append(cmd, "ping")
+
+	return wait.ForExec(cmd).WithOutputMatcher(func(stdout []byte) bool {
+		return bytes.Contains(stdout, []byte("PONG")) || bytes.Contains(stdout, []byte("NOAUTH"))
+	})
+}
+
+// applyTLSSettings copies the certificates to the container, replacing the plain port with the TLS one,
+// and waits for the PING command over TLS.
+func applyTLSSettings(settings *TLSSettings) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.CACertFile,
+				ContainerFilePath: tlsDir + "/ca.crt",
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.CertFile,
+				ContainerFilePath: tlsDir + "/server.crt",
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				HostFilePath:      settings.KeyFile,
+				ContainerFilePath: tlsDir + "/server.key",
+				FileMode:          0o644,
+			},
+		)
+
+		processValkeyServerArgs(req, []string{
+			"--port", "0",
+			"--tls-port", "6379",
+			"--tls-cert-file", tlsDir + "/server.crt",
+			"--tls-key-file", tlsDir + "/server.key",
+			"--tls-ca-cert-file", tlsDir + "/ca.crt",
+			"--tls-auth-clients", "no",
+		})
+
+		req.WaitingFor = waitForPing("--tls", "--cacert", tlsDir+"/ca.crt")
+	}
+}
+
+// WithConfigFile sets the config file to be used for the valkey container, and sets the command to run the valkey server
+// using the passed config file
+func WithConfigFile(configFile string) testcontainers.CustomizeRequestOption {
+	const defaultConfigFile = "/usr/local/valkey.conf"
+
+	return func(req *testcontainers.GenericContainerRequest) {
+		cf := testcontainers.ContainerFile{
+			HostFilePath:      configFile,
+			ContainerFilePath: defaultConfigFile,
+			FileMode:          0o755,
+		}
+		req.Files = append(req.Files, cf)
+
+		if len(req.Cmd) == 0 {
+			req.Cmd = []string{valkeyServerProcess, defaultConfigFile}
+			return
+		}
+
+		// prepend the command to run the valkey server with the config file, which must be the first argument of the valkey server process
+		if req.Cmd[0] == valkeyServerProcess {
+			// just insert the config file, then the rest of the args
+			req.Cmd = append([]string{valkeyServerProcess, defaultConfigFile}, req.Cmd[1:]...)
+		} else if req.Cmd[0] != valkeyServerProcess {
+			// prepend the valkey server and the config file, then the rest of the args
+			req.Cmd = append([]string{valkeyServerProcess, defaultConfigFile}, req.Cmd...)
+		}
+	}
+}
+
+// WithLogLevel sets the log level for the valkey server process
+func WithLogLevel(level LogLevel) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		processValkeyServerArgs(req, []string{"--loglevel", string(level)})
+	}
+}
+
+// WithSnapshotting sets the snapshotting configuration for the valkey server process. You can configure Valkey to have it
+// save the dataset every N seconds if there are at least M changes in the dataset.
+// This method allows Valkey to benefit from copy-on-write semantics.
+// See https://valkey.io/topics/persistence/ for more information.
+func WithSnapshotting(seconds int, changedKeys int) testcontainers.CustomizeRequestOption {
+	if changedKeys < 1 {
+		changedKeys = 1
+	}
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return func(req *testcontainers.GenericContainerRequest) {
+		processValkeyServerArgs(req, []string{"--save", fmt.Sprintf("%d", seconds), fmt.Sprintf("%d", changedKeys)})
+	}
+}
+
+func processValkeyServerArgs(req *testcontainers.GenericContainerRequest, args []string) {
+	if len(req.Cmd) == 0 {
+		req.Cmd = append([]string{valkeyServerProcess}, args...)
+		return
+	}
+
+	// prepend the command to run the valkey server with the config file
+	if req.Cmd[0] == valkeyServerProcess {
+		// valkey server is already set as the first argument, so just append the config file
+		req.Cmd = append(req.Cmd, args...)
+	} else if req.Cmd[0] != valkeyServerProcess {
+		// valkey server is not set as the first argument, so prepend it alongside the config file
+		req.Cmd = append([]string{valkeyServerProcess}, req.Cmd...)
+		req.Cmd = append(req.Cmd, args...)
+	}
+}
@@ -0,0 +1,265 @@
+package valkey_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcvalkey "github.com/testcontainers/testcontainers-go/modules/valkey"
+)
+
+func TestIntegrationSetGet(t *testing.T) {
+	ctx := context.Background()
+
+	valkeyContainer, err := tcvalkey.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := valkeyContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assertSetsGets(t, ctx, valkeyContainer, 1)
+}
+
+func TestValkeyWithConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	valkeyContainer, err := tcvalkey.RunContainer(ctx, tcvalkey.WithConfigFile(filepath.Join("testdata", "valkey7.conf")))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := valkeyContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assertSetsGets(t, ctx, valkeyContainer, 1)
+}
+
+func TestValkeyWithLogLevel(t *testing.T) {
+	ctx := context.Background()
+
+	valkeyContainer, err := tcvalkey.RunContainer(ctx, tcvalkey.WithLogLevel(tcvalkey.LogLevelVerbose))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := valkeyContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assertSetsGets(t, ctx, valkeyContainer, 10)
+}
+
+func TestValkeyWithSnapshotting(t *testing.T) {
+	ctx := context.Background()
+
+	valkeyContainer, err := tcvalkey.RunContainer(ctx, tcvalkey.WithSnapshotting(10, 1))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := valkeyContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assertSetsGets(t, ctx, valkeyContainer, 10)
+}
+
+func TestValkeyWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	settings := generateCertificates(t)
+
+	// withTLS {
+	valkeyContainer, err := tcvalkey.RunContainer(ctx, tcvalkey.WithTLS(settings))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := valkeyContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := valkeyContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(uri, "rediss://"))
+
+	// tlsConfig {
+	tlsConfig, err := valkeyContainer.TLSConfig()
+	// }
+	require.NoError(t, err)
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	// the server name is the host of the connection string, set by ParseURL
+	tlsConfig.ServerName = options.TLSConfig.ServerName
+	options.TLSConfig = tlsConfig
+
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	pong, err := client.Ping(ctx).Result()
+	require.NoError(t, err)
+	require.Equal(t, "PONG", pong)
+}
+
+func TestValkeyWithPassword(t *testing.T) {
+	ctx := context.Background()
+
+	// the server is ready once it replies NOAUTH to the PING command
+	valkeyContainer, err := tcvalkey.RunContainer(ctx, testcontainers.CustomizeRequest(testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Cmd: []string{"valkey-server", "--requirepass", "secret"},
+		},
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := valkeyContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := valkeyContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+	options.Password = "secret"
+
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	require.NoError(t, client.Ping(ctx).Err())
+}
+
+func assertSetsGets(t *testing.T, ctx context.Context, valkeyContainer *tcvalkey.ValkeyContainer, keyCount int) {
+	// connectionString {
+	uri, err := valkeyContainer.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+
+	// You will likely want to wrap your Valkey package of choice in an
+	// interface to aid in unit testing and limit lock-in throughout your
+	// codebase but that's out of scope for this example
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	client := redis.NewClient(options)
+	defer func(t *testing.T, ctx context.Context, client *redis.Client) {
+		require.NoError(t, flushValkey(ctx, *client))
+	}(t, ctx, client)
+
+	t.Log("pinging valkey")
+	pong, err := client.Ping(ctx).Result()
+	require.NoError(t, err)
+
+	t.Log("received response from valkey")
+
+	if pong != "PONG" {
+		t.Fatalf("received unexpected response from valkey: %s", pong)
+	}
+
+	for i := 0; i < keyCount; i++ {
+		// Set data
+		key := fmt.Sprintf("{user.%s}.favoritefood.%d", uuid.NewString(), i)
+		value := fmt.Sprintf("Cabbage Biscuits %d", i)
+
+		ttl, _ := time.ParseDuration("2h")
+		err = client.Set(ctx, key, value, ttl).Err()
+		require.NoError(t, err)
+
+		// Get data
+		savedValue, err := client.Get(ctx, key).Result()
+		require.NoError(t, err)
+
+		if savedValue != value {
+			t.Fatalf("Expected value %s. Got %s.", savedValue, value)
+		}
+	}
+}
+
+func flushValkey(ctx context.Context, client redis.Client) error {
+	return client.FlushAll(ctx).Err()
+}
+
+// generateCertificates generates a CA and a server certificate signed by it, valid for localhost,
+// writing them to a temporary directory
+func generateCertificates(t *testing.T) tcvalkey.TLSSettings {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Valkey CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caCert, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverKeyDER, err := x509.MarshalPKCS8PrivateKey(serverKey)
+	require.NoError(t, err)
+
+	settings := tcvalkey.TLSSettings{
+		CACertFile: filepath.Join(dir, "ca.crt"),
+		CertFile:   filepath.Join(dir, "server.crt"),
+		KeyFile:    filepath.Join(dir, "server.key"),
+	}
+
+	writePEM(t, settings.CACertFile, "CERTIFICATE", caDER)
+	writePEM(t, settings.CertFile, "CERTIFICATE", serverDER)
+	writePEM(t, settings.KeyFile, "PRIVATE KEY", serverKeyDER)
+
+	return settings
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o644)
+	require.NoError(t, err)
+}
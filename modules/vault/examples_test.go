@@ -80,7 +80,7 @@ func ExampleRunContainer_withInitCommand() {
 	// runVaultContainerWithInitCommand {
 	ctx := context.Background()
 
-	vaultContainer, err := vault.RunContainer(ctx, vault.WithToken("MyToKeN"), vault.WithInitCommand(
+	vaultContainer, err := vault.RunContainer(ctx, vault.WithToken("MyToKeN"), vault.WithInitCommands(
 		"auth enable approle",                         // Enable the approle auth method
 		"secrets disable secret",                      // Disable the default secret engine
 		"secrets enable -version=1 -path=secret kv",   // Enable the kv secret engine at version 1
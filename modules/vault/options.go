@@ -0,0 +1,47 @@
+package vault
+
+import (
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	// configFile is the path of the Vault config file in the host. If set, Vault runs in server mode
+	configFile string
+
+	// initCommands are the Vault commands to run once the container is ready
+	initCommands []string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Vault container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithConfigFile runs Vault in server mode with the given config file, instead of in dev mode.
+// The config must define a storage backend and a TCP listener on port 8200 with TLS disabled.
+// Once the container is ready, Vault is initialised with a single unseal key and unsealed,
+// and the generated root token is available with the RootToken method.
+func WithConfigFile(configFile string) Option {
+	return func(o *options) {
+		o.configFile = configFile
+	}
+}
+
+// WithInitCommands adds a set of Vault commands, without the "vault" prefix, to run once the container
+// is ready, and unsealed when running in server mode, authenticated with the root token.
+// The container fails to start if any of the commands fails.
+func WithInitCommands(commands ...string) Option {
+	return func(o *options) {
+		for _, command := range commands {
+			o.initCommands = append(o.initCommands, "vault "+strings.TrimSpace(command))
+		}
+	}
+}
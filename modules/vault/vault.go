@@ -1,27 +1,44 @@
 package vault
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
 	defaultPort      = "8200"
 	defaultImageName = "hashicorp/vault:1.13.0"
+
+	// configFile is the path of the Vault config file in the container,
+	// inside the config dir read by the entrypoint of the image
+	configFile = "/vault/config/config.hcl"
 )
 
 // VaultContainer represents the vault container type used in the module
 type VaultContainer struct {
 	testcontainers.Container
+	rootToken string
+}
+
+// initResponse is the output of the "vault operator init" command, in JSON format
+type initResponse struct {
+	UnsealKeys []string `json:"unseal_keys_b64"`
+	RootToken  string   `json:"root_token"`
 }
 
-// RunContainer creates an instance of the vault container type
+// RunContainer creates an instance of the vault container type. By default, Vault runs in dev mode,
+// unsealed, with the root token set with WithToken, or a random one. Use WithConfigFile to run it in server mode instead.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*VaultContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImageName,
@@ -40,19 +57,62 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Started:          true,
 	}
 
+	settings := options{}
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		opt.Customize(&genericContainerReq)
 	}
 
+	if settings.configFile != "" {
+		applyConfigFile(settings.configFile)(&genericContainerReq)
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &VaultContainer{container}, nil
+	c := &VaultContainer{Container: container, rootToken: genericContainerReq.Env["VAULT_DEV_ROOT_TOKEN_ID"]}
+
+	if settings.configFile != "" {
+		if err := c.initAndUnseal(ctx); err != nil {
+			return c, err
+		}
+	} else if c.rootToken == "" {
+		if c.rootToken, err = c.devRootToken(ctx); err != nil {
+			return c, err
+		}
+	}
+
+	if len(settings.initCommands) > 0 {
+		if _, err := c.exec(ctx, []string{"/bin/sh", "-c", strings.Join(settings.initCommands, " && ")}); err != nil {
+			return c, fmt.Errorf("failed to run the init commands: %w", err)
+		}
+	}
+
+	return c, nil
 }
 
-// WithToken is a container option function that sets the root token for the Vault
+// applyConfigFile copies the config file to the container and runs Vault in server mode,
+// waiting for the server to be up, as it's neither initialised nor unsealed at startup
+func applyConfigFile(hostPath string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      hostPath,
+			ContainerFilePath: configFile,
+			FileMode:          0o644,
+		})
+
+		// the entrypoint of the image adds the config dir to the server command
+		req.Cmd = []string{"server"}
+
+		req.WaitingFor = wait.ForHTTP("/v1/sys/health?uninitcode=200&sealedcode=200").WithPort(defaultPort)
+	}
+}
+
+// WithToken is a container option function that sets the root token for the Vault running in dev mode
 func WithToken(token string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Env["VAULT_DEV_ROOT_TOKEN_ID"] = token
@@ -60,7 +120,11 @@ func WithToken(token string) testcontainers.CustomizeRequestOption {
 	}
 }
 
-// WithInitCommand is an option function that adds a set of initialization commands to the Vault's configuration
+// WithInitCommand is an option function that adds a set of initialization commands to the Vault's configuration,
+// running them as part of the wait strategy of the container.
+//
+// Deprecated: use WithInitCommands instead, which runs the commands once the container is ready,
+// authenticated with the root token, also in server mode, failing if any of them fails.
 func WithInitCommand(commands ...string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		commandsList := make([]string, 0, len(commands))
@@ -88,3 +152,72 @@ func (v *VaultContainer) HttpHostAddress(ctx context.Context) (string, error) {
 
 	return fmt.Sprintf("http://%s:%d", host, port.Int()), nil
 }
+
+// RootToken returns the root token of Vault: the one set with WithToken, or the random one, in dev mode,
+// or the one generated when initialising Vault in server mode.
+func (v *VaultContainer) RootToken() string {
+	return v.rootToken
+}
+
+// initAndUnseal initialises Vault with a single unseal key, and unseals it with that key
+func (v *VaultContainer) initAndUnseal(ctx context.Context) error {
+	output, err := v.exec(ctx, []string{"vault", "operator", "init", "-key-shares=1", "-key-threshold=1", "-format=json"})
+	if err != nil {
+		return fmt.Errorf("failed to initialise vault: %w", err)
+	}
+
+	var resp initResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return fmt.Errorf("failed to parse the init response: %w", err)
+	}
+
+	if len(resp.UnsealKeys) == 0 {
+		return fmt.Errorf("no unseal keys in the init response")
+	}
+
+	if _, err := v.exec(ctx, []string{"vault", "operator", "unseal", resp.UnsealKeys[0]}); err != nil {
+		return fmt.Errorf("failed to unseal vault: %w", err)
+	}
+
+	v.rootToken = resp.RootToken
+
+	return nil
+}
+
+// devRootToken returns the random root token of Vault running in dev mode, printed in the logs at startup
+func (v *VaultContainer) devRootToken(ctx context.Context) (string, error) {
+	logs, err := v.Logs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the logs: %w", err)
+	}
+	defer logs.Close()
+
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		if token, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "Root Token: "); ok {
+			return token, nil
+		}
+	}
+
+	return "", fmt.Errorf("root token not found in the logs")
+}
+
+// exec runs the command in the container, authenticated with the root token, returning its output.
+// It fails if the command exits with a non-zero code.
+func (v *VaultContainer) exec(ctx context.Context, cmd []string) ([]byte, error) {
+	code, reader, err := v.Exec(ctx, cmd, tcexec.Multiplexed(), tcexec.WithEnv([]string{"VAULT_TOKEN=" + v.rootToken}))
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != 0 {
+		return nil, fmt.Errorf("command %q exited with code %d: %s", strings.Join(cmd, " "), code, bytes.TrimSpace(output))
+	}
+
+	return output, nil
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -129,3 +130,116 @@ func TestVault(t *testing.T) {
 		}
 	})
 }
+
+func TestVault_withConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	// withConfigFile {
+	vaultContainer, err := testcontainervault.RunContainer(ctx,
+		testcontainervault.WithConfigFile(filepath.Join("testdata", "config.hcl")),
+		testcontainervault.WithInitCommands("secrets enable transit", "write -f transit/keys/my-key"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := vaultContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate vault: %s", err)
+		}
+	})
+
+	// rootToken {
+	rootToken := vaultContainer.RootToken()
+	// }
+	require.NotEmpty(t, rootToken)
+
+	hostAddress, err := vaultContainer.HttpHostAddress(ctx)
+	require.NoError(t, err)
+
+	response, err := http.Get(hostAddress + "/v1/sys/seal-status")
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.False(t, gjson.Get(string(body), "sealed").Bool())
+
+	// the key created by the init commands is available with the root token
+	request, err := http.NewRequest(http.MethodGet, hostAddress+"/v1/transit/keys/my-key", nil)
+	require.NoError(t, err)
+	request.Header.Add("X-Vault-Token", rootToken)
+
+	response, err = http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestVault_rootToken(t *testing.T) {
+	ctx := context.Background()
+
+	vaultContainer, err := testcontainervault.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := vaultContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate vault: %s", err)
+		}
+	})
+
+	rootToken := vaultContainer.RootToken()
+	require.NotEmpty(t, rootToken)
+
+	hostAddress, err := vaultContainer.HttpHostAddress(ctx)
+	require.NoError(t, err)
+
+	// the random root token of the dev mode is valid
+	request, err := http.NewRequest(http.MethodGet, hostAddress+"/v1/auth/token/lookup-self", nil)
+	require.NoError(t, err)
+	request.Header.Add("X-Vault-Token", rootToken)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestVault_initCommands(t *testing.T) {
+	ctx := context.Background()
+
+	// WithInitCommands {
+	vaultContainer, err := testcontainervault.RunContainer(ctx,
+		testcontainervault.WithInitCommands("secrets enable transit", "write -f transit/keys/my-key"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := vaultContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate vault: %s", err)
+		}
+	})
+
+	hostAddress, err := vaultContainer.HttpHostAddress(ctx)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodGet, hostAddress+"/v1/transit/keys/my-key", nil)
+	require.NoError(t, err)
+	request.Header.Add("X-Vault-Token", vaultContainer.RootToken())
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestVault_initCommandFails(t *testing.T) {
+	ctx := context.Background()
+
+	vaultContainer, err := testcontainervault.RunContainer(ctx, testcontainervault.WithInitCommands("secrets enable not-an-engine"))
+	if vaultContainer != nil {
+		t.Cleanup(func() {
+			if err := vaultContainer.Terminate(ctx); err != nil {
+				t.Fatalf("failed to terminate vault: %s", err)
+			}
+		})
+	}
+	require.ErrorContains(t, err, "failed to run the init commands")
+}
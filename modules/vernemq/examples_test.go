@@ -0,0 +1,41 @@
+package vernemq_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/vernemq"
+)
+
+func ExampleRunContainer() {
+	// runVerneMQContainer {
+	ctx := context.Background()
+
+	vernemqContainer, err := vernemq.RunContainer(ctx,
+		testcontainers.WithImage("vernemq/vernemq:1.13.0-alpine"),
+		vernemq.WithUser("user", "pass"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := vernemqContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := vernemqContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,183 @@
+package vernemq
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage     = "vernemq/vernemq:1.13.0-alpine"
+	defaultMQTTPort  = "1883/tcp"
+	defaultWSPort    = "8080/tcp"
+	defaultHTTPPort  = "8888/tcp"
+	envPrefix        = "DOCKER_VERNEMQ_"
+	envUserPrefix    = envPrefix + "USER_"
+	envDiscoveryNode = envPrefix + "DISCOVERY_NODE"
+	envNodeName      = envPrefix + "NODENAME"
+)
+
+// VerneMQContainer represents the VerneMQ container type used in the module
+type VerneMQContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the VerneMQ container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*VerneMQContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultMQTTPort, defaultWSPort, defaultHTTPPort},
+		Env: map[string]string{
+			envPrefix + "ACCEPT_EULA":     "yes",
+			envPrefix + "ALLOW_ANONYMOUS": "on",
+		},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(defaultMQTTPort),
+			wait.ForHTTP("/health").WithPort(defaultHTTPPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerneMQContainer{Container: container}, nil
+}
+
+// WithAllowAnonymous sets whether anonymous clients are allowed to connect to the broker.
+// Anonymous clients are allowed by default.
+func WithAllowAnonymous(allow bool) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		value := "off"
+		if allow {
+			value = "on"
+		}
+
+		req.Env[envPrefix+"ALLOW_ANONYMOUS"] = value
+	}
+}
+
+// WithUser adds a user to the broker, with the given password
+func WithUser(user string, password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[envUserPrefix+user] = password
+	}
+}
+
+// WithNodeName sets the host part of the name of the VerneMQ node, which becomes "VerneMQ@<name>".
+// It must be resolvable by the other nodes of the cluster, e.g. a network alias of the container.
+// By default, the node name is built from the IP address of the container.
+func WithNodeName(name string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[envNodeName] = name
+	}
+}
+
+// WithDiscoveryNode makes the node join the cluster of the given node, identified by the host part
+// of its node name: its IP address, or the name set with WithNodeName.
+func WithDiscoveryNode(node string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[envDiscoveryNode] = node
+	}
+}
+
+// WithConfig sets a configuration value of the broker, using the key as defined in the vernemq.conf file,
+// e.g. "listener.tcp.allowed_protocol_versions". The dots in the key are converted to double underscores,
+// following the conventions of the VerneMQ Docker image.
+func WithConfig(key string, value string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env[envPrefix+strings.ToUpper(strings.ReplaceAll(key, ".", "__"))] = value
+	}
+}
+
+// BrokerURL returns the URL for MQTT clients, with the format tcp://<host>:<port>
+func (c *VerneMQContainer) BrokerURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultMQTTPort, "tcp")
+}
+
+// WebSocketURL returns the URL for MQTT over WebSocket clients, with the format ws://<host>:<port>/mqtt
+func (c *VerneMQContainer) WebSocketURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, defaultWSPort, "ws")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/mqtt", nil
+}
+
+// HTTPURL returns the URL of the HTTP listener, which serves the health, status and metrics endpoints,
+// with the format http://<host>:<port>
+func (c *VerneMQContainer) HTTPURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// ClusterNodes returns the nodes of the cluster the container belongs to, as seen by the container,
+// and whether each one of them is running or not.
+func (c *VerneMQContainer) ClusterNodes(ctx context.Context) (map[string]bool, error) {
+	code, reader, err := c.Exec(ctx, []string{"vmq-admin", "cluster", "show"}, tcexec.Multiplexed())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != 0 {
+		return nil, fmt.Errorf("failed to show the cluster, exit code %d: %s", code, output)
+	}
+
+	return parseClusterNodes(string(output)), nil
+}
+
+// parseClusterNodes parses the table returned by "vmq-admin cluster show":
+//
+//	+------------------+-------+
+//	|       Node       |Running|
+//	+------------------+-------+
+//	|VerneMQ@172.17.0.2| true  |
+//	+------------------+-------+
+func parseClusterNodes(output string) map[string]bool {
+	nodes := map[string]bool{}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		columns := strings.Split(strings.Trim(line, "|"), "|")
+		if len(columns) != 2 {
+			continue
+		}
+
+		node := strings.TrimSpace(columns[0])
+		running := strings.TrimSpace(columns[1])
+		if node == "Node" {
+			// header
+			continue
+		}
+
+		nodes[node] = running == "true"
+	}
+
+	return nodes
+}
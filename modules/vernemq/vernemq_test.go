@@ -0,0 +1,131 @@
+package vernemq_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/vernemq"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+func TestVerneMQ(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := vernemq.RunContainer(ctx, vernemq.WithUser("user", "pass"))
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// brokerURL {
+	brokerURL, err := container.BrokerURL(ctx)
+	// }
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(brokerURL, "tcp://"))
+
+	wsURL, err := container.WebSocketURL(ctx)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(wsURL, "ws://"))
+	assert.True(t, strings.HasSuffix(wsURL, "/mqtt"))
+
+	httpURL, err := container.HTTPURL(ctx)
+	require.NoError(t, err)
+
+	resp, err := http.Get(httpURL + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "OK")
+
+	nodes, err := container.ClusterNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	for _, running := range nodes {
+		assert.True(t, running)
+	}
+}
+
+func TestVerneMQ_cluster(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	// clusterNodes {
+	node1, err := vernemq.RunContainer(ctx,
+		network.WithNetwork([]string{"vernemq1"}, nw),
+		vernemq.WithNodeName("vernemq1"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := node1.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// joinCluster {
+	node2, err := vernemq.RunContainer(ctx,
+		network.WithNetwork([]string{"vernemq2"}, nw),
+		vernemq.WithNodeName("vernemq2"),
+		vernemq.WithDiscoveryNode("vernemq1"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := node2.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	require.Eventually(t, func() bool {
+		nodes, err := node1.ClusterNodes(ctx)
+		if err != nil {
+			return false
+		}
+
+		return len(nodes) == 2 && nodes["VerneMQ@vernemq1"] && nodes["VerneMQ@vernemq2"]
+	}, 30*time.Second, time.Second)
+}
+
+func TestVerneMQ_withConfig(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := vernemq.RunContainer(ctx,
+		vernemq.WithAllowAnonymous(false),
+		vernemq.WithConfig("max_client_id_size", "42"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	_, reader, err := container.Exec(ctx, []string{"sh", "-c", "grep -E '^(allow_anonymous|max_client_id_size)' /vernemq/etc/vernemq.conf"})
+	require.NoError(t, err)
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "allow_anonymous = off")
+	assert.Contains(t, string(output), "max_client_id_size = 42")
+}
@@ -0,0 +1,40 @@
+package vllm_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/vllm"
+)
+
+func ExampleRunContainer() {
+	// runVLLMContainer {
+	ctx := context.Background()
+
+	vllmContainer, err := vllm.RunContainer(ctx,
+		testcontainers.WithImage("vllm/vllm-openai:v0.4.2"),
+		vllm.WithGPU(),
+		vllm.WithModel("facebook/opt-125m"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := vllmContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := vllmContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	// the example is not run, as it requires a GPU
+	fmt.Println(state.Running)
+}
@@ -0,0 +1,66 @@
+package vllm
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	model           string
+	servedModelName string
+	apiKey          string
+	args            []string
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the vLLM container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithModel sets the Hugging Face model served by vLLM, which is downloaded when the container starts.
+// Defaults to "facebook/opt-125m", a small model suited for tests.
+func WithModel(model string) Option {
+	return func(o *options) {
+		o.model = model
+	}
+}
+
+// WithServedModelName sets the name of the model for the clients of the API, e.g. "gpt-4o"
+// to test the routing of a gateway. Defaults to the name of the model.
+func WithServedModelName(name string) Option {
+	return func(o *options) {
+		o.servedModelName = name
+	}
+}
+
+// WithAPIKey sets the API key the clients must send. It's random by default.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithArgs adds arguments to the vLLM server, e.g. "--max-model-len", "512".
+func WithArgs(args ...string) Option {
+	return func(o *options) {
+		o.args = append(o.args, args...)
+	}
+}
+
+// WithHuggingFaceToken sets the Hugging Face token used to download gated models.
+func WithHuggingFaceToken(token string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["HUGGING_FACE_HUB_TOKEN"] = token
+	}
+}
+
+// WithGPU requests all the GPUs of the Docker host, which the default image of the module requires.
+// The Docker daemon needs a GPU runtime, e.g. the NVIDIA Container Toolkit.
+func WithGPU() testcontainers.CustomizeRequestOption {
+	return testcontainers.WithGPUs("all")
+}
@@ -0,0 +1,104 @@
+package vllm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/credentials"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "vllm/vllm-openai:v0.4.2"
+	defaultPort  = "8000/tcp"
+	defaultModel = "facebook/opt-125m"
+
+	// defaultAPIKey is the API key used when deterministic credentials are enabled
+	defaultAPIKey = "test"
+)
+
+// VLLMContainer represents the vLLM container type used in the module
+type VLLMContainer struct {
+	testcontainers.Container
+	apiKey string
+	model  string
+}
+
+// RunContainer creates an instance of the vLLM container type, serving an OpenAI-compatible API for the model
+// set with WithModel. It's ready once the model is downloaded and loaded, and listed by the /v1/models endpoint,
+// which can take several minutes. The default image requires a GPU, see WithGPU.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*VLLMContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env:          map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{model: defaultModel}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if settings.apiKey == "" {
+		apiKey, err := credentials.Token(defaultAPIKey)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the API key: %w", err)
+		}
+
+		settings.apiKey = apiKey
+	}
+
+	model := settings.model
+	cmd := []string{"--model", settings.model, "--api-key", settings.apiKey}
+	if settings.servedModelName != "" {
+		model = settings.servedModelName
+		cmd = append(cmd, "--served-model-name", settings.servedModelName)
+	}
+	genericContainerReq.Cmd = append(cmd, settings.args...)
+
+	if genericContainerReq.WaitingFor == nil {
+		// the model is downloaded and loaded before the server listens
+		genericContainerReq.WaitingFor = wait.ForHTTP("/v1/models").
+			WithPort(defaultPort).
+			WithHeaders(map[string]string{"Authorization": "Bearer " + settings.apiKey}).
+			WithStartupTimeout(10 * time.Minute)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VLLMContainer{Container: container, apiKey: settings.apiKey, model: model}, nil
+}
+
+// BaseURL returns the base URL of the OpenAI-compatible API, with the format http://<host>:<port>/v1,
+// which is the base URL expected by the OpenAI clients.
+func (c *VLLMContainer) BaseURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, defaultPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/v1", nil
+}
+
+// APIKey returns the API key the clients must send.
+func (c *VLLMContainer) APIKey() string {
+	return c.apiKey
+}
+
+// Model returns the name of the model for the clients of the API, which is the served model name, if set.
+func (c *VLLMContainer) Model() string {
+	return c.model
+}
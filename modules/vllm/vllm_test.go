@@ -0,0 +1,70 @@
+package vllm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/vllm"
+)
+
+func TestVLLM(t *testing.T) {
+	testcontainers.RequireCapability(t, testcontainers.CapabilityGPU)
+
+	ctx := context.Background()
+
+	// withModel {
+	container, err := vllm.RunContainer(ctx,
+		vllm.WithGPU(),
+		vllm.WithModel("facebook/opt-125m"),
+		vllm.WithServedModelName("gpt-4o"),
+		vllm.WithAPIKey("token-abc123"),
+		vllm.WithArgs("--max-model-len", "512"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// baseURL {
+	baseURL, err := container.BaseURL(ctx)
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, "token-abc123", container.APIKey())
+	assert.Equal(t, "gpt-4o", container.Model())
+
+	body := `{"model": "` + container.Model() + `", "prompt": "San Francisco is a", "max_tokens": 5}`
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/completions", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+container.APIKey())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var completion struct {
+		Choices []struct {
+			Text string `json:"text"`
+		} `json:"choices"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&completion))
+	require.Len(t, completion.Choices, 1)
+	assert.NotEmpty(t, completion.Choices[0].Text)
+
+	// the requests without the API key are rejected
+	resp, err = http.Get(baseURL + "/models")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,43 @@
+package zitadel_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/testcontainers/testcontainers-go/modules/zitadel"
+)
+
+func ExampleRun() {
+	// runZitadelStack {
+	ctx := context.Background()
+
+	stack, err := zitadel.Run(ctx)
+	if err != nil {
+		log.Fatalf("failed to start stack: %s", err)
+	}
+
+	// Clean up the stack
+	defer func() {
+		if err := stack.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate stack: %s", err)
+		}
+	}()
+	// }
+
+	// issuerURL {
+	issuer := stack.IssuerURL()
+	// }
+
+	resp, err := http.Get(issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		log.Fatalf("failed to get the discovery document: %s", err) // nolint:gocritic
+	}
+	defer resp.Body.Close()
+
+	fmt.Println(resp.StatusCode)
+
+	// Output:
+	// 200
+}
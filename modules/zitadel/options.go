@@ -0,0 +1,64 @@
+package zitadel
+
+const (
+	defaultOrganization  = "ZITADEL"
+	defaultAdminUsername = "zitadel-admin"
+	defaultAdminPassword = "Password1!"
+)
+
+// defaultImages are the images of the services of the stack
+var defaultImages = map[string]string{
+	"zitadel":  "ghcr.io/zitadel/zitadel:v2.48.3",
+	"postgres": "postgres:16-alpine",
+}
+
+type options struct {
+	images        map[string]string
+	organization  string
+	adminUsername string
+	adminPassword string
+}
+
+func defaultOptions() options {
+	images := make(map[string]string, len(defaultImages))
+	for service, image := range defaultImages {
+		images[service] = image
+	}
+
+	return options{
+		images:        images,
+		organization:  defaultOrganization,
+		adminUsername: defaultAdminUsername,
+		adminPassword: defaultAdminPassword,
+	}
+}
+
+// Option is an option for the ZITADEL stack.
+type Option func(*options)
+
+// WithImage sets the Docker image of a service of the stack: zitadel or postgres.
+// Unknown services are ignored.
+func WithImage(service string, image string) Option {
+	return func(o *options) {
+		if _, ok := o.images[service]; ok {
+			o.images[service] = image
+		}
+	}
+}
+
+// WithOrganization sets the name of the organization of the first instance. Defaults to ZITADEL.
+func WithOrganization(name string) Option {
+	return func(o *options) {
+		o.organization = name
+	}
+}
+
+// WithAdmin sets the credentials of the admin user of the organization. The password must
+// satisfy the default password policy of ZITADEL: at least 8 characters, with upper and lower case
+// letters, a number and a symbol.
+func WithAdmin(username string, password string) Option {
+	return func(o *options) {
+		o.adminUsername = username
+		o.adminPassword = password
+	}
+}
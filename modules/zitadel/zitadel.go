@@ -0,0 +1,312 @@
+package zitadel
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/modules/compose"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	apiPort    = "8080/tcp"
+	apiService = "zitadel"
+
+	// patPath is the path of the personal access token of the machine user of the first instance
+	patPath = "/machinekey/pat.txt"
+
+	// masterKey encrypts the secrets stored by ZITADEL, and must be 32 characters long
+	masterKey = "MasterkeyNeedsToHave32Characters"
+)
+
+//go:embed stack
+var stackFS embed.FS
+
+// ZitadelStack represents the ZITADEL stack used in the module: a ZITADEL instance,
+// backed by Postgres, with an organization, its admin user and a machine user.
+type ZitadelStack struct {
+	compose.ComposeStack
+	dir           string
+	port          int
+	organization  string
+	adminUsername string
+	adminPassword string
+	pat           string
+}
+
+// MachineClient represents the credentials of a machine user, to request access tokens
+// with the client credentials grant.
+type MachineClient struct {
+	UserID       string
+	ClientID     string
+	ClientSecret string
+}
+
+// Run starts a ZITADEL stack, using Docker Compose, and waits for it to be ready.
+// The first instance is bootstrapped with an organization, its admin user, and a machine
+// user owning the instance, whose personal access token is used to call the management API.
+// The stack is removed if it fails to start.
+func Run(ctx context.Context, opts ...Option) (*ZitadelStack, error) {
+	settings := defaultOptions()
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	// ZITADEL checks the host of the requests against its external domain and port,
+	// so the port is chosen before starting the stack
+	port, err := freePort()
+	if err != nil {
+		return nil, fmt.Errorf("error getting a free port: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "testcontainers-zitadel-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating the stack directory: %w", err)
+	}
+
+	s := &ZitadelStack{
+		dir:           dir,
+		port:          port,
+		organization:  settings.organization,
+		adminUsername: settings.adminUsername,
+		adminPassword: settings.adminPassword,
+	}
+
+	err = writeStack(dir, stackData{
+		Images:        settings.images,
+		Port:          port,
+		MasterKey:     masterKey,
+		Organization:  settings.organization,
+		AdminUsername: settings.adminUsername,
+		AdminPassword: settings.adminPassword,
+		PATPath:       patPath,
+	})
+	if err != nil {
+		return nil, errors.Join(err, os.RemoveAll(dir))
+	}
+
+	stack, err := compose.NewDockerComposeWith(compose.WithStackFiles(filepath.Join(dir, "docker-compose.yml")))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error creating the stack: %w", err), os.RemoveAll(dir))
+	}
+	s.ComposeStack = stack
+
+	// the instance is set up, and the personal access token written, before serving the requests
+	stack.WaitForService(apiService, wait.ForHTTP("/debug/ready").WithPort(apiPort).WithStartupTimeout(2*time.Minute))
+
+	if err := stack.Up(ctx, compose.Wait(true)); err != nil {
+		return nil, errors.Join(fmt.Errorf("error starting the stack: %w", err), s.Terminate(ctx))
+	}
+
+	pat, err := s.readPAT(ctx)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error reading the personal access token: %w", err), s.Terminate(ctx))
+	}
+	s.pat = pat
+
+	return s, nil
+}
+
+// IssuerURL returns the URL of the OpenID Connect issuer of the instance, e.g. http://localhost:32768.
+// The discovery document is served at /.well-known/openid-configuration.
+func (s *ZitadelStack) IssuerURL() string {
+	return "http://localhost:" + strconv.Itoa(s.port)
+}
+
+// AdminLoginName returns the login name of the admin user of the organization,
+// e.g. zitadel-admin@zitadel.localhost, used to sign in the console at /ui/console.
+func (s *ZitadelStack) AdminLoginName() string {
+	orgDomain := strings.ReplaceAll(strings.ToLower(s.organization), " ", "-")
+	return s.adminUsername + "@" + orgDomain + ".localhost"
+}
+
+// AdminPassword returns the password of the admin user of the organization.
+func (s *ZitadelStack) AdminPassword() string {
+	return s.adminPassword
+}
+
+// PersonalAccessToken returns the personal access token of the machine user owning the instance,
+// to be sent as a bearer token to the APIs of ZITADEL.
+func (s *ZitadelStack) PersonalAccessToken() string {
+	return s.pat
+}
+
+// CreateMachineClient creates a machine user in the organization, with a client secret,
+// to request access tokens from the token endpoint with the client credentials grant.
+func (s *ZitadelStack) CreateMachineClient(ctx context.Context, name string) (*MachineClient, error) {
+	var user struct {
+		UserID string `json:"userId"`
+	}
+	err := s.call(ctx, http.MethodPost, "/management/v1/users/machine", map[string]any{
+		"userName":        name,
+		"name":            name,
+		"accessTokenType": "ACCESS_TOKEN_TYPE_JWT",
+	}, &user)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the machine user: %w", err)
+	}
+
+	var secret struct {
+		ClientID     string `json:"clientId"`
+		ClientSecret string `json:"clientSecret"`
+	}
+	err = s.call(ctx, http.MethodPut, "/management/v1/users/"+user.UserID+"/secret", map[string]any{}, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the client secret: %w", err)
+	}
+
+	return &MachineClient{
+		UserID:       user.UserID,
+		ClientID:     secret.ClientID,
+		ClientSecret: secret.ClientSecret,
+	}, nil
+}
+
+// Terminate removes the containers, networks and volumes of the stack, and its temporary files.
+func (s *ZitadelStack) Terminate(ctx context.Context) error {
+	var err error
+	if s.ComposeStack != nil {
+		err = s.Down(ctx, compose.RemoveOrphans(true), compose.RemoveVolumes(true))
+	}
+
+	return errors.Join(err, os.RemoveAll(s.dir))
+}
+
+// readPAT reads the personal access token of the machine user from the ZITADEL container
+func (s *ZitadelStack) readPAT(ctx context.Context) (string, error) {
+	c, err := s.ServiceContainer(ctx, apiService)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := c.CopyFileFromContainer(ctx, patPath)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	pat, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(pat)), nil
+}
+
+// call sends the body as JSON to the path of the API, authenticated with the personal access token,
+// decoding the response into the result, if not nil.
+func (s *ZitadelStack) call(ctx context.Context, method string, path string, body any, result any) error {
+	c, err := s.ServiceContainer(ctx, apiService)
+	if err != nil {
+		return err
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	url := "http://" + net.JoinHostPort(host, strconv.Itoa(s.port)) + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	// the requests must target the external domain, even with a remote Docker host
+	req.Host = "localhost:" + strconv.Itoa(s.port)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.pat)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// freePort returns a TCP port that is free on the host
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// stackData is the data used to render the templates of the stack
+type stackData struct {
+	Images        map[string]string
+	Port          int
+	MasterKey     string
+	Organization  string
+	AdminUsername string
+	AdminPassword string
+	PATPath       string
+}
+
+// writeStack writes the files of the stack to the directory, rendering the templates
+func writeStack(dir string, data stackData) error {
+	entries, err := stackFS.ReadDir("stack")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		content, err := stackFS.ReadFile("stack/" + entry.Name())
+		if err != nil {
+			return err
+		}
+
+		name := entry.Name()
+		if strings.HasSuffix(name, ".tmpl") {
+			t, err := template.New(name).Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
+
+			var sb strings.Builder
+			if err := t.Execute(&sb, data); err != nil {
+				return fmt.Errorf("error rendering %s: %w", name, err)
+			}
+
+			name = strings.TrimSuffix(name, ".tmpl")
+			content = []byte(sb.String())
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
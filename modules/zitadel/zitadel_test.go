@@ -0,0 +1,80 @@
+package zitadel_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/zitadel"
+)
+
+func TestZitadel(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithOrganization {
+	stack, err := zitadel.Run(ctx,
+		zitadel.WithOrganization("Acme"),
+		zitadel.WithAdmin("admin", "Adm1n-Passw0rd!"),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := stack.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate stack: %s", err)
+		}
+	})
+
+	assert.Equal(t, "admin@acme.localhost", stack.AdminLoginName())
+	assert.Equal(t, "Adm1n-Passw0rd!", stack.AdminPassword())
+	require.NotEmpty(t, stack.PersonalAccessToken())
+
+	t.Run("discovery", func(t *testing.T) {
+		resp, err := http.Get(stack.IssuerURL() + "/.well-known/openid-configuration")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var discovery struct {
+			Issuer        string `json:"issuer"`
+			TokenEndpoint string `json:"token_endpoint"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&discovery))
+		assert.Equal(t, stack.IssuerURL(), discovery.Issuer)
+		assert.Equal(t, stack.IssuerURL()+"/oauth/v2/token", discovery.TokenEndpoint)
+	})
+
+	t.Run("machine-client", func(t *testing.T) {
+		// createMachineClient {
+		client, err := stack.CreateMachineClient(ctx, "orders-service")
+		// }
+		require.NoError(t, err)
+		require.NotEmpty(t, client.ClientID)
+		require.NotEmpty(t, client.ClientSecret)
+
+		form := url.Values{
+			"grant_type": {"client_credentials"},
+			"scope":      {"openid"},
+		}
+		req, err := http.NewRequest(http.MethodPost, stack.IssuerURL()+"/oauth/v2/token", strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(client.ClientID, client.ClientSecret)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var token struct {
+			AccessToken string `json:"access_token"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+		require.NotEmpty(t, token.AccessToken)
+	})
+}
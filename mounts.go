@@ -1,6 +1,11 @@
 package testcontainers
 
-import "errors"
+import (
+	"errors"
+	"os"
+
+	"github.com/docker/docker/api/types/mount"
+)
 
 const (
 	MountTypeBind MountType = iota // Deprecated: Use MountTypeVolume instead
@@ -18,6 +23,10 @@ var (
 	_ ContainerMountSource = (*GenericBindMountSource)(nil) // Deprecated: use Files or HostConfigModifier in the ContainerRequest, or copy files container APIs to make containers portable across Docker environments
 	_ ContainerMountSource = (*GenericVolumeMountSource)(nil)
 	_ ContainerMountSource = (*GenericTmpfsMountSource)(nil)
+
+	_ BindMounter   = (*GenericBindMountSource)(nil)
+	_ VolumeMounter = (*GenericVolumeMountSource)(nil)
+	_ TmpfsMounter  = (*GenericTmpfsMountSource)(nil)
 )
 
 type (
@@ -44,6 +53,11 @@ type GenericBindMountSource struct {
 	// HostPath is the path mounted into the container
 	// the same host path might be mounted to multiple locations within a single container
 	HostPath string
+
+	// Propagation is the propagation mode of the bind mount, e.g. mount.PropagationRShared, so that the mounts
+	// created by the container are visible on the host, as needed to run nested containers.
+	// It's the default of the engine, rprivate, if empty.
+	Propagation mount.Propagation
 }
 
 // Deprecated: use Files or HostConfigModifier in the ContainerRequest, or copy files container APIs to make containers portable across Docker environments
@@ -56,11 +70,30 @@ func (GenericBindMountSource) Type() MountType {
 	return MountTypeBind
 }
 
+// Deprecated: use Files or HostConfigModifier in the ContainerRequest, or copy files container APIs to make containers portable across Docker environments
+// GetBindOptions implements BindMounter, returning nil if the propagation mode is not set
+func (s GenericBindMountSource) GetBindOptions() *mount.BindOptions {
+	if s.Propagation == "" {
+		return nil
+	}
+
+	return &mount.BindOptions{Propagation: s.Propagation}
+}
+
 // GenericVolumeMountSource implements ContainerMountSource and represents a volume mount
 type GenericVolumeMountSource struct {
 	// Name refers to the name of the volume to be mounted
 	// the same volume might be mounted to multiple locations within a single container
 	Name string
+
+	// Driver is the driver creating the volume, if it does not exist yet. It's the default of the engine,
+	// local, if empty.
+	Driver string
+
+	// DriverOpts are the options of the driver creating the volume, if it does not exist yet, e.g. the type,
+	// the device and the options of an NFS share for the local driver: {"type": "nfs", "device": ":/export",
+	// "o": "addr=10.0.0.1,rw"}.
+	DriverOpts map[string]string
 }
 
 func (s GenericVolumeMountSource) Source() string {
@@ -71,9 +104,30 @@ func (GenericVolumeMountSource) Type() MountType {
 	return MountTypeVolume
 }
 
+// GetVolumeOptions implements VolumeMounter, returning nil if the driver is not set
+func (s GenericVolumeMountSource) GetVolumeOptions() *mount.VolumeOptions {
+	if s.Driver == "" && len(s.DriverOpts) == 0 {
+		return nil
+	}
+
+	return &mount.VolumeOptions{
+		DriverConfig: &mount.Driver{
+			Name:    s.Driver,
+			Options: s.DriverOpts,
+		},
+	}
+}
+
 // GenericTmpfsMountSource implements ContainerMountSource and represents a TmpFS mount
 // Optionally mount.TmpfsOptions might be added for advanced scenarios
-type GenericTmpfsMountSource struct{}
+type GenericTmpfsMountSource struct {
+	// Size is the size of the tmpfs mount, in bytes. It's unlimited if zero.
+	Size int64
+
+	// FileMode is the mode of the root directory of the tmpfs mount, e.g. 0o1777. It's the default
+	// of the engine if zero.
+	FileMode os.FileMode
+}
 
 func (s GenericTmpfsMountSource) Source() string {
 	return ""
@@ -83,6 +137,18 @@ func (GenericTmpfsMountSource) Type() MountType {
 	return MountTypeTmpfs
 }
 
+// GetTmpfsOptions implements TmpfsMounter, returning nil if neither the size nor the mode are set
+func (s GenericTmpfsMountSource) GetTmpfsOptions() *mount.TmpfsOptions {
+	if s.Size == 0 && s.FileMode == 0 {
+		return nil
+	}
+
+	return &mount.TmpfsOptions{
+		SizeBytes: s.Size,
+		Mode:      s.FileMode,
+	}
+}
+
 // ContainerMountTarget represents the target path within a container where the mount will be available
 // Note that mount targets must be unique. It's not supported to mount different sources to the same target.
 type ContainerMountTarget string
@@ -110,6 +176,15 @@ func VolumeMount(volumeName string, mountTarget ContainerMountTarget) ContainerM
 	}
 }
 
+// TmpfsMount returns a new ContainerMount with a GenericTmpfsMountSource of the given size, in bytes, as source.
+// This is a convenience method to cover typical use cases.
+func TmpfsMount(size int64, mountTarget ContainerMountTarget) ContainerMount {
+	return ContainerMount{
+		Source: GenericTmpfsMountSource{Size: size},
+		Target: mountTarget,
+	}
+}
+
 // Mounts returns a ContainerMounts to support a more fluent API
 func Mounts(mounts ...ContainerMount) ContainerMounts {
 	return mounts
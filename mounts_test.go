@@ -2,6 +2,8 @@ package testcontainers_test
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/api/types/mount"
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
 
 func TestVolumeMount(t *testing.T) {
@@ -160,6 +163,118 @@ func TestContainerMounts_PrepareMounts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Single tmpfs mount - with size and mode",
+			mounts: testcontainers.ContainerMounts{
+				{
+					Source:   testcontainers.GenericTmpfsMountSource{Size: 64 * 1024 * 1024, FileMode: 0o1777},
+					Target:   "/data",
+					ReadOnly: true,
+				},
+			},
+			want: []mount.Mount{
+				{
+					Type:     mount.TypeTmpfs,
+					Target:   "/data",
+					ReadOnly: true,
+					TmpfsOptions: &mount.TmpfsOptions{
+						SizeBytes: 64 * 1024 * 1024,
+						Mode:      0o1777,
+					},
+				},
+			},
+		},
+		{
+			name:   "Single tmpfs mount - with size helper",
+			mounts: testcontainers.Mounts(testcontainers.TmpfsMount(1024, "/data")),
+			want: []mount.Mount{
+				{
+					Type:         mount.TypeTmpfs,
+					Target:       "/data",
+					TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 1024},
+				},
+			},
+		},
+		{
+			name: "Single volume mount - with driver options",
+			mounts: testcontainers.ContainerMounts{
+				{
+					Source: testcontainers.GenericVolumeMountSource{
+						Name:       "nfs-data",
+						Driver:     "local",
+						DriverOpts: map[string]string{"type": "nfs", "device": ":/export", "o": "addr=10.0.0.1,rw"},
+					},
+					Target: "/data",
+				},
+			},
+			want: []mount.Mount{
+				{
+					Type:   mount.TypeVolume,
+					Source: "nfs-data",
+					Target: "/data",
+					VolumeOptions: &mount.VolumeOptions{
+						Labels: testcontainers.GenericLabels(),
+						DriverConfig: &mount.Driver{
+							Name:    "local",
+							Options: map[string]string{"type": "nfs", "device": ":/export", "o": "addr=10.0.0.1,rw"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Single bind mount - with propagation",
+			mounts: testcontainers.ContainerMounts{
+				{
+					Source: testcontainers.GenericBindMountSource{HostPath: "/var/lib/docker", Propagation: mount.PropagationRShared},
+					Target: "/var/lib/docker",
+				},
+			},
+			want: []mount.Mount{
+				{
+					Type:        mount.TypeBind,
+					Source:      "/var/lib/docker",
+					Target:      "/var/lib/docker",
+					BindOptions: &mount.BindOptions{Propagation: mount.PropagationRShared},
+				},
+			},
+		},
+		{
+			name: "Single bind mount - with options",
+			mounts: testcontainers.ContainerMounts{
+				{
+					Source: testcontainers.DockerBindMountSource{
+						HostPath:    "/mnt",
+						BindOptions: &mount.BindOptions{Propagation: mount.PropagationRSlave},
+					},
+					Target: "/mnt",
+				},
+			},
+			want: []mount.Mount{
+				{
+					Type:        mount.TypeBind,
+					Source:      "/mnt",
+					Target:      "/mnt",
+					BindOptions: &mount.BindOptions{Propagation: mount.PropagationRSlave},
+				},
+			},
+		},
+		{
+			name: "Single bind mount - without options",
+			mounts: testcontainers.ContainerMounts{
+				{
+					Source: testcontainers.GenericBindMountSource{HostPath: "/mnt"},
+					Target: "/mnt",
+				},
+			},
+			want: []mount.Mount{
+				{
+					Type:   mount.TypeBind,
+					Source: "/mnt",
+					Target: "/mnt",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -233,3 +348,111 @@ func TestMountsReceiveRyukLabels(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, testcontainers.GenericLabels(), volume.Labels)
 }
+
+func TestDockerContainer_Mounts(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "alpine",
+			Mounts: testcontainers.Mounts(
+				testcontainers.VolumeMount("mounts-volume", "/data"),
+				testcontainers.ContainerMount{
+					Source:   testcontainers.GenericVolumeMountSource{Name: "mounts-volume-ro"},
+					Target:   "/config",
+					ReadOnly: true,
+				},
+			),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	// containerMounts {
+	mounts, err := c.(*testcontainers.DockerContainer).Mounts(ctx)
+	// }
+	require.NoError(t, err)
+	require.Len(t, mounts, 2)
+
+	byDestination := map[string]testcontainers.MountPoint{}
+	for _, m := range mounts {
+		byDestination[m.Destination] = m
+	}
+
+	data := byDestination["/data"]
+	assert.Equal(t, mount.TypeVolume, data.Type)
+	assert.Equal(t, "mounts-volume", data.Name)
+	assert.Equal(t, "local", data.Driver)
+	assert.True(t, data.RW)
+
+	config := byDestination["/config"]
+	assert.Equal(t, mount.TypeVolume, config.Type)
+	assert.Equal(t, "mounts-volume-ro", config.Name)
+	assert.False(t, config.RW)
+}
+
+func TestDockerContainer_MountOptions(t *testing.T) {
+	ctx := context.Background()
+
+	// mountOptions {
+	mounts := testcontainers.Mounts(
+		testcontainers.ContainerMount{
+			Source:   testcontainers.GenericTmpfsMountSource{Size: 64 * 1024 * 1024, FileMode: 0o1777},
+			Target:   "/scratch",
+			ReadOnly: true,
+		},
+		testcontainers.ContainerMount{
+			// a volume of the local driver, backed by a tmpfs filesystem. Use "nfs" to mount an NFS share
+			Source: testcontainers.GenericVolumeMountSource{
+				Name:       "mounts-volume-tmpfs",
+				Driver:     "local",
+				DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs", "o": "size=1m"},
+			},
+			Target: "/data",
+		},
+	)
+	// }
+
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:  "alpine",
+			Cmd:    []string{"sleep", "300"},
+			Mounts: mounts,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	t.Run("tmpfs", func(t *testing.T) {
+		// the size of the filesystem in 1K blocks
+		code, r, err := c.Exec(ctx, []string{"sh", "-c", "df -k /scratch | tail -n 1 | awk '{print $2}'"}, tcexec.Multiplexed())
+		require.NoError(t, err)
+		require.Zero(t, code)
+
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "65536", strings.TrimSpace(string(out)))
+
+		// the mount is read-only
+		code, _, err = c.Exec(ctx, []string{"touch", "/scratch/file"})
+		require.NoError(t, err)
+		assert.NotZero(t, code)
+	})
+
+	t.Run("volume", func(t *testing.T) {
+		mountPoints, err := c.(*testcontainers.DockerContainer).Mounts(ctx)
+		require.NoError(t, err)
+
+		var found bool
+		for _, m := range mountPoints {
+			if m.Destination == "/data" {
+				found = true
+				assert.Equal(t, "mounts-volume-tmpfs", m.Name)
+				assert.Equal(t, "local", m.Driver)
+			}
+		}
+		require.True(t, found)
+	})
+}
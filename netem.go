@@ -0,0 +1,166 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// netemImage is the image of the sidecar container applying the network conditions,
+// which must provide the tc command
+const netemImage = "nicolaka/netshoot:v0.12"
+
+// NetworkConditions defines the degraded network conditions applied to the traffic sent by a container,
+// using the netem queueing discipline of the Linux traffic control. As only the outgoing traffic is shaped,
+// the latency is added once per round trip between the tests and the container.
+type NetworkConditions struct {
+	// Latency is the delay added to the outgoing packets
+	Latency time.Duration
+	// Jitter is the random variation of the latency
+	Jitter time.Duration
+	// PacketLoss is the percentage of outgoing packets dropped, from 0 to 100
+	PacketLoss float64
+	// Rate limits the bandwidth of the outgoing traffic, using the tc units, e.g. "1mbit" or "512kbit"
+	Rate string
+	// Interface is the network interface of the container to shape. Defaults to eth0
+	Interface string
+}
+
+var (
+	// NetworkProfile3G simulates a mobile 3G network
+	NetworkProfile3G = NetworkConditions{Latency: 100 * time.Millisecond, Jitter: 30 * time.Millisecond, PacketLoss: 1, Rate: "750kbit"}
+
+	// NetworkProfileLossy simulates an unreliable network dropping packets
+	NetworkProfileLossy = NetworkConditions{Latency: 20 * time.Millisecond, PacketLoss: 10}
+
+	// NetworkProfileSatellite simulates a high latency satellite link
+	NetworkProfileSatellite = NetworkConditions{Latency: 600 * time.Millisecond, Jitter: 50 * time.Millisecond, Rate: "1mbit"}
+)
+
+// WithNetworkConditions applies the given network conditions to the container once it's ready,
+// so its startup is not slowed down by them. Use SetNetworkConditions and ResetNetworkConditions
+// to change them while the container is running, e.g. in the subtests of a test.
+func WithNetworkConditions(conditions NetworkConditions) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostReadies: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					return SetNetworkConditions(ctx, c, conditions)
+				},
+			},
+		})
+	}
+}
+
+// SetNetworkConditions applies the given network conditions to the traffic sent by the container,
+// replacing the ones applied before. It runs tc in a short-lived sidecar container sharing the network
+// namespace of the container, so the container image doesn't need to provide it.
+func SetNetworkConditions(ctx context.Context, c Container, conditions NetworkConditions) error {
+	args, err := conditions.netemArgs()
+	if err != nil {
+		return err
+	}
+
+	cmd := append([]string{"tc", "qdisc", "replace", "dev", conditions.iface(), "root", "netem"}, args...)
+
+	return runNetemSidecar(ctx, c, cmd)
+}
+
+// ResetNetworkConditions removes the given network conditions applied to the container,
+// from the network interface defined by them.
+func ResetNetworkConditions(ctx context.Context, c Container, conditions NetworkConditions) error {
+	// deleting the root qdisc fails if there are no conditions applied, so the error is ignored
+	cmd := []string{"sh", "-c", "tc qdisc del dev " + conditions.iface() + " root 2>/dev/null || true"}
+
+	return runNetemSidecar(ctx, c, cmd)
+}
+
+// netemArgs returns the arguments of the netem queueing discipline for the network conditions
+func (nc NetworkConditions) netemArgs() ([]string, error) {
+	if nc.Latency < 0 || nc.Jitter < 0 {
+		return nil, fmt.Errorf("invalid network conditions: the latency and the jitter cannot be negative")
+	}
+
+	if nc.PacketLoss < 0 || nc.PacketLoss > 100 {
+		return nil, fmt.Errorf("invalid network conditions: the packet loss must be between 0 and 100, got %v", nc.PacketLoss)
+	}
+
+	if nc.Jitter > 0 && nc.Latency == 0 {
+		return nil, fmt.Errorf("invalid network conditions: the jitter requires a latency")
+	}
+
+	args := []string{}
+	if nc.Latency > 0 {
+		args = append(args, "delay", strconv.FormatInt(nc.Latency.Microseconds(), 10)+"us")
+		if nc.Jitter > 0 {
+			args = append(args, strconv.FormatInt(nc.Jitter.Microseconds(), 10)+"us")
+		}
+	}
+
+	if nc.PacketLoss > 0 {
+		args = append(args, "loss", strconv.FormatFloat(nc.PacketLoss, 'f', -1, 64)+"%")
+	}
+
+	if nc.Rate != "" {
+		args = append(args, "rate", nc.Rate)
+	}
+
+	return args, nil
+}
+
+// iface returns the network interface to shape
+func (nc NetworkConditions) iface() string {
+	if nc.Interface == "" {
+		return "eth0"
+	}
+
+	return nc.Interface
+}
+
+// runNetemSidecar runs the command in a sidecar container sharing the network namespace of the given container,
+// with the capability to administer it, waiting for the command to exit.
+func runNetemSidecar(ctx context.Context, c Container, cmd []string) error {
+	sidecar, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: netemImage,
+			Cmd:   cmd,
+			HostConfigModifier: func(hostConfig *container.HostConfig) {
+				hostConfig.NetworkMode = container.NetworkMode("container:" + c.GetContainerID())
+				hostConfig.CapAdd = []string{"NET_ADMIN"}
+			},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error running the network conditions sidecar: %w", err)
+	}
+	defer func() {
+		_ = sidecar.Terminate(ctx)
+	}()
+
+	state, err := sidecar.State(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting the state of the network conditions sidecar: %w", err)
+	}
+
+	if state.ExitCode != 0 {
+		output := ""
+		if logs, err := sidecar.Logs(ctx); err == nil {
+			b, _ := io.ReadAll(logs)
+			logs.Close()
+			output = strings.TrimSpace(string(b))
+		}
+
+		return fmt.Errorf("error applying the network conditions, %q exited with code %d: %s", strings.Join(cmd, " "), state.ExitCode, output)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package testcontainers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNetworkConditions_netemArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions NetworkConditions
+		expected   []string
+		err        string
+	}{
+		{
+			name:       "empty",
+			conditions: NetworkConditions{},
+			expected:   []string{},
+		},
+		{
+			name:       "latency",
+			conditions: NetworkConditions{Latency: 100 * time.Millisecond},
+			expected:   []string{"delay", "100000us"},
+		},
+		{
+			name:       "latency-jitter",
+			conditions: NetworkConditions{Latency: 100 * time.Millisecond, Jitter: 1500 * time.Microsecond},
+			expected:   []string{"delay", "100000us", "1500us"},
+		},
+		{
+			name:       "all",
+			conditions: NetworkConditions{Latency: time.Second, PacketLoss: 0.5, Rate: "1mbit"},
+			expected:   []string{"delay", "1000000us", "loss", "0.5%", "rate", "1mbit"},
+		},
+		{
+			name:       "negative-latency",
+			conditions: NetworkConditions{Latency: -time.Second},
+			err:        "cannot be negative",
+		},
+		{
+			name:       "packet-loss-out-of-range",
+			conditions: NetworkConditions{PacketLoss: 101},
+			err:        "between 0 and 100",
+		},
+		{
+			name:       "jitter-without-latency",
+			conditions: NetworkConditions{Jitter: time.Second},
+			err:        "requires a latency",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args, err := test.conditions.netemArgs()
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+}
+
+func TestWithNetworkConditions(t *testing.T) {
+	ctx := context.Background()
+
+	// withNetworkConditions {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForHTTP("/"),
+		},
+		Started: true,
+	}
+	conditions := NetworkConditions{Latency: 500 * time.Millisecond}
+	WithNetworkConditions(conditions)(&req)
+
+	nginxC, err := GenericContainer(ctx, req)
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	endpoint, err := nginxC.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	// the latency is added to the packets of the connection handshake and the response
+	require.GreaterOrEqual(t, roundTrip(t, endpoint), 500*time.Millisecond)
+
+	// resetNetworkConditions {
+	err = ResetNetworkConditions(ctx, nginxC, conditions)
+	// }
+	require.NoError(t, err)
+	require.Less(t, roundTrip(t, endpoint), 500*time.Millisecond)
+
+	// setNetworkConditions {
+	err = SetNetworkConditions(ctx, nginxC, NetworkProfileSatellite)
+	// }
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, roundTrip(t, endpoint), 550*time.Millisecond)
+}
+
+func TestSetNetworkConditions_invalidInterface(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	err = SetNetworkConditions(ctx, nginxC, NetworkConditions{Latency: time.Second, Interface: "eth42"})
+	require.ErrorContains(t, err, "error applying the network conditions")
+}
+
+// roundTrip returns the duration of an HTTP request to the endpoint, using a new connection
+func roundTrip(t *testing.T, endpoint string) time.Duration {
+	t.Helper()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	start := time.Now()
+	resp, err := client.Get(endpoint)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	return time.Since(start)
+}
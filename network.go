@@ -48,3 +48,36 @@ type NetworkRequest struct {
 	ReaperImage   string            // Deprecated: use WithImageName ContainerOption instead. Alternative reaper registry
 	ReaperOptions []ContainerOption // Deprecated: the reaper is configured at the properties level, for an entire test session
 }
+
+// NetworkAttachment defines a network the container is attached to when it's created, before it starts,
+// with its aliases and its static IPv4 address in that network.
+type NetworkAttachment struct {
+	Name    string   // the name of the network
+	Aliases []string // the DNS names of the container in the network
+	IPv4    string   // the static IPv4 address of the container in the network. Empty for a dynamic address
+}
+
+// endpointSettings returns the endpoint settings of the attachment for the network with the given ID
+func (a NetworkAttachment) endpointSettings(networkID string) *network.EndpointSettings {
+	settings := &network.EndpointSettings{
+		Aliases:   append([]string{}, a.Aliases...),
+		NetworkID: networkID,
+	}
+
+	if a.IPv4 != "" {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: a.IPv4}
+	}
+
+	return settings
+}
+
+// hasNetworkAttachment returns true if the request has an attachment for the network
+func (c *ContainerRequest) hasNetworkAttachment(name string) bool {
+	for _, attachment := range c.NetworkAttachments {
+		if attachment.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -229,6 +229,70 @@ func TestContainerIPs(t *testing.T) {
 	if len(ips) != 2 {
 		t.Errorf("Expected two IP addresses, got %v", len(ips))
 	}
+
+	// containerIPsByNetwork {
+	ipsByNetwork, err := nginx.(*testcontainers.DockerContainer).ContainerIPsByNetwork(ctx)
+	// }
+	require.NoError(t, err)
+
+	require.Len(t, ipsByNetwork, 2)
+	assert.NotEmpty(t, ipsByNetwork["bridge"])
+	assert.NotEmpty(t, ipsByNetwork[networkName])
+	assert.ElementsMatch(t, ips, []string{ipsByNetwork["bridge"], ipsByNetwork[networkName]})
+}
+
+func TestNetworkAttachments(t *testing.T) {
+	ctx := context.Background()
+
+	frontend, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, frontend.Remove(ctx))
+	})
+
+	backend, err := network.New(ctx,
+		network.WithIPAM(&dockernetwork.IPAM{
+			Config: []dockernetwork.IPAMConfig{
+				{Subnet: "10.2.2.0/24", Gateway: "10.2.2.254"},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, backend.Remove(ctx))
+	})
+
+	// networkAttachments {
+	req := testcontainers.ContainerRequest{
+		Image: "alpine",
+		// the aliases of all the networks are resolved from the first log line
+		Cmd: []string{"sh", "-c", "getent hosts api && getent hosts db-client && sleep 60"},
+		NetworkAttachments: []testcontainers.NetworkAttachment{
+			{Name: frontend.Name, Aliases: []string{"api"}},
+			{Name: backend.Name, Aliases: []string{"db-client"}, IPv4: "10.2.2.10"},
+		},
+		WaitingFor: wait.ForLog("db-client"),
+	}
+	// }
+
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	ips, err := c.(*testcontainers.DockerContainer).ContainerIPsByNetwork(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ips[frontend.Name])
+	assert.Equal(t, "10.2.2.10", ips[backend.Name])
+
+	aliases, err := c.NetworkAliases(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, aliases[frontend.Name], "api")
+	assert.Contains(t, aliases[backend.Name], "db-client")
 }
 
 func TestContainerWithReaperNetwork(t *testing.T) {
@@ -0,0 +1,245 @@
+package testcontainers
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// NetworkScenario defines how the tests reach a container, so the same test body can be run
+// against the container under different network conditions, e.g. to validate the timeouts
+// and retries of a client.
+type NetworkScenario struct {
+	// Name is the name of the subtest running the scenario
+	Name string
+	// Latency is the delay added in each direction by a proxy running between the tests
+	// and the container. A zero latency connects the tests to the mapped port of the container.
+	Latency time.Duration
+	// Partitioned drops all the traffic sent by the container, so the tests cannot reach it
+	Partitioned bool
+}
+
+var (
+	// NetworkScenarioDirect connects the tests to the mapped port of the container
+	NetworkScenarioDirect = NetworkScenario{Name: "direct"}
+
+	// NetworkScenarioLatency connects the tests to the container through a proxy adding latency
+	NetworkScenarioLatency = NetworkScenario{Name: "latency", Latency: 200 * time.Millisecond}
+
+	// NetworkScenarioPartitioned cuts the network of the container
+	NetworkScenarioPartitioned = NetworkScenario{Name: "partitioned", Partitioned: true}
+
+	// DefaultNetworkScenarios are the scenarios run by RunNetworkScenarios when none is given
+	DefaultNetworkScenarios = []NetworkScenario{NetworkScenarioDirect, NetworkScenarioLatency, NetworkScenarioPartitioned}
+)
+
+// partitionConditions are the network conditions dropping all the traffic sent by a container
+var partitionConditions = NetworkConditions{PacketLoss: 100}
+
+// RunNetworkScenarios runs the test body as a subtest for each scenario, passing it the host:port
+// endpoint to reach the given port of the container in that scenario. The scenarios run
+// sequentially against the same container, which is restored to its normal network once
+// each subtest completes. If no scenarios are given, DefaultNetworkScenarios are run.
+func RunNetworkScenarios(t *testing.T, c Container, port nat.Port, scenarios []NetworkScenario, body func(t *testing.T, endpoint string)) {
+	t.Helper()
+
+	if len(scenarios) == 0 {
+		scenarios = DefaultNetworkScenarios
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			endpoint, err := c.PortEndpoint(ctx, port, "")
+			if err != nil {
+				t.Fatalf("failed to get the endpoint of %s: %s", port, err)
+			}
+
+			if scenario.Partitioned {
+				if err := SetNetworkConditions(ctx, c, partitionConditions); err != nil {
+					t.Fatalf("failed to partition the network of the container: %s", err)
+				}
+				t.Cleanup(func() {
+					if err := ResetNetworkConditions(ctx, c, partitionConditions); err != nil {
+						t.Errorf("failed to restore the network of the container: %s", err)
+					}
+				})
+			}
+
+			if scenario.Latency > 0 {
+				proxy, err := newLatencyProxy(endpoint, scenario.Latency)
+				if err != nil {
+					t.Fatalf("failed to start the latency proxy: %s", err)
+				}
+				t.Cleanup(proxy.Close)
+
+				endpoint = proxy.Addr()
+			}
+
+			body(t, endpoint)
+		})
+	}
+}
+
+// latencyProxy is a TCP proxy listening on the loopback interface, forwarding the connections
+// to a target and delaying the data sent in each direction
+type latencyProxy struct {
+	listener net.Listener
+	target   string
+	latency  time.Duration
+
+	mtx    sync.Mutex
+	conns  []net.Conn
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// newLatencyProxy starts a proxy forwarding the connections to the target with the given latency
+func newLatencyProxy(target string, latency time.Duration) (*latencyProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &latencyProxy{listener: listener, target: target, latency: latency}
+
+	p.wg.Add(1)
+	go p.serve()
+
+	return p, nil
+}
+
+// Addr returns the host:port address of the proxy
+func (p *latencyProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// Close stops the proxy, closing the forwarded connections
+func (p *latencyProxy) Close() {
+	_ = p.listener.Close()
+
+	p.mtx.Lock()
+	p.closed = true
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.mtx.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *latencyProxy) serve() {
+	defer p.wg.Done()
+
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		p.wg.Add(1)
+		go p.forward(client)
+	}
+}
+
+// forward forwards the client connection to the target, once the latency of the handshake elapsed
+func (p *latencyProxy) forward(client net.Conn) {
+	defer p.wg.Done()
+
+	if !p.track(client) {
+		return
+	}
+
+	time.Sleep(p.latency)
+
+	upstream, err := net.Dial("tcp", p.target)
+	if err != nil {
+		_ = client.Close()
+		return
+	}
+
+	if !p.track(upstream) {
+		_ = client.Close()
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		p.pipe(upstream, client)
+	}()
+	go func() {
+		defer wg.Done()
+		p.pipe(client, upstream)
+	}()
+	wg.Wait()
+
+	_ = client.Close()
+	_ = upstream.Close()
+}
+
+// track registers the connection to close it with the proxy, returning false if the proxy is closed
+func (p *latencyProxy) track(conn net.Conn) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed {
+		_ = conn.Close()
+		return false
+	}
+
+	p.conns = append(p.conns, conn)
+	return true
+}
+
+// delayedChunk is a chunk of data read from a connection, to be written once the latency elapsed
+type delayedChunk struct {
+	data []byte
+	due  time.Time
+}
+
+// pipe copies the data read from src to dst, delaying each chunk by the latency of the proxy
+// without limiting the throughput of the connection
+func (p *latencyProxy) pipe(dst net.Conn, src net.Conn) {
+	chunks := make(chan delayedChunk, 64)
+
+	go func() {
+		defer close(chunks)
+
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := src.Read(buf)
+			if n > 0 {
+				data := make([]byte, n)
+				copy(data, buf[:n])
+				chunks <- delayedChunk{data: data, due: time.Now().Add(p.latency)}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for chunk := range chunks {
+		time.Sleep(time.Until(chunk.due))
+
+		if _, err := dst.Write(chunk.data); err != nil {
+			// drain the reader, which is unblocked when the connections are closed
+			_ = src.Close()
+			for range chunks {
+			}
+			return
+		}
+	}
+
+	// propagate the end of the stream, keeping the other direction open
+	if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+	}
+}
@@ -0,0 +1,112 @@
+package testcontainers
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestLatencyProxy(t *testing.T) {
+	// an echo server, replying with the lines it receives
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					_, _ = conn.Write(append(scanner.Bytes(), '\n'))
+				}
+			}()
+		}
+	}()
+
+	latency := 100 * time.Millisecond
+	proxy, err := newLatencyProxy(listener.Addr().String(), latency)
+	require.NoError(t, err)
+	defer proxy.Close()
+
+	conn, err := net.Dial("tcp", proxy.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for _, msg := range []string{"hello\n", "world\n"} {
+		start := time.Now()
+
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, msg, line)
+
+		// the latency is added once in each direction
+		assert.GreaterOrEqual(t, time.Since(start), 2*latency)
+	}
+
+	proxy.Close()
+
+	_, err = reader.ReadString('\n')
+	require.Error(t, err)
+}
+
+func TestRunNetworkScenarios(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForHTTP("/"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	// runNetworkScenarios {
+	RunNetworkScenarios(t, nginxC, nginxDefaultPort, DefaultNetworkScenarios, func(t *testing.T, endpoint string) {
+		client := &http.Client{
+			Timeout:   time.Second,
+			Transport: &http.Transport{DisableKeepAlives: true},
+		}
+
+		start := time.Now()
+		resp, err := client.Get("http://" + endpoint)
+
+		switch t.Name() {
+		case "TestRunNetworkScenarios/partitioned":
+			require.Error(t, err)
+		case "TestRunNetworkScenarios/latency":
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.GreaterOrEqual(t, time.Since(start), 2*NetworkScenarioLatency.Latency)
+		default:
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	})
+	// }
+
+	// the network of the container is restored after the partitioned scenario
+	endpoint, err := nginxC.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+	require.Less(t, roundTrip(t, endpoint), time.Second)
+}
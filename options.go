@@ -3,11 +3,16 @@ package testcontainers
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"dario.cat/mergo"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/internal/core"
@@ -33,7 +38,7 @@ func (opt CustomizeRequestOption) Customize(req *GenericContainerRequest) {
 func CustomizeRequest(src GenericContainerRequest) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
 		if err := mergo.Merge(req, &src, mergo.WithOverride, mergo.WithAppendSlice); err != nil {
-			Logger.Printf("error merging container request, keeping the original one. Error: %v", err)
+			logAt(context.Background(), Logger, slog.LevelWarn, fmt.Sprintf("error merging container request, keeping the original one. Error: %v", err))
 			return
 		}
 	}
@@ -67,6 +72,41 @@ func WithEnv(envs map[string]string) CustomizeRequestOption {
 	}
 }
 
+// WithEnvFile sets the environment variables of the .env file for a container, in the format of the env files
+// of compose, e.g. DB_HOST=db, so that the container is configured as in the compose stack of the application.
+// Blank lines and comments are ignored, the values can be quoted, and a variable without value takes the one
+// of the environment of the tests, if it's set. If an environment variable already exists, it will be overridden.
+// The request is not modified if the file cannot be read or parsed, and the error is logged.
+func WithEnvFile(path string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		env, err := parseEnvFile(path)
+		if err != nil {
+			logAt(context.Background(), Logger, slog.LevelWarn, fmt.Sprintf("error reading the env file, keeping the original environment. Error: %v", err))
+			return
+		}
+
+		WithEnv(env)(req)
+	}
+}
+
+// WithEnvStruct sets the environment variables of the fields of the struct with an env tag for a container,
+// e.g. `env:"DB_HOST"`, so that the configuration struct of the application is reused. The zero values of the fields
+// with the omitempty option are skipped, e.g. `env:"DB_PORT,omitempty"`, as well as the nil pointers.
+// The values are formatted with encoding.TextMarshaler or fmt.Stringer if implemented, and the slices are joined with commas.
+// If an environment variable already exists, it will be overridden.
+// The request is not modified if the struct has unsupported fields, and the error is logged.
+func WithEnvStruct(v any) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		env, err := envFromStruct(v)
+		if err != nil {
+			logAt(context.Background(), Logger, slog.LevelWarn, fmt.Sprintf("error reading the env struct, keeping the original environment. Error: %v", err))
+			return
+		}
+
+		WithEnv(env)(req)
+	}
+}
+
 // WithHostConfigModifier allows to override the default host config
 func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
@@ -74,6 +114,206 @@ func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) Cus
 	}
 }
 
+// WithHostPortBinding binds the given container port to a fixed port on the host, instead of a random one,
+// for those cases where the system under test needs a stable port, e.g. OAuth redirects or webhooks.
+// The container port is exposed if it is not already, using TCP if no protocol is given.
+// Binding fixed host ports makes the tests prone to port conflicts with other processes or tests
+// running in parallel, so use it only when a random port is not an option. See FreeHostPort.
+func WithHostPortBinding(containerPort string, hostPort int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		proto, portNumber := nat.SplitProtoPort(containerPort)
+		port := nat.Port(portNumber + "/" + proto)
+		binding := fmt.Sprintf("%d:%s", hostPort, port)
+
+		for i, exposedPort := range req.ExposedPorts {
+			mappings, err := nat.ParsePortSpec(exposedPort)
+			if err != nil || len(mappings) != 1 {
+				continue
+			}
+
+			if mappings[0].Port == port {
+				req.ExposedPorts[i] = binding
+				return
+			}
+		}
+
+		req.ExposedPorts = append(req.ExposedPorts, binding)
+	}
+}
+
+// FreeHostPort returns a TCP port that is free on the host at the time of the call, to be used
+// with WithHostPortBinding. The port is not reserved: another process could take it before the
+// container starts, so tests relying on it can still be flaky.
+func FreeHostPort() (int, error) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// WithMemoryLimit sets the maximum amount of memory the container can use, in bytes.
+// It can be combined with other options modifying the host config, but it's overridden by
+// WithHostConfigModifier if that option is passed after it.
+func WithMemoryLimit(bytes int64) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		chainHostConfigModifier(req, func(hostConfig *container.HostConfig) {
+			hostConfig.Memory = bytes
+		})
+	}
+}
+
+// WithCPULimit sets the maximum amount of CPU the container can use, in units of 10^-9 CPUs,
+// e.g. 1_500_000_000 for 1.5 CPUs. It can be combined with other options modifying the host config,
+// but it's overridden by WithHostConfigModifier if that option is passed after it.
+func WithCPULimit(nanoCPUs int64) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		chainHostConfigModifier(req, func(hostConfig *container.HostConfig) {
+			hostConfig.NanoCPUs = nanoCPUs
+		})
+	}
+}
+
+// WithShmSize sets the size of /dev/shm in the container, in bytes. Browsers and some databases
+// need more than the default 64MB.
+func WithShmSize(bytes int64) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ShmSize = bytes
+	}
+}
+
+// WithUser sets the user the processes of the container run as, in the format of the --user flag
+// of the Docker CLI: a name or UID, optionally followed by a group name or GID, e.g. "1000:1000".
+// It's needed by the images refusing to run as root, and to test the ones running as a non-root user.
+func WithUser(user string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.User = user
+	}
+}
+
+// WithReadOnlyRootFS mounts the root filesystem of the container as read-only, so that it can only
+// write to its volumes and tmpfs mounts, e.g. the ones added with WithTmpfs. It can be combined with
+// other options modifying the host config, but it's overridden by WithHostConfigModifier if that option
+// is passed after it.
+func WithReadOnlyRootFS() CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		chainHostConfigModifier(req, func(hostConfig *container.HostConfig) {
+			hostConfig.ReadonlyRootfs = true
+		})
+	}
+}
+
+// WithTmpfs mounts tmpfs filesystems in the container, indexed by their path, with the options of the
+// --tmpfs flag of the Docker CLI, e.g. {"/tmp": "rw,size=64m", "/run": ""}. The mounts are added to the
+// ones of the request, replacing the ones with the same path.
+func WithTmpfs(tmpfs map[string]string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if req.Tmpfs == nil {
+			req.Tmpfs = make(map[string]string, len(tmpfs))
+		}
+
+		for path, options := range tmpfs {
+			req.Tmpfs[path] = options
+		}
+	}
+}
+
+// WithHealthCheck defines the health check of the container, overriding the HEALTHCHECK of the image,
+// e.g. for images without one, in the format of the Docker API: the test is either a command run with
+// the shell, {"CMD-SHELL", "curl -f http://localhost"}, or without it, {"CMD", "pg_isready"}.
+// The container is reported as unhealthy after the given retries fail, once the start period is over.
+// Use it with wait.ForHealthCheck to wait for the container to be healthy.
+func WithHealthCheck(healthCheck container.HealthConfig) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.HealthCheck = &healthCheck
+	}
+}
+
+// WithGPUs requests GPUs for the container, in the format of the --gpus flag of the Docker CLI:
+// "all" for all the GPUs, a number of GPUs, e.g. "2", or a comma-separated list of device IDs
+// or UUIDs, optionally prefixed with "device=", e.g. "device=0,2". The GPUs are requested
+// with the gpu capability, so the Docker daemon needs a GPU runtime, e.g. the NVIDIA Container Toolkit.
+func WithGPUs(gpus string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		deviceRequest := container.DeviceRequest{
+			Capabilities: [][]string{{"gpu"}},
+		}
+
+		value := strings.TrimPrefix(gpus, "device=")
+		if count, err := strconv.Atoi(value); err == nil && value == gpus {
+			deviceRequest.Count = count
+		} else if value == "all" {
+			deviceRequest.Count = -1
+		} else {
+			deviceRequest.DeviceIDs = strings.Split(value, ",")
+		}
+
+		req.DeviceRequests = append(req.DeviceRequests, deviceRequest)
+	}
+}
+
+// WithDevices maps devices of the host into the container, in the format of the --device flag
+// of the Docker CLI: "host-path[:container-path][:permissions]", e.g. "/dev/fuse" or "/dev/sda:/dev/xvda:r".
+// The device is mapped at the same path if no container path is given, with "rwm" permissions by default.
+func WithDevices(devices ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		for _, device := range devices {
+			req.Devices = append(req.Devices, parseDeviceMapping(device))
+		}
+	}
+}
+
+// parseDeviceMapping parses a device in the format of the --device flag of the Docker CLI
+func parseDeviceMapping(device string) container.DeviceMapping {
+	mapping := container.DeviceMapping{
+		CgroupPermissions: "rwm",
+	}
+
+	parts := strings.Split(device, ":")
+	mapping.PathOnHost = parts[0]
+	mapping.PathInContainer = parts[0]
+
+	switch len(parts) {
+	case 2:
+		if isDevicePermissions(parts[1]) {
+			mapping.CgroupPermissions = parts[1]
+		} else {
+			mapping.PathInContainer = parts[1]
+		}
+	case 3:
+		mapping.PathInContainer = parts[1]
+		mapping.CgroupPermissions = parts[2]
+	}
+
+	return mapping
+}
+
+// isDevicePermissions returns true if the value is a combination of the r, w and m cgroup permissions
+func isDevicePermissions(value string) bool {
+	if value == "" || len(value) > 3 {
+		return false
+	}
+
+	return strings.Trim(value, "rwm") == ""
+}
+
+// chainHostConfigModifier runs the given modifier after the host config modifier of the request,
+// or after the default one if there is none, so that the options modifying the host config can be combined.
+func chainHostConfigModifier(req *GenericContainerRequest, modifier func(hostConfig *container.HostConfig)) {
+	previous := req.HostConfigModifier
+	req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+		if previous != nil {
+			previous(hostConfig)
+		} else {
+			defaultHostConfigModifier(req.ContainerRequest)(hostConfig)
+		}
+
+		modifier(hostConfig)
+	}
+}
+
 // WithImage sets the image for a container
 func WithImage(image string) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
@@ -81,6 +321,22 @@ func WithImage(image string) CustomizeRequestOption {
 	}
 }
 
+// WithImagePullPolicy sets the pull policy for the image of a container
+func WithImagePullPolicy(policy ImagePullPolicy) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ImagePullPolicy = policy
+	}
+}
+
+// WithImageDigest pins the image of a container to the given digest, e.g. "sha256:...".
+// A local image not matching the digest is pulled again, unless the pull policy is PullNever,
+// and the container creation fails if the resulting image does not match the digest.
+func WithImageDigest(digest string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ImageDigest = digest
+	}
+}
+
 // imageSubstitutor {
 
 // ImageSubstitutor represents a way to substitute container image names
@@ -249,3 +505,23 @@ func WithWaitStrategyAndDeadline(deadline time.Duration, strategies ...wait.Stra
 		req.WaitingFor = wait.ForAll(strategies...).WithDeadline(deadline)
 	}
 }
+
+// WithGracefulStop makes Terminate stop the container gracefully before removing it, sending it the signal
+// of the options and waiting for it to stop within the timeout of the options. It allows processes like
+// databases to flush their data to their volumes, which could be corrupted if the container was killed.
+func WithGracefulStop(opts StopOptions) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreTerminates: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					dc, ok := c.(*DockerContainer)
+					if !ok {
+						return fmt.Errorf("unsupported container type %T", c)
+					}
+
+					return dc.StopWithOptions(ctx, opts)
+				},
+			},
+		})
+	}
+}
@@ -3,8 +3,15 @@ package testcontainers_test
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/strslice"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -210,3 +217,554 @@ func TestWithEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestWithEnvFile(t *testing.T) {
+	t.Setenv("FROM_ENVIRONMENT", "env-value")
+
+	envFile := filepath.Join(t.TempDir(), ".env")
+	content := `# the database
+export DB_HOST=db
+DB_PORT = 5432 # the default port
+EMPTY=
+
+DOUBLE_QUOTED="hello \"world\"\n"
+SINGLE_QUOTED='hello \n # world'
+MULTILINE="first
+second"
+FROM_ENVIRONMENT
+NOT_IN_ENVIRONMENT
+`
+	require.NoError(t, os.WriteFile(envFile, []byte(content), 0o600))
+
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Env: map[string]string{"DB_HOST": "localhost", "KEY": "VAL"},
+		},
+	}
+
+	// withEnvFile {
+	opt := testcontainers.WithEnvFile(envFile)
+	// }
+	opt.Customize(req)
+
+	require.Equal(t, map[string]string{
+		"KEY":              "VAL",
+		"DB_HOST":          "db",
+		"DB_PORT":          "5432",
+		"EMPTY":            "",
+		"DOUBLE_QUOTED":    "hello \"world\"\n",
+		"SINGLE_QUOTED":    `hello \n # world`,
+		"MULTILINE":        "first\nsecond",
+		"FROM_ENVIRONMENT": "env-value",
+	}, req.Env)
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"unterminated-quote": "KEY=\"value\n",
+			"invalid-name":       "INVALID KEY=value\n",
+		} {
+			t.Run(name, func(t *testing.T) {
+				envFile := filepath.Join(t.TempDir(), ".env")
+				require.NoError(t, os.WriteFile(envFile, []byte(content), 0o600))
+
+				req := &testcontainers.GenericContainerRequest{}
+				testcontainers.WithEnvFile(envFile).Customize(req)
+				require.Empty(t, req.Env)
+			})
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+		testcontainers.WithEnvFile(filepath.Join(t.TempDir(), "missing.env")).Customize(req)
+		require.Empty(t, req.Env)
+	})
+}
+
+// envBase is embedded in the environment structs of the tests
+type envBase struct {
+	LogLevel string `env:"LOG_LEVEL"`
+}
+
+func TestWithEnvStruct(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		port := 5432
+
+		// withEnvStruct {
+		type config struct {
+			envBase
+			Host     string        `env:"DB_HOST"`
+			Port     *int          `env:"DB_PORT"`
+			Password *string       `env:"DB_PASSWORD"`
+			Debug    bool          `env:"DEBUG"`
+			Ratio    float64       `env:"RATIO"`
+			Timeout  time.Duration `env:"TIMEOUT"`
+			Tags     []string      `env:"TAGS"`
+			Replicas int           `env:"REPLICAS,omitempty"`
+			Ignored  string        `env:"-"`
+			Untagged string
+		}
+
+		opt := testcontainers.WithEnvStruct(config{
+			envBase: envBase{LogLevel: "debug"},
+			Host:    "db",
+			Port:    &port,
+			Debug:   true,
+			Ratio:   0.5,
+			Timeout: 90 * time.Second,
+			Tags:    []string{"a", "b"},
+			Ignored: "ignored",
+		})
+		// }
+
+		req := &testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Env: map[string]string{"DB_HOST": "localhost", "KEY": "VAL"},
+			},
+		}
+		opt.Customize(req)
+
+		require.Equal(t, map[string]string{
+			"KEY":       "VAL",
+			"LOG_LEVEL": "debug",
+			"DB_HOST":   "db",
+			"DB_PORT":   "5432",
+			"DEBUG":     "true",
+			"RATIO":     "0.5",
+			"TIMEOUT":   "1m30s",
+			"TAGS":      "a,b",
+		}, req.Env)
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+		testcontainers.WithEnvStruct(&struct {
+			Host string `env:"DB_HOST"`
+		}{Host: "db"}).Customize(req)
+		require.Equal(t, map[string]string{"DB_HOST": "db"}, req.Env)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, v := range map[string]any{
+			"not-a-struct": "DB_HOST=db",
+			"nil":          (*struct{})(nil),
+			"unsupported-type": struct {
+				M map[string]string `env:"M"`
+			}{M: map[string]string{}},
+			"no-name": struct {
+				Host string `env:",omitempty"`
+			}{Host: "db"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				req := &testcontainers.GenericContainerRequest{}
+				testcontainers.WithEnvStruct(v).Customize(req)
+				require.Empty(t, req.Env)
+			})
+		}
+	})
+}
+
+func TestWithHostPortBinding(t *testing.T) {
+	tests := map[string]struct {
+		exposedPorts  []string
+		containerPort string
+		expect        []string
+	}{
+		"append": {
+			exposedPorts:  []string{"6379/tcp"},
+			containerPort: "8080/tcp",
+			expect:        []string{"6379/tcp", "9999:8080/tcp"},
+		},
+		"replace": {
+			exposedPorts:  []string{"6379/tcp", "8080/tcp"},
+			containerPort: "8080/tcp",
+			expect:        []string{"6379/tcp", "9999:8080/tcp"},
+		},
+		"replace-without-protocol": {
+			exposedPorts:  []string{"8080"},
+			containerPort: "8080",
+			expect:        []string{"9999:8080/tcp"},
+		},
+		"replace-binding": {
+			exposedPorts:  []string{"5000:8080/tcp"},
+			containerPort: "8080/tcp",
+			expect:        []string{"9999:8080/tcp"},
+		},
+		"udp": {
+			exposedPorts:  []string{"8080/tcp"},
+			containerPort: "8080/udp",
+			expect:        []string{"8080/tcp", "9999:8080/udp"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					ExposedPorts: tc.exposedPorts,
+				},
+			}
+
+			opt := testcontainers.WithHostPortBinding(tc.containerPort, 9999)
+			opt.Customize(req)
+			require.Equal(t, tc.expect, req.ExposedPorts)
+		})
+	}
+}
+
+func TestWithHostPortBinding_container(t *testing.T) {
+	ctx := context.Background()
+
+	// withHostPortBinding {
+	hostPort, err := testcontainers.FreeHostPort()
+	require.NoError(t, err)
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	}
+
+	testcontainers.WithHostPortBinding(nginxDefaultPort, hostPort).Customize(&req)
+	// }
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	port, err := c.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	assert.Equal(t, hostPort, port.Int())
+}
+
+func TestWithResourceLimits(t *testing.T) {
+	t.Run("combined", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+
+		testcontainers.WithMemoryLimit(256 * 1024 * 1024).Customize(req)
+		testcontainers.WithCPULimit(500_000_000).Customize(req)
+		testcontainers.WithShmSize(128 * 1024 * 1024).Customize(req)
+
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+
+		assert.Equal(t, int64(256*1024*1024), hostConfig.Memory)
+		assert.Equal(t, int64(500_000_000), hostConfig.NanoCPUs)
+		assert.Equal(t, int64(128*1024*1024), req.ShmSize)
+	})
+
+	t.Run("keeps-previous-host-config-modifier", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+
+		testcontainers.WithHostConfigModifier(func(hostConfig *container.HostConfig) {
+			hostConfig.Privileged = true
+		}).Customize(req)
+		testcontainers.WithMemoryLimit(256 * 1024 * 1024).Customize(req)
+
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+
+		assert.True(t, hostConfig.Privileged)
+		assert.Equal(t, int64(256*1024*1024), hostConfig.Memory)
+	})
+
+	t.Run("keeps-default-host-config", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				CapAdd: []string{"NET_ADMIN"},
+			},
+		}
+
+		testcontainers.WithCPULimit(500_000_000).Customize(req)
+
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+
+		assert.Equal(t, strslice.StrSlice{"NET_ADMIN"}, hostConfig.CapAdd)
+		assert.Equal(t, int64(500_000_000), hostConfig.NanoCPUs)
+	})
+}
+
+func TestWithResourceLimits_container(t *testing.T) {
+	ctx := context.Background()
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	}
+
+	// withResourceLimits {
+	opts := []testcontainers.ContainerCustomizer{
+		testcontainers.WithMemoryLimit(256 * 1024 * 1024), // 256MB
+		testcontainers.WithCPULimit(500_000_000),          // 0.5 CPUs
+		testcontainers.WithShmSize(128 * 1024 * 1024),     // 128MB
+	}
+	// }
+	for _, opt := range opts {
+		opt.Customize(&req)
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	inspect, err := cli.ContainerInspect(ctx, c.GetContainerID())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(256*1024*1024), inspect.HostConfig.Memory)
+	assert.Equal(t, int64(500_000_000), inspect.HostConfig.NanoCPUs)
+	assert.Equal(t, int64(128*1024*1024), inspect.HostConfig.ShmSize)
+}
+
+func TestWithSecurityHardening(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Tmpfs: map[string]string{"/run": ""},
+		},
+	}
+
+	testcontainers.WithUser("1000:1000").Customize(req)
+	testcontainers.WithReadOnlyRootFS().Customize(req)
+	testcontainers.WithTmpfs(map[string]string{"/tmp": "rw,size=64m", "/run": "rw"}).Customize(req)
+
+	hostConfig := &container.HostConfig{}
+	req.HostConfigModifier(hostConfig)
+
+	assert.Equal(t, "1000:1000", req.User)
+	assert.True(t, hostConfig.ReadonlyRootfs)
+	assert.Equal(t, map[string]string{"/tmp": "rw,size=64m", "/run": "rw"}, req.Tmpfs)
+}
+
+func TestWithSecurityHardening_container(t *testing.T) {
+	ctx := context.Background()
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "alpine:latest",
+			Cmd:   []string{"sleep", "infinity"},
+		},
+		Started: true,
+	}
+
+	// withSecurityHardening {
+	opts := []testcontainers.ContainerCustomizer{
+		testcontainers.WithUser("1000:1000"),
+		testcontainers.WithReadOnlyRootFS(),
+		testcontainers.WithTmpfs(map[string]string{"/tmp": "rw,size=64m"}),
+	}
+	// }
+	for _, opt := range opts {
+		opt.Customize(&req)
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	code, reader, err := c.Exec(ctx, []string{"id", "-u"}, exec.Multiplexed())
+	require.NoError(t, err)
+	require.Equal(t, 0, code)
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", strings.TrimSpace(string(output)))
+
+	// the root filesystem is read-only, but the tmpfs mount is writable
+	code, _, err = c.Exec(ctx, []string{"touch", "/testcontainers"})
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, code)
+
+	code, _, err = c.Exec(ctx, []string{"touch", "/tmp/testcontainers"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{}
+
+	testcontainers.WithHealthCheck(container.HealthConfig{
+		Test:     []string{"CMD", "true"},
+		Interval: time.Second,
+		Retries:  5,
+	}).Customize(req)
+
+	require.NotNil(t, req.HealthCheck)
+	assert.Equal(t, []string{"CMD", "true"}, req.HealthCheck.Test)
+	assert.Equal(t, time.Second, req.HealthCheck.Interval)
+	assert.Equal(t, 5, req.HealthCheck.Retries)
+}
+
+func TestWithHealthCheck_container(t *testing.T) {
+	ctx := context.Background()
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	}
+
+	// withHealthCheck {
+	opts := []testcontainers.ContainerCustomizer{
+		testcontainers.WithHealthCheck(container.HealthConfig{
+			Test:     []string{"CMD-SHELL", "wget -q -O /dev/null http://localhost"},
+			Interval: 500 * time.Millisecond,
+			Timeout:  time.Second,
+			Retries:  10,
+		}),
+		testcontainers.WithWaitStrategy(wait.ForHealthCheck()),
+	}
+	// }
+	for _, opt := range opts {
+		opt.Customize(&req)
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	state, err := c.State(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, state.Health)
+	assert.Equal(t, types.Healthy, state.Health.Status)
+}
+
+func TestWithGPUs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		gpus     string
+		expected container.DeviceRequest
+	}{
+		{
+			name:     "all",
+			gpus:     "all",
+			expected: container.DeviceRequest{Count: -1, Capabilities: [][]string{{"gpu"}}},
+		},
+		{
+			name:     "count",
+			gpus:     "2",
+			expected: container.DeviceRequest{Count: 2, Capabilities: [][]string{{"gpu"}}},
+		},
+		{
+			name:     "device-ids",
+			gpus:     "device=0,2",
+			expected: container.DeviceRequest{DeviceIDs: []string{"0", "2"}, Capabilities: [][]string{{"gpu"}}},
+		},
+		{
+			name:     "device-uuid",
+			gpus:     "GPU-3a23c669-1f69-c64e-cf85-44e9b07e7a2a",
+			expected: container.DeviceRequest{DeviceIDs: []string{"GPU-3a23c669-1f69-c64e-cf85-44e9b07e7a2a"}, Capabilities: [][]string{{"gpu"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &testcontainers.GenericContainerRequest{}
+
+			testcontainers.WithGPUs(tc.gpus).Customize(req)
+
+			assert.Equal(t, []container.DeviceRequest{tc.expected}, req.DeviceRequests)
+		})
+	}
+}
+
+func TestWithDevices(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{}
+
+	testcontainers.WithDevices("/dev/fuse", "/dev/sda:/dev/xvda", "/dev/sdb:r", "/dev/sdc:/dev/xvdc:rw").Customize(req)
+
+	expected := []container.DeviceMapping{
+		{PathOnHost: "/dev/fuse", PathInContainer: "/dev/fuse", CgroupPermissions: "rwm"},
+		{PathOnHost: "/dev/sda", PathInContainer: "/dev/xvda", CgroupPermissions: "rwm"},
+		{PathOnHost: "/dev/sdb", PathInContainer: "/dev/sdb", CgroupPermissions: "r"},
+		{PathOnHost: "/dev/sdc", PathInContainer: "/dev/xvdc", CgroupPermissions: "rw"},
+	}
+	assert.Equal(t, expected, req.Devices)
+}
+
+func TestWithDevices_container(t *testing.T) {
+	ctx := context.Background()
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+			// the devices must not be reset by the host config modifier
+			HostConfigModifier: func(hostConfig *container.HostConfig) {
+				hostConfig.Memory = 256 * 1024 * 1024
+			},
+		},
+		Started: true,
+	}
+
+	// withDevices {
+	testcontainers.WithDevices("/dev/null:/dev/testcontainers").Customize(&req)
+	// }
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	code, _, err := c.Exec(ctx, []string{"test", "-c", "/dev/testcontainers"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+}
+
+func TestWithGracefulStop(t *testing.T) {
+	ctx := context.Background()
+
+	exitCode := -1
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "docker.io/alpine",
+			Cmd:        []string{"sh", "-c", "trap 'exit 0' INT; echo ready; while true; do sleep 0.1; done"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	}
+
+	// withGracefulStop {
+	testcontainers.WithGracefulStop(testcontainers.StopOptions{Signal: "SIGINT", Timeout: 30 * time.Second})(&req)
+	// }
+
+	// the hooks run in order, so the container is already stopped when this one runs
+	req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PreTerminates: []testcontainers.ContainerHook{
+			func(ctx context.Context, c testcontainers.Container) error {
+				state, err := c.State(ctx)
+				if err != nil {
+					return err
+				}
+
+				exitCode = state.ExitCode
+				return nil
+			},
+		},
+	})
+
+	c, err := testcontainers.GenericContainer(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Terminate(ctx))
+	assert.Equal(t, 0, exitCode)
+}
+
+func TestWithGracefulStop_unsupportedContainer(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{}
+	testcontainers.WithGracefulStop(testcontainers.StopOptions{Signal: "SIGINT"})(&req)
+
+	// a container not created by the Docker provider
+	var c struct{ testcontainers.Container }
+
+	err := req.LifecycleHooks[0].PreTerminates[0](context.Background(), c)
+	require.ErrorContains(t, err, "unsupported container type")
+}
@@ -0,0 +1,191 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+const (
+	// portForwardDialTimeout is the time a forwarded connection waits for the port of the container
+	// to be reachable, e.g. while the container is restarted
+	portForwardDialTimeout = 30 * time.Second
+
+	// portForwardDialInterval is the interval between the attempts to reach the port of the container
+	portForwardDialInterval = 100 * time.Millisecond
+)
+
+// PortForwarder forwards the connections of a local TCP address to a port of a container. The mapped port
+// of the container is resolved for each connection, so the local address does not change when the container
+// is restarted, and its port mapped to another host port. This is convenient for the clients caching their
+// endpoints, e.g. connection pools.
+type PortForwarder struct {
+	container *DockerContainer
+	port      nat.Port
+	listener  net.Listener
+
+	// done is closed when the forwarder is closed, stopping the pending dials
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// PortForward starts forwarding the connections of a local TCP address, on the loopback interface,
+// to the port of the container, until the forwarder or the container are closed. The forwarded
+// connections wait for the port to be reachable, e.g. while the container is restarted.
+func (c *DockerContainer) PortForward(ctx context.Context, port nat.Port) (*PortForwarder, error) {
+	if _, err := c.MappedPort(ctx, port); err != nil {
+		return nil, err
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error listening on a local port: %w", err)
+	}
+
+	f := &PortForwarder{
+		container: c,
+		port:      port,
+		listener:  l,
+		done:      make(chan struct{}),
+	}
+
+	c.portForwardersMtx.Lock()
+	c.portForwarders = append(c.portForwarders, f)
+	c.portForwardersMtx.Unlock()
+
+	f.wg.Add(1)
+	go f.accept()
+
+	return f, nil
+}
+
+// Addr returns the local address forwarded to the port of the container, with the format 127.0.0.1:<port>.
+func (f *PortForwarder) Addr() string {
+	return f.listener.Addr().String()
+}
+
+// DialContext connects to the local address forwarded to the port of the container, ignoring
+// the network and the address, so it can be used as the dialer of the clients, e.g. an http.Transport.
+func (f *PortForwarder) DialContext(ctx context.Context, _ string, _ string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", f.Addr())
+}
+
+// Close stops forwarding the connections, closing the local address and the forwarded connections.
+func (f *PortForwarder) Close() error {
+	var err error
+	f.closeOnce.Do(func() {
+		close(f.done)
+		err = f.listener.Close()
+		f.wg.Wait()
+	})
+
+	return err
+}
+
+// accept forwards the connections of the local address, until it's closed
+func (f *PortForwarder) accept() {
+	defer f.wg.Done()
+
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+
+		f.wg.Add(1)
+		go f.forward(conn)
+	}
+}
+
+// forward copies the data of the local connection to the port of the container, and back
+func (f *PortForwarder) forward(local net.Conn) {
+	defer f.wg.Done()
+	defer local.Close()
+
+	remote, err := f.dial()
+	if err != nil {
+		logAt(context.Background(), f.container.logger, slog.LevelWarn,
+			fmt.Sprintf("🔌 Failed to forward a connection to port %s of container %s: %s", f.port, f.container.shortID(), err),
+			LogKeyContainerID, f.container.shortID())
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-f.done:
+	}
+}
+
+// dial connects to the mapped port of the container, resolving it on each attempt,
+// until it's reachable, the dial timeout elapses, or the forwarder is closed
+func (f *PortForwarder) dial() (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), portForwardDialTimeout)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-f.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		conn, err := f.dialOnce(ctx)
+		if err == nil {
+			return conn, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		case <-time.After(portForwardDialInterval):
+		}
+	}
+}
+
+// dialOnce connects to the port of the container, at the host port it's currently mapped to
+func (f *PortForwarder) dialOnce(ctx context.Context) (net.Conn, error) {
+	endpoint, err := f.container.PortEndpoint(ctx, f.port, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", endpoint)
+}
+
+// closePortForwarders closes the port forwarders of the container, once it's terminated
+func (c *DockerContainer) closePortForwarders() error {
+	c.portForwardersMtx.Lock()
+	defer c.portForwardersMtx.Unlock()
+
+	var errs []error
+	for _, f := range c.portForwarders {
+		errs = append(errs, f.Close())
+	}
+	c.portForwarders = nil
+
+	return errors.Join(errs...)
+}
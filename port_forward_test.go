@@ -0,0 +1,91 @@
+package testcontainers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestPortForward(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForHTTP("/"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	// portForward {
+	forwarder, err := nginxC.(*DockerContainer).PortForward(ctx, nginxDefaultPort)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, forwarder.Close())
+	})
+
+	get := func(t *testing.T, client *http.Client, url string) {
+		t.Helper()
+
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// portForwardClient {
+	client := &http.Client{
+		Transport: &http.Transport{DialContext: forwarder.DialContext, DisableKeepAlives: true},
+	}
+	// }
+
+	get(t, client, "http://"+forwarder.Addr())
+
+	t.Run("restart", func(t *testing.T) {
+		timeout := 10 * time.Second
+		require.NoError(t, nginxC.Stop(ctx, &timeout))
+		require.NoError(t, nginxC.Start(ctx))
+
+		// the local address is the same, even if the port is mapped to another host port
+		get(t, client, "http://"+forwarder.Addr())
+	})
+
+	t.Run("not-exposed", func(t *testing.T) {
+		_, err := nginxC.(*DockerContainer).PortForward(ctx, "8080/tcp")
+		require.ErrorIs(t, err, ErrPortNotExposed)
+	})
+}
+
+func TestPortForward_closedOnTerminate(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	forwarder, err := nginxC.(*DockerContainer).PortForward(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	require.NoError(t, nginxC.Terminate(ctx))
+
+	_, err = forwarder.DialContext(ctx, "tcp", "")
+	require.Error(t, err)
+
+	// closing it again is a no-op
+	require.NoError(t, forwarder.Close())
+}
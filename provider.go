@@ -25,6 +25,8 @@ type (
 	GenericProviderOptions struct {
 		Logger         Logging
 		DefaultNetwork string
+		DockerHost     string
+		reaperOptions  []ReaperOption
 	}
 
 	// GenericProviderOption defines a common interface to modify GenericProviderOptions
@@ -78,6 +80,39 @@ func WithDefaultBridgeNetwork(bridgeNetworkName string) DockerProviderOption {
 	})
 }
 
+// WithDockerHost returns a generic option that sets the Docker host, e.g. tcp://remote:2376, of the daemon
+// where the provider creates the containers, instead of the one extracted from the environment and the properties.
+// The containers of each Docker host are removed by their own reaper.
+//
+// This way a test can run containers in several Docker daemons.
+func WithDockerHost(dockerHost string) DockerHostOption {
+	return DockerHostOption{
+		dockerHost: dockerHost,
+	}
+}
+
+// DockerHostOption is a generic option that sets the Docker host to be used.
+//
+// It can be used to set the Docker host for providers and containers.
+type DockerHostOption struct {
+	dockerHost string
+}
+
+// ApplyGenericTo implements GenericProviderOption.
+func (o DockerHostOption) ApplyGenericTo(opts *GenericProviderOptions) {
+	opts.DockerHost = o.dockerHost
+}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o DockerHostOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.DockerHost = o.dockerHost
+}
+
+// Customize implements ContainerCustomizer.
+func (o DockerHostOption) Customize(req *GenericContainerRequest) {
+	req.DockerHost = o.dockerHost
+}
+
 func (f GenericProviderOptionFunc) ApplyGenericTo(opts *GenericProviderOptions) {
 	f(opts)
 }
@@ -139,13 +174,32 @@ func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error
 	}
 
 	ctx := context.Background()
+
+	tcConfig := ReadConfig()
+	for _, ro := range o.reaperOptions {
+		ro.apply(&tcConfig)
+	}
+
+	if o.DockerHost != "" {
+		// the info of the daemon is not cached, as it belongs to the Docker host of the environment
+		c, err := core.NewClientWithHost(ctx, o.DockerHost)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DockerProvider{
+			DockerProviderOptions: o,
+			host:                  o.DockerHost,
+			client:                c,
+			config:                tcConfig,
+		}, nil
+	}
+
 	c, err := NewDockerClientWithOpts(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	tcConfig := ReadConfig()
-
 	dockerHost := core.ExtractDockerHost(ctx)
 
 	p := &DockerProvider{
@@ -79,3 +79,76 @@ func TestProviderTypeGetProviderAutodetect(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDockerProviderWithDockerHost(t *testing.T) {
+	remoteHost := core.TCPSchema + "127.0.0.1:12345"
+
+	provider, err := NewDockerProvider(WithDockerHost(remoteHost))
+	if err != nil {
+		t.Fatalf("NewDockerProvider() error = %v", err)
+	}
+	defer provider.Close()
+
+	if provider.host != remoteHost {
+		t.Errorf("NewDockerProvider() host = %v, want %v", provider.host, remoteHost)
+	}
+
+	if got := provider.client.DaemonHost(); got != remoteHost {
+		t.Errorf("NewDockerProvider() daemon host = %v, want %v", got, remoteHost)
+	}
+
+	if opts := reaperProviderOptions(provider); len(opts) != 1 {
+		t.Errorf("reaperProviderOptions() = %v, want the Docker host option", opts)
+	}
+}
+
+func TestWithDockerHost(t *testing.T) {
+	remoteHost := core.TCPSchema + "127.0.0.1:12345"
+
+	req := GenericContainerRequest{}
+	WithDockerHost(remoteHost).Customize(&req)
+	if req.DockerHost != remoteHost {
+		t.Errorf("WithDockerHost() = %v, want %v", req.DockerHost, remoteHost)
+	}
+
+	opts := &GenericProviderOptions{}
+	WithDockerHost(remoteHost).ApplyGenericTo(opts)
+	if opts.DockerHost != remoteHost {
+		t.Errorf("WithDockerHost() = %v, want %v", opts.DockerHost, remoteHost)
+	}
+}
+
+func TestDaemonHostIPv6(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerHost string
+		tcHost     string
+	}{
+		{name: "docker-host", dockerHost: core.TCPSchema + "[::1]:12345"},
+		{name: "tc-host", dockerHost: core.TCPSchema + "127.0.0.1:12345", tcHost: "[::1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tcHost != "" {
+				t.Setenv("TC_HOST", tt.tcHost)
+			}
+
+			provider, err := NewDockerProvider(WithDockerHost(tt.dockerHost))
+			if err != nil {
+				t.Fatalf("NewDockerProvider() error = %v", err)
+			}
+			defer provider.Close()
+
+			host, err := provider.DaemonHost(context.Background())
+			if err != nil {
+				t.Fatalf("DaemonHost() error = %v", err)
+			}
+
+			// the host is an IP address, to be enclosed in square brackets in the endpoints
+			if host != "::1" {
+				t.Errorf("DaemonHost() = %v, want ::1", host)
+			}
+		})
+	}
+}
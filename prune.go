@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// PruneReport represents the resources removed by Prune
+type PruneReport struct {
+	Containers []string // the IDs of the removed containers
+	Networks   []string // the IDs of the removed networks
+	Volumes    []string // the names of the removed volumes
+}
+
+// Prune removes the containers, networks and volumes created by Testcontainers matching all the labels,
+// e.g. the session labels added with WithLabels, regardless of the session that created them.
+// It's meant to remove the resources leaked by test runs when the reaper is disabled, e.g. in a CI janitor job.
+// The containers are removed first, with their anonymous volumes, so the networks and volumes are not in use.
+// If a resource cannot be removed, the others are still removed, and the errors are returned together.
+func Prune(ctx context.Context, labels map[string]string) (PruneReport, error) {
+	report := PruneReport{}
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer cli.Close()
+
+	// only the resources created by Testcontainers are removed, even without labels
+	args := filters.NewArgs(filters.Arg("label", core.LabelBase+"=true"))
+	for k, v := range labels {
+		args.Add("label", k+"="+v)
+	}
+
+	var errs []error
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return report, fmt.Errorf("error listing the containers: %w", err)
+	}
+
+	for _, c := range containers {
+		if err := cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			errs = append(errs, fmt.Errorf("error removing the container %s: %w", c.ID, err))
+			continue
+		}
+		report.Containers = append(report.Containers, c.ID)
+	}
+
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return report, errors.Join(append(errs, fmt.Errorf("error listing the networks: %w", err))...)
+	}
+
+	for _, n := range networks {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil {
+			errs = append(errs, fmt.Errorf("error removing the network %s: %w", n.Name, err))
+			continue
+		}
+		report.Networks = append(report.Networks, n.ID)
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return report, errors.Join(append(errs, fmt.Errorf("error listing the volumes: %w", err))...)
+	}
+
+	for _, v := range volumes.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil {
+			errs = append(errs, fmt.Errorf("error removing the volume %s: %w", v.Name, err))
+			continue
+		}
+		report.Volumes = append(report.Volumes, v.Name)
+	}
+
+	return report, errors.Join(errs...)
+}
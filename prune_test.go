@@ -0,0 +1,82 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// resetSessionLabels removes the session labels added by a test
+func resetSessionLabels(t *testing.T) {
+	t.Cleanup(func() {
+		sessionLabelsMx.Lock()
+		defer sessionLabelsMx.Unlock()
+
+		sessionLabels = map[string]string{}
+	})
+}
+
+func TestWithLabels(t *testing.T) {
+	resetSessionLabels(t)
+
+	WithLabels(map[string]string{"ci.job": "42", core.LabelLang: "java"})
+
+	labels := GenericLabels()
+	assert.Equal(t, "42", labels["ci.job"])
+	// the labels of Testcontainers cannot be overridden
+	assert.Equal(t, "go", labels[core.LabelLang])
+	assert.Equal(t, core.SessionID(), labels[core.LabelSessionID])
+}
+
+func TestPrune(t *testing.T) {
+	resetSessionLabels(t)
+	ctx := context.Background()
+
+	// withLabels {
+	runID := uuid.NewString()
+	WithLabels(map[string]string{"ci.run": runID})
+	// }
+
+	net, err := GenericNetwork(ctx, GenericNetworkRequest{
+		NetworkRequest: NetworkRequest{Name: "prune-" + runID},
+	})
+	require.NoError(t, err)
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:    nginxAlpineImage,
+			Networks: []string{"prune-" + runID},
+			Mounts:   Mounts(VolumeMount("prune-"+runID, "/data")),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	// prune {
+	report, err := Prune(ctx, map[string]string{"ci.run": runID})
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, []string{c.GetContainerID()}, report.Containers)
+	assert.Equal(t, []string{net.(*DockerNetwork).ID}, report.Networks)
+	assert.Equal(t, []string{"prune-" + runID}, report.Volumes)
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.ContainerInspect(ctx, c.GetContainerID())
+	assert.True(t, errdefs.IsNotFound(err), err)
+
+	// the resources of other test runs are kept
+	report, err = Prune(ctx, map[string]string{"ci.run": uuid.NewString()})
+	require.NoError(t, err)
+	assert.Empty(t, report.Containers)
+	assert.Empty(t, report.Networks)
+	assert.Empty(t, report.Volumes)
+}
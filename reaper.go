@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"net"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
 
@@ -36,6 +38,8 @@ var (
 	reaperInstance     *Reaper // We would like to create reaper only once
 	reaperMutex        sync.Mutex
 	reaperOnce         sync.Once
+	// remoteReapers are the reapers of the Docker hosts set with WithDockerHost, indexed by Docker host
+	remoteReapers = map[string]*Reaper{}
 )
 
 // ReaperProvider represents a provider for the reaper to run itself with
@@ -45,6 +49,82 @@ type ReaperProvider interface {
 	Config() TestcontainersConfig
 }
 
+// WithReaperImage returns a generic option that sets the image of the reaper, e.g. a copy of
+// the Ryuk image in a private registry, instead of the default one. It takes precedence over
+// the ryuk.container.image property and the TESTCONTAINERS_RYUK_CONTAINER_IMAGE environment variable.
+func WithReaperImage(image string) ReaperOption {
+	return ReaperOption{
+		apply: func(cfg *TestcontainersConfig) {
+			cfg.Config.RyukImage = image
+		},
+	}
+}
+
+// WithReaperPrivileged returns a generic option that sets whether the reaper runs as a privileged
+// container. It takes precedence over the ryuk.container.privileged property and the
+// TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED environment variable.
+func WithReaperPrivileged(privileged bool) ReaperOption {
+	return ReaperOption{
+		apply: func(cfg *TestcontainersConfig) {
+			cfg.RyukPrivileged = privileged
+			cfg.Config.RyukPrivileged = privileged
+		},
+	}
+}
+
+// WithReaperConnectionTimeout returns a generic option that sets how long the reaper waits for
+// the first connection of the test process before removing the resources of the session.
+func WithReaperConnectionTimeout(timeout time.Duration) ReaperOption {
+	return ReaperOption{
+		apply: func(cfg *TestcontainersConfig) {
+			cfg.Config.RyukConnectionTimeout = timeout
+		},
+	}
+}
+
+// WithReaperReconnectionTimeout returns a generic option that sets how long the reaper waits for
+// the test process to reconnect, once all its connections have been closed, before removing the
+// resources of the session.
+func WithReaperReconnectionTimeout(timeout time.Duration) ReaperOption {
+	return ReaperOption{
+		apply: func(cfg *TestcontainersConfig) {
+			cfg.Config.RyukReconnectionTimeout = timeout
+		},
+	}
+}
+
+// ReaperOption is a generic option that overrides a setting of the reaper.
+//
+// It can be used for providers and containers. As there is one reaper per test session and
+// Docker host, the settings apply only when the reaper is created, i.e. by the first container.
+type ReaperOption struct {
+	apply func(cfg *TestcontainersConfig)
+}
+
+// ApplyGenericTo implements GenericProviderOption.
+func (o ReaperOption) ApplyGenericTo(opts *GenericProviderOptions) {
+	opts.reaperOptions = append(opts.reaperOptions, o)
+}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o ReaperOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.reaperOptions = append(opts.reaperOptions, o)
+}
+
+// Customize implements ContainerCustomizer.
+func (o ReaperOption) Customize(req *GenericContainerRequest) {
+	req.ReaperOptions = append(req.ReaperOptions, o)
+}
+
+// reaperImage returns the image of the reaper, which is the one set in the configuration, if any
+func reaperImage(cfg config.Config) string {
+	if cfg.RyukImage != "" {
+		return cfg.RyukImage
+	}
+
+	return config.ReaperDefaultImage
+}
+
 // NewReaper creates a Reaper with a sessionID to identify containers and a provider to use
 // Deprecated: it's not possible to create a reaper anymore.
 func NewReaper(ctx context.Context, sessionID string, provider ReaperProvider, reaperImageName string) (*Reaper, error) {
@@ -63,10 +143,21 @@ func reaperContainerNameFromSessionID(sessionID string) string {
 // it's found in the running state, and including the labels for sessionID, reaper, and ryuk.
 // It will perform a retry with exponential backoff to allow for the container to be started and
 // avoid potential false negatives.
-func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContainer, error) {
-	dockerClient, err := NewDockerClientWithOpts(ctx)
-	if err != nil {
-		return nil, err
+// The options of the provider select the Docker daemon where to look for it, the one of the environment by default.
+func lookUpReaperContainer(ctx context.Context, sessionID string, opts ...DockerProviderOption) (*DockerContainer, error) {
+	var dockerClient client.APIClient
+	if len(opts) > 0 {
+		provider, err := NewDockerProvider(opts...)
+		if err != nil {
+			return nil, err
+		}
+		dockerClient = provider.client
+	} else {
+		cli, err := NewDockerClientWithOpts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dockerClient = cli
 	}
 	defer dockerClient.Close()
 
@@ -86,7 +177,7 @@ func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContai
 	exp.MaxElapsedTime = 1 * time.Minute // max time to keep trying
 
 	var reaperContainer *DockerContainer
-	err = backoff.Retry(func() error {
+	err := backoff.Retry(func() error {
 		args := []filters.KeyValuePair{
 			filters.Arg("label", fmt.Sprintf("%s=%s", core.LabelSessionID, sessionID)),
 			filters.Arg("label", fmt.Sprintf("%s=%t", core.LabelReaper, true)),
@@ -111,7 +202,7 @@ func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContai
 			return fmt.Errorf("not possible to have multiple reaper containers found for session ID %s", sessionID)
 		}
 
-		r, err := containerFromDockerResponse(ctx, resp[0])
+		r, err := containerFromDockerResponse(ctx, resp[0], opts...)
 		if err != nil {
 			return err
 		}
@@ -130,6 +221,10 @@ func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContai
 // reuseOrCreateReaper returns an existing Reaper instance if it exists and is running. Otherwise, a new Reaper instance
 // will be created with a sessionID to identify containers in the same test session/program.
 func reuseOrCreateReaper(ctx context.Context, sessionID string, provider ReaperProvider) (*Reaper, error) {
+	if p, ok := provider.(*DockerProvider); ok && p.DockerHost != "" {
+		return reuseOrCreateRemoteReaper(ctx, sessionID, p)
+	}
+
 	reaperMutex.Lock()
 	defer reaperMutex.Unlock()
 
@@ -156,7 +251,7 @@ func reuseOrCreateReaper(ctx context.Context, sessionID string, provider ReaperP
 	reaperContainer, err := lookUpReaperContainer(context.Background(), sessionID)
 	if err == nil && reaperContainer != nil {
 		// The reaper container exists as a Docker container: re-use it
-		Logger.Printf("🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID)
+		logAt(ctx, Logger, slog.LevelInfo, fmt.Sprintf("🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID), LogKeyContainerID, reaperContainer.shortID(), LogKeySessionID, sessionID)
 		reaperInstance, err = reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
 		if err != nil {
 			return nil, err
@@ -184,6 +279,56 @@ func reuseOrCreateReaper(ctx context.Context, sessionID string, provider ReaperP
 	return reaperInstance, nil
 }
 
+// reuseOrCreateRemoteReaper returns the Reaper of the Docker host of the provider, set with WithDockerHost,
+// if it exists and is running. Otherwise, it looks for the reaper container of the session in that Docker daemon,
+// creating it if it does not exist. Each Docker host has its own Reaper, removing the resources of the session
+// in its daemon.
+func reuseOrCreateRemoteReaper(ctx context.Context, sessionID string, provider *DockerProvider) (*Reaper, error) {
+	reaperMutex.Lock()
+	defer reaperMutex.Unlock()
+
+	dockerHost := provider.DockerHost
+
+	if r, ok := remoteReapers[dockerHost]; ok {
+		state, err := r.container.State(ctx)
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				return nil, err
+			}
+		} else if state.Running {
+			return r, nil
+		}
+		// else: the reaper has been terminated, so we need to create a new one
+		delete(remoteReapers, dockerHost)
+	}
+
+	var r *Reaper
+	reaperContainer, err := lookUpReaperContainer(context.Background(), sessionID, WithDockerHost(dockerHost))
+	if err == nil && reaperContainer != nil {
+		logAt(ctx, Logger, slog.LevelInfo, fmt.Sprintf("🔥 Reaper obtained from Docker host %s for this test session %s", dockerHost, reaperContainer.ID), LogKeyContainerID, reaperContainer.shortID(), LogKeySessionID, sessionID)
+		r, err = reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
+	} else {
+		r, err = newReaper(ctx, sessionID, provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remoteReapers[dockerHost] = r
+
+	return r, nil
+}
+
+// reaperProviderOptions returns the options of the provider selecting the Docker daemon of the reaper,
+// which is the Docker host set with WithDockerHost, if any
+func reaperProviderOptions(provider ReaperProvider) []DockerProviderOption {
+	if p, ok := provider.(*DockerProvider); ok && p.DockerHost != "" {
+		return []DockerProviderOption{WithDockerHost(p.DockerHost)}
+	}
+
+	return nil
+}
+
 // reuseReaperContainer constructs a Reaper from an already running reaper
 // DockerContainer.
 func reuseReaperContainer(ctx context.Context, sessionID string, provider ReaperProvider, reaperContainer *DockerContainer) (*Reaper, error) {
@@ -203,6 +348,10 @@ func reuseReaperContainer(ctx context.Context, sessionID string, provider Reaper
 // provider to use. Do not call this directly, use reuseOrCreateReaper instead.
 func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (*Reaper, error) {
 	dockerHostMount := core.ExtractDockerSocket(ctx)
+	if p, ok := provider.(*DockerProvider); ok && p.DockerHost != "" {
+		// the socket is the one of the remote Docker daemon, not the one of the environment
+		dockerHostMount = core.DockerSocketFromHost(p.DockerHost)
+	}
 
 	reaper := &Reaper{
 		Provider:  provider,
@@ -214,7 +363,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 	tcConfig := provider.Config().Config
 
 	req := ContainerRequest{
-		Image:        config.ReaperDefaultImage,
+		Image:        reaperImage(tcConfig),
 		ExposedPorts: []string{string(listeningPort)},
 		Labels:       core.DefaultLabels(sessionID),
 		Privileged:   tcConfig.RyukPrivileged,
@@ -264,7 +413,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 			start := time.Now()
 			var reaperContainer *DockerContainer
 			for time.Since(start) < timeout {
-				reaperContainer, err = lookUpReaperContainer(ctx, sessionID)
+				reaperContainer, err = lookUpReaperContainer(ctx, sessionID, reaperProviderOptions(provider)...)
 				if err == nil && reaperContainer != nil {
 					break
 				}
@@ -283,7 +432,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 			if reaperContainer == nil {
 				return nil, fmt.Errorf("look up reaper container returned nil although creation failed due to name conflict")
 			}
-			Logger.Printf("🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID)
+			logAt(ctx, Logger, slog.LevelInfo, fmt.Sprintf("🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID), LogKeyContainerID, reaperContainer.shortID(), LogKeySessionID, sessionID)
 			reaper, err := reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
 			if err != nil {
 				return nil, err
@@ -311,13 +460,20 @@ type Reaper struct {
 	container Container
 }
 
-// Connect runs a goroutine which can be terminated by sending true into the returned channel
+// Connect runs a goroutine which can be terminated by sending true into the returned channel.
+// When the connection to Ryuk drops before the termination, it logs the endpoint, the session and the
+// reconnection timeout, as Ryuk removes the resources of the session once that timeout elapses.
 func (r *Reaper) Connect() (chan bool, error) {
 	conn, err := net.DialTimeout("tcp", r.Endpoint, 10*time.Second)
 	if err != nil {
 		return nil, fmt.Errorf("%w: Connecting to Ryuk on %s failed", err, r.Endpoint)
 	}
 
+	var tcConfig config.Config
+	if r.Provider != nil {
+		tcConfig = r.Provider.Config().Config
+	}
+
 	terminationSignal := make(chan bool)
 	go func(conn net.Conn) {
 		sock := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
@@ -328,35 +484,61 @@ func (r *Reaper) Connect() (chan bool, error) {
 			labelFilters = append(labelFilters, fmt.Sprintf("label=%s=%s", l, v))
 		}
 
-		retryLimit := 3
-		for retryLimit > 0 {
-			retryLimit--
-
-			if _, err := sock.WriteString(strings.Join(labelFilters, "&")); err != nil {
-				continue
+		const maxAttempts = 3
+		acked := false
+		var lastErr error
+		for attempt := 1; attempt <= maxAttempts && !acked; attempt++ {
+			lastErr = r.sendFilters(sock, labelFilters)
+			acked = lastErr == nil
+			if !acked && tcConfig.RyukVerbose {
+				logAt(context.Background(), Logger, slog.LevelWarn, fmt.Sprintf("🔥 Ryuk did not acknowledge the filters: endpoint=%s session=%s attempt=%d/%d err=%v", r.Endpoint, r.SessionID, attempt, maxAttempts, lastErr), LogKeySessionID, r.SessionID)
 			}
+		}
+		if !acked {
+			logAt(context.Background(), Logger, slog.LevelError, fmt.Sprintf("🔥 Ryuk did not acknowledge the filters, the resources of the session may not be removed: endpoint=%s session=%s attempts=%d err=%v", r.Endpoint, r.SessionID, maxAttempts, lastErr), LogKeySessionID, r.SessionID)
+		}
 
-			if _, err := sock.WriteString("\n"); err != nil {
-				continue
-			}
+		dropped := make(chan error, 1)
+		go func() {
+			// Ryuk does not write anything else, so the read returns only when the connection is closed
+			_, err := sock.ReadString('\n')
+			dropped <- err
+		}()
+
+		select {
+		case <-terminationSignal:
+		case err := <-dropped:
+			logAt(context.Background(), Logger, slog.LevelWarn, fmt.Sprintf("🔥 Connection to Ryuk dropped, the resources of the session are removed once the reconnection timeout elapses: endpoint=%s session=%s reconnection_timeout=%s err=%v", r.Endpoint, r.SessionID, tcConfig.RyukReconnectionTimeout, err), LogKeySessionID, r.SessionID)
+			<-terminationSignal
+		}
+	}(conn)
+	return terminationSignal, nil
+}
 
-			if err := sock.Flush(); err != nil {
-				continue
-			}
+// sendFilters sends the label filters of the session to Ryuk, returning an error if Ryuk does not acknowledge them
+func (r *Reaper) sendFilters(sock *bufio.ReadWriter, labelFilters []string) error {
+	if _, err := sock.WriteString(strings.Join(labelFilters, "&")); err != nil {
+		return err
+	}
 
-			resp, err := sock.ReadString('\n')
-			if err != nil {
-				continue
-			}
+	if _, err := sock.WriteString("\n"); err != nil {
+		return err
+	}
 
-			if resp == "ACK\n" {
-				break
-			}
-		}
+	if err := sock.Flush(); err != nil {
+		return err
+	}
 
-		<-terminationSignal
-	}(conn)
-	return terminationSignal, nil
+	resp, err := sock.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	if resp != "ACK\n" {
+		return fmt.Errorf("unexpected response %q", resp)
+	}
+
+	return nil
 }
 
 // Labels returns the container labels to use so that this Reaper cleans them up
@@ -625,3 +625,33 @@ func TestReaper_ReuseRunning(t *testing.T) {
 		assert.Equal(t, firstContainerID, containerID, "call %d should have returned same container id", i)
 	}
 }
+
+func TestReaperOptions(t *testing.T) {
+	cfg := TestcontainersConfig{}
+	require.Equal(t, config.ReaperDefaultImage, reaperImage(cfg.Config))
+
+	opts := &GenericProviderOptions{}
+	for _, o := range []GenericProviderOption{
+		WithReaperImage("registry.mycompany.com/testcontainers/ryuk:0.7.0"),
+		WithReaperPrivileged(true),
+		WithReaperConnectionTimeout(2 * time.Minute),
+		WithReaperReconnectionTimeout(30 * time.Second),
+	} {
+		o.ApplyGenericTo(opts)
+	}
+	require.Len(t, opts.reaperOptions, 4)
+
+	for _, o := range opts.reaperOptions {
+		o.apply(&cfg)
+	}
+
+	assert.Equal(t, "registry.mycompany.com/testcontainers/ryuk:0.7.0", reaperImage(cfg.Config))
+	assert.True(t, cfg.RyukPrivileged)
+	assert.True(t, cfg.Config.RyukPrivileged)
+	assert.Equal(t, 2*time.Minute, cfg.Config.RyukConnectionTimeout)
+	assert.Equal(t, 30*time.Second, cfg.Config.RyukReconnectionTimeout)
+
+	req := GenericContainerRequest{}
+	WithReaperImage("mirror.local/testcontainers/ryuk:0.7.0").Customize(&req)
+	require.Len(t, req.ReaperOptions, 1)
+}
@@ -60,10 +60,11 @@ function bumpVersion() {
       module="${module%?}" # remove trailing slash
       module_mod_file="${module}/go.mod" # e.g. modules/mongodb/go.mod
       if [[ "${DRY_RUN}" == "true" ]]; then
-        echo "sed \"s/testcontainers-go v.*/testcontainers-go v${versionToBumpWithoutV}/g\" ${module_mod_file} > ${module_mod_file}.tmp"
+        echo "sed -e \"s/testcontainers-go v.*/testcontainers-go v${versionToBumpWithoutV}/g\" -e \"s/testcontainers-go\/modules\/compose v.*/testcontainers-go\/modules\/compose v${versionToBumpWithoutV}/g\" ${module_mod_file} > ${module_mod_file}.tmp"
         echo "mv ${module_mod_file}.tmp ${module_mod_file}"
       else
-        sed "s/testcontainers-go v.*/testcontainers-go v${versionToBumpWithoutV}/g" ${module_mod_file} > ${module_mod_file}.tmp
+        # the modules built on the compose module depend on it too
+        sed -e "s/testcontainers-go v.*/testcontainers-go v${versionToBumpWithoutV}/g" -e "s/testcontainers-go\/modules\/compose v.*/testcontainers-go\/modules\/compose v${versionToBumpWithoutV}/g" ${module_mod_file} > ${module_mod_file}.tmp
         mv ${module_mod_file}.tmp ${module_mod_file}
       fi
     done
@@ -23,6 +23,62 @@ func ExtractDockerSocket() string {
 	return core.ExtractDockerSocket(context.Background())
 }
 
+// DaemonHost returns the endpoint of the Docker daemon used by Testcontainers, e.g. unix:///var/run/docker.sock
+// or tcp://my.docker.host:2375, resolved with the Docker host resolution chain and cached for the whole test session.
+// The steps of the default chain are, in order:
+//
+//  1. Docker host from the "tc.host" property in the ~/.testcontainers.properties file.
+//  2. DOCKER_HOST environment variable.
+//  3. Docker host from the Go context, used internally to pass the Docker host to the resource reaper.
+//  4. Docker host from the "docker.host" property in the ~/.testcontainers.properties file.
+//  5. Docker host of the Docker context, selected with the "docker.context" property, or the current one of the Docker CLI.
+//  6. Rootless Docker socket, e.g. $XDG_RUNTIME_DIR/docker.sock.
+//  7. Default Docker socket, if it exists.
+//  8. Else, the default Docker socket including schema is returned.
+//
+// Use SetDockerHostStrategies to customise the chain.
+func DaemonHost() string {
+	return core.ExtractDockerHost(context.Background())
+}
+
+// DockerHostStrategy is a step of the Docker host resolution chain, returning the Docker host,
+// or an error if it cannot be resolved by the step, so the next step in the chain is tried.
+type DockerHostStrategy = core.DockerHostStrategy
+
+// The steps of the default Docker host resolution chain, which can be combined with custom steps
+// using SetDockerHostStrategies.
+var (
+	// TestcontainersHostFromProperties resolves the Docker host from the "tc.host" property in the ~/.testcontainers.properties file
+	TestcontainersHostFromProperties = core.TestcontainersHostFromPropertiesStrategy
+	// DockerHostFromEnv resolves the Docker host from the DOCKER_HOST environment variable
+	DockerHostFromEnv = core.DockerHostFromEnvStrategy
+	// DockerHostFromContext resolves the Docker host from the Go context
+	DockerHostFromContext = core.DockerHostFromContextStrategy
+	// DockerHostFromProperties resolves the Docker host from the "docker.host" property in the ~/.testcontainers.properties file
+	DockerHostFromProperties = core.DockerHostFromPropertiesStrategy
+	// DockerContext resolves the Docker host from the endpoint of the Docker context, selected with the "docker.context"
+	// property or the current one of the Docker CLI
+	DockerContext = core.DockerContextStrategy
+	// RootlessDockerSocket resolves the Docker host from the socket of a rootless Docker, if it exists
+	RootlessDockerSocket = core.RootlessDockerSocketStrategy
+	// DefaultDockerSocket resolves the Docker host from the default Docker socket, if it exists
+	DefaultDockerSocket = core.DefaultDockerSocketStrategy
+)
+
+// DefaultDockerHostStrategies returns the steps of the default Docker host resolution chain, in order.
+func DefaultDockerHostStrategies() []DockerHostStrategy {
+	return core.DefaultDockerHostStrategies()
+}
+
+// SetDockerHostStrategies replaces the steps of the Docker host resolution chain, e.g. to add a step
+// for a custom environment before the default ones, discarding the Docker host resolved before.
+// It must be called before creating any container, e.g. in TestMain. If no steps are passed,
+// the default chain is restored. The steps must not call back into the Docker host resolution,
+// e.g. with DaemonHost or ExtractDockerSocket, as they are part of it.
+func SetDockerHostStrategies(strategies ...DockerHostStrategy) {
+	core.SetDockerHostStrategies(strategies...)
+}
+
 // SessionID returns a unique session ID for the current test session. Because each Go package
 // will be run in a separate process, we need a way to identify the current test session.
 // By test session, we mean:
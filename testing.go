@@ -2,8 +2,15 @@ package testcontainers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
 )
 
 // SkipIfProviderIsNotHealthy is a utility function capable of skipping tests
@@ -40,6 +47,223 @@ func SkipIfDockerDesktop(t *testing.T, ctx context.Context) {
 	}
 }
 
+// RequireCapability is a utility function capable of skipping tests if the Docker daemon
+// doesn't provide all the given capabilities, e.g. RequireCapability(t, CapabilityGPU).
+// The tests are skipped too if the Docker daemon is not running.
+func RequireCapability(t testing.TB, capabilities ...Capability) {
+	t.Helper()
+
+	info, err := DaemonInfo(context.Background())
+	if err != nil {
+		t.Skipf("Docker is not running. TestContainers can't perform is work without it: %s", err)
+	}
+
+	for _, capability := range capabilities {
+		if !info.Has(capability) {
+			t.Skipf("Skipping test that requires the %q capability of the Docker daemon", capability)
+		}
+	}
+}
+
+// Run starts the container of the request for the test, failing the test if it cannot be started,
+// and terminates it when the test completes, with t.Cleanup. It replaces the boilerplate of the tests:
+//   - if the container fails to start, its logs are written with t.Log before failing the test.
+//   - the test fails if the container cannot be terminated, or if it still exists afterwards, i.e. it leaks.
+//
+// The container is started even if the request is not marked as Started.
+func Run(t testing.TB, req GenericContainerRequest) Container {
+	t.Helper()
+
+	req.Started = true
+
+	return runForTest(t, func(ctx context.Context) (Container, error) {
+		return GenericContainer(ctx, req)
+	})
+}
+
+// RunModule starts the container of a module for the test with its RunContainer function and options,
+// as Run does, e.g.:
+//
+//	pg := testcontainers.RunModule(t, postgres.RunContainer, postgres.WithDatabase("app"))
+//
+// The modules return no container when it fails to start, so the logs written with t.Log
+// are the ones included in the error, e.g. in a TimeoutError.
+func RunModule[T Container](t testing.TB, run func(context.Context, ...ContainerCustomizer) (T, error), opts ...ContainerCustomizer) T {
+	t.Helper()
+
+	var ctr T
+	runForTest(t, func(ctx context.Context) (Container, error) {
+		c, err := run(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		ctr = c
+		return c, nil
+	})
+
+	return ctr
+}
+
+// runForTest runs the container for the test, registering its termination with t.Cleanup
+func runForTest(t testing.TB, run func(ctx context.Context) (Container, error)) Container {
+	t.Helper()
+
+	ctx := context.Background()
+
+	c, err := run(ctx)
+	if err != nil {
+		logStartupFailure(t, c, err)
+		if c != nil {
+			// the container is not returned, so it's removed at once
+			if terr := c.Terminate(ctx); terr != nil {
+				t.Logf("failed to terminate the container: %s", terr)
+			}
+		}
+
+		t.Fatalf("failed to start the container: %s", err)
+	}
+
+	t.Cleanup(func() {
+		terminateForTest(t, c)
+	})
+
+	return c
+}
+
+// logStartupFailure writes the logs of the container which failed to start with t.Log,
+// unless they are already included in the error
+func logStartupFailure(t testing.TB, c Container, err error) {
+	t.Helper()
+
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) || c == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	logs, lerr := c.Logs(ctx)
+	if lerr != nil {
+		t.Logf("failed to get the logs of the container: %s", lerr)
+		return
+	}
+	defer logs.Close()
+
+	b, lerr := io.ReadAll(logs)
+	if lerr != nil {
+		t.Logf("failed to read the logs of the container: %s", lerr)
+		return
+	}
+
+	t.Logf("logs of the container %s:\n%s", c.GetContainerID(), b)
+}
+
+// terminateForTest terminates the container at the end of the test, failing it if the container
+// cannot be terminated or still exists afterwards
+func terminateForTest(t testing.TB, c Container) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if err := c.Terminate(ctx); err != nil {
+		t.Errorf("failed to terminate the container %s: %s", c.GetContainerID(), err)
+		return
+	}
+
+	_, err := c.State(ctx)
+	switch {
+	case err == nil:
+		t.Errorf("leaked container %s: it still exists after being terminated", c.GetContainerID())
+	case !errors.Is(err, ErrContainerNotFound):
+		t.Errorf("failed to check the removal of the container %s: %s", c.GetContainerID(), err)
+	}
+}
+
+// EnvSetter sets environment variables, e.g. a *testing.T, which restores them when the test completes.
+// It allows the modules to export the connection info of their containers without importing the testing package.
+type EnvSetter interface {
+	Setenv(key, value string)
+}
+
+// SetEnvOnT exports the connection info of the container as environment variables, using t.Setenv,
+// so that code configured only through environment variables can be pointed at the container.
+// The variables are restored when the test completes. Given the "DB" prefix, it exports:
+//   - DB_HOST: the host where the ports of the container are exposed.
+//   - DB_PORT: the mapped port of the lowest exposed port of the container.
+//   - DB_PORT_<port>: the mapped port of each TCP exposed port, e.g. DB_PORT_5432,
+//     and DB_PORT_<port>_UDP for the UDP ones.
+//
+// Modules add the credentials of the container to them, e.g. DB_USER and DB_PASSWORD.
+// As t.Setenv, it cannot be used in parallel tests.
+func (c *DockerContainer) SetEnvOnT(t testing.TB, prefix string) {
+	t.Helper()
+
+	if err := c.SetEnv(context.Background(), t, prefix); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// SetEnv exports the connection info of the container as environment variables with the given setter,
+// as SetEnvOnT does, returning an error if it cannot be retrieved.
+func (c *DockerContainer) SetEnv(ctx context.Context, env EnvSetter, prefix string) error {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the host of the container: %w", err)
+	}
+	env.Setenv(EnvName(prefix, "HOST"), host)
+
+	ports, err := c.Ports(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the ports of the container: %w", err)
+	}
+
+	exposedPorts := make([]nat.Port, 0, len(ports))
+	for port, bindings := range ports {
+		if len(bindings) > 0 {
+			exposedPorts = append(exposedPorts, port)
+		}
+	}
+	sort.Slice(exposedPorts, func(i, j int) bool {
+		if exposedPorts[i].Int() != exposedPorts[j].Int() {
+			return exposedPorts[i].Int() < exposedPorts[j].Int()
+		}
+		return exposedPorts[i].Proto() < exposedPorts[j].Proto()
+	})
+
+	for i, port := range exposedPorts {
+		mappedPort, err := c.MappedPort(ctx, port)
+		if err != nil {
+			return fmt.Errorf("failed to get the mapped port of %s: %w", port, err)
+		}
+
+		if i == 0 {
+			env.Setenv(EnvName(prefix, "PORT"), mappedPort.Port())
+		}
+
+		name := EnvName(prefix, "PORT", port.Port())
+		if port.Proto() != "tcp" {
+			name = EnvName(name, port.Proto())
+		}
+		env.Setenv(name, mappedPort.Port())
+	}
+
+	return nil
+}
+
+// EnvName returns the name of an environment variable, joining the prefix and the parts
+// with underscores, in upper case, e.g. EnvName("db", "port") returns DB_PORT.
+// An empty prefix is ignored.
+func EnvName(prefix string, parts ...string) string {
+	name := strings.Join(parts, "_")
+	if prefix = strings.TrimSuffix(prefix, "_"); prefix != "" {
+		name = prefix + "_" + name
+	}
+
+	return strings.ToUpper(name)
+}
+
 // exampleLogConsumer {
 
 // StdoutLogConsumer is a LogConsumer that prints the log to stdout
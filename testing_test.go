@@ -1,7 +1,146 @@
 package testcontainers
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
 
 func ExampleSkipIfProviderIsNotHealthy() {
 	SkipIfProviderIsNotHealthy(&testing.T{})
 }
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "DB_PORT", EnvName("db", "port"))
+	assert.Equal(t, "DB_PORT_5432", EnvName("DB_", "PORT", "5432"))
+	assert.Equal(t, "PORT", EnvName("", "PORT"))
+}
+
+func TestSetEnvOnT(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"8125/udp", nginxDefaultPort},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	host, err := c.Host(ctx)
+	require.NoError(t, err)
+	httpPort, err := c.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	udpPort, err := c.MappedPort(ctx, "8125/udp")
+	require.NoError(t, err)
+
+	t.Run("scoped-to-test", func(t *testing.T) {
+		// setEnvOnT {
+		c.(*DockerContainer).SetEnvOnT(t, "NGINX")
+		// }
+
+		assert.Equal(t, host, os.Getenv("NGINX_HOST"))
+		assert.Equal(t, httpPort.Port(), os.Getenv("NGINX_PORT"))
+		assert.Equal(t, httpPort.Port(), os.Getenv("NGINX_PORT_80"))
+		assert.Equal(t, udpPort.Port(), os.Getenv("NGINX_PORT_8125_UDP"))
+	})
+
+	_, ok := os.LookupEnv("NGINX_HOST")
+	assert.False(t, ok, "the environment variables must be restored after the test")
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	var c Container
+	t.Run("scoped-to-test", func(t *testing.T) {
+		// runForTest {
+		c = Run(t, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+				WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+			},
+		})
+		// }
+
+		assert.True(t, c.IsRunning())
+	})
+
+	_, err := c.State(ctx)
+	require.ErrorIs(t, err, ErrContainerNotFound, "the container must be terminated after the test")
+}
+
+func TestRun_startupFailure(t *testing.T) {
+	lt := &leakT{}
+
+	// the fake test panics on Fatalf
+	assert.Panics(t, func() {
+		Run(lt, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      nginxAlpineImage,
+				Cmd:        []string{"sh", "-c", "echo failed to start; exit 1"},
+				WaitingFor: wait.ForLog("never logged"),
+			},
+		})
+	})
+
+	require.Len(t, lt.logs, 1)
+	assert.Contains(t, lt.logs[0], "failed to start")
+	assert.Empty(t, lt.cleanups)
+}
+
+func TestRunModule(t *testing.T) {
+	t.Run("started", func(t *testing.T) {
+		lt := &leakT{}
+
+		run := func(ctx context.Context, opts ...ContainerCustomizer) (*DockerContainer, error) {
+			req := GenericContainerRequest{
+				Started: true,
+			}
+			for _, opt := range opts {
+				opt.Customize(&req)
+			}
+
+			c, err := GenericContainer(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			return c.(*DockerContainer), nil
+		}
+
+		// the image is set by the option
+		c := RunModule(lt, run, WithImage(nginxAlpineImage))
+		require.NotNil(t, c)
+		assert.True(t, c.IsRunning())
+
+		lt.runCleanups()
+		assert.Empty(t, lt.errors)
+
+		_, err := c.State(context.Background())
+		require.ErrorIs(t, err, ErrContainerNotFound)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		lt := &leakT{}
+
+		run := func(ctx context.Context, opts ...ContainerCustomizer) (*DockerContainer, error) {
+			return nil, errors.New("no image")
+		}
+
+		assert.PanicsWithValue(t, "failed to start the container: no image", func() {
+			RunModule(lt, run)
+		})
+		assert.Empty(t, lt.logs)
+		assert.Empty(t, lt.cleanups)
+	})
+}
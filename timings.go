@@ -0,0 +1,231 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Compiler check to ensure that TimingReporter implements the ContainerCustomizer interface.
+var _ ContainerCustomizer = (*TimingReporter)(nil)
+
+// ContainerTiming represents the time spent in each step of the lifecycle of a container
+type ContainerTiming struct {
+	Image       string        // the image of the container
+	ContainerID string        // the ID of the container, empty if it was not created
+	Create      time.Duration // creating the container, once its image is pulled or built
+	Start       time.Duration // starting the container
+	Wait        time.Duration // waiting for the container to be ready, with its wait strategy
+	Terminate   time.Duration // terminating the container, zero if it was not terminated
+	Attempts    int           // the readiness attempts of the wait strategy, zero if it has no wait strategy
+}
+
+// Startup returns the time spent until the container was ready
+func (t ContainerTiming) Startup() time.Duration {
+	return t.Create + t.Start + t.Wait
+}
+
+// TimingReporter records the timings of the containers it's passed to as an option, so they can be written,
+// at the end of the test suite, as a JUnit XML report or a CSV file, e.g. to trend in the CI dashboards
+// which images are slowing the suite down. It's safe to use it in parallel tests.
+type TimingReporter struct {
+	mx      sync.Mutex
+	timings []*ContainerTiming
+}
+
+// NewTimingReporter returns a TimingReporter without timings
+func NewTimingReporter() *TimingReporter {
+	return &TimingReporter{}
+}
+
+// Customize adds the lifecycle hooks recording the timings of the container to the request
+func (r *TimingReporter) Customize(req *GenericContainerRequest) {
+	timing := &ContainerTiming{}
+
+	var last time.Time
+	// elapsed returns the time since the last step of the lifecycle, starting a new step
+	elapsed := func() time.Duration {
+		now := time.Now()
+		d := now.Sub(last)
+		last = now
+		return d
+	}
+
+	req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+		PreCreates: []ContainerRequestHook{
+			func(ctx context.Context, req ContainerRequest) error {
+				last = time.Now()
+				timing.Image = req.Image
+				r.add(timing)
+				return nil
+			},
+		},
+		PostCreates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				r.update(func() {
+					timing.ContainerID = c.GetContainerID()
+					timing.Create = elapsed()
+					// the image built from a Dockerfile is only known once the container is created
+					if dc, ok := c.(*DockerContainer); ok && dc.Image != "" {
+						timing.Image = dc.Image
+					}
+				})
+				return nil
+			},
+		},
+		PreStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				elapsed()
+				return nil
+			},
+		},
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				r.update(func() { timing.Start = elapsed() })
+				return nil
+			},
+		},
+		PostReadies: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				r.update(func() {
+					timing.Wait = elapsed()
+					if dc, ok := c.(*DockerContainer); ok {
+						timing.Attempts = dc.waitAttempts
+					}
+				})
+				return nil
+			},
+		},
+		PreTerminates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				elapsed()
+				return nil
+			},
+		},
+		PostTerminates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				r.update(func() { timing.Terminate = elapsed() })
+				return nil
+			},
+		},
+	})
+}
+
+// Timings returns the timings of the containers, in the order they were created
+func (r *TimingReporter) Timings() []ContainerTiming {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	timings := make([]ContainerTiming, 0, len(r.timings))
+	for _, t := range r.timings {
+		timings = append(timings, *t)
+	}
+
+	return timings
+}
+
+// WriteJUnit writes the timings as a JUnit XML report, with a test suite named "testcontainers", and a test case
+// for each container, named after its image, whose time is the time spent until it was ready. The time spent
+// in each step of its lifecycle, in seconds, its ID, and the readiness attempts of its wait strategy, are written as properties of the test case.
+func (r *TimingReporter) WriteJUnit(w io.Writer) error {
+	type property struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+	}
+
+	type testCase struct {
+		ClassName  string     `xml:"classname,attr"`
+		Name       string     `xml:"name,attr"`
+		Time       string     `xml:"time,attr"`
+		Properties []property `xml:"properties>property"`
+	}
+
+	type testSuite struct {
+		XMLName   xml.Name   `xml:"testsuite"`
+		Name      string     `xml:"name,attr"`
+		Tests     int        `xml:"tests,attr"`
+		Time      string     `xml:"time,attr"`
+		TestCases []testCase `xml:"testcase"`
+	}
+
+	timings := r.Timings()
+
+	suite := testSuite{Name: "testcontainers", Tests: len(timings)}
+	var total time.Duration
+	for _, t := range timings {
+		total += t.Startup() + t.Terminate
+		suite.TestCases = append(suite.TestCases, testCase{
+			ClassName: "testcontainers",
+			Name:      t.Image,
+			Time:      seconds(t.Startup()),
+			Properties: []property{
+				{Name: "testcontainers.container.id", Value: t.ContainerID},
+				{Name: "testcontainers.create", Value: seconds(t.Create)},
+				{Name: "testcontainers.start", Value: seconds(t.Start)},
+				{Name: "testcontainers.wait", Value: seconds(t.Wait)},
+				{Name: "testcontainers.terminate", Value: seconds(t.Terminate)},
+				{Name: "testcontainers.wait.attempts", Value: strconv.Itoa(t.Attempts)},
+			},
+		})
+	}
+	suite.Time = seconds(total)
+
+	report := struct {
+		XMLName xml.Name    `xml:"testsuites"`
+		Suites  []testSuite `xml:"testsuite"`
+	}{Suites: []testSuite{suite}}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("error writing the JUnit report: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("error writing the JUnit report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the timings as CSV, with a header and a row for each container, with its image, its ID,
+// the time spent in each step of its lifecycle, in seconds, and the readiness attempts of its wait strategy.
+func (r *TimingReporter) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	records := [][]string{{"image", "container_id", "create", "start", "wait", "terminate", "wait_attempts"}}
+	for _, t := range r.Timings() {
+		records = append(records, []string{t.Image, t.ContainerID, seconds(t.Create), seconds(t.Start), seconds(t.Wait), seconds(t.Terminate), strconv.Itoa(t.Attempts)})
+	}
+
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("error writing the CSV report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TimingReporter) add(timing *ContainerTiming) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.timings = append(r.timings, timing)
+}
+
+// update modifies a timing, under the lock of the reporter, as the timings can be read concurrently
+func (r *TimingReporter) update(fn func()) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	fn()
+}
+
+// seconds formats the duration as seconds, with millisecond precision, as JUnit does
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,149 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestTimingReporter(t *testing.T) {
+	ctx := context.Background()
+
+	// timingReporter {
+	reporter := NewTimingReporter()
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	}
+	// the reporter is an option, which can also be passed to the RunContainer function of the modules
+	reporter.Customize(&req)
+
+	c, err := GenericContainer(ctx, req)
+	// }
+	require.NoError(t, err)
+	require.NoError(t, c.Terminate(ctx))
+
+	timings := reporter.Timings()
+	require.Len(t, timings, 1)
+	assert.Equal(t, nginxAlpineImage, timings[0].Image)
+	assert.Equal(t, c.GetContainerID(), timings[0].ContainerID)
+	assert.Positive(t, timings[0].Create)
+	assert.Positive(t, timings[0].Start)
+	assert.Positive(t, timings[0].Wait)
+	assert.Positive(t, timings[0].Terminate)
+	assert.Positive(t, timings[0].Attempts)
+	assert.Equal(t, timings[0].Create+timings[0].Start+timings[0].Wait, timings[0].Startup())
+}
+
+func TestTimingReporter_Write(t *testing.T) {
+	reporter := NewTimingReporter()
+	reporter.add(&ContainerTiming{
+		Image:       "nginx:alpine",
+		ContainerID: "abc",
+		Create:      100 * time.Millisecond,
+		Start:       200 * time.Millisecond,
+		Wait:        1500 * time.Millisecond,
+		Terminate:   250 * time.Millisecond,
+		Attempts:    3,
+	})
+	reporter.add(&ContainerTiming{Image: "redis:7"})
+
+	t.Run("junit", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, reporter.WriteJUnit(buf))
+
+		var report struct {
+			Suites []struct {
+				Name      string `xml:"name,attr"`
+				Tests     int    `xml:"tests,attr"`
+				Time      string `xml:"time,attr"`
+				TestCases []struct {
+					Name       string `xml:"name,attr"`
+					Time       string `xml:"time,attr"`
+					Properties []struct {
+						Name  string `xml:"name,attr"`
+						Value string `xml:"value,attr"`
+					} `xml:"properties>property"`
+				} `xml:"testcase"`
+			} `xml:"testsuite"`
+		}
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+		require.Len(t, report.Suites, 1)
+		suite := report.Suites[0]
+		assert.Equal(t, "testcontainers", suite.Name)
+		assert.Equal(t, 2, suite.Tests)
+		assert.Equal(t, "2.050", suite.Time)
+
+		require.Len(t, suite.TestCases, 2)
+		assert.Equal(t, "nginx:alpine", suite.TestCases[0].Name)
+		assert.Equal(t, "1.800", suite.TestCases[0].Time)
+
+		properties := map[string]string{}
+		for _, p := range suite.TestCases[0].Properties {
+			properties[p.Name] = p.Value
+		}
+		assert.Equal(t, map[string]string{
+			"testcontainers.container.id":  "abc",
+			"testcontainers.create":        "0.100",
+			"testcontainers.start":         "0.200",
+			"testcontainers.wait":          "1.500",
+			"testcontainers.terminate":     "0.250",
+			"testcontainers.wait.attempts": "3",
+		}, properties)
+
+		assert.Equal(t, "redis:7", suite.TestCases[1].Name)
+		assert.Equal(t, "0.000", suite.TestCases[1].Time)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, reporter.WriteCSV(buf))
+
+		records, err := csv.NewReader(buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"image", "container_id", "create", "start", "wait", "terminate", "wait_attempts"},
+			{"nginx:alpine", "abc", "0.100", "0.200", "1.500", "0.250", "3"},
+			{"redis:7", "", "0.000", "0.000", "0.000", "0.000", "0"},
+		}, records)
+	})
+}
+
+func TestTimingReporter_attempts(t *testing.T) {
+	ctx := context.Background()
+
+	reporter := NewTimingReporter()
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			// the command succeeds on its third run
+			WaitingFor: wait.ForExec([]string{"sh", "-c", "n=$(( $(cat /tmp/attempts 2>/dev/null || echo 0) + 1 )); echo $n > /tmp/attempts; [ $n -ge 3 ]"}).
+				WithPollInterval(50 * time.Millisecond),
+		},
+		Started: true,
+	}
+	reporter.Customize(&req)
+
+	c, err := GenericContainer(ctx, req)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	timings := reporter.Timings()
+	require.Len(t, timings, 1)
+	assert.Equal(t, 3, timings[0].Attempts)
+}
@@ -0,0 +1,117 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/uuid"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// VolumeRequest represents the parameters used to create a volume with NewVolume
+type VolumeRequest struct {
+	Name       string            // the name of the volume. Random if empty
+	Labels     map[string]string // the labels of the volume, added to the Testcontainers ones
+	Driver     string            // the driver of the volume. Defaults to "local"
+	DriverOpts map[string]string // the options of the driver, e.g. the type and device of a tmpfs volume
+}
+
+// DockerVolume represents a volume created with NewVolume
+type DockerVolume struct {
+	Name              string
+	Driver            string
+	Mountpoint        string
+	terminationSignal chan bool
+	provider          *DockerProvider
+}
+
+// NewVolume creates a volume, labelled with the Testcontainers labels, so that the reaper removes it
+// when the test session ends, unless it's removed before with Remove. Mount it in the containers with WithVolumeMount.
+func NewVolume(ctx context.Context, req VolumeRequest) (*DockerVolume, error) {
+	provider, err := NewDockerProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	// defer the close of the Docker client connection the soonest
+	defer provider.Close()
+
+	if req.Name == "" {
+		req.Name = uuid.NewString()
+	}
+
+	labels := make(map[string]string, len(req.Labels))
+	for k, v := range req.Labels {
+		labels[k] = v
+	}
+	// add the labels that the reaper will use to remove the volume, and the session labels
+	for k, v := range GenericLabels() {
+		labels[k] = v
+	}
+
+	var termSignal chan bool
+	if !provider.Config().Config.RyukDisabled {
+		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, provider.host), core.SessionID(), provider)
+		if err != nil {
+			return nil, fmt.Errorf("%w: creating volume reaper failed", err)
+		}
+		termSignal, err = r.Connect()
+		if err != nil {
+			return nil, fmt.Errorf("%w: connecting to volume reaper failed", err)
+		}
+	}
+
+	// Cleanup on error, otherwise set termSignal to nil before successful return.
+	defer func() {
+		if termSignal != nil {
+			termSignal <- true
+		}
+	}()
+
+	response, err := provider.client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       req.Name,
+		Driver:     req.Driver,
+		DriverOpts: req.DriverOpts,
+		Labels:     labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create volume", err)
+	}
+	trackResource(provider.host, volumeResource(response.Name))
+
+	v := &DockerVolume{
+		Name:              response.Name,
+		Driver:            response.Driver,
+		Mountpoint:        response.Mountpoint,
+		terminationSignal: termSignal,
+		provider:          provider,
+	}
+
+	// Disable cleanup on success
+	termSignal = nil
+
+	return v, nil
+}
+
+// Remove removes the volume, which must not be used by any container, e.g. after terminating them.
+func (v *DockerVolume) Remove(ctx context.Context) error {
+	select {
+	// close reaper if it was created
+	case v.terminationSignal <- true:
+	default:
+	}
+
+	defer v.provider.Close()
+
+	return v.provider.client.VolumeRemove(ctx, v.Name, false)
+}
+
+// WithVolumeMount mounts the volume created with NewVolume in the container, at the target path.
+// The volume can be mounted by several containers, e.g. to share data between them.
+func WithVolumeMount(v *DockerVolume, target ContainerMountTarget) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Mounts = append(req.Mounts, VolumeMount(v.Name, target))
+	}
+}
@@ -0,0 +1,81 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNewVolume(t *testing.T) {
+	ctx := context.Background()
+
+	// newVolume {
+	vol, err := NewVolume(ctx, VolumeRequest{
+		Labels: map[string]string{"app": "shop"},
+	})
+	// }
+	require.NoError(t, err)
+	require.NotEmpty(t, vol.Name)
+	assert.Equal(t, "local", vol.Driver)
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	inspect, err := cli.VolumeInspect(ctx, vol.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "shop", inspect.Labels["app"])
+	assert.Equal(t, core.SessionID(), inspect.Labels[core.LabelSessionID])
+
+	// the data written by a container is read by the next one
+	// withVolumeMount {
+	writerReq := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine:3.19",
+			Cmd:        []string{"sh", "-c", "echo hello > /data/greeting"},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	}
+	WithVolumeMount(vol, "/data").Customize(&writerReq)
+
+	writer, err := GenericContainer(ctx, writerReq)
+	// }
+	require.NoError(t, err)
+	require.NoError(t, writer.Terminate(ctx))
+
+	readerReq := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine:3.19",
+			Cmd:        []string{"cat", "/data/greeting"},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	}
+	WithVolumeMount(vol, "/data").Customize(&readerReq)
+
+	reader, err := GenericContainer(ctx, readerReq)
+	require.NoError(t, err)
+
+	logs, err := reader.Logs(ctx)
+	require.NoError(t, err)
+	output, err := io.ReadAll(logs)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(output))
+	require.NoError(t, reader.Terminate(ctx))
+
+	// removeVolume {
+	err = vol.Remove(ctx)
+	// }
+	require.NoError(t, err)
+
+	_, err = cli.VolumeInspect(ctx, vol.Name)
+	require.True(t, errdefs.IsNotFound(err))
+}
@@ -14,8 +14,9 @@ var (
 
 type MultiStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
-	timeout  *time.Duration
-	deadline *time.Duration
+	timeout      *time.Duration
+	deadline     *time.Duration
+	pollInterval *time.Duration
 
 	// additional properties
 	Strategies []Strategy
@@ -40,6 +41,12 @@ func (ms *MultiStrategy) WithDeadline(deadline time.Duration) *MultiStrategy {
 	return ms
 }
 
+// WithPollInterval sets the poll interval of all inner wait strategies, overriding theirs
+func (ms *MultiStrategy) WithPollInterval(pollInterval time.Duration) *MultiStrategy {
+	ms.pollInterval = &pollInterval
+	return ms
+}
+
 func ForAll(strategies ...Strategy) *MultiStrategy {
 	return &MultiStrategy{
 		Strategies: strategies,
@@ -61,6 +68,8 @@ func (ms *MultiStrategy) WaitUntilReady(ctx context.Context, target StrategyTarg
 		return fmt.Errorf("no wait strategy supplied")
 	}
 
+	setPollInterval(ms.pollInterval, ms.Strategies...)
+
 	for _, strategy := range ms.Strategies {
 		strategyCtx := ctx
 
@@ -80,3 +89,7 @@ func (ms *MultiStrategy) WaitUntilReady(ctx context.Context, target StrategyTarg
 
 	return nil
 }
+
+func (ms *MultiStrategy) setPollInterval(pollInterval time.Duration) {
+	ms.pollInterval = &pollInterval
+}
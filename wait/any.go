@@ -0,0 +1,108 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*AnyStrategy)(nil)
+	_ StrategyTimeout = (*AnyStrategy)(nil)
+)
+
+// AnyStrategy waits until any of its strategies is ready.
+// The strategies are run concurrently, and the rest of them are cancelled
+// once the first one succeeds.
+type AnyStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout      *time.Duration
+	deadline     *time.Duration
+	pollInterval *time.Duration
+
+	// additional properties
+	Strategies []Strategy
+}
+
+// ForAny is a convenience method to assign AnyStrategy
+func ForAny(strategies ...Strategy) *AnyStrategy {
+	return &AnyStrategy{
+		Strategies: strategies,
+	}
+}
+
+// WithStartupTimeoutDefault sets the default timeout for all inner wait strategies
+func (as *AnyStrategy) WithStartupTimeoutDefault(timeout time.Duration) *AnyStrategy {
+	as.timeout = &timeout
+	return as
+}
+
+// WithDeadline sets a time.Duration which limits all wait strategies
+func (as *AnyStrategy) WithDeadline(deadline time.Duration) *AnyStrategy {
+	as.deadline = &deadline
+	return as
+}
+
+// WithPollInterval sets the poll interval of all inner wait strategies, overriding theirs
+func (as *AnyStrategy) WithPollInterval(pollInterval time.Duration) *AnyStrategy {
+	as.pollInterval = &pollInterval
+	return as
+}
+
+func (as *AnyStrategy) Timeout() *time.Duration {
+	return as.timeout
+}
+
+func (as *AnyStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if len(as.Strategies) == 0 {
+		return fmt.Errorf("no wait strategy supplied")
+	}
+
+	setPollInterval(as.pollInterval, as.Strategies...)
+
+	var cancel context.CancelFunc
+	if as.deadline != nil {
+		ctx, cancel = context.WithTimeout(ctx, *as.deadline)
+		defer cancel()
+	}
+
+	// cancel the rest of the strategies once the first one succeeds
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(as.Strategies))
+	for _, strategy := range as.Strategies {
+		go func(strategy Strategy) {
+			strategyCtx := ctx
+
+			// Set default Timeout when strategy implements StrategyTimeout
+			if st, ok := strategy.(StrategyTimeout); ok {
+				if as.Timeout() != nil && st.Timeout() == nil {
+					var strategyCancel context.CancelFunc
+					strategyCtx, strategyCancel = context.WithTimeout(ctx, *as.Timeout())
+					defer strategyCancel()
+				}
+			}
+
+			errs <- strategy.WaitUntilReady(strategyCtx, target)
+		}(strategy)
+	}
+
+	var allErrs []error
+	for range as.Strategies {
+		err := <-errs
+		if err == nil {
+			return nil
+		}
+
+		allErrs = append(allErrs, err)
+	}
+
+	return fmt.Errorf("none of the wait strategies succeeded: %w", errors.Join(allErrs...))
+}
+
+func (as *AnyStrategy) setPollInterval(pollInterval time.Duration) {
+	as.pollInterval = &pollInterval
+}
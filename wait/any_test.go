@@ -0,0 +1,84 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAnyStrategy_WaitUntilReady(t *testing.T) {
+	t.Parallel()
+
+	errIntentional := errors.New("intentional failure")
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		wantErr  bool
+	}{
+		{
+			name:     "returns error when no WaitStrategies are passed",
+			strategy: ForAny(),
+			wantErr:  true,
+		},
+		{
+			name: "returns error when all WaitStrategies fail",
+			strategy: ForAny(
+				ForNop(func(context.Context, StrategyTarget) error { return errIntentional }),
+				ForNop(func(context.Context, StrategyTarget) error { return errIntentional }),
+			),
+			wantErr: true,
+		},
+		{
+			name: "succeeds when any WaitStrategy succeeds",
+			strategy: ForAny(
+				ForNop(func(context.Context, StrategyTarget) error { return errIntentional }),
+				ForNop(func(context.Context, StrategyTarget) error { return nil }),
+			),
+			wantErr: false,
+		},
+		{
+			name: "cancels the rest of WaitStrategies when one succeeds",
+			strategy: ForAny(
+				ForNop(func(ctx context.Context, _ StrategyTarget) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+				ForNop(func(context.Context, StrategyTarget) error { return nil }),
+			),
+			wantErr: false,
+		},
+		{
+			name: "WithDeadline limits all WaitStrategies",
+			strategy: ForAny(
+				ForNop(func(ctx context.Context, _ StrategyTarget) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+			).WithDeadline(100 * time.Millisecond),
+			wantErr: true,
+		},
+		{
+			name: "WithStartupTimeoutDefault sets context.Deadline for WaitStrategy",
+			strategy: ForAny(
+				ForNop(func(ctx context.Context, _ StrategyTarget) error {
+					if _, set := ctx.Deadline(); !set {
+						return errors.New("expected context.Deadline to be set")
+					}
+					return nil
+				}),
+			).WithStartupTimeoutDefault(time.Second),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); (err != nil) != tt.wantErr {
+				t.Errorf("ForAny.WaitUntilReady() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
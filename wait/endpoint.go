@@ -0,0 +1,106 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var _ StrategyTarget = (*EndpointTarget)(nil)
+
+// ErrExecNotSupported is returned by targets that are not able to execute commands,
+// such as the EndpointTarget. Strategies performing checks inside the container
+// (e.g. the internal check of the HostPort strategy) will skip those checks.
+var ErrExecNotSupported = errors.New("exec is not supported by the target")
+
+// EndpointTarget is a StrategyTarget that is not backed by a container, but by an
+// arbitrary host and a set of ports: e.g. a service started locally by the test, or
+// a service exposed by a compose stack. It allows reusing the existing wait strategies
+// outside the container startup.
+type EndpointTarget struct {
+	host  string
+	ports nat.PortMap
+}
+
+// NewEndpointTarget creates a StrategyTarget for the given host and ports.
+// The ports are exposed as they are, meaning that the mapped port of each one
+// is the port itself. Use WithPortMapping to map a port to a different one.
+func NewEndpointTarget(host string, ports ...nat.Port) *EndpointTarget {
+	t := &EndpointTarget{
+		host:  host,
+		ports: nat.PortMap{},
+	}
+
+	for _, p := range ports {
+		t.WithPortMapping(p, p)
+	}
+
+	return t
+}
+
+// WithPortMapping maps a port, as defined in the wait strategy, to the port in which
+// the endpoint is actually listening on the host.
+func (t *EndpointTarget) WithPortMapping(port nat.Port, hostPort nat.Port) *EndpointTarget {
+	t.ports[port] = []nat.PortBinding{
+		{
+			HostIP:   t.host,
+			HostPort: hostPort.Port(),
+		},
+	}
+
+	return t
+}
+
+// Host returns the host of the endpoint.
+func (t *EndpointTarget) Host(_ context.Context) (string, error) {
+	return t.host, nil
+}
+
+// Ports returns the ports of the endpoint.
+func (t *EndpointTarget) Ports(_ context.Context) (nat.PortMap, error) {
+	return t.ports, nil
+}
+
+// MappedPort returns the host port for the given port.
+func (t *EndpointTarget) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	for k, bindings := range t.ports {
+		if k.Port() != port.Port() {
+			continue
+		}
+		if port.Proto() != "" && k.Proto() != port.Proto() {
+			continue
+		}
+		if len(bindings) == 0 {
+			continue
+		}
+		return nat.NewPort(k.Proto(), bindings[0].HostPort)
+	}
+
+	return "", fmt.Errorf("port %s not found", port)
+}
+
+// Logs returns an empty reader, as an endpoint has no logs.
+func (t *EndpointTarget) Logs(_ context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+// Exec always returns ErrExecNotSupported, as there is no container to execute commands into.
+func (t *EndpointTarget) Exec(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+	return 0, nil, ErrExecNotSupported
+}
+
+// State returns a running state, as the endpoint lifecycle is not managed by Testcontainers.
+func (t *EndpointTarget) State(_ context.Context) (*types.ContainerState, error) {
+	return &types.ContainerState{
+		Status:  "running",
+		Running: true,
+	}, nil
+}
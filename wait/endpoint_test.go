@@ -0,0 +1,78 @@
+package wait_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestEndpointTarget_ForListeningPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := wait.NewEndpointTarget("localhost", port)
+
+	wg := wait.ForListeningPort(port).
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEndpointTarget_ForHTTPWithPortMapping(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rawPort := srv.Listener.Addr().(*net.TCPAddr).Port
+	hostPort, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the strategy refers to the port as defined by the service, and the target maps it
+	target := wait.NewEndpointTarget("127.0.0.1").WithPortMapping("8080/tcp", hostPort)
+
+	wg := wait.ForHTTP("/").
+		WithPort("8080/tcp").
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEndpointTarget_MappedPortNotFound(t *testing.T) {
+	target := wait.NewEndpointTarget("localhost", "8080/tcp")
+
+	_, err := target.MappedPort(context.Background(), "9090/tcp")
+	if err == nil {
+		t.Fatal("expected error for a port that is not exposed")
+	}
+
+	_, _, err = target.Exec(context.Background(), []string{"ls"})
+	if err != wait.ErrExecNotSupported {
+		t.Fatalf("expected %v, got %v", wait.ErrExecNotSupported, err)
+	}
+}
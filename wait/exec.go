@@ -1,10 +1,14 @@
 package wait
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"time"
 
+	"github.com/docker/docker/pkg/stdcopy"
+
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
 
@@ -22,9 +26,18 @@ type ExecStrategy struct {
 	// additional properties
 	ExitCodeMatcher func(exitCode int) bool
 	ResponseMatcher func(body io.Reader) bool
+	OutputMatcher   func(stdout []byte) bool
 	PollInterval    time.Duration
+
+	// BackoffFactor multiplies the poll interval after each unsuccessful check, up to MaxPollInterval.
+	// Values lower or equal than 1 keep the poll interval constant.
+	BackoffFactor   float64
+	MaxPollInterval time.Duration
 }
 
+// execOutputLimit is the number of bytes of the last output of the command included in the timeout errors
+const execOutputLimit = 1024
+
 // NewExecStrategy constructs an Exec strategy ...
 func NewExecStrategy(cmd []string) *ExecStrategy {
 	return &ExecStrategy{
@@ -61,12 +74,28 @@ func (ws *ExecStrategy) WithResponseMatcher(matcher func(body io.Reader) bool) *
 	return ws
 }
 
+// WithOutputMatcher can be used to match the standard output of the command, which is read once per check,
+// e.g. to wait for a status reported by a CLI. It's checked in addition to the response matcher, which
+// receives both the standard output and the standard error.
+func (ws *ExecStrategy) WithOutputMatcher(matcher func(stdout []byte) bool) *ExecStrategy {
+	ws.OutputMatcher = matcher
+	return ws
+}
+
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *ExecStrategy) WithPollInterval(pollInterval time.Duration) *ExecStrategy {
 	ws.PollInterval = pollInterval
 	return ws
 }
 
+// WithBackoff multiplies the poll interval by factor after each unsuccessful check, up to maxInterval,
+// for the commands too expensive to run every poll interval, e.g. the ones starting a JVM.
+func (ws *ExecStrategy) WithBackoff(factor float64, maxInterval time.Duration) *ExecStrategy {
+	ws.BackoffFactor = factor
+	ws.MaxPollInterval = maxInterval
+	return ws
+}
+
 // ForExec is a convenience method to assign ExecStrategy
 func ForExec(cmd []string) *ExecStrategy {
 	return NewExecStrategy(cmd)
@@ -85,23 +114,105 @@ func (ws *ExecStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	for {
+	// the result of the last check, to diagnose why the command never matched
+	var (
+		checked      bool
+		lastExitCode int
+		lastOutput   []byte
+	)
+
+	interval := ws.PollInterval
+	start := time.Now()
+	for attempts := 0; ; attempts++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(ws.PollInterval):
-			exitCode, resp, err := target.Exec(ctx, ws.cmd, tcexec.Multiplexed())
+			if !checked {
+				return startupTimeoutError(ctx, "exec", attempts, start, ctx.Err())
+			}
+
+			return startupTimeoutError(ctx, "exec", attempts, start, fmt.Errorf("%w: last exit code of %v: %d, last output: %q", ctx.Err(), ws.cmd, lastExitCode, truncateOutput(lastOutput)))
+		case <-time.After(interval):
+			// the targets not applying the options, e.g. the test doubles, return the output as is
+			stdout := &demultiplexedOutput{}
+			exitCode, resp, err := target.Exec(ctx, ws.cmd, stdout)
 			if err != nil {
 				return err
 			}
-			if !ws.ExitCodeMatcher(exitCode) {
-				continue
+
+			var output []byte
+			if resp != nil {
+				if output, err = io.ReadAll(resp); err != nil {
+					return fmt.Errorf("read the output of %v: %w", ws.cmd, err)
+				}
 			}
-			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp) {
-				continue
+
+			checked, lastExitCode, lastOutput = true, exitCode, output
+
+			if ws.ExitCodeMatcher(exitCode) &&
+				(ws.ResponseMatcher == nil || ws.ResponseMatcher(bytes.NewReader(output))) &&
+				(ws.OutputMatcher == nil || ws.OutputMatcher(stdout.or(output))) {
+				return nil
 			}
 
-			return nil
+			interval = ws.nextInterval(interval)
 		}
 	}
 }
+
+func (ws *ExecStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
+
+// nextInterval returns the poll interval following the given one, applying the backoff, if any
+func (ws *ExecStrategy) nextInterval(interval time.Duration) time.Duration {
+	if ws.BackoffFactor <= 1 {
+		return interval
+	}
+
+	next := time.Duration(float64(interval) * ws.BackoffFactor)
+	if ws.MaxPollInterval > 0 && next > ws.MaxPollInterval {
+		return ws.MaxPollInterval
+	}
+
+	return next
+}
+
+// demultiplexedOutput is a process option demultiplexing the output of the command, like tcexec.Multiplexed,
+// which keeps its standard output apart for the output matcher
+type demultiplexedOutput struct {
+	stdout  []byte
+	applied bool
+}
+
+func (o *demultiplexedOutput) Apply(opts *tcexec.ProcessOptions) {
+	// with a TTY, the output is already a single stream
+	if opts.Reader == nil || opts.ExecConfig.Tty {
+		return
+	}
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, opts.Reader); err != nil {
+		return
+	}
+
+	o.stdout, o.applied = stdout.Bytes(), true
+	opts.Reader = io.MultiReader(bytes.NewReader(o.stdout), &stderr)
+}
+
+// or returns the standard output of the command, or the given output if it was not demultiplexed
+func (o *demultiplexedOutput) or(output []byte) []byte {
+	if !o.applied {
+		return output
+	}
+
+	return o.stdout
+}
+
+// truncateOutput returns the last bytes of the output, up to execOutputLimit
+func truncateOutput(output []byte) []byte {
+	if len(output) <= execOutputLimit {
+		return output
+	}
+
+	return output[len(output)-execOutputLimit:]
+}
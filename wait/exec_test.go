@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go"
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
@@ -206,3 +208,111 @@ func TestExecStrategyWaitUntilReady_CustomResponseMatcher(t *testing.T) {
 	})
 	// }
 }
+
+func TestExecStrategyWaitUntilReady_withOutputMatcher(t *testing.T) {
+	target := mockExecTarget{
+		response: "status: healthy\n",
+	}
+
+	// waitForExecOutput {
+	wg := wait.ForExec([]string{"status"}).
+		WithOutputMatcher(func(stdout []byte) bool {
+			return bytes.Contains(stdout, []byte("healthy"))
+		})
+	// }
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+}
+
+// multiplexedExecTarget returns the output of the command multiplexed, as the Docker daemon does
+type multiplexedExecTarget struct {
+	mockExecTarget
+	stdout string
+	stderr string
+}
+
+func (st multiplexedExecTarget) Exec(_ context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	var output bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte(st.stdout))
+	_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stderr).Write([]byte(st.stderr))
+
+	opts := tcexec.NewProcessOptions(cmd)
+	opts.Reader = &output
+	for _, o := range options {
+		o.Apply(opts)
+	}
+
+	return st.exitCode, opts.Reader, nil
+}
+
+func TestExecStrategyWaitUntilReady_withOutputMatcherOnStdout(t *testing.T) {
+	matcher := func(stdout []byte) bool {
+		return bytes.Equal(stdout, []byte("status: healthy\n"))
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		var response []byte
+		target := multiplexedExecTarget{stdout: "status: healthy\n", stderr: "warning: deprecated flag\n"}
+
+		wg := wait.ForExec([]string{"status"}).
+			WithOutputMatcher(matcher).
+			WithResponseMatcher(func(body io.Reader) bool {
+				response, _ = io.ReadAll(body)
+				return true
+			})
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+
+		// the response matcher receives the standard error too
+		require.Equal(t, "status: healthy\nwarning: deprecated flag\n", string(response))
+	})
+
+	t.Run("stderr", func(t *testing.T) {
+		target := multiplexedExecTarget{stderr: "status: healthy\n"}
+
+		wg := wait.ForExec([]string{"status"}).
+			WithOutputMatcher(matcher).
+			WithStartupTimeout(500 * time.Millisecond)
+		require.ErrorIs(t, wg.WaitUntilReady(context.Background(), target), context.DeadlineExceeded)
+	})
+}
+
+func TestExecStrategyWaitUntilReady_timeoutIncludesLastOutput(t *testing.T) {
+	target := mockExecTarget{
+		exitCode: 1,
+		response: "status: starting\n",
+	}
+
+	wg := wait.ForExec([]string{"status"}).
+		WithStartupTimeout(500 * time.Millisecond)
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "last exit code of [status]: 1")
+	require.ErrorContains(t, err, `last output: "status: starting\n"`)
+}
+
+type countingExecTarget struct {
+	mockExecTarget
+	calls *int
+}
+
+func (st countingExecTarget) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	*st.calls++
+	return st.mockExecTarget.Exec(ctx, cmd, options...)
+}
+
+func TestExecStrategyWaitUntilReady_withBackoff(t *testing.T) {
+	var calls int
+	target := countingExecTarget{mockExecTarget: mockExecTarget{exitCode: 1}, calls: &calls}
+
+	// waitForExecBackoff {
+	wg := wait.ForExec([]string{"status"}).
+		WithPollInterval(50*time.Millisecond).
+		WithBackoff(2, 400*time.Millisecond).
+		WithStartupTimeout(time.Second)
+	// }
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// 50ms, 100ms, 200ms and 400ms intervals fit in one second, instead of twenty 50ms ones
+	require.Equal(t, 4, calls)
+}
@@ -67,24 +67,31 @@ func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		defer cancel()
 	}
 
-	for {
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return startupTimeoutError(ctx, "exit", attempts-1, start, ctx.Err())
 		default:
 			state, err := target.State(ctx)
 			if err != nil {
 				if !strings.Contains(err.Error(), "No such container") {
-					return err
+					return startupTimeoutError(ctx, "exit", attempts, start, err)
 				} else {
 					return nil
 				}
 			}
 			if state.Running {
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return startupTimeoutError(ctx, "exit", attempts, start, err)
+				}
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+func (ws *ExitStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
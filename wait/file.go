@@ -0,0 +1,137 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*FileStrategy)(nil)
+	_ StrategyTimeout = (*FileStrategy)(nil)
+)
+
+// fileCopier is implemented by the targets which can copy a file from the container with the archive API,
+// like the Docker containers, which does not require any binary in the image
+type fileCopier interface {
+	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
+}
+
+// FileStrategy waits for a file to exist in the container, optionally with a content matching a matcher,
+// for images signalling their readiness by writing a file.
+type FileStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	file    string
+
+	// additional properties
+	Matcher      func(content io.Reader) bool
+	PollInterval time.Duration
+}
+
+// NewFileStrategy constructs a File strategy, waiting for the file to exist
+func NewFileStrategy(file string) *FileStrategy {
+	return &FileStrategy{
+		file:         file,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForFile is a convenience method to assign FileStrategy
+func ForFile(file string) *FileStrategy {
+	return NewFileStrategy(file)
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *FileStrategy) WithStartupTimeout(startupTimeout time.Duration) *FileStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithMatcher can be used to wait until the content of the file matches, e.g. to wait for a status written to it
+func (ws *FileStrategy) WithMatcher(matcher func(content io.Reader) bool) *FileStrategy {
+	ws.Matcher = matcher
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *FileStrategy) WithPollInterval(pollInterval time.Duration) *FileStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+func (ws *FileStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady polls the file with the archive API, if the target supports it, or by executing cat otherwise
+func (ws *FileStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	for attempts := 0; ; attempts++ {
+		select {
+		case <-ctx.Done():
+			return startupTimeoutError(ctx, "file", attempts, start, ctx.Err())
+		case <-time.After(ws.PollInterval):
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+
+			content, ok := ws.readFile(ctx, target)
+			if !ok {
+				continue
+			}
+
+			if ws.Matcher != nil && !ws.Matcher(bytes.NewReader(content)) {
+				continue
+			}
+
+			return nil
+		}
+	}
+}
+
+func (ws *FileStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
+
+// readFile returns the content of the file, and false if it does not exist yet
+func (ws *FileStrategy) readFile(ctx context.Context, target StrategyTarget) ([]byte, bool) {
+	if copier, ok := target.(fileCopier); ok {
+		r, err := copier.CopyFileFromContainer(ctx, ws.file)
+		if err != nil {
+			return nil, false
+		}
+		defer r.Close()
+
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, false
+		}
+
+		return content, true
+	}
+
+	exitCode, r, err := target.Exec(ctx, []string{"cat", ws.file}, tcexec.Multiplexed())
+	if err != nil || exitCode != 0 {
+		return nil, false
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false
+	}
+
+	return content, true
+}
@@ -0,0 +1,137 @@
+package wait_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// mockFileTarget is a target whose file is written after a while
+type mockFileTarget struct {
+	writtenAfter time.Time
+	content      string
+}
+
+func (st mockFileTarget) Host(_ context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (st mockFileTarget) Ports(_ context.Context) (nat.PortMap, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (st mockFileTarget) MappedPort(_ context.Context, n nat.Port) (nat.Port, error) {
+	return n, errors.New("not implemented")
+}
+
+func (st mockFileTarget) Logs(_ context.Context) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Exec emulates cat, failing if the file is not written yet
+func (st mockFileTarget) Exec(_ context.Context, _ []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	if time.Now().Before(st.writtenAfter) {
+		return 1, strings.NewReader("cat: can't open '/tmp/ready': No such file or directory"), nil
+	}
+
+	return 0, strings.NewReader(st.content), nil
+}
+
+func (st mockFileTarget) State(_ context.Context) (*types.ContainerState, error) {
+	return &types.ContainerState{Running: true}, nil
+}
+
+// mockFileCopierTarget is a target which copies the file with the archive API, without an exec
+type mockFileCopierTarget struct {
+	mockFileTarget
+}
+
+func (st mockFileCopierTarget) Exec(_ context.Context, _ []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	return 0, nil, errors.New("the image does not include cat")
+}
+
+func (st mockFileCopierTarget) CopyFileFromContainer(_ context.Context, _ string) (io.ReadCloser, error) {
+	if time.Now().Before(st.writtenAfter) {
+		return nil, errors.New("Could not find the file /tmp/ready in container")
+	}
+
+	return io.NopCloser(bytes.NewReader([]byte(st.content))), nil
+}
+
+func TestFileStrategyWaitUntilReady(t *testing.T) {
+	// the file is written 500ms after the target is created
+	targets := map[string]func() wait.StrategyTarget{
+		"exec": func() wait.StrategyTarget {
+			return mockFileTarget{writtenAfter: time.Now().Add(500 * time.Millisecond)}
+		},
+		"archive": func() wait.StrategyTarget {
+			return mockFileCopierTarget{mockFileTarget{writtenAfter: time.Now().Add(500 * time.Millisecond)}}
+		},
+	}
+
+	for name, newTarget := range targets {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			target := newTarget()
+
+			err := wait.ForFile("/tmp/ready").WithStartupTimeout(5*time.Second).WaitUntilReady(context.Background(), target)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+		})
+	}
+}
+
+func TestFileStrategyWaitUntilReady_Matcher(t *testing.T) {
+	target := mockFileCopierTarget{mockFileTarget{content: "starting"}}
+
+	ready := func(content io.Reader) bool {
+		data, _ := io.ReadAll(content)
+		return string(data) == "ready"
+	}
+
+	err := wait.ForFile("/tmp/ready").WithMatcher(ready).WithStartupTimeout(500*time.Millisecond).WaitUntilReady(context.Background(), target)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	target.content = "ready"
+	err = wait.ForFile("/tmp/ready").WithMatcher(ready).WithStartupTimeout(500*time.Millisecond).WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+}
+
+func TestFileStrategyWaitUntilReady_Container(t *testing.T) {
+	ctx := context.Background()
+
+	// waitForFile {
+	req := testcontainers.ContainerRequest{
+		Image: "docker.io/alpine:3.19",
+		Cmd:   []string{"sh", "-c", "sleep 2; echo ready > /tmp/ready; sleep 60"},
+		WaitingFor: wait.ForFile("/tmp/ready").WithMatcher(func(content io.Reader) bool {
+			data, _ := io.ReadAll(content)
+			return strings.TrimSpace(string(data)) == "ready"
+		}),
+	}
+	// }
+
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: req, Started: true})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := c.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	_, _, err = c.Exec(ctx, []string{"test", "-f", "/tmp/ready"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,77 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*FuncStrategy)(nil)
+	_ StrategyTimeout = (*FuncStrategy)(nil)
+)
+
+// FuncStrategy waits until a function, which implements custom readiness logic,
+// returns no error. The function is called on every poll interval, until it succeeds
+// or the startup timeout is reached.
+type FuncStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	fn           func(context.Context, StrategyTarget) error
+	PollInterval time.Duration
+}
+
+// ForFunc is a convenience method to assign FuncStrategy
+func ForFunc(fn func(ctx context.Context, target StrategyTarget) error) *FuncStrategy {
+	return &FuncStrategy{
+		fn:           fn,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *FuncStrategy) WithStartupTimeout(timeout time.Duration) *FuncStrategy {
+	ws.timeout = &timeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *FuncStrategy) WithPollInterval(pollInterval time.Duration) *FuncStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+func (ws *FuncStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+func (ws *FuncStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
+		if lastErr = ws.fn(ctx, target); lastErr == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return startupTimeoutError(ctx, "func", attempts, start, fmt.Errorf("%w: %w", ctx.Err(), lastErr))
+		case <-time.After(ws.PollInterval):
+		}
+	}
+}
+
+func (ws *FuncStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
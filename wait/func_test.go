@@ -0,0 +1,51 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFuncStrategy_WaitUntilReady(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries until the function succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		strategy := ForFunc(func(context.Context, StrategyTarget) error {
+			if calls.Add(1) < 3 {
+				return errors.New("not ready")
+			}
+			return nil
+		}).WithPollInterval(10 * time.Millisecond)
+
+		if err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if calls.Load() != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls.Load())
+		}
+	})
+
+	t.Run("returns the last error on timeout", func(t *testing.T) {
+		t.Parallel()
+
+		errNotReady := errors.New("not ready")
+		strategy := ForFunc(func(context.Context, StrategyTarget) error {
+			return errNotReady
+		}).WithStartupTimeout(100 * time.Millisecond).WithPollInterval(10 * time.Millisecond)
+
+		err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{})
+		if !errors.Is(err, errNotReady) {
+			t.Fatalf("expected %v, got %v", errNotReady, err)
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
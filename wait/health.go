@@ -2,9 +2,11 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 )
 
 // Implement interface
@@ -13,6 +15,19 @@ var (
 	_ StrategyTimeout = (*HealthStrategy)(nil)
 )
 
+// The defaults of the Docker daemon for the health check properties which are not set
+const (
+	defaultHealthCheckInterval = 30 * time.Second
+	defaultHealthCheckTimeout  = 30 * time.Second
+	defaultHealthCheckRetries  = 3
+)
+
+// healthCheckTarget is implemented by the targets which can return the health check of the container,
+// like the Docker containers, either the HEALTHCHECK of the image or the one of the container request
+type healthCheckTarget interface {
+	HealthCheck(ctx context.Context) (*container.HealthConfig, error)
+}
+
 // HealthStrategy will wait until the container becomes healthy
 type HealthStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
@@ -46,6 +61,8 @@ func (ws *HealthStrategy) WithPollInterval(pollInterval time.Duration) *HealthSt
 }
 
 // ForHealthCheck is the default construction for the fluid interface.
+// Unless a startup timeout is set, the wait lasts long enough for the health check of the container
+// to report it as unhealthy, i.e. its start period and all its retries, if that's longer than the default timeout.
 //
 // For Example:
 //
@@ -65,28 +82,67 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 	timeout := defaultStartupTimeout()
 	if ws.timeout != nil {
 		timeout = *ws.timeout
+	} else if t, ok := target.(healthCheckTarget); ok {
+		healthCheck, err := t.HealthCheck(ctx)
+		if err != nil {
+			return err
+		}
+
+		if healthCheck == nil || len(healthCheck.Test) == 0 || healthCheck.Test[0] == "NONE" {
+			return errors.New("the container has no health check: define it with the HealthCheck of the container request")
+		}
+
+		timeout = max(timeout, healthCheckDuration(healthCheck))
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	for {
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return startupTimeoutError(ctx, "health", attempts-1, start, ctx.Err())
 		default:
 			state, err := target.State(ctx)
 			if err != nil {
-				return err
+				return startupTimeoutError(ctx, "health", attempts, start, err)
 			}
 			if err := checkState(state); err != nil {
 				return err
 			}
 			if state.Health == nil || state.Health.Status != types.Healthy {
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return startupTimeoutError(ctx, "health", attempts, start, err)
+				}
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+func (ws *HealthStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
+
+// healthCheckDuration returns the maximum time the health check takes to report the container as unhealthy:
+// its start period, and then all its retries, each one after the interval and lasting its timeout at most.
+func healthCheckDuration(healthCheck *container.HealthConfig) time.Duration {
+	interval := healthCheck.Interval
+	if interval == 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	timeout := healthCheck.Timeout
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	retries := healthCheck.Retries
+	if retries == 0 {
+		retries = defaultHealthCheckRetries
+	}
+
+	return healthCheck.StartPeriod + time.Duration(retries)*(interval+timeout)
+}
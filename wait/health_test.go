@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/require"
 
@@ -157,3 +158,84 @@ func TestWaitForHealthFailsDueToUnexpectedContainerStatus(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "unexpected container status \"dead\"")
 }
+
+// healthCheckStrategyTarget is a target returning the health check of the container
+type healthCheckStrategyTarget struct {
+	healthStrategyTarget
+	healthCheck *container.HealthConfig
+}
+
+func (st healthCheckStrategyTarget) HealthCheck(ctx context.Context) (*container.HealthConfig, error) {
+	return st.healthCheck, nil
+}
+
+func TestWaitForHealthWithHealthCheck(t *testing.T) {
+	target := healthCheckStrategyTarget{
+		healthStrategyTarget: healthStrategyTarget{
+			state: &types.ContainerState{
+				Running: true,
+				Health:  &types.Health{Status: types.Healthy},
+			},
+		},
+		healthCheck: &container.HealthConfig{Test: []string{"CMD", "true"}},
+	}
+
+	err := ForHealthCheck().WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+}
+
+func TestWaitForHealthFailsWithoutHealthCheck(t *testing.T) {
+	testCases := []struct {
+		name        string
+		healthCheck *container.HealthConfig
+	}{
+		{name: "nil", healthCheck: nil},
+		{name: "empty", healthCheck: &container.HealthConfig{}},
+		{name: "disabled", healthCheck: &container.HealthConfig{Test: []string{"NONE"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := healthCheckStrategyTarget{
+				healthStrategyTarget: healthStrategyTarget{
+					state: &types.ContainerState{Running: true},
+				},
+				healthCheck: tc.healthCheck,
+			}
+
+			err := ForHealthCheck().WaitUntilReady(context.Background(), target)
+			require.ErrorContains(t, err, "the container has no health check")
+		})
+	}
+}
+
+func TestHealthCheckDuration(t *testing.T) {
+	testCases := []struct {
+		name        string
+		healthCheck container.HealthConfig
+		expected    time.Duration
+	}{
+		{
+			name:        "defaults",
+			healthCheck: container.HealthConfig{Test: []string{"CMD", "true"}},
+			expected:    3 * time.Minute,
+		},
+		{
+			name: "custom",
+			healthCheck: container.HealthConfig{
+				Test:        []string{"CMD", "true"},
+				Interval:    5 * time.Second,
+				Timeout:     2 * time.Second,
+				StartPeriod: 30 * time.Second,
+				Retries:     20,
+			},
+			expected: 170 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, healthCheckDuration(&tc.healthCheck))
+		})
+	}
+}
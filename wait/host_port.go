@@ -28,6 +28,8 @@ type HostPortStrategy struct {
 	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
 	timeout      *time.Duration
 	PollInterval time.Duration
+	// IPVersion restricts the connections to the IP addresses of the given version, IPAny by default
+	IPVersion IPVersion
 }
 
 // NewHostPortStrategy constructs a default host port strategy
@@ -66,6 +68,13 @@ func (hp *HostPortStrategy) WithPollInterval(pollInterval time.Duration) *HostPo
 	return hp
 }
 
+// WithIPVersion restricts the connections from the host to the IP addresses of the given version,
+// e.g. IPv6 for the Docker environments publishing the ports on the IPv6 addresses only.
+func (hp *HostPortStrategy) WithIPVersion(version IPVersion) *HostPortStrategy {
+	hp.IPVersion = version
+	return hp
+}
+
 func (hp *HostPortStrategy) Timeout() *time.Duration {
 	return hp.timeout
 }
@@ -80,6 +89,9 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	start := time.Now()
+	attempts := 0
+
 	ipAddress, err := target.Host(ctx)
 	if err != nil {
 		return err
@@ -115,8 +127,9 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("%w: %w", ctx.Err(), err)
+			return startupTimeoutError(ctx, "host port", attempts, start, fmt.Errorf("%w: %w", ctx.Err(), err))
 		case <-time.After(waitInterval):
+			attempts++
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -127,39 +140,46 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		}
 	}
 
-	if err := externalCheck(ctx, ipAddress, port, target, waitInterval); err != nil {
-		return err
+	if err := externalCheck(ctx, hp.IPVersion.host(ipAddress), hp.IPVersion.network(port.Proto()), port, target, waitInterval, &attempts); err != nil {
+		return startupTimeoutError(ctx, "host port", attempts, start, err)
 	}
 
-	err = internalCheck(ctx, internalPort, target)
+	err = internalCheck(ctx, internalPort, target, waitInterval, &attempts)
 	if err != nil && errors.Is(errShellNotExecutable, err) {
 		log.Println("Shell not executable in container, only external port check will be performed")
-	} else {
-		return err
+	} else if err != nil {
+		return startupTimeoutError(ctx, "host port", attempts, start, err)
 	}
 
 	return nil
 }
 
-func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target StrategyTarget, waitInterval time.Duration) error {
-	proto := port.Proto()
+func (hp *HostPortStrategy) setPollInterval(pollInterval time.Duration) {
+	hp.PollInterval = pollInterval
+}
+
+// externalCheck dials the port from the host until it accepts connections, counting the attempts
+func externalCheck(ctx context.Context, ipAddress string, network string, port nat.Port, target StrategyTarget, waitInterval time.Duration, attempts *int) error {
 	portNumber := port.Int()
 	portString := strconv.Itoa(portNumber)
 
 	dialer := net.Dialer{}
 	address := net.JoinHostPort(ipAddress, portString)
 	for {
+		*attempts++
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
-		conn, err := dialer.DialContext(ctx, proto, address)
+		conn, err := dialer.DialContext(ctx, network, address)
 		if err != nil {
 			var v *net.OpError
 			if errors.As(err, &v) {
 				var v2 *os.SyscallError
 				if errors.As(v.Err, &v2) {
 					if isConnRefusedErr(v2.Err) {
-						time.Sleep(waitInterval)
+						if err := sleep(ctx, waitInterval); err != nil {
+							return err
+						}
 						continue
 					}
 				}
@@ -173,16 +193,21 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 	return nil
 }
 
-func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget) error {
+// internalCheck checks the port is listening from inside the container until it is, counting the attempts
+func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget, waitInterval time.Duration, attempts *int) error {
 	command := buildInternalCheckCommand(internalPort.Int())
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		*attempts++
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
 		exitCode, _, err := target.Exec(ctx, []string{"/bin/sh", "-c", command})
+		if errors.Is(err, ErrExecNotSupported) {
+			return errShellNotExecutable
+		}
 		if err != nil {
 			return fmt.Errorf("%w, host port waiting failed", err)
 		}
@@ -192,6 +217,10 @@ func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTa
 		} else if exitCode == 126 {
 			return errShellNotExecutable
 		}
+
+		if err := sleep(ctx, waitInterval); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -42,6 +42,12 @@ type HTTPStrategy struct {
 	PollInterval           time.Duration
 	UserInfo               *url.Userinfo
 	ForceIPv4LocalHost     bool
+	ForceIPv6LocalHost     bool
+	HostHeader             string    // the Host header of the requests, and the server name of the TLS handshake
+	IPVersion              IPVersion // restricts the connections to the IP addresses of the given version, IPAny by default
+
+	// err is returned by WaitUntilReady before sending any request, e.g. the invalid metric selector of ForMetric
+	err error
 }
 
 // NewHTTPStrategy constructs a HTTP strategy waiting on port 80 and status code 200
@@ -142,6 +148,36 @@ func (ws *HTTPStrategy) WithForcedIPv4LocalHost() *HTTPStrategy {
 	return ws
 }
 
+// WithForcedIPv6LocalHost forces usage of localhost to be ipv6 ::1,
+// for the containers whose ports are only exposed on IPv6
+func (ws *HTTPStrategy) WithForcedIPv6LocalHost() *HTTPStrategy {
+	ws.ForceIPv6LocalHost = true
+	return ws
+}
+
+// WithIPVersion restricts the connections to the IP addresses of the given version, replacing
+// localhost with its loopback address, e.g. IPv6 for the Docker environments publishing the ports
+// on the IPv6 addresses only. It generalizes WithForcedIPv4LocalHost and WithForcedIPv6LocalHost.
+func (ws *HTTPStrategy) WithIPVersion(version IPVersion) *HTTPStrategy {
+	ws.IPVersion = version
+	return ws
+}
+
+// WithTLSClientConfig enables TLS with the given config, e.g. including the client certificates
+// of the servers enforcing mutual TLS, and the root CAs verifying the server certificate.
+func (ws *HTTPStrategy) WithTLSClientConfig(tlsConfig *tls.Config) *HTTPStrategy {
+	ws.UseTLS = true
+	ws.TLSConfig = tlsConfig
+	return ws
+}
+
+// WithHost sets the Host header of the requests, e.g. for the servers routing them by virtual host.
+// With TLS, it's the server name of the handshake too, unless the TLS config sets it.
+func (ws *HTTPStrategy) WithHost(host string) *HTTPStrategy {
+	ws.HostHeader = host
+	return ws
+}
+
 // ForHTTP is a convenience method similar to Wait.java
 // https://github.com/testcontainers/testcontainers-java/blob/1d85a3834bd937f80aad3a4cec249c027f31aeb4/core/src/main/java/org/testcontainers/containers/wait/strategy/Wait.java
 func ForHTTP(path string) *HTTPStrategy {
@@ -154,6 +190,10 @@ func (ws *HTTPStrategy) Timeout() *time.Duration {
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if ws.err != nil {
+		return ws.err
+	}
+
 	timeout := defaultStartupTimeout()
 	if ws.timeout != nil {
 		timeout = *ws.timeout
@@ -162,6 +202,9 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	start := time.Now()
+	attempts := 0
+
 	ipAddress, err := target.Host(ctx)
 	if err != nil {
 		return err
@@ -169,7 +212,10 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	// to avoid ipv6 docker bugs https://github.com/moby/moby/issues/42442 https://github.com/moby/moby/issues/42375
 	if ws.ForceIPv4LocalHost {
 		ipAddress = strings.Replace(ipAddress, "localhost", "127.0.0.1", 1)
+	} else if ws.ForceIPv6LocalHost {
+		ipAddress = strings.Replace(ipAddress, "localhost", "::1", 1)
 	}
+	ipAddress = ws.IPVersion.host(ipAddress)
 
 	var mappedPort nat.Port
 	if ws.Port == "" {
@@ -179,8 +225,9 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		for err != nil || ports == nil {
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("%w: %w", ctx.Err(), err)
+				return startupTimeoutError(ctx, "http", attempts, start, fmt.Errorf("%w: %w", ctx.Err(), err))
 			case <-time.After(ws.PollInterval):
+				attempts++
 				if err := checkTarget(ctx, target); err != nil {
 					return err
 				}
@@ -206,8 +253,9 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		for mappedPort == "" {
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("%w: %w", ctx.Err(), err)
+				return startupTimeoutError(ctx, "http", attempts, start, fmt.Errorf("%w: %w", ctx.Err(), err))
 			case <-time.After(ws.PollInterval):
+				attempts++
 				if err := checkTarget(ctx, target); err != nil {
 					return err
 				}
@@ -232,29 +280,38 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		ws.Method = http.MethodGet
 	}
 
+	tlsConfig := ws.TLSConfig
+	if ws.UseTLS && ws.HostHeader != "" {
+		tlsConfig = tlsConfigWithServerName(tlsConfig, ws.HostHeader)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   time.Second,
+		KeepAlive: 30 * time.Second,
+		DualStack: true,
+	}
+
 	tripper := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: true,
-		}).DialContext,
+		DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, ws.IPVersion.network(network), addr)
+		},
 		ForceAttemptHTTP2:     true,
 		MaxIdleConns:          100,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
-		TLSClientConfig:       ws.TLSConfig,
+		TLSClientConfig:       tlsConfig,
 	}
 
 	var proto string
 	if ws.UseTLS {
 		proto = "https"
 		if ws.AllowInsecure {
-			if ws.TLSConfig == nil {
+			if tlsConfig == nil {
 				tripper.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 			} else {
-				ws.TLSConfig.InsecureSkipVerify = true
+				tlsConfig.InsecureSkipVerify = true
 			}
 		}
 	} else {
@@ -287,8 +344,9 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return startupTimeoutError(ctx, "http", attempts, start, ctx.Err())
 		case <-time.After(ws.PollInterval):
+			attempts++
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -301,6 +359,10 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 				req.Header.Set(k, v)
 			}
 
+			if ws.HostHeader != "" {
+				req.Host = ws.HostHeader
+			}
+
 			resp, err := client.Do(req)
 			if err != nil {
 				continue
@@ -324,3 +386,30 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		}
 	}
 }
+
+func (ws *HTTPStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
+
+// tlsConfigWithServerName returns the TLS config with the host name of the Host header as the server name,
+// unless it's already set, without modifying the given config.
+func tlsConfigWithServerName(tlsConfig *tls.Config, hostHeader string) *tls.Config {
+	if tlsConfig != nil && tlsConfig.ServerName != "" {
+		return tlsConfig
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+
+	serverName, _, err := net.SplitHostPort(hostHeader)
+	if err != nil {
+		// the Host header has no port
+		serverName = hostHeader
+	}
+	tlsConfig.ServerName = serverName
+
+	return tlsConfig
+}
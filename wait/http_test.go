@@ -3,13 +3,19 @@ package wait_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"io"
 	"log"
+	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -873,3 +879,187 @@ func TestHttpStrategyFailsWhileGettingPortDueToExposedPortNoBindings(t *testing.
 		}
 	}
 }
+
+// clientCertificate generates a self-signed client certificate, returned with a pool trusting it
+func clientCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "testcontainers"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: cert}, pool
+}
+
+// serverTarget returns a target exposing the port of the server, listening on the given host
+func serverTarget(t *testing.T, srv *httptest.Server, host string) *wait.MockStrategyTarget {
+	t.Helper()
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &wait.MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return host, nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", port)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}
+
+func TestHTTPStrategyWaitUntilReadyWithTLSClientConfigAndHost(t *testing.T) {
+	clientCert, clientCAs := clientCertificate(t)
+
+	var serverName string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the server only answers to its virtual host
+		if r.Host != "example.com" {
+			w.WriteHeader(http.StatusMisdirectedRequest)
+			return
+		}
+		serverName = r.TLS.ServerName
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	// the handshakes without the client certificate are expected to fail
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	target := serverTarget(t, srv, "127.0.0.1")
+
+	t.Run("with client certificate and host", func(t *testing.T) {
+		tlsConfig := &tls.Config{
+			Certificates: []tls.Certificate{clientCert},
+			RootCAs:      srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+		}
+
+		// waitForHTTPWithClientCertificate {
+		wg := wait.ForHTTP("/").
+			WithPort("443/tcp").
+			WithTLSClientConfig(tlsConfig).
+			WithHost("example.com").
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(100 * time.Millisecond)
+		// }
+
+		if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+
+		if serverName != "example.com" {
+			t.Fatalf("expected the server name %q, got %q", "example.com", serverName)
+		}
+
+		if tlsConfig.ServerName != "" {
+			t.Fatal("the TLS config must not be modified")
+		}
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		wg := wait.ForHTTP("/").
+			WithPort("443/tcp").
+			WithTLSClientConfig(&tls.Config{InsecureSkipVerify: true}).
+			WithHost("example.com").
+			WithStartupTimeout(500 * time.Millisecond).
+			WithPollInterval(100 * time.Millisecond)
+
+		if err := wg.WaitUntilReady(context.Background(), target); err == nil {
+			t.Fatal("expected an error without the client certificate")
+		}
+	})
+
+	t.Run("without host", func(t *testing.T) {
+		wg := wait.ForHTTP("/").
+			WithPort("443/tcp").
+			WithTLSClientConfig(&tls.Config{Certificates: []tls.Certificate{clientCert}, InsecureSkipVerify: true}).
+			WithStartupTimeout(500 * time.Millisecond).
+			WithPollInterval(100 * time.Millisecond)
+
+		if err := wg.WaitUntilReady(context.Background(), target); err == nil {
+			t.Fatal("expected an error without the virtual host")
+		}
+	})
+}
+
+func TestHTTPStrategyWaitUntilReadyWithForcedIPv6LocalHost(t *testing.T) {
+	listener, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 is not available: %s", err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Listener = listener
+	srv.Start()
+	defer srv.Close()
+
+	wg := wait.ForHTTP("/").
+		WithPort("80/tcp").
+		WithForcedIPv6LocalHost().
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), serverTarget(t, srv, "localhost")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHTTPStrategyWaitUntilReadyWithIPVersion(t *testing.T) {
+	listener, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 is not available: %s", err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Listener = listener
+	srv.Start()
+	defer srv.Close()
+
+	// ipVersion {
+	wg := wait.ForHTTP("/").
+		WithPort("80/tcp").
+		WithIPVersion(wait.IPv6).
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+	// }
+
+	if err := wg.WaitUntilReady(context.Background(), serverTarget(t, srv, "localhost")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,10 +1,16 @@
 package wait
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,10 +26,27 @@ type LogStrategy struct {
 	timeout *time.Duration
 
 	// additional properties
-	Log          string
-	IsRegexp     bool
-	Occurrence   int
-	PollInterval time.Duration
+	Log               string
+	IsRegexp          bool
+	IsCaseInsensitive bool
+	Occurrence        int
+	PollInterval      time.Duration
+
+	// CountAcrossRestarts makes the occurrences matched by a previous wait
+	// not count for the next one, e.g. after the container is restarted
+	CountAcrossRestarts bool
+
+	// JSONFields makes the strategy parse each log line as JSON, matching the lines
+	// where all the fields have the given values, instead of the Log entry
+	JSONFields map[string]any
+
+	mtx sync.Mutex
+	// matched is the number of occurrences matched when the strategy was last ready
+	matched int
+	// submatches holds the submatches of the occurrences of the regular expression
+	submatches [][]string
+	// subexpNames holds the names of the capturing groups of the regular expression
+	subexpNames []string
 }
 
 // NewLogStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
@@ -46,6 +69,63 @@ func (ws *LogStrategy) AsRegexp() *LogStrategy {
 	return ws
 }
 
+// CaseInsensitive can be used to match the log entry regardless of its case, for both plain text and regexp
+func (ws *LogStrategy) CaseInsensitive() *LogStrategy {
+	ws.IsCaseInsensitive = true
+	return ws
+}
+
+// AsJSON can be used to parse each log line as a JSON object, matching the lines where all the fields
+// have the given values, e.g. {"level": "info", "msg": "server started"}, instead of the Log entry.
+// The fields of nested objects are matched with their path separated by dots, e.g. "http.port".
+// The values are compared with the decoded JSON values, so numbers match regardless of their Go type.
+// The lines that are not JSON objects are ignored.
+func (ws *LogStrategy) AsJSON(fields map[string]any) *LogStrategy {
+	ws.JSONFields = fields
+	return ws
+}
+
+// WithCountAcrossRestarts can be used to keep the count of the occurrences matched by previous waits,
+// so that waiting again, e.g. when the container is started again after being stopped, needs the log entry
+// to show up Occurrence more times, instead of matching the entries logged by the previous runs,
+// which are kept by Docker. The strategy must not be shared by different containers.
+func (ws *LogStrategy) WithCountAcrossRestarts() *LogStrategy {
+	ws.CountAcrossRestarts = true
+	return ws
+}
+
+// Submatches returns the submatches of the occurrences of the regular expression
+// when the strategy was last ready, in the order they were logged. The first element of
+// each submatch is the whole match, followed by the capturing groups of the expression.
+// It returns nil if the strategy does not use a regular expression.
+func (ws *LogStrategy) Submatches() [][]string {
+	ws.mtx.Lock()
+	defer ws.mtx.Unlock()
+
+	return ws.submatches
+}
+
+// NamedSubmatch returns the value of the named capturing group of the regular expression, e.g.
+// "password" for `password: (?P<password>\S+)`, in the last occurrence when the strategy was ready.
+// It returns an empty string if there is no such group, or if the strategy is not ready yet.
+func (ws *LogStrategy) NamedSubmatch(name string) string {
+	ws.mtx.Lock()
+	defer ws.mtx.Unlock()
+
+	if len(ws.submatches) == 0 {
+		return ""
+	}
+
+	last := ws.submatches[len(ws.submatches)-1]
+	for i, n := range ws.subexpNames {
+		if n == name && i < len(last) {
+			return last[i]
+		}
+	}
+
+	return ""
+}
+
 // WithStartupTimeout can be used to change the default startup timeout
 func (ws *LogStrategy) WithStartupTimeout(timeout time.Duration) *LogStrategy {
 	ws.timeout = &timeout
@@ -78,6 +158,18 @@ func ForLog(log string) *LogStrategy {
 	return NewLogStrategy(log)
 }
 
+// ForJSONLog is a convenience method similar to ForLog, which parses
+// each log line as JSON, waiting for a line with all the given field values.
+//
+// For Example:
+//
+//	wait.
+//		ForJSONLog(map[string]any{"level": "info", "msg": "server started"}).
+//		WithStartupTimeout(10 * time.Second)
+func ForJSONLog(fields map[string]any) *LogStrategy {
+	return NewLogStrategy("").AsJSON(fields)
+}
+
 func (ws *LogStrategy) Timeout() *time.Duration {
 	return ws.timeout
 }
@@ -92,39 +184,48 @@ func (ws *LogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	re, err := ws.regexp()
+	if err != nil {
+		return err
+	}
+
+	ws.mtx.Lock()
+	previous := 0
+	if ws.CountAcrossRestarts {
+		previous = ws.matched
+	}
+	ws.mtx.Unlock()
+
 	length := 0
+	start := time.Now()
 
 LOOP:
-	for {
+	for attempts := 1; ; attempts++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return startupTimeoutError(ctx, "log", attempts-1, start, ctx.Err())
 		default:
 			checkErr := checkTarget(ctx, target)
 
 			reader, err := target.Logs(ctx)
-			if err != nil {
-				time.Sleep(ws.PollInterval)
-				continue
-			}
+			if err == nil {
+				var b []byte
+				if b, err = io.ReadAll(reader); err == nil {
+					logs := string(b)
 
-			b, err := io.ReadAll(reader)
-			if err != nil {
-				time.Sleep(ws.PollInterval)
-				continue
+					switch {
+					case length == len(logs) && checkErr != nil:
+						return checkErr
+					case ws.checkLogs(re, b, previous):
+						break LOOP
+					default:
+						length = len(logs)
+					}
+				}
 			}
 
-			logs := string(b)
-
-			switch {
-			case length == len(logs) && checkErr != nil:
-				return checkErr
-			case checkLogsFn(ws, b):
-				break LOOP
-			default:
-				length = len(logs)
-				time.Sleep(ws.PollInterval)
-				continue
+			if err := sleep(ctx, ws.PollInterval); err != nil {
+				return startupTimeoutError(ctx, "log", attempts, start, err)
 			}
 		}
 	}
@@ -132,14 +233,136 @@ LOOP:
 	return nil
 }
 
-func checkLogsFn(ws *LogStrategy, b []byte) bool {
-	if ws.IsRegexp {
-		re := regexp.MustCompile(ws.Log)
-		occurrences := re.FindAll(b, -1)
+func (ws *LogStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.PollInterval = pollInterval
+}
+
+// regexp returns the regular expression to match the logs, or nil if the
+// log entry is matched as plain text.
+func (ws *LogStrategy) regexp() (*regexp.Regexp, error) {
+	if ws.JSONFields != nil || (!ws.IsRegexp && !ws.IsCaseInsensitive) {
+		return nil, nil
+	}
 
-		return len(occurrences) >= ws.Occurrence
+	expr := ws.Log
+	if !ws.IsRegexp {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if ws.IsCaseInsensitive {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log regexp %q: %w", ws.Log, err)
+	}
+
+	return re, nil
+}
+
+// checkLogs returns true if the logs contain at least Occurrence occurrences of the log entry,
+// besides the previous ones, recording the matches when they do.
+func (ws *LogStrategy) checkLogs(re *regexp.Regexp, b []byte, previous int) bool {
+	var (
+		count      int
+		submatches [][]string
+	)
+
+	switch {
+	case ws.JSONFields != nil:
+		count = countJSONLines(b, ws.JSONFields)
+	case re == nil:
+		count = strings.Count(string(b), ws.Log)
+	default:
+		matches := re.FindAllSubmatch(b, -1)
+		count = len(matches)
+
+		if ws.IsRegexp {
+			submatches = make([][]string, 0, len(matches))
+			for _, m := range matches {
+				sm := make([]string, len(m))
+				for i := range m {
+					sm[i] = string(m[i])
+				}
+				submatches = append(submatches, sm)
+			}
+		}
+	}
+
+	if count-previous < ws.Occurrence {
+		return false
+	}
+
+	ws.mtx.Lock()
+	defer ws.mtx.Unlock()
+
+	ws.matched = count
+	if re != nil && ws.IsRegexp {
+		ws.submatches = submatches
+		ws.subexpNames = re.SubexpNames()
+	}
+
+	return true
+}
+
+// countJSONLines returns the number of log lines that are JSON objects with all the given field values.
+func countJSONLines(b []byte, fields map[string]any) int {
+	expected := make(map[string]any, len(fields))
+	for k, v := range fields {
+		// round trip the values, so they are compared as decoded JSON values
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return 0
+		}
+		var decoded any
+		if err := json.Unmarshal(bs, &decoded); err != nil {
+			return 0
+		}
+		expected[k] = decoded
+	}
+
+	count := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(b)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var entry map[string]any
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+
+		if matchJSONFields(entry, expected) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// matchJSONFields returns true if the entry has all the expected field values,
+// looking up the fields of nested objects by their path separated by dots.
+func matchJSONFields(entry map[string]any, expected map[string]any) bool {
+	for path, want := range expected {
+		var got any = entry
+		for _, key := range strings.Split(path, ".") {
+			obj, ok := got.(map[string]any)
+			if !ok {
+				return false
+			}
+			if got, ok = obj[key]; !ok {
+				return false
+			}
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			return false
+		}
 	}
 
-	logs := string(b)
-	return strings.Count(logs, ws.Log) >= ws.Occurrence
+	return true
 }
@@ -276,3 +276,195 @@ func TestWaitForLogFailsDueToUnexpectedContainerStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestWaitForLogCaseInsensitive(t *testing.T) {
+	t.Run("no regexp", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte("Server READY to accept connections"))),
+		}
+		wg := NewLogStrategy("ready to accept").WithStartupTimeout(100 * time.Microsecond).CaseInsensitive()
+		err := wg.WaitUntilReady(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("as regexp", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(loremIpsum))),
+		}
+		wg := NewLogStrategy(`^LOREM IPSUM`).WithStartupTimeout(100 * time.Microsecond).AsRegexp().CaseInsensitive()
+		err := wg.WaitUntilReady(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("case sensitive by default", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte("Server READY to accept connections"))),
+		}
+		wg := NewLogStrategy("ready to accept").WithStartupTimeout(100 * time.Microsecond)
+		err := wg.WaitUntilReady(context.Background(), target)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestWaitForLogSubmatches(t *testing.T) {
+	logs := "starting\nGenerated admin password: s3cr3t\nGenerated admin password: n3w-s3cr3t\nready\n"
+
+	target := NopStrategyTarget{
+		ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+	}
+
+	// logSubmatches {
+	wg := ForLog(`Generated admin password: (?P<password>\S+)`).AsRegexp().WithOccurrence(2)
+	// }
+	wg.WithStartupTimeout(100 * time.Microsecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submatches := wg.Submatches()
+	if len(submatches) != 2 {
+		t.Fatalf("expected 2 submatches, got %d", len(submatches))
+	}
+	if submatches[0][1] != "s3cr3t" {
+		t.Fatalf("expected %q, got %q", "s3cr3t", submatches[0][1])
+	}
+
+	// namedSubmatch {
+	password := wg.NamedSubmatch("password")
+	// }
+	if password != "n3w-s3cr3t" {
+		t.Fatalf("expected %q, got %q", "n3w-s3cr3t", password)
+	}
+
+	if wg.NamedSubmatch("user") != "" {
+		t.Fatal("expected empty submatch for an unknown group")
+	}
+}
+
+func TestWaitForLogCountAcrossRestarts(t *testing.T) {
+	firstRun := "database system is ready to accept connections\n"
+
+	wg := NewLogStrategy("ready to accept connections").
+		WithStartupTimeout(100 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond).
+		WithCountAcrossRestarts()
+
+	target := NopStrategyTarget{
+		ReaderCloser: io.NopCloser(bytes.NewReader([]byte(firstRun))),
+	}
+	err := wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("logs of the previous run do not count", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(firstRun + "shutting down\n"))),
+		}
+		err := wg.WaitUntilReady(context.Background(), target)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("new occurrence after the restart", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(firstRun + "shutting down\n" + firstRun))),
+		}
+		err := wg.WaitUntilReady(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestWaitForJSONLog(t *testing.T) {
+	logs := `starting server
+{"level":"debug","msg":"server started","http":{"port":8080}}
+{"level":"info","msg":"loading config"}
+{"level":"info","msg":"server started","http":{"port":8080}}
+{"level":"info","msg":"server started",
+`
+
+	tests := []struct {
+		name        string
+		fields      map[string]any
+		occurrence  int
+		expectError bool
+	}{
+		{
+			name:   "top-level fields",
+			fields: map[string]any{"level": "info", "msg": "server started"},
+		},
+		{
+			name:   "nested field",
+			fields: map[string]any{"level": "info", "http.port": 8080},
+		},
+		{
+			name:       "occurrences",
+			fields:     map[string]any{"msg": "server started"},
+			occurrence: 2,
+		},
+		{
+			name:        "value not matching",
+			fields:      map[string]any{"level": "error"},
+			expectError: true,
+		},
+		{
+			name:        "nested field of a non-object",
+			fields:      map[string]any{"level.name": "info"},
+			expectError: true,
+		},
+		{
+			name:        "not enough occurrences",
+			fields:      map[string]any{"msg": "server started"},
+			occurrence:  3,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NopStrategyTarget{
+				ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+			}
+
+			wg := ForJSONLog(tt.fields).WithStartupTimeout(100 * time.Millisecond).WithPollInterval(10 * time.Millisecond)
+			if tt.occurrence > 0 {
+				wg.WithOccurrence(tt.occurrence)
+			}
+
+			err := wg.WaitUntilReady(context.Background(), target)
+			if tt.expectError && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	t.Run("as json", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+		}
+
+		// logAsJSON {
+		wg := ForLog("").AsJSON(map[string]any{"level": "info", "msg": "server started"})
+		// }
+		wg.WithStartupTimeout(100 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
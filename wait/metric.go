@@ -0,0 +1,189 @@
+package wait
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ForMetric constructs an HTTP strategy that scrapes the Prometheus metrics exposed by the container
+// at the given path, e.g. "/metrics", and completes when a sample of the metric satisfies the predicate.
+//
+// The metric can be selected by name, or by name and labels, e.g. `http_server_ready{handler="api"}`,
+// in which case only the samples having those labels are considered. If several samples match,
+// any of them satisfying the predicate is enough.
+// If the metric selector cannot be parsed, the strategy fails with the error of the selector, without waiting.
+//
+// As it's an HTTP strategy, the port, the TLS settings or the timeouts can be customised with its builders.
+//
+// For Example:
+//
+//	wait.
+//		ForMetric("/metrics", "app_ready", func(v float64) bool { return v == 1 }).
+//		WithPort("8080/tcp")
+func ForMetric(path string, metric string, predicate func(value float64) bool) *HTTPStrategy {
+	name, labels, selectorErr := parseMetricSelector(metric)
+
+	strategy := ForHTTP(path).WithResponseMatcher(func(body io.Reader) bool {
+		samples, err := parseMetrics(body)
+		if err != nil {
+			return false
+		}
+
+		for _, s := range samples {
+			if s.name == name && s.hasLabels(labels) && predicate(s.value) {
+				return true
+			}
+		}
+
+		return false
+	})
+	strategy.err = selectorErr
+
+	return strategy
+}
+
+// metricSample represents a sample of the Prometheus text exposition format
+type metricSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// hasLabels returns true if the sample has all the given labels, with the same values
+func (s metricSample) hasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if lv, ok := s.labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseMetrics parses the samples of the Prometheus text exposition format,
+// skipping comments, and the optional timestamps of the samples.
+func parseMetrics(r io.Reader) ([]metricSample, error) {
+	var samples []metricSample
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, labels, rest, err := parseMetricNameAndLabels(line)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("missing value for metric %s", name)
+		}
+
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for metric %s: %w", name, err)
+		}
+
+		samples = append(samples, metricSample{name: name, labels: labels, value: value})
+	}
+
+	return samples, scanner.Err()
+}
+
+// parseMetricSelector parses a metric name, optionally followed by its labels, e.g. `up{job="api"}`
+func parseMetricSelector(selector string) (string, map[string]string, error) {
+	name, labels, rest, err := parseMetricNameAndLabels(strings.TrimSpace(selector))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if strings.TrimSpace(rest) != "" {
+		return "", nil, fmt.Errorf("invalid metric selector %q", selector)
+	}
+
+	return name, labels, nil
+}
+
+// parseMetricNameAndLabels parses the name and the labels at the beginning of s,
+// returning the remainder of s.
+func parseMetricNameAndLabels(s string) (string, map[string]string, string, error) {
+	end := strings.IndexAny(s, "{ \t")
+	if end == -1 {
+		end = len(s)
+	}
+
+	name := s[:end]
+	if name == "" {
+		return "", nil, "", errors.New("missing metric name")
+	}
+
+	rest := s[end:]
+	if !strings.HasPrefix(rest, "{") {
+		return name, nil, rest, nil
+	}
+
+	labels := map[string]string{}
+	rest = rest[1:]
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if strings.HasPrefix(rest, "}") {
+			return name, labels, rest[1:], nil
+		}
+
+		eq := strings.Index(rest, "=")
+		if eq == -1 {
+			return "", nil, "", fmt.Errorf("invalid labels for metric %s", name)
+		}
+
+		key := strings.TrimSpace(rest[:eq])
+		rest = strings.TrimLeft(rest[eq+1:], " \t")
+		if !strings.HasPrefix(rest, `"`) {
+			return "", nil, "", fmt.Errorf("invalid value for label %s of metric %s", key, name)
+		}
+
+		value, n, err := unquoteLabelValue(rest)
+		if err != nil {
+			return "", nil, "", fmt.Errorf("invalid value for label %s of metric %s: %w", key, name, err)
+		}
+
+		labels[key] = value
+		rest = rest[n:]
+	}
+}
+
+// unquoteLabelValue reads the double-quoted label value at the beginning of s, handling the
+// escape sequences of the exposition format, and returns it with the number of bytes read.
+func unquoteLabelValue(s string) (string, int, error) {
+	var sb strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", 0, errors.New("unterminated escape sequence")
+			}
+
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			default:
+				sb.WriteByte(s[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", 0, errors.New("unterminated label value")
+}
@@ -0,0 +1,136 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+const metricsFixture = `# HELP app_ready Whether the application is ready.
+# TYPE app_ready gauge
+app_ready %d
+# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="post",code="200"} 1027 1395066363000
+http_requests_total{method="post",code="400"}    3 1395066363000
+jvm_startup_seconds_count 0
+msdos_file_access_time_seconds{path="C:\\DIR\\FILE.TXT",error="Cannot find file:\n\"FILE.TXT\""} 1.458255915e9
+`
+
+func TestParseMetrics(t *testing.T) {
+	samples, err := parseMetrics(strings.NewReader(fmt.Sprintf(metricsFixture, 1)))
+	require.NoError(t, err)
+	require.Len(t, samples, 5)
+
+	require.Equal(t, metricSample{name: "app_ready", value: 1}, samples[0])
+	require.Equal(t, metricSample{
+		name:   "http_requests_total",
+		labels: map[string]string{"method": "post", "code": "400"},
+		value:  3,
+	}, samples[2])
+	require.Equal(t, `C:\DIR\FILE.TXT`, samples[4].labels["path"])
+	require.Equal(t, "Cannot find file:\n\"FILE.TXT\"", samples[4].labels["error"])
+
+	_, err = parseMetrics(strings.NewReader(`app_ready{code="200 1`))
+	require.Error(t, err)
+
+	_, err = parseMetrics(strings.NewReader(`app_ready ready`))
+	require.Error(t, err)
+}
+
+func TestParseMetricSelector(t *testing.T) {
+	name, labels, err := parseMetricSelector(`http_requests_total{code="200", method="post"}`)
+	require.NoError(t, err)
+	require.Equal(t, "http_requests_total", name)
+	require.Equal(t, map[string]string{"code": "200", "method": "post"}, labels)
+
+	name, labels, err = parseMetricSelector("app_ready")
+	require.NoError(t, err)
+	require.Equal(t, "app_ready", name)
+	require.Empty(t, labels)
+
+	_, _, err = parseMetricSelector("app_ready 1")
+	require.Error(t, err)
+}
+
+func TestWaitForMetric(t *testing.T) {
+	var ready atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, metricsFixture, ready.Load())
+	}))
+	defer srv.Close()
+
+	_, rawPort, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	target := &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "127.0.0.1", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", rawPort)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	t.Run("metric-satisfies-predicate", func(t *testing.T) {
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			ready.Store(1)
+		}()
+
+		// waitForMetric {
+		wg := ForMetric("/metrics", "app_ready", func(v float64) bool { return v == 1 }).
+			WithPort("8080/tcp").
+			WithStartupTimeout(5 * time.Second)
+		// }
+
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	})
+
+	t.Run("metric-with-labels", func(t *testing.T) {
+		wg := ForMetric("/metrics", `http_requests_total{code="200"}`, func(v float64) bool { return v > 1000 }).
+			WithPort("8080/tcp").
+			WithStartupTimeout(time.Second)
+
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	})
+
+	t.Run("predicate-never-satisfied", func(t *testing.T) {
+		wg := ForMetric("/metrics", "jvm_startup_seconds_count", func(v float64) bool { return v > 0 }).
+			WithPort("8080/tcp").
+			WithStartupTimeout(500 * time.Millisecond)
+
+		require.ErrorIs(t, wg.WaitUntilReady(context.Background(), target), context.DeadlineExceeded)
+	})
+
+	t.Run("invalid-selector", func(t *testing.T) {
+		wg := ForMetric("/metrics", `app_ready{`, func(v float64) bool { return true }).
+			WithPort("8080/tcp").
+			WithStartupTimeout(30 * time.Second)
+
+		// the strategy fails fast, instead of waiting for the startup timeout
+		start := time.Now()
+		err := wg.WaitUntilReady(context.Background(), target)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), time.Second)
+	})
+}
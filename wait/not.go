@@ -0,0 +1,86 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*NotStrategy)(nil)
+	_ StrategyTimeout = (*NotStrategy)(nil)
+)
+
+// ErrStrategySucceeded is returned by NotStrategy when the negated strategy succeeds.
+var ErrStrategySucceeded = errors.New("the negated wait strategy succeeded")
+
+// NotStrategy negates a strategy: it succeeds if the strategy times out without succeeding
+// within the startup timeout, e.g. to check that an error is not logged at startup.
+// Any other error of the strategy, e.g. a misconfiguration, is returned, as well as
+// the error of the context if it's done before the startup timeout elapses.
+type NotStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout      *time.Duration
+	pollInterval *time.Duration
+
+	// additional properties
+	Strategy Strategy
+}
+
+// ForNot is a convenience method to assign NotStrategy
+func ForNot(strategy Strategy) *NotStrategy {
+	return &NotStrategy{
+		Strategy: strategy,
+	}
+}
+
+// WithStartupTimeout sets the time the negated strategy is given to succeed.
+// Waiting succeeds once it elapses without the negated strategy succeeding.
+func (ws *NotStrategy) WithStartupTimeout(timeout time.Duration) *NotStrategy {
+	ws.timeout = &timeout
+	return ws
+}
+
+// WithPollInterval sets the poll interval of the negated strategy, overriding its own
+func (ws *NotStrategy) WithPollInterval(pollInterval time.Duration) *NotStrategy {
+	ws.pollInterval = &pollInterval
+	return ws
+}
+
+func (ws *NotStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+func (ws *NotStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	strategyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	setPollInterval(ws.pollInterval, ws.Strategy)
+
+	err := ws.Strategy.WaitUntilReady(strategyCtx, target)
+	if err == nil {
+		return ErrStrategySucceeded
+	}
+
+	// the parent context is done, so the strategy could not be checked for the whole startup timeout
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	// only timing out without succeeding negates the strategy
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+
+	return err
+}
+
+func (ws *NotStrategy) setPollInterval(pollInterval time.Duration) {
+	ws.pollInterval = &pollInterval
+}
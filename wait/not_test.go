@@ -0,0 +1,79 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotStrategy_WaitUntilReady(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fails when the strategy fails", func(t *testing.T) {
+		t.Parallel()
+
+		errFailure := errors.New("intentional failure")
+		strategy := ForNot(ForNop(func(context.Context, StrategyTarget) error {
+			return errFailure
+		}))
+
+		if err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); !errors.Is(err, errFailure) {
+			t.Fatalf("expected %v, got %v", errFailure, err)
+		}
+	})
+
+	t.Run("succeeds when the strategy times out", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := ForNot(ForFunc(func(context.Context, StrategyTarget) error {
+			return errors.New("not ready")
+		})).WithStartupTimeout(200 * time.Millisecond)
+
+		if err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("fails when the strategy succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := ForNot(ForNop(func(context.Context, StrategyTarget) error {
+			return nil
+		}))
+
+		if err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); !errors.Is(err, ErrStrategySucceeded) {
+			t.Fatalf("expected %v, got %v", ErrStrategySucceeded, err)
+		}
+	})
+
+	t.Run("fails when the context is cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		strategy := ForNot(ForNop(func(ctx context.Context, _ StrategyTarget) error {
+			return ctx.Err()
+		}))
+
+		if err := strategy.WaitUntilReady(ctx, NopStrategyTarget{}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected %v, got %v", context.Canceled, err)
+		}
+	})
+
+	t.Run("fails when the context deadline is exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		strategy := ForNot(ForFunc(func(context.Context, StrategyTarget) error {
+			return errors.New("not ready")
+		})).WithStartupTimeout(time.Minute)
+
+		if err := strategy.WaitUntilReady(ctx, NopStrategyTarget{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
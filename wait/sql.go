@@ -73,6 +73,9 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	start := time.Now()
+	attempts := 0
+
 	host, err := target.Host(ctx)
 	if err != nil {
 		return err
@@ -87,8 +90,9 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 	for port == "" {
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("%w: %w", ctx.Err(), err)
+			return startupTimeoutError(ctx, "sql", attempts, start, fmt.Errorf("%w: %w", ctx.Err(), err))
 		case <-ticker.C:
+			attempts++
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -104,8 +108,9 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return startupTimeoutError(ctx, "sql", attempts, start, ctx.Err())
 		case <-ticker.C:
+			attempts++
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -116,3 +121,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		}
 	}
 }
+
+func (w *waitForSql) setPollInterval(pollInterval time.Duration) {
+	w.PollInterval = pollInterval
+}
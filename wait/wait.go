@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/internal/config"
 )
 
 // Strategy defines the basic interface for a Wait Strategy
@@ -23,6 +25,25 @@ type StrategyTimeout interface {
 	Timeout() *time.Duration
 }
 
+// strategyPollInterval allows MultiStrategy, AnyStrategy and NotStrategy to configure the poll interval
+// of their strategies
+type strategyPollInterval interface {
+	setPollInterval(time.Duration)
+}
+
+// setPollInterval sets the poll interval, if any, of the strategies supporting it
+func setPollInterval(pollInterval *time.Duration, strategies ...Strategy) {
+	if pollInterval == nil {
+		return
+	}
+
+	for _, strategy := range strategies {
+		if sp, ok := strategy.(strategyPollInterval); ok {
+			sp.setPollInterval(*pollInterval)
+		}
+	}
+}
+
 type StrategyTarget interface {
 	Host(context.Context) (string, error)
 	Ports(ctx context.Context) (nat.PortMap, error)
@@ -54,10 +75,114 @@ func checkState(state *types.ContainerState) error {
 	}
 }
 
+// IPVersion is the version of the IP addresses the strategies connect to from the host, e.g. to wait
+// for the containers of the Docker environments publishing their ports on the IPv6 addresses only.
+type IPVersion int
+
+const (
+	// IPAny connects to the IPv4 or IPv6 addresses of the host, as resolved. It's the default.
+	IPAny IPVersion = iota
+	// IPv4 connects to the IPv4 addresses of the host only
+	IPv4
+	// IPv6 connects to the IPv6 addresses of the host only
+	IPv6
+)
+
+// network returns the network of the protocol restricted to the IP version, e.g. tcp6
+func (v IPVersion) network(proto string) string {
+	switch v {
+	case IPv4:
+		return proto + "4"
+	case IPv6:
+		return proto + "6"
+	default:
+		return proto
+	}
+}
+
+// host returns the host replacing localhost with the loopback address of the IP version,
+// e.g. ::1 for IPv6, as localhost may be resolved to the address of the other version only.
+func (v IPVersion) host(host string) string {
+	if !strings.EqualFold(host, "localhost") {
+		return host
+	}
+
+	switch v {
+	case IPv4:
+		return "127.0.0.1"
+	case IPv6:
+		return "::1"
+	default:
+		return host
+	}
+}
+
+// defaultStartupTimeout returns the startup timeout of the strategies without an explicit one,
+// which is configured with the wait.startup.timeout property, 60 seconds by default.
 func defaultStartupTimeout() time.Duration {
+	if timeout := config.Read().StartupTimeout; timeout > 0 {
+		return timeout
+	}
+
 	return 60 * time.Second
 }
 
+// defaultPollInterval returns the poll interval of the strategies without an explicit one,
+// which is configured with the wait.poll.interval property, 100 milliseconds by default.
 func defaultPollInterval() time.Duration {
+	if interval := config.Read().WaitPollInterval; interval > 0 {
+		return interval
+	}
+
 	return 100 * time.Millisecond
 }
+
+// StartupTimeoutError is returned by the strategies when the container is not ready before their startup timeout,
+// or the deadline of the context passed to WaitUntilReady, elapses. It wraps the error of the last check, which
+// wraps context.DeadlineExceeded, so errors.Is(err, context.DeadlineExceeded) keeps being true.
+type StartupTimeoutError struct {
+	// Strategy is the name of the strategy timing out, e.g. "log"
+	Strategy string
+	// Attempts is the number of checks performed before timing out
+	Attempts int
+	// Elapsed is the time spent waiting for the container
+	Elapsed time.Duration
+	// Err is the error the strategy timed out with
+	Err error
+}
+
+func (e *StartupTimeoutError) Error() string {
+	return fmt.Sprintf("%s strategy timed out after %d attempts in %s: %v", e.Strategy, e.Attempts, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+func (e *StartupTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// startupTimeoutError returns a StartupTimeoutError wrapping err if the context is done because its deadline is
+// exceeded, or err as is otherwise, e.g. if the context is canceled.
+func startupTimeoutError(ctx context.Context, strategy string, attempts int, start time.Time, err error) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return &StartupTimeoutError{
+		Strategy: strategy,
+		Attempts: attempts,
+		Elapsed:  time.Since(start),
+		Err:      err,
+	}
+}
+
+// sleep waits for the poll interval, returning the error of the context if it's done before.
+func sleep(ctx context.Context, interval time.Duration) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
+	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
@@ -45,3 +48,133 @@ func (st MockStrategyTarget) Exec(ctx context.Context, cmd []string, options ...
 func (st MockStrategyTarget) State(ctx context.Context) (*types.ContainerState, error) {
 	return st.StateImpl(ctx)
 }
+
+func TestIPVersion(t *testing.T) {
+	testCases := []struct {
+		version IPVersion
+		network string
+		host    string
+	}{
+		{version: IPAny, network: "tcp", host: "localhost"},
+		{version: IPv4, network: "tcp4", host: "127.0.0.1"},
+		{version: IPv6, network: "tcp6", host: "::1"},
+	}
+
+	for _, tc := range testCases {
+		if network := tc.version.network("tcp"); network != tc.network {
+			t.Errorf("expected network %s for IP version %d, got %s", tc.network, tc.version, network)
+		}
+
+		if host := tc.version.host("localhost"); host != tc.host {
+			t.Errorf("expected host %s for IP version %d, got %s", tc.host, tc.version, host)
+		}
+
+		// the other hosts are not replaced
+		if host := tc.version.host("10.0.0.1"); host != "10.0.0.1" {
+			t.Errorf("expected host 10.0.0.1 for IP version %d, got %s", tc.version, host)
+		}
+	}
+}
+
+func TestStartupTimeoutError(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		checkErr := errors.New("not ready")
+		strategy := ForFunc(func(_ context.Context, _ StrategyTarget) error {
+			return checkErr
+		}).WithStartupTimeout(100 * time.Millisecond).WithPollInterval(10 * time.Millisecond)
+
+		err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{})
+
+		var timeoutErr *StartupTimeoutError
+		if !errors.As(err, &timeoutErr) {
+			t.Fatalf("expected a StartupTimeoutError, got %v", err)
+		}
+		if timeoutErr.Strategy != "func" {
+			t.Errorf("expected the func strategy, got %q", timeoutErr.Strategy)
+		}
+		if timeoutErr.Attempts < 2 {
+			t.Errorf("expected several attempts, got %d", timeoutErr.Attempts)
+		}
+		if timeoutErr.Elapsed < 100*time.Millisecond {
+			t.Errorf("expected the startup timeout to elapse, got %s", timeoutErr.Elapsed)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, checkErr) {
+			t.Errorf("expected the error to wrap the errors of the context and of the last check, got %v", err)
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// the poll interval is longer than the deadline, which interrupts it
+		strategy := ForLog("never logged").WithPollInterval(time.Hour)
+
+		start := time.Now()
+		err := strategy.WaitUntilReady(ctx, NopStrategyTarget{
+			ReaderCloser:   io.NopCloser(strings.NewReader("")),
+			ContainerState: types.ContainerState{Running: true},
+		})
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Fatalf("expected the deadline of the context to interrupt the poll interval, waited %s", elapsed)
+		}
+
+		var timeoutErr *StartupTimeoutError
+		if !errors.As(err, &timeoutErr) {
+			t.Fatalf("expected a StartupTimeoutError, got %v", err)
+		}
+		if timeoutErr.Strategy != "log" || timeoutErr.Attempts != 1 {
+			t.Errorf("expected 1 attempt of the log strategy, got %d of the %s strategy", timeoutErr.Attempts, timeoutErr.Strategy)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		strategy := ForFunc(func(_ context.Context, _ StrategyTarget) error {
+			return errors.New("not ready")
+		})
+
+		err := strategy.WaitUntilReady(ctx, NopStrategyTarget{})
+
+		var timeoutErr *StartupTimeoutError
+		if errors.As(err, &timeoutErr) {
+			t.Fatalf("expected no StartupTimeoutError when the context is canceled, got %v", err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestWithPollInterval_composite(t *testing.T) {
+	log := ForLog("ready")
+	ready := ForFunc(func(_ context.Context, _ StrategyTarget) error {
+		return nil
+	})
+	notReady := ForFunc(func(_ context.Context, _ StrategyTarget) error {
+		return errors.New("not ready")
+	})
+
+	// the poll interval is propagated to the strategies of the nested strategies too
+	strategy := ForAll(
+		log,
+		ForAny(ready),
+		ForNot(notReady).WithStartupTimeout(50*time.Millisecond),
+	).WithPollInterval(5 * time.Millisecond)
+
+	err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{
+		ReaderCloser:   io.NopCloser(strings.NewReader("ready")),
+		ContainerState: types.ContainerState{Running: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, interval := range []time.Duration{log.PollInterval, ready.PollInterval, notReady.PollInterval} {
+		if interval != 5*time.Millisecond {
+			t.Errorf("expected a poll interval of 5ms, got %s", interval)
+		}
+	}
+}